@@ -233,8 +233,13 @@ def create_page(request: HttpRequest, payload: PageIn):
 
 
 @pages_router.get("/{external_id}/", response=PageOut)
-def get_page(request: HttpRequest, external_id: str):
-    """Get a specific page by external ID."""
+def get_page(request: HttpRequest, external_id: str, omit: str = ""):
+    """Get a specific page by external ID.
+
+    `omit=content` leaves `content` out of `details` and reports its length in
+    UTF-8 bytes as `details.content_size`, for clients that only need the
+    page's metadata and would otherwise download a large page to read it.
+    """
     page = get_object_or_404(
         # select_related on folder + project + project.org keeps the
         # PageOut serialization at a single query — folder for folder_id,
@@ -244,6 +249,11 @@ def get_page(request: HttpRequest, external_id: str):
         external_id=external_id,
     )
     page.role = get_page_access_level(request.user, page).value
+    if omit == "content":
+        # Only the response is changed; the page is not saved.
+        details = dict(page.details or {})
+        details["content_size"] = len((details.pop("content", None) or "").encode("utf-8"))
+        page.details = details
     return page
 
 
@@ -283,15 +293,16 @@ def update_page(
     folder_id_in_body = b'"folder_id"' in raw_body
 
     title_changing = payload.title != page.title
+    project_changing = payload.project_id is not None and payload.project_id != page.project.external_id
 
     folder_changing = False
     if folder_id_in_body:
         current_folder_external_id = page.folder.external_id if page.folder else None
         folder_changing = payload.folder_id != current_folder_external_id
 
-    if title_changing or folder_changing:
+    if title_changing or folder_changing or project_changing:
         if not user_can_delete_page_in_project(request.user, page):
-            field = "title" if title_changing else "folder"
+            field = "title" if title_changing else "folder" if folder_changing else "project"
             return 403, {"message": f"Only the creator can change a page's {field}"}
     else:
         # Content-only writes (or no-op writes) require editor access. This
@@ -303,6 +314,22 @@ def update_page(
 
     page.title = payload.title
 
+    # Moving to another project takes the page out of its folder, since
+    # folders belong to a project. A folder_id in the same request is then
+    # looked up in the new project.
+    project_changed = False
+    if project_changing:
+        target = Project.objects.filter(external_id=payload.project_id, is_deleted=False).first()
+        if target is None or not user_can_access_project(request.user, target):
+            return 404, {"message": "Project not found"}
+        if target.org_id != page.project.org_id:
+            return 400, {"message": "Pages cannot move to a project in another organization"}
+        if not user_can_edit_in_project(request.user, target):
+            return 403, {"message": "You don't have permission to add pages to this project"}
+        page.project = target
+        page.folder = None
+        project_changed = True
+
     # Handle folder_id if provided in request body
     folder_changed = False
     if folder_id_in_body:
@@ -356,8 +383,10 @@ def update_page(
                 yjs_sync = ("overwrite", content)
 
     update_fields = ["title", "details", "modified"]
-    if folder_changed:
+    if folder_changed or project_changed:
         update_fields.append("folder_id")
+    if project_changed:
+        update_fields.append("project_id")
     page.save(update_fields=update_fields)
 
     # The editor reads from Yjs, not page.details. Push the content
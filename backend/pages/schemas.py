@@ -27,11 +27,15 @@ class PageIn(Schema):
 
 
 class PageUpdateIn(Schema):
-    """Request body for update (no project_id - project cannot be changed)."""
+    """Request body for update."""
 
     title: str = Field(..., min_length=1, max_length=100)
     details: Optional[Dict[str, Any]] = None
     folder_id: Optional[str] = Field(None, description="Folder external_id, or null to move to project root")
+    project_id: Optional[str] = Field(
+        None,
+        description="Project external_id to move the page to, in the same org; the page leaves its folder",
+    )
     mode: Optional[str] = Field(
         None,
         description="Content update mode: 'append' (default), 'prepend', or 'overwrite'",
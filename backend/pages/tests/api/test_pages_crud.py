@@ -253,6 +253,72 @@ class TestPagesUpdateAPI(BaseAuthenticatedViewTestCase):
         )
         self.assertEqual(response.status_code, HTTPStatus.NOT_FOUND)
 
+    @override_settings(ASK_FEATURE_ENABLED=False)
+    def test_update_page_move_to_project(self):
+        """Test moving a page to another project in the org via project_id, leaving its folder."""
+        folder = FolderFactory(project=self.project, parent=None, name="Design")
+        page = PageFactory(project=self.project, creator=self.user, title="Page", folder=folder)
+        target = ProjectFactory(org=self.org, creator=self.user)
+
+        response = self.send_api_request(
+            url=f"/api/pages/{page.external_id}/",
+            method="put",
+            data={"title": "Page", "project_id": str(target.external_id)},
+        )
+        self.assertEqual(response.status_code, HTTPStatus.OK)
+        self.assertEqual(response.json()["project_external_id"], str(target.external_id))
+
+        page.refresh_from_db()
+        self.assertEqual(page.project, target)
+        self.assertIsNone(page.folder_id)
+
+    @override_settings(ASK_FEATURE_ENABLED=False)
+    def test_update_page_move_to_project_in_other_org_returns_400(self):
+        """Test moving a page to a project in another org is refused."""
+        other_org = OrgFactory()
+        OrgMemberFactory(org=other_org, user=self.user, role=OrgMemberRole.MEMBER.value)
+        target = ProjectFactory(org=other_org, creator=self.user)
+        page = PageFactory(project=self.project, creator=self.user, title="Page")
+
+        response = self.send_api_request(
+            url=f"/api/pages/{page.external_id}/",
+            method="put",
+            data={"title": "Page", "project_id": str(target.external_id)},
+        )
+        self.assertEqual(response.status_code, HTTPStatus.BAD_REQUEST)
+
+        page.refresh_from_db()
+        self.assertEqual(page.project, self.project)
+
+    @override_settings(ASK_FEATURE_ENABLED=False)
+    def test_update_page_move_to_project_not_creator_returns_403(self):
+        """Test only the page's creator can move it to another project."""
+        page = PageFactory(project=self.project, title="Page")
+        PageEditorFactory(page=page, user=self.user, role=PageEditorRole.EDITOR.value)
+        target = ProjectFactory(org=self.org, creator=self.user)
+
+        response = self.send_api_request(
+            url=f"/api/pages/{page.external_id}/",
+            method="put",
+            data={"title": "Page", "project_id": str(target.external_id)},
+        )
+        self.assertEqual(response.status_code, HTTPStatus.FORBIDDEN)
+
+        page.refresh_from_db()
+        self.assertEqual(page.project, self.project)
+
+    @override_settings(ASK_FEATURE_ENABLED=False)
+    def test_update_page_move_to_nonexistent_project_returns_404(self):
+        """Test moving a page to a project that does not exist returns 404."""
+        page = PageFactory(project=self.project, creator=self.user, title="Page")
+
+        response = self.send_api_request(
+            url=f"/api/pages/{page.external_id}/",
+            method="put",
+            data={"title": "Page", "project_id": "prj_nonexistent"},
+        )
+        self.assertEqual(response.status_code, HTTPStatus.NOT_FOUND)
+
     @override_settings(ASK_FEATURE_ENABLED=False)
     def test_update_page_title_only_does_not_clear_folder(self):
         """
@@ -1640,6 +1706,45 @@ class TestGetPageRoleField(BaseAuthenticatedViewTestCase):
         self.assertIsNone(items[0]["role"])
 
 
+class TestGetPageOmitContent(BaseAuthenticatedViewTestCase):
+    """Test that GET /api/pages/{id}/?omit=content leaves the content out."""
+
+    def setUp(self):
+        super().setUp()
+        self.org = OrgFactory()
+        OrgMemberFactory(org=self.org, user=self.user, role=OrgMemberRole.ADMIN.value)
+        self.project = ProjectFactory(org=self.org, creator=self.user)
+        self.page = PageFactory(
+            project=self.project,
+            creator=self.user,
+            details={"content": "café\n", "filetype": "md", "status": "draft"},
+        )
+
+    def test_omit_content_reports_size_instead(self):
+        response = self.send_api_request(url=f"/api/pages/{self.page.external_id}/?omit=content", method="get")
+
+        self.assertEqual(response.status_code, HTTPStatus.OK)
+        details = response.json()["details"]
+        self.assertNotIn("content", details)
+        self.assertEqual(details["content_size"], len("café\n".encode("utf-8")))
+        self.assertEqual(details["filetype"], "md")
+        self.assertEqual(details["status"], "draft")
+
+    def test_omit_content_does_not_change_the_page(self):
+        self.send_api_request(url=f"/api/pages/{self.page.external_id}/?omit=content", method="get")
+
+        self.page.refresh_from_db()
+        self.assertEqual(self.page.details["content"], "café\n")
+        self.assertNotIn("content_size", self.page.details)
+
+    def test_without_omit_content_is_sent(self):
+        response = self.send_api_request(url=f"/api/pages/{self.page.external_id}/", method="get")
+
+        self.assertEqual(response.status_code, HTTPStatus.OK)
+        self.assertEqual(response.json()["details"]["content"], "café\n")
+        self.assertNotIn("content_size", response.json()["details"])
+
+
 class TestPagesListQueryCount(BaseAuthenticatedViewTestCase):
     """Regression tests for the `select_related("folder")` N+1 fix on
     PageOut-returning endpoints.
@@ -0,0 +1,369 @@
+// Package capture lets Go programs stream output to a Hyperclast page, using
+// the same configuration and credentials as the hyperclast CLI.
+//
+//	w, err := capture.Writer("", "nightly-reindex diagnostics")
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Close()
+//	log.SetOutput(io.MultiWriter(os.Stderr, w))
+//
+// Writes are buffered and appended to the page in batches, so a chatty
+// logger costs one request per interval rather than one per line. The CLI
+// config's quiet_hours and upload_rate hold those batches back during
+// video calls or on metered connections.
+package capture
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/schedule"
+	"github.com/hyperclast/workspace/cli/internal/tlspin"
+)
+
+const (
+	// DefaultFlushInterval is how often buffered output is appended.
+	DefaultFlushInterval = 2 * time.Second
+	// DefaultMaxBatchBytes is the buffer size that triggers an early append.
+	DefaultMaxBatchBytes = 64 * 1024
+)
+
+// ErrPageFull is returned once appending more output would exceed the
+// server's per-page size limit. Output written after that is dropped.
+var ErrPageFull = errors.New("capture: page would exceed the server's size limit")
+
+// ErrClosed is returned by writes after Close.
+var ErrClosed = errors.New("capture: writer is closed")
+
+// Options configures a PageWriter. Zero values use the defaults.
+type Options struct {
+	// ConfigPath is the CLI config file to use. Empty means the same
+	// resolution as the CLI: $HYPERCLAST_CONFIG, then
+	// $HYPERCLAST_CONFIG_DIR/config.yaml, then the default path.
+	// $HYPERCLAST_TOKEN overrides the token either way.
+	ConfigPath string
+	// FlushInterval is how often buffered output is appended.
+	FlushInterval time.Duration
+	// MaxBatchBytes triggers an append as soon as this much is buffered.
+	MaxBatchBytes int
+	// QuietHours are daily local-time windows, such as "09:00-17:30,
+	// 22:00-07:00", during which output is buffered rather than appended.
+	// Empty means the CLI config's quiet_hours.
+	QuietHours string
+	// UploadRate caps the average rate of appends, such as "64KB/s";
+	// output beyond it waits for a later interval. Empty means the CLI
+	// config's upload_rate.
+	UploadRate string
+}
+
+// PageWriter is an io.WriteCloser that appends to a page. It is safe for
+// concurrent use.
+type PageWriter struct {
+	client  *api.Client
+	page    *api.Page
+	size    int64
+	maxSize int
+	quiet   []schedule.Window
+	budget  *schedule.Budget
+	now     func() time.Time
+
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	err    error
+	closed bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Writer creates a page titled title in projectID and returns a writer that
+// appends to it with default options. An empty projectID uses the CLI's
+// default project, unless it is listed in protected_projects; a protected
+// project must be passed explicitly.
+func Writer(projectID, title string) (*PageWriter, error) {
+	return NewWriter(projectID, title, Options{})
+}
+
+// NewWriter is Writer with options.
+func NewWriter(projectID, title string, opts Options) (*PageWriter, error) {
+	cfg, err := config.Load(opts.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.IsAuthenticated() {
+		return nil, errors.New("capture: not authenticated (run 'hyperclast auth login' or set HYPERCLAST_TOKEN)")
+	}
+	if projectID == "" {
+		projectID = cfg.GetDefaultProject()
+		if cfg.IsProtectedProject(projectID) {
+			return nil, fmt.Errorf("capture: default project %s is protected: pass it explicitly to write to it", projectID)
+		}
+	}
+	if projectID == "" {
+		return nil, errors.New("capture: no project specified and no default project configured")
+	}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if opts.QuietHours == "" {
+		opts.QuietHours = cfg.QuietHours
+	}
+	if opts.UploadRate == "" {
+		opts.UploadRate = cfg.UploadRate
+	}
+	if _, _, err := parseSchedule(opts); err != nil {
+		return nil, err
+	}
+
+	page, err := client.CreatePage(projectID, title, "", "log")
+	if err != nil {
+		return nil, fmt.Errorf("capture: failed to create page: %w", err)
+	}
+	return newPageWriter(client, page, opts)
+}
+
+// parseSchedule parses the quiet hours and upload rate in opts.
+func parseSchedule(opts Options) ([]schedule.Window, int64, error) {
+	quiet, err := schedule.ParseWindows(opts.QuietHours)
+	if err != nil {
+		return nil, 0, fmt.Errorf("capture: quiet hours: %w", err)
+	}
+	var rate int64
+	if opts.UploadRate != "" {
+		if rate, err = config.ParseByteRate(opts.UploadRate); err != nil {
+			return nil, 0, fmt.Errorf("capture: upload rate: %w", err)
+		}
+	}
+	return quiet, rate, nil
+}
+
+// newClient builds an API client from the CLI config the way the CLI does:
+// same timeout, retries, throttle, and certificate pinning.
+func newClient(cfg *config.Config) (*api.Client, error) {
+	timeout, err := cfg.GetTimeout()
+	if err != nil {
+		return nil, err
+	}
+	throttle, err := cfg.GetThrottle()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := api.ClientOptions{Timeout: timeout, Retries: cfg.GetRetries()}
+	if throttle > 0 {
+		opts.RateLimiter = api.NewRateLimiter(throttle)
+	}
+	if host := tlspin.Host(cfg.APIURL); host != "" && !cfg.UsesDefaultAPIURL() {
+		store, err := tlspin.Open(config.StateDir())
+		if err != nil {
+			return nil, err
+		}
+		opts.VerifyConnection = store.Verifier(host, nil)
+	}
+	return api.NewClientWithOptions(cfg.APIURL, cfg.Token, opts), nil
+}
+
+func newPageWriter(client *api.Client, page *api.Page, opts Options) (*PageWriter, error) {
+	quiet, rate, err := parseSchedule(opts)
+	if err != nil {
+		return nil, err
+	}
+	interval := opts.FlushInterval
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	maxSize := opts.MaxBatchBytes
+	if maxSize <= 0 {
+		maxSize = DefaultMaxBatchBytes
+	}
+
+	w := &PageWriter{
+		client:  client,
+		page:    page,
+		maxSize: maxSize,
+		quiet:   quiet,
+		budget:  schedule.NewBudget(rate, interval),
+		now:     time.Now,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if page.Details != nil {
+		w.size = int64(len(page.Details.Content))
+	}
+	go w.flushEvery(interval)
+	return w, nil
+}
+
+// PageID returns the external ID of the page being written to.
+func (w *PageWriter) PageID() string {
+	return w.page.ExternalID
+}
+
+// Write buffers p. It appends immediately, blocking, once the buffer reaches
+// the batch size; otherwise output is appended on the next interval. During
+// quiet hours, or beyond the upload rate, output stays buffered until a
+// later interval. An error from an earlier append is returned and the data
+// is not buffered.
+func (w *PageWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buf.Write(p)
+	if w.buf.Len() >= w.maxSize {
+		w.flushScheduledLocked()
+	}
+	return len(p), nil
+}
+
+// Flush appends everything buffered up to the last complete line, even
+// during quiet hours or beyond the upload rate.
+func (w *PageWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushLocked(false)
+	return w.err
+}
+
+// Close appends any remaining output, including a final partial line, and
+// stops the background flusher. Like Flush, it ignores quiet hours and the
+// upload rate.
+func (w *PageWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return w.err
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	close(w.stop)
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushLocked(true)
+	return w.err
+}
+
+func (w *PageWriter) flushEvery(interval time.Duration) {
+	defer close(w.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			w.flushScheduledLocked()
+			w.mu.Unlock()
+		}
+	}
+}
+
+// flushLocked appends the flushable part of the buffer. Unless final, only
+// complete lines are sent, so appends never split a line mid-way; a buffer
+// holding one oversized line is sent up to its last complete character.
+func (w *PageWriter) flushLocked(final bool) {
+	if w.err != nil || w.buf.Len() == 0 {
+		return
+	}
+	n := flushableBytes(w.buf.Bytes(), final, w.maxSize)
+	if n == 0 {
+		return
+	}
+
+	w.appendLocked(n)
+}
+
+// flushScheduledLocked is flushLocked for the appends the writer makes on
+// its own: nothing is sent during quiet hours, and no more than the upload
+// budget allows, except that a line longer than the whole budget is sent
+// once the budget has any room.
+func (w *PageWriter) flushScheduledLocked() {
+	if w.err != nil || w.buf.Len() == 0 {
+		return
+	}
+	// Output held back still has to fit on the page; fail now rather than
+	// buffer without bound.
+	if w.size+int64(w.buf.Len()) > api.MaxPageBytes {
+		w.err = ErrPageFull
+		w.buf.Reset()
+		return
+	}
+	now := w.now()
+	if schedule.Quiet(w.quiet, now) {
+		return
+	}
+	avail := w.budget.Available(now)
+	if avail == 0 {
+		return
+	}
+
+	buf := w.buf.Bytes()
+	n := flushableBytes(buf[:min(len(buf), avail)], false, w.maxSize)
+	if n == 0 && avail < len(buf) {
+		n = firstChunk(buf, w.maxSize)
+	}
+	if n > 0 && w.appendLocked(n) {
+		w.budget.Spend(n)
+	}
+}
+
+// appendLocked appends the first n buffered bytes and reports whether it
+// did.
+func (w *PageWriter) appendLocked(n int) bool {
+	if w.size+int64(n) > api.MaxPageBytes {
+		w.err = ErrPageFull
+		w.buf.Reset()
+		return false
+	}
+	chunk := string(w.buf.Next(n))
+	if _, err := w.client.UpdateFetchedPageContent(w.page, chunk, "append"); err != nil {
+		w.err = fmt.Errorf("capture: failed to append to page: %w", err)
+		return false
+	}
+	w.size += int64(n)
+	return true
+}
+
+// firstChunk returns the length of buf's first complete line, or of its
+// first maxSize bytes if that line is longer; 0 if the line is incomplete.
+func firstChunk(buf []byte, maxSize int) int {
+	if i := bytes.IndexByte(buf, '\n'); i >= 0 && i < maxSize {
+		return i + 1
+	}
+	return flushableBytes(buf[:min(len(buf), maxSize)], false, maxSize)
+}
+
+// flushableBytes returns how many leading bytes of buf to send.
+func flushableBytes(buf []byte, final bool, maxSize int) int {
+	if final {
+		return len(buf)
+	}
+	if i := bytes.LastIndexByte(buf, '\n'); i >= 0 {
+		return i + 1
+	}
+	if len(buf) < maxSize {
+		return 0
+	}
+	n := len(buf)
+	for n > 0 && !utf8.Valid(buf[:n]) && len(buf)-n < utf8.UTFMax {
+		n--
+	}
+	return n
+}
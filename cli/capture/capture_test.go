@@ -0,0 +1,282 @@
+package capture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// pageServer records the page created and the content of each append.
+type pageServer struct {
+	mu      sync.Mutex
+	created api.CreatePageRequest
+	appends []string
+}
+
+func newPageServer(t *testing.T) (*pageServer, *httptest.Server) {
+	s := &pageServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&s.created)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_cap", Title: s.created.Title, Role: api.RoleAdmin})
+		case http.MethodPut:
+			var req api.UpdatePageContentRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Mode != "append" || r.URL.Path != "/pages/page_cap/" {
+				t.Errorf("unexpected update: %s mode=%q", r.URL.Path, req.Mode)
+			}
+			s.appends = append(s.appends, req.Details.Content)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_cap"})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func (s *pageServer) sent() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.appends...)
+}
+
+func testWriter(t *testing.T, opts Options) (*PageWriter, *pageServer) {
+	s, server := newPageServer(t)
+	page := &api.Page{ExternalID: "page_cap", Title: "diag", Role: api.RoleAdmin}
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = time.Hour
+	}
+	w, err := newPageWriter(api.NewClient(server.URL, "test-token"), page, opts)
+	if err != nil {
+		t.Fatalf("newPageWriter: %v", err)
+	}
+	return w, s
+}
+
+func TestNewWriter_UsesCLIConfig(t *testing.T) {
+	s, server := newPageServer(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := fmt.Sprintf("api_url: %s\ndefaults:\n  project_id: proj_default\n", server.URL)
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HYPERCLAST_CONFIG", path)
+	t.Setenv("HYPERCLAST_TOKEN", "env-token")
+
+	w, err := Writer("", "nightly diagnostics")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Close()
+
+	if w.PageID() != "page_cap" {
+		t.Errorf("PageID() = %q, want page_cap", w.PageID())
+	}
+	if s.created.ProjectID != "proj_default" || s.created.Title != "nightly diagnostics" {
+		t.Errorf("created %+v, want default project and title", s.created)
+	}
+}
+
+func TestNewWriter_ProtectedDefaultProject(t *testing.T) {
+	s, server := newPageServer(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := fmt.Sprintf("api_url: %s\ntoken: tok\ndefaults:\n  project_id: proj_prod\nprotected_projects: [proj_prod]\n", server.URL)
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewWriter("", "diag", Options{ConfigPath: path})
+	if err == nil || !strings.Contains(err.Error(), "proj_prod is protected") {
+		t.Fatalf("NewWriter() = %v, want a protected project error", err)
+	}
+	if s.created.ProjectID != "" {
+		t.Errorf("created a page in %s despite the error", s.created.ProjectID)
+	}
+
+	w, err := NewWriter("proj_prod", "diag", Options{ConfigPath: path})
+	if err != nil {
+		t.Fatalf("explicit protected project refused: %v", err)
+	}
+	defer w.Close()
+	if s.created.ProjectID != "proj_prod" {
+		t.Errorf("created in %q, want proj_prod", s.created.ProjectID)
+	}
+}
+
+func TestNewWriter_RequiresAuth(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("HYPERCLAST_TOKEN", "")
+	_, err := NewWriter("proj_abc", "x", Options{ConfigPath: path})
+	if err == nil || !strings.Contains(err.Error(), "not authenticated") {
+		t.Errorf("err = %v, want not authenticated", err)
+	}
+}
+
+func TestPageWriter_BatchesCompleteLines(t *testing.T) {
+	w, s := testWriter(t, Options{})
+
+	fmt.Fprintln(w, "first")
+	fmt.Fprint(w, "second")
+	fmt.Fprint(w, " line")
+	if got := s.sent(); len(got) != 0 {
+		t.Fatalf("appended %q before flush, want nothing", got)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error: %v", err)
+	}
+	if got := s.sent(); len(got) != 1 || got[0] != "first\n" {
+		t.Errorf("after Flush appended %q, want only the complete line", got)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if got := s.sent(); len(got) != 2 || got[1] != "second line" {
+		t.Errorf("after Close appended %q, want the partial line", got)
+	}
+	if _, err := w.Write([]byte("late")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Write after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestPageWriter_FlushesWhenBatchFull(t *testing.T) {
+	w, s := testWriter(t, Options{MaxBatchBytes: 16})
+	defer w.Close()
+
+	fmt.Fprint(w, "0123456789\nabcdefghij")
+	if got := s.sent(); len(got) != 1 || got[0] != "0123456789\n" {
+		t.Errorf("appended %q, want the complete line once the batch filled", got)
+	}
+}
+
+func TestPageWriter_FlushesOnInterval(t *testing.T) {
+	w, s := testWriter(t, Options{FlushInterval: 10 * time.Millisecond})
+	defer w.Close()
+
+	fmt.Fprintln(w, "tick")
+	deadline := time.Now().Add(2 * time.Second)
+	for len(s.sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := s.sent(); len(got) != 1 || got[0] != "tick\n" {
+		t.Errorf("appended %q, want the line after one interval", got)
+	}
+}
+
+func TestPageWriter_QuietHours(t *testing.T) {
+	w, s := testWriter(t, Options{MaxBatchBytes: 8, QuietHours: "09:00-17:30"})
+	defer w.Close()
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.Local)
+	w.now = func() time.Time { return now }
+
+	fmt.Fprintln(w, "during the call")
+	if got := s.sent(); len(got) != 0 {
+		t.Fatalf("appended %q during quiet hours", got)
+	}
+
+	now = now.Add(8 * time.Hour)
+	fmt.Fprintln(w, "after")
+	if got := s.sent(); len(got) != 1 || got[0] != "during the call\nafter\n" {
+		t.Errorf("appended %q, want the held output once quiet hours ended", got)
+	}
+}
+
+func TestPageWriter_UploadRate(t *testing.T) {
+	w, s := testWriter(t, Options{FlushInterval: time.Second, UploadRate: "10/s"})
+	defer w.Close()
+	now := time.Now()
+	w.now = func() time.Time { return now }
+	tick := func(after time.Duration) []string {
+		now = now.Add(after)
+		w.mu.Lock()
+		w.flushScheduledLocked()
+		w.mu.Unlock()
+		return s.sent()
+	}
+
+	fmt.Fprint(w, "aaaa\nbbbb\n"+strings.Repeat("c", 20)+"\ndd\n")
+	if got := tick(0); len(got) != 1 || got[0] != "aaaa\nbbbb\n" {
+		t.Fatalf("appended %q, want the 10 bytes one second allows", got)
+	}
+	if got := tick(0); len(got) != 1 {
+		t.Fatalf("appended %q with the budget spent", got)
+	}
+	if got := tick(100 * time.Millisecond); len(got) != 2 || len(got[1]) != 21 {
+		t.Fatalf("appended %q, want the long line once the budget had room", got)
+	}
+	if got := tick(time.Second); len(got) != 2 {
+		t.Fatalf("appended %q while the long line was paid back", got)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.sent(); len(got) != 3 || got[2] != "dd\n" {
+		t.Errorf("after Flush appended %q, want the rest regardless of the rate", got)
+	}
+}
+
+func TestNewWriter_InvalidSchedule(t *testing.T) {
+	_, server := newPageServer(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := fmt.Sprintf("api_url: %s\ntoken: tok\nquiet_hours: lunchtime\n", server.URL)
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewWriter("proj_x", "diag", Options{ConfigPath: path}); err == nil || !strings.Contains(err.Error(), "quiet hours") {
+		t.Errorf("NewWriter() = %v, want a quiet hours error", err)
+	}
+}
+
+func TestPageWriter_PageFull(t *testing.T) {
+	w, s := testWriter(t, Options{})
+	w.size = api.MaxPageBytes - 3
+
+	fmt.Fprintln(w, "too long")
+	if err := w.Flush(); !errors.Is(err, ErrPageFull) {
+		t.Errorf("Flush() = %v, want ErrPageFull", err)
+	}
+	if _, err := w.Write([]byte("more\n")); !errors.Is(err, ErrPageFull) {
+		t.Errorf("Write() = %v, want ErrPageFull", err)
+	}
+	if got := s.sent(); len(got) != 0 {
+		t.Errorf("appended %q, want nothing", got)
+	}
+	_ = w.Close()
+}
+
+func TestFlushableBytes(t *testing.T) {
+	tests := []struct {
+		buf   string
+		final bool
+		max   int
+		want  int
+	}{
+		{"a\nb", false, 100, 2},
+		{"abc", false, 100, 0},
+		{"abc", true, 100, 3},
+		{"abcdef", false, 4, 6},
+		{"ab\xc3\xa9", false, 4, 4},
+		{"abc\xc3", false, 4, 3},
+	}
+	for _, tt := range tests {
+		if got := flushableBytes([]byte(tt.buf), tt.final, tt.max); got != tt.want {
+			t.Errorf("flushableBytes(%q, %v, %d) = %d, want %d", tt.buf, tt.final, tt.max, got, tt.want)
+		}
+	}
+}
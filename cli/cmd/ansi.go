@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for terminal escape sequences in captured output. Commands that
+// capture another program's output strip them unless --keep-ansi is given;
+// 'page new' and 'page append' keep them unless --strip-ansi is.
+var (
+	stripANSI    bool
+	keepANSI     bool
+	ansiMarkdown bool
+)
+
+const (
+	ansiKeep       = "keep"
+	ansiStrip      = "strip"
+	ansiToMarkdown = "markdown"
+)
+
+// ansiMode is how the running command treats escape sequences, set by
+// resolveANSI.
+var ansiMode = ansiKeep
+
+// resolveANSI sets ansiMode from the flags, stripping by default if
+// stripByDefault is set.
+func resolveANSI(stripByDefault bool) error {
+	switch {
+	case ansiMarkdown && keepANSI:
+		return fmt.Errorf("--ansi-markdown cannot be combined with --keep-ansi")
+	case ansiMarkdown:
+		ansiMode = ansiToMarkdown
+	case stripANSI:
+		ansiMode = ansiStrip
+	case keepANSI:
+		ansiMode = ansiKeep
+	case stripByDefault:
+		ansiMode = ansiStrip
+	default:
+		ansiMode = ansiKeep
+	}
+	return nil
+}
+
+// checkPageNewANSI resolves the escape handling for 'page new', refusing
+// --ansi-markdown where the page would not be a single markdown page.
+func checkPageNewANSI() error {
+	if err := resolveANSI(false); err != nil || ansiMode != ansiToMarkdown {
+		return err
+	}
+	if pageFiletype != autoFiletype && pageFiletype != "md" {
+		return fmt.Errorf("--ansi-markdown saves a md page, so it cannot be combined with --filetype %s", pageFiletype)
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{streamOutput, "--stream"},
+		{len(pageFiles) > 0, "--files"},
+		{pageSplitOn != "", "--split-on"},
+		{csvColumnOpsSet(), "--csv-select, --csv-drop, and --csv-rename"},
+	} {
+		if conflict.set {
+			return fmt.Errorf("--ansi-markdown cannot be combined with %s", conflict.flag)
+		}
+	}
+	return nil
+}
+
+// filterANSI applies ansiMode to content.
+func filterANSI(content string) string {
+	switch ansiMode {
+	case ansiStrip:
+		return stripANSIEscapes(content)
+	case ansiToMarkdown:
+		return ansiToMarkdownText(content)
+	}
+	return content
+}
+
+// stripANSIEscapes removes terminal escape sequences from s: colors and
+// cursor movement, window titles and hyperlinks, and the rest.
+func stripANSIEscapes(s string) string {
+	if strings.IndexByte(s, 0x1b) < 0 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for {
+		i := strings.IndexByte(s, 0x1b)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		n, _, _ := ansiEscape(s[i:])
+		s = s[i+n:]
+	}
+}
+
+// ansiEscape returns the length of the escape sequence at the start of s,
+// which begins with ESC, and for a control sequence (CSI) its parameters and
+// final byte. A sequence cut off by the end of s takes the rest of it; a
+// string sequence, such as a window title, also ends at a newline.
+func ansiEscape(s string) (n int, params string, final byte) {
+	if len(s) < 2 {
+		return len(s), "", 0
+	}
+	switch c := s[1]; {
+	case c == '[':
+		i := 2
+		for i < len(s) && s[i] >= 0x30 && s[i] <= 0x3f {
+			i++
+		}
+		params = s[2:i]
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		if i < len(s) && s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1, params, s[i]
+		}
+		return i, "", 0
+	case c == ']' || c == 'P' || c == '_' || c == '^' || c == 'X':
+		for i := 2; i < len(s); i++ {
+			switch {
+			case s[i] == 0x07:
+				return i + 1, "", 0
+			case s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\':
+				return i + 2, "", 0
+			case s[i] == '\n':
+				return i, "", 0
+			}
+		}
+		return len(s), "", 0
+	case c >= 0x20 && c <= 0x2f:
+		// A character set selection, such as ESC ( B.
+		i := 2
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+			i++
+		}
+		return min(i+1, len(s)), "", 0
+	}
+	return 2, "", 0
+}
+
+// sgrState is the text style set by SGR sequences (ESC [ ... m).
+type sgrState struct {
+	bold, italic, underline bool
+	// color is the foreground: sgrDefault, sgrRed, or sgrOther.
+	color int
+}
+
+const (
+	sgrDefault = iota
+	sgrRed
+	sgrOther
+)
+
+// apply updates s with the parameters of one SGR sequence.
+func (s *sgrState) apply(params string) {
+	codes := strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' })
+	if len(codes) == 0 {
+		*s = sgrState{}
+		return
+	}
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*s = sgrState{}
+		case code == 1:
+			s.bold = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 21 || code == 22:
+			s.bold = false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 31 || code == 91:
+			s.color = sgrRed
+		case code >= 30 && code <= 37, code >= 90 && code <= 97:
+			s.color = sgrOther
+		case code == 39:
+			s.color = sgrDefault
+		case code == 38 || code == 48:
+			// 38;5;n or 38;2;r;g;b, and the same for backgrounds.
+			color := sgrOther
+			if i+2 < len(codes) && codes[i+1] == "5" {
+				if n := codes[i+2]; n == "1" || n == "9" {
+					color = sgrRed
+				}
+				i += 2
+			} else if i+1 < len(codes) && codes[i+1] == "2" {
+				i = min(i+4, len(codes)-1)
+			}
+			if code == 38 {
+				s.color = color
+			}
+		}
+	}
+}
+
+// markers returns the markdown that wraps text in style s: strong for bold
+// or red text, where errors usually are, and emphasis for other styles.
+func (s sgrState) markers() string {
+	switch {
+	case s.bold || s.color == sgrRed:
+		return "**"
+	case s.italic || s.underline || s.color == sgrOther:
+		return "*"
+	}
+	return ""
+}
+
+// ansiToMarkdownText converts text styled with escape sequences to
+// markdown: each line kept as a line, styled runs as strong or emphasis,
+// and markdown syntax in the text escaped, so that a colored log reads as
+// it did in the terminal. Other escape sequences are dropped.
+func ansiToMarkdownText(s string) string {
+	var b strings.Builder
+	var style sgrState
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		type run struct {
+			markers string
+			text    string
+		}
+		var runs []run
+		for line != "" {
+			j := strings.IndexByte(line, 0x1b)
+			if j < 0 {
+				j = len(line)
+			}
+			if j > 0 {
+				runs = append(runs, run{style.markers(), line[:j]})
+			}
+			if j == len(line) {
+				break
+			}
+			n, params, final := ansiEscape(line[j:])
+			if final == 'm' {
+				style.apply(params)
+			}
+			line = line[j+n:]
+		}
+
+		var out strings.Builder
+		for _, r := range runs {
+			text := escapeMarkdownText(r.text, out.Len() == 0)
+			core := strings.TrimSpace(text)
+			if r.markers == "" || core == "" {
+				out.WriteString(text)
+				continue
+			}
+			start := strings.Index(text, core)
+			out.WriteString(text[:start] + r.markers + core + r.markers + text[start+len(core):])
+		}
+		line = strings.TrimRight(out.String(), " \t")
+		b.WriteString(line)
+		if i < len(lines)-1 {
+			if line != "" && lines[i+1] != "" {
+				// A hard break keeps the next line from joining this one.
+				b.WriteString("  ")
+			}
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// escapeMarkdownText escapes the characters in text that markdown would
+// read as syntax. At the start of a line, indentation becomes no-break
+// spaces, so it is kept rather than read as a code block, and characters
+// that would start a heading, list, or rule are escaped.
+func escapeMarkdownText(text string, lineStart bool) string {
+	var b strings.Builder
+	if lineStart {
+		i := 0
+		for ; i < len(text) && (text[i] == ' ' || text[i] == '\t'); i++ {
+			if text[i] == '\t' {
+				b.WriteString(strings.Repeat("\u00a0", 4))
+			} else {
+				b.WriteString("\u00a0")
+			}
+		}
+		text = text[i:]
+		if text != "" && strings.IndexByte("#-+=", text[0]) >= 0 {
+			b.WriteByte('\\')
+		} else if j := strings.IndexFunc(text, func(r rune) bool { return r < '0' || r > '9' }); j > 0 && (text[j] == '.' || text[j] == ')') {
+			b.WriteString(text[:j] + "\\")
+			text = text[j:]
+		}
+	}
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte("\\`*_[]<>&|~", text[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(text[i])
+	}
+	return b.String()
+}
+
+// addKeepANSIFlag adds --keep-ansi to a command that strips escape
+// sequences by default.
+func addKeepANSIFlag(c *cobra.Command) {
+	c.Flags().BoolVar(&keepANSI, "keep-ansi", false, "keep terminal escape sequences, such as colors, in the saved output")
+}
+
+// addANSIMarkdownFlag adds --ansi-markdown to a command that creates a page.
+func addANSIMarkdownFlag(c *cobra.Command) {
+	c.Flags().BoolVar(&ansiMarkdown, "ansi-markdown", false, "convert colors and bold in the output to markdown, saving a md page")
+}
+
+func init() {
+	pageNewCmd.Flags().BoolVar(&stripANSI, "strip-ansi", false, "remove terminal escape sequences, such as colors, from the input")
+	pageAppendCmd.Flags().BoolVar(&stripANSI, "strip-ansi", false, "remove terminal escape sequences, such as colors, from the input")
+	addANSIMarkdownFlag(pageNewCmd)
+}
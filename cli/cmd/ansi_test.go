@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestStripANSIEscapes(t *testing.T) {
+	for in, want := range map[string]string{
+		"plain\n":                                          "plain\n",
+		"\x1b[1;31mFAIL\x1b[0m test_x\n":                   "FAIL test_x\n",
+		"50%\x1b[2K\x1b[1G100%\n":                          "50%100%\n",
+		"\x1b]0;build\x07done":                             "done",
+		"see \x1b]8;;https://x.io\x1b\\docs\x1b]8;;\x1b\\": "see docs",
+		"\x1b(Bok":                                         "ok",
+		"cut \x1b[3":                                       "cut ",
+		"lone \x1b":                                        "lone ",
+		"\x1b]2;no end\nnext":                              "\nnext",
+	} {
+		if got := stripANSIEscapes(in); got != want {
+			t.Errorf("stripANSIEscapes(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestANSIToMarkdownText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"\x1b[1mBuild\x1b[0m ok\n\x1b[31mFAIL\x1b[0m: test_x", "**Build** ok  \n**FAIL**: test\\_x"},
+		{"\x1b[32mone\ntwo\x1b[39m three", "*one*  \n*two* three"},
+		{"\x1b[38;5;9m red \x1b[0m", "\u00a0**red**"},
+		{"  - item *star*\n\n# done\n1. first", "\u00a0\u00a0\\- item \\*star\\*\n\n\\# done  \n1\\. first"},
+		{"<b>&</b> | x", "\\<b\\>\\&\\</b\\> \\| x"},
+	}
+	for _, tt := range tests {
+		if got := ansiToMarkdownText(tt.in); got != tt.want {
+			t.Errorf("ansiToMarkdownText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPageNew_ANSI(t *testing.T) {
+	env := newCLIEnv(t)
+	env.stdin = "\x1b[1mok\x1b[0m\n"
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID))
+	if page, _ := env.server.Page(id); page.Details.Content != env.stdin {
+		t.Errorf("by default, content = %q, want the input unchanged", page.Details.Content)
+	}
+	id = strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--strip-ansi"))
+	if page, _ := env.server.Page(id); page.Details.Content != "ok\n" {
+		t.Errorf("with --strip-ansi, content = %q", page.Details.Content)
+	}
+	id = strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--ansi-markdown"))
+	if page, _ := env.server.Page(id); page.Details.Content != "**ok**\n" || page.Details.Filetype != "md" {
+		t.Errorf("with --ansi-markdown, page has %q (%s)", page.Details.Content, page.Details.Filetype)
+	}
+
+	env.stdin = "\x1b[31mlater\x1b[0m\n"
+	env.mustRun("page", "append", id, "--strip-ansi")
+	if page, _ := env.server.Page(id); !strings.HasSuffix(page.Details.Content, "**ok**\nlater\n") {
+		t.Errorf("appended page has %q", page.Details.Content)
+	}
+}
+
+func TestTee_StripsANSI(t *testing.T) {
+	env := newCLIEnv(t)
+	env.stdin = "\x1b[32mPASS\x1b[0m\n"
+
+	stdout := env.mustRun("tee", "--project", apitest.DefaultProjectID)
+	if stdout != env.stdin {
+		t.Errorf("stdout = %q, want the input unchanged", stdout)
+	}
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	if page, _ := env.server.Page(pages[0].ExternalID); page.Details.Content != "PASS\n" {
+		t.Errorf("content = %q", page.Details.Content)
+	}
+}
+
+func TestRun_ANSI(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+	script := `printf '\033[31mred\033[0m\n'`
+
+	stdout := env.mustRun("--quiet", "run", "--project", apitest.DefaultProjectID, "--", "sh", "-c", script)
+	if stdout != "\x1b[31mred\x1b[0m\n" {
+		t.Errorf("stdout = %q, want the output passed through unchanged", stdout)
+	}
+	env.mustRun("--quiet", "run", "--project", apitest.DefaultProjectID, "--keep-ansi", "--", "sh", "-c", script)
+	env.mustRun("--quiet", "run", "--project", apitest.DefaultProjectID, "--ansi-markdown", "--", "sh", "-c", script)
+
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 3 {
+		t.Fatalf("%d pages, want 3", len(pages))
+	}
+	want := map[string]string{"\n\nred\n": "log", "\n\n\x1b[31mred\x1b[0m\n": "log", "\n\n**red**\n": "md"}
+	for _, p := range pages {
+		page, _ := env.server.Page(p.ExternalID)
+		found := false
+		for suffix, filetype := range want {
+			if strings.HasSuffix(page.Details.Content, "---"+suffix) && page.Details.Filetype == filetype {
+				delete(want, suffix)
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("unexpected page %q (%s)", page.Details.Content, page.Details.Filetype)
+		}
+	}
+}
+
+func TestANSI_Errors(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--ansi-markdown", "--filetype", "log"}, "cannot be combined with --filetype log"},
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--ansi-markdown", "--stream"}, "--ansi-markdown cannot be combined with --stream"},
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--ansi-markdown", "--keep-ansi", "--", "true"}, "cannot be combined with --keep-ansi"},
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--ansi-markdown", "--split-streams", "--", "true"}, "--ansi-markdown cannot be combined with"},
+		{[]string{"tee", "--page", "page_x", "--ansi-markdown"}, "--ansi-markdown cannot be combined with --page"},
+	} {
+		if _, _, err := env.run(tt.args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	pageAppendMaxLines int
+	pageAppendMaxBytes string
+	pageAppendKeep     string
+)
+
+// appendBudget limits how much of the incoming content 'page append'
+// sends. Zero means no limit.
+type appendBudget struct {
+	MaxLines int
+	MaxBytes int64
+	// KeepHead keeps the start of the content instead of the end.
+	KeepHead bool
+	// Verb says what happened to the content in the marker line, e.g.
+	// "appended".
+	Verb string
+}
+
+// newAppendBudget checks the budget flags. It returns nil without them.
+func newAppendBudget() (*appendBudget, error) {
+	if pageAppendKeep != "head" && pageAppendKeep != "tail" {
+		return nil, fmt.Errorf("--keep must be head or tail")
+	}
+	if pageAppendMaxLines < 0 {
+		return nil, fmt.Errorf("--max-lines must not be negative")
+	}
+	b := &appendBudget{MaxLines: pageAppendMaxLines, KeepHead: pageAppendKeep == "head", Verb: "appended"}
+	if tailLines != 0 {
+		switch {
+		case tailLines < 0:
+			return nil, fmt.Errorf("--tail-lines must not be negative")
+		case pageAppendMaxLines != 0:
+			return nil, fmt.Errorf("--tail-lines cannot be combined with --max-lines")
+		case b.KeepHead:
+			return nil, fmt.Errorf("--tail-lines cannot be combined with --keep head")
+		}
+		b.MaxLines = tailLines
+	}
+	if pageAppendMaxBytes != "" {
+		n, err := parseByteSize(pageAppendMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("--max-bytes: %w", err)
+		}
+		b.MaxBytes = n
+	}
+	if b.MaxLines == 0 && b.MaxBytes == 0 {
+		return nil, nil
+	}
+	return b, nil
+}
+
+// budgetResult is content cut to a budget, with what was dropped.
+type budgetResult struct {
+	Content      string
+	DroppedLines int
+	DroppedBytes int64
+}
+
+// apply reads r and keeps what fits the budget. Only the kept part is held
+// in memory, apart from the last MaxLines lines when keeping the tail, so
+// input far over the server's size limit can still be cut down.
+func (b *appendBudget) apply(r io.Reader) (budgetResult, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	var res budgetResult
+	var kept []string
+	var keptBytes int64
+
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			if b.KeepHead {
+				if b.fits(len(kept)+1, keptBytes+int64(len(line))) {
+					kept = append(kept, line)
+					keptBytes += int64(len(line))
+				} else {
+					if partial := b.partialHead(len(kept), keptBytes, line); partial != "" {
+						kept = append(kept, partial)
+						keptBytes += int64(len(partial))
+						res.DroppedBytes += int64(len(line) - len(partial))
+						line = ""
+					}
+					if line != "" {
+						res.DroppedLines++
+						res.DroppedBytes += int64(len(line))
+					}
+				}
+			} else {
+				kept = append(kept, line)
+				keptBytes += int64(len(line))
+				for len(kept) > 0 && !b.fits(len(kept), keptBytes) {
+					if len(kept) == 1 {
+						// A single line over the byte budget keeps its end.
+						cut := trimToRuneStart(kept[0], b.MaxBytes)
+						res.DroppedBytes += int64(len(kept[0]) - len(cut))
+						keptBytes = int64(len(cut))
+						kept[0] = cut
+						break
+					}
+					res.DroppedLines++
+					res.DroppedBytes += int64(len(kept[0]))
+					keptBytes -= int64(len(kept[0]))
+					kept = kept[1:]
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return budgetResult{}, err
+		}
+	}
+
+	res.Content = strings.Join(kept, "")
+	return res, nil
+}
+
+func (b *appendBudget) fits(lines int, bytes int64) bool {
+	return (b.MaxLines == 0 || lines <= b.MaxLines) && (b.MaxBytes == 0 || bytes <= b.MaxBytes)
+}
+
+// partialHead returns the start of line when it is the first line and alone
+// exceeds the byte budget; otherwise only whole lines are kept.
+func (b *appendBudget) partialHead(lines int, bytes int64, line string) string {
+	if b.MaxBytes == 0 || lines > 0 {
+		return ""
+	}
+	n := int(b.MaxBytes - bytes)
+	for n > 0 && !utf8.RuneStart(line[n]) {
+		n--
+	}
+	return line[:n]
+}
+
+// trimToRuneStart returns the last max bytes of s, starting on a rune.
+func trimToRuneStart(s string, max int64) string {
+	start := len(s) - int(max)
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return s[start:]
+}
+
+// dropped describes what was cut, or "" if nothing was.
+func (r budgetResult) dropped() string {
+	switch {
+	case r.DroppedLines > 0:
+		return fmt.Sprintf("%d lines, %s", r.DroppedLines, formatBytes(r.DroppedBytes))
+	case r.DroppedBytes > 0:
+		return formatBytes(r.DroppedBytes)
+	}
+	return ""
+}
+
+// mark adds the marker line recording what was dropped to content: after
+// a kept head, or before a kept tail. It is not counted against the budget.
+func (b *appendBudget) mark(content, dropped string) string {
+	if b.KeepHead {
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		return content + fmt.Sprintf("[truncated by hyperclast: %s after this not %s]\n", dropped, b.Verb)
+	}
+	return fmt.Sprintf("[truncated by hyperclast: %s before this not %s]\n", dropped, b.Verb) + content
+}
+
+// readBudgetedFile reads path cut to budget, with a marker line where
+// content was dropped. The size limit is checked after cutting.
+func readBudgetedFile(path string, budget *appendBudget) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	res, err := budget.apply(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := validateTextContent([]byte(res.Content)); err != nil {
+		return "", err
+	}
+	content := normalizeNewlines(res.Content)
+	if content == "" {
+		return "", fmt.Errorf("no content provided")
+	}
+
+	if dropped := res.dropped(); dropped != "" {
+		printWarning("Input over budget; dropped %s", dropped)
+		content = budget.mark(content, dropped)
+	}
+
+	if len(content) > maxContentSize {
+		return "", fmt.Errorf("content too large (%d bytes, max %d)", len(content), maxContentSize)
+	}
+	return content, nil
+}
+
+func init() {
+	pageAppendCmd.Flags().IntVar(&pageAppendMaxLines, "max-lines", 0, "send at most this many lines of the input")
+	pageAppendCmd.Flags().StringVar(&pageAppendMaxBytes, "max-bytes", "", "send at most this much of the input, e.g. 64KB")
+	pageAppendCmd.Flags().StringVar(&pageAppendKeep, "keep", "tail", "with --max-lines or --max-bytes, keep the input's head or tail")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestAppendBudget_Apply(t *testing.T) {
+	in := "one\ntwo\nthree\nfour\n"
+	tests := []struct {
+		name   string
+		budget appendBudget
+		want   string
+		lines  int
+		bytes  int64
+	}{
+		{"tail lines", appendBudget{MaxLines: 2}, "three\nfour\n", 2, 8},
+		{"head lines", appendBudget{MaxLines: 2, KeepHead: true}, "one\ntwo\n", 2, 11},
+		{"tail bytes", appendBudget{MaxBytes: 10}, "four\n", 3, 14},
+		{"head bytes", appendBudget{MaxBytes: 10, KeepHead: true}, "one\ntwo\n", 2, 11},
+		{"one long line", appendBudget{MaxBytes: 2, KeepHead: true}, "on", 3, 17},
+		{"both", appendBudget{MaxLines: 3, MaxBytes: 14, KeepHead: true}, "one\ntwo\nthree\n", 1, 5},
+		{"under budget", appendBudget{MaxLines: 10}, in, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.budget.apply(strings.NewReader(in))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Content != tt.want || res.DroppedLines != tt.lines || res.DroppedBytes != tt.bytes {
+				t.Errorf("got %q (dropped %d lines, %d bytes), want %q (%d, %d)",
+					res.Content, res.DroppedLines, res.DroppedBytes, tt.want, tt.lines, tt.bytes)
+			}
+		})
+	}
+}
+
+func TestAppendBudget_KeepsRunes(t *testing.T) {
+	res, _ := (&appendBudget{MaxBytes: 4}).apply(strings.NewReader("héllo"))
+	if res.Content != "llo" {
+		t.Errorf("tail = %q, want %q", res.Content, "llo")
+	}
+	res, _ = (&appendBudget{MaxBytes: 2, KeepHead: true}).apply(strings.NewReader("héllo"))
+	if res.Content != "h" {
+		t.Errorf("head = %q, want %q", res.Content, "h")
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	for in, want := range map[string]int64{"512": 512, "64KB": 64 << 10, "1.5 MB": 3 << 19, "2mb": 2 << 20} {
+		if got, err := parseByteSize(in); err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	if _, err := parseByteSize("lots"); err == nil {
+		t.Error("parseByteSize(\"lots\") succeeded")
+	}
+}
+
+func TestPageAppend_MaxLines(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	var sent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var req api.UpdatePageContentRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			sent = req.Details.Content
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Title: "Shared"})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	// Well over the server's size limit, which the budget brings under.
+	pageFile = filepath.Join(t.TempDir(), "runaway.log")
+	line := strings.Repeat("x", 1023) + "\n"
+	_ = os.WriteFile(pageFile, []byte(strings.Repeat(line, 11*1024)+"the end\n"), 0644)
+	pageAppendMaxLines = 2
+	quiet = true
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, _ = os.Open(os.DevNull)
+	os.Stderr, _ = os.Open(os.DevNull)
+	err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+	os.Stdout, os.Stderr = stdout, stderr
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "[truncated by hyperclast: 11263 lines, 11.0 MB before this not appended]\n" + line + "the end\n"
+	if sent != want {
+		t.Errorf("sent %q...", sent[:min(len(sent), 120)])
+	}
+}
+
+func TestPageAppend_InvalidKeep(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	cfg = &config.Config{Token: "test-token"}
+	pageAppendKeep = "middle"
+
+	if err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"}); err == nil || !strings.Contains(err.Error(), "--keep") {
+		t.Errorf("err = %v, want --keep error", err)
+	}
+}
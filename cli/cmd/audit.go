@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/audit"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/usage"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// redactedFlags are never written to the audit log.
+var redactedFlags = map[string]bool{"token": true}
+
+// auditTrail collects the mutating requests made by the running command.
+// Requests may come from several goroutines.
+type auditTrail struct {
+	mu       sync.Mutex
+	started  time.Time
+	targets  []string
+	seen     map[string]bool
+	requests int
+	failed   int
+	bytes    int64
+}
+
+var currentAudit *auditTrail
+
+func newAuditTrail() *auditTrail {
+	return &auditTrail{started: time.Now().UTC(), seen: make(map[string]bool)}
+}
+
+// add records r if it changed, or tried to change, something.
+func (a *auditTrail) add(r api.RequestRecord) {
+	if a == nil || r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.requests++
+	if r.Status < 200 || r.Status >= 300 {
+		a.failed++
+	}
+	a.bytes += r.BytesSent
+	for _, id := range usage.PathIDs(r.Path) {
+		if !a.seen[id] {
+			a.seen[id] = true
+			a.targets = append(a.targets, id)
+		}
+	}
+}
+
+// observeRequest is the API request observer: every request goes to the
+// usage ledger, and mutating ones to the audit trail.
+func observeRequest(r api.RequestRecord) {
+	recordUsage(r)
+	currentAudit.add(r)
+}
+
+// recordAudit appends the finished command to the audit log if it made any
+// mutating request. Like usage accounting, failures are only reported in
+// verbose mode.
+func recordAudit(cmd *cobra.Command, runErr error) {
+	a := currentAudit
+	if a == nil || cmd == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.requests == 0 {
+		return
+	}
+
+	e := audit.Entry{
+		Time:       a.started,
+		Command:    cmd.CommandPath(),
+		Args:       cmd.Flags().Args(),
+		Targets:    append([]string{}, a.targets...),
+		Requests:   a.requests,
+		Failed:     a.failed,
+		BytesSent:  a.bytes,
+		Result:     audit.ResultOK,
+		OnBehalfOf: onBehalfOf(),
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if redactedFlags[f.Name] {
+			return
+		}
+		if e.Flags == nil {
+			e.Flags = make(map[string]string)
+		}
+		e.Flags[f.Name] = f.Value.String()
+	})
+	if runErr != nil {
+		e.Result, e.Error = audit.ResultError, runErr.Error()
+	}
+	if cfg != nil {
+		e.Token, e.APIURL = audit.TokenFingerprint(cfg.Token), cfg.APIURL
+	}
+	if err := audit.Open(config.StateDir()).Record(e); err != nil {
+		printDebug("failed to record audit entry: %v", err)
+	}
+}
+
+var (
+	auditShowSince   string
+	auditExportSince string
+	auditUntil       string
+	auditCommand     string
+	auditTarget      string
+	auditToken       string
+	auditFormat      string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the local log of changes made by the CLI",
+	Long: `Every command that creates, changes, or deletes something on the server
+is recorded in an append-only audit log in the CLI state directory: the
+command and its arguments, the IDs it touched, the bytes sent, whether it
+succeeded, and a fingerprint of the token used. Tokens themselves are never
+recorded.`,
+}
+
+var auditShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show recorded changes",
+	Long: `Show the changes recorded in the audit log, oldest first.
+
+--since and --until take a duration ago (7d, 12h) or a date (2026-01-13,
+or an RFC 3339 time).
+
+Examples:
+  hyperclast audit show
+  hyperclast audit show --since 2026-01-13 --until 2026-01-14
+  hyperclast audit show --token 3f2a9c1e07bd --command "page append"
+  hyperclast audit show --target page_xyz789 --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := auditEntries(auditShowSince)
+		if err != nil {
+			return err
+		}
+
+		if outputFmt == "json" {
+			if entries == nil {
+				entries = []audit.Entry{}
+			}
+			return json.NewEncoder(os.Stdout).Encode(entries)
+		}
+		if len(entries) == 0 {
+			printInfo("No changes recorded")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TIME\tCOMMAND\tTARGETS\tSENT\tTOKEN\tRESULT")
+		for _, e := range entries {
+			result := e.Result
+			if e.Failed > 0 {
+				result = fmt.Sprintf("%s (%d of %d requests failed)", result, e.Failed, e.Requests)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				e.Time.Local().Format("Jan 2, 2006 3:04 PM"), strings.TrimPrefix(e.Command, rootCmd.Name()+" "),
+				strings.Join(e.Targets, " "), formatBytes(e.BytesSent), e.Token, result)
+		}
+		return w.Flush()
+	},
+}
+
+var auditExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export recorded changes as JSON lines or CSV",
+	Long: `Write the audit log to stdout, filtered like 'audit show', as JSON lines
+(one entry per line, as stored) or CSV.
+
+Examples:
+  hyperclast audit export > audit.jsonl
+  hyperclast audit export --since 30d --format csv > audit.csv`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if auditFormat != "jsonl" && auditFormat != "csv" {
+			return fmt.Errorf("invalid --format %q (must be jsonl or csv)", auditFormat)
+		}
+		entries, err := auditEntries(auditExportSince)
+		if err != nil {
+			return err
+		}
+
+		if auditFormat == "jsonl" {
+			enc := json.NewEncoder(os.Stdout)
+			for _, e := range entries {
+				if err := enc.Encode(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"time", "command", "args", "flags", "targets", "requests", "failed", "bytes_sent", "result", "error", "token", "on_behalf_of", "api_url"})
+		for _, e := range entries {
+			var flags []string
+			for name, value := range e.Flags {
+				flags = append(flags, name+"="+value)
+			}
+			slices.Sort(flags)
+			_ = w.Write([]string{
+				e.Time.Format(time.RFC3339), e.Command, strings.Join(e.Args, " "), strings.Join(flags, " "),
+				strings.Join(e.Targets, " "), strconv.Itoa(e.Requests), strconv.Itoa(e.Failed),
+				strconv.FormatInt(e.BytesSent, 10), e.Result, e.Error, e.Token, e.OnBehalfOf, e.APIURL,
+			})
+		}
+		w.Flush()
+		return w.Error()
+	},
+}
+
+// auditEntries reads the audit log from sinceFlag on, applying the other
+// filter flags.
+func auditEntries(sinceFlag string) ([]audit.Entry, error) {
+	now := time.Now()
+	since, err := parseAuditTime(sinceFlag, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --since: %w", err)
+	}
+	until, err := parseAuditTime(auditUntil, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --until: %w", err)
+	}
+
+	entries, err := audit.Open(config.StateDir()).Entries(since, until)
+	if err != nil {
+		return nil, err
+	}
+	var matched []audit.Entry
+	for _, e := range entries {
+		if auditCommand != "" && !strings.Contains(e.Command, auditCommand) {
+			continue
+		}
+		if auditTarget != "" && !slices.Contains(e.Targets, auditTarget) {
+			continue
+		}
+		if auditToken != "" && !strings.HasPrefix(e.Token, auditToken) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	return matched, nil
+}
+
+// parseAuditTime parses a duration ago (7d, 12h), a date (2006-01-02, local
+// midnight), or an RFC 3339 time. An empty string is the zero time.
+func parseAuditTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	ago, err := parseLongDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a duration ago (7d, 12h) or a date (2006-01-02)", s)
+	}
+	return now.Add(-ago), nil
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditShowCmd)
+	auditCmd.AddCommand(auditExportCmd)
+
+	for _, cmd := range []*cobra.Command{auditShowCmd, auditExportCmd} {
+		cmd.Flags().StringVar(&auditUntil, "until", "", "only changes before this time: a duration ago or a date")
+		cmd.Flags().StringVar(&auditCommand, "command", "", "only commands containing this text, e.g. \"page append\"")
+		cmd.Flags().StringVar(&auditTarget, "target", "", "only changes touching this page, project, or other ID")
+		cmd.Flags().StringVar(&auditToken, "token", "", "only changes made with the token of this fingerprint (or a prefix of it)")
+	}
+	auditShowCmd.Flags().StringVar(&auditShowSince, "since", "7d", "only changes at or after this time: a duration ago (7d) or a date (2006-01-02)")
+	auditExportCmd.Flags().StringVar(&auditExportSince, "since", "", "only changes at or after this time: a duration ago (7d) or a date (2006-01-02) (default: all)")
+	auditExportCmd.Flags().StringVar(&auditFormat, "format", "jsonl", "output format: jsonl or csv")
+}
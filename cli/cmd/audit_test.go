@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/audit"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func resetAuditFlags() {
+	currentAudit = nil
+	auditShowSince, auditExportSince, auditUntil = "7d", "", ""
+	auditCommand, auditTarget, auditToken, auditFormat = "", "", "", "jsonl"
+	outputFmt = "text"
+}
+
+// runAudited records one command as recordAudit does after Execute.
+func runAudited(t *testing.T, args []string, requests []api.RequestRecord, runErr error) {
+	t.Helper()
+	c := &cobra.Command{Use: "append"}
+	c.Flags().String("mode", "", "")
+	c.Flags().String("token", "", "")
+	if err := c.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	currentAudit = newAuditTrail()
+	for _, r := range requests {
+		currentAudit.add(r)
+	}
+	recordAudit(c, runErr)
+}
+
+func TestAudit_RecordsMutatingCommands(t *testing.T) {
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	resetAuditFlags()
+	defer resetAuditFlags()
+	cfg = &config.Config{APIURL: "https://hc.example.com/api", Token: "bot-token"}
+
+	runAudited(t, []string{"page_1", "--mode", "append", "--token", "secret"}, []api.RequestRecord{
+		{Method: http.MethodGet, Path: "/pages/page_1/?omit=content", Status: 200},
+		{Method: http.MethodPut, Path: "/pages/page_1/", Status: 200, BytesSent: 40},
+		{Method: http.MethodPut, Path: "/pages/page_1/", Status: 500, BytesSent: 2},
+	}, nil)
+	runAudited(t, []string{"page_2"}, []api.RequestRecord{
+		{Method: http.MethodGet, Path: "/pages/page_2/", Status: 200},
+	}, nil)
+
+	entries, err := audit.Open(config.StateDir()).Entries(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("recorded %d entries, want only the command that made changes", len(entries))
+	}
+	e := entries[0]
+	if strings.Join(e.Targets, ",") != "page_1" || e.Requests != 2 || e.Failed != 1 || e.BytesSent != 42 {
+		t.Errorf("entry = %+v, want page_1 with 2 requests, 1 failed, 42 bytes", e)
+	}
+	if e.Flags["mode"] != "append" || e.Flags["token"] != "" || e.Token != audit.TokenFingerprint("bot-token") {
+		t.Errorf("flags = %v, token = %q; want mode kept, token flag redacted, token fingerprinted", e.Flags, e.Token)
+	}
+}
+
+func TestAudit_ShowAndExportFilter(t *testing.T) {
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	resetAuditFlags()
+	defer resetAuditFlags()
+	cfg = &config.Config{Token: "bot-token"}
+
+	runAudited(t, []string{"page_1"}, []api.RequestRecord{{Method: http.MethodPut, Path: "/pages/page_1/", Status: 200}}, nil)
+	runAudited(t, []string{"page_2"}, []api.RequestRecord{{Method: http.MethodDelete, Path: "/pages/page_2/", Status: 404}}, errors.New("boom"))
+
+	outputFmt, auditTarget = "json", "page_2"
+	out := captureArchiveOutput(t, func() error { return auditShowCmd.RunE(auditShowCmd, nil) })
+	var shown []audit.Entry
+	if err := json.Unmarshal([]byte(out), &shown); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(shown) != 1 || shown[0].Result != audit.ResultError || shown[0].Error != "boom" {
+		t.Errorf("audit show --target page_2 = %+v, want the failed delete", shown)
+	}
+
+	auditTarget, auditFormat, auditUntil = "", "csv", "1h"
+	out = captureArchiveOutput(t, func() error { return auditExportCmd.RunE(auditExportCmd, nil) })
+	if rows, _ := csv.NewReader(strings.NewReader(out)).ReadAll(); len(rows) != 1 {
+		t.Errorf("export --until 1h = %d rows, want only the header", len(rows))
+	}
+
+	auditUntil = ""
+	out = captureArchiveOutput(t, func() error { return auditExportCmd.RunE(auditExportCmd, nil) })
+	if rows, _ := csv.NewReader(strings.NewReader(out)).ReadAll(); len(rows) != 3 || rows[1][4] != "page_1" {
+		t.Errorf("export = %v, want a header and two rows", rows)
+	}
+}
+
+func TestParseAuditTime(t *testing.T) {
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.Local)
+	for in, want := range map[string]time.Time{
+		"":                     {},
+		"2d":                   now.Add(-48 * time.Hour),
+		"2026-01-13":           time.Date(2026, 1, 13, 0, 0, 0, 0, time.Local),
+		"2026-01-13T09:30:00Z": time.Date(2026, 1, 13, 9, 30, 0, 0, time.UTC),
+	} {
+		got, err := parseAuditTime(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseAuditTime(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseAuditTime("last tuesday", now); err == nil {
+		t.Error("parseAuditTime accepted \"last tuesday\"")
+	}
+}
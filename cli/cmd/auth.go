@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,10 +9,17 @@ import (
 	"syscall"
 
 	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/tlspin"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
+var (
+	authValidateScopes bool
+	authStatusScopes   bool
+)
+
 var authCmd = &cobra.Command{
 	Use:   "auth",
 	Short: "Manage authentication",
@@ -21,7 +29,10 @@ var authCmd = &cobra.Command{
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Authenticate with your API token",
-	Long:  `Authenticate with Hyperclast using your API token.`,
+	Long: `Authenticate with Hyperclast using your API token.
+
+With --validate-scopes, the token is also checked against the organizations
+and projects endpoints before it is saved, and its label and orgs are shown.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		settingsURL := baseURL() + "/settings/#developer"
 
@@ -48,18 +59,29 @@ var authLoginCmd = &cobra.Command{
 		printDebug("API URL: %s", cfg.APIURL)
 		printDebug("Token length: %d", len(token))
 
-		client := api.NewClient(cfg.APIURL, token)
+		client := api.NewClientWithOptions(cfg.APIURL, token, clientOptions())
 		user, err := client.GetCurrentUser()
 		if err != nil {
 			return fmt.Errorf("authentication failed: %w", err)
 		}
 
+		var scopes *tokenScopes
+		if authValidateScopes {
+			scopes, err = fetchTokenScopes(client, token)
+			if err != nil {
+				return fmt.Errorf("token validation failed: %w", err)
+			}
+		}
+
 		cfg.SetToken(token)
 		if err := cfg.Save(); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
 		printSuccess("Authenticated as %s", user.Email)
+		if scopes != nil {
+			printScopes(scopes)
+		}
 		printInfo("Config saved to %s", cfg.Path())
 
 		return nil
@@ -101,7 +123,7 @@ var authStatusCmd = &cobra.Command{
 			return nil
 		}
 
-		client := api.NewClient(cfg.APIURL, cfg.Token)
+		client := newClient()
 		user, err := client.GetCurrentUser()
 		if err != nil {
 			if outputFmt == "json" {
@@ -113,22 +135,145 @@ var authStatusCmd = &cobra.Command{
 			return fmt.Errorf("failed to verify token: %w", err)
 		}
 
+		var scopes *tokenScopes
+		if authStatusScopes {
+			scopes, err = fetchTokenScopes(client, cfg.Token)
+			if err != nil {
+				return fmt.Errorf("failed to fetch token scopes: %w", err)
+			}
+		}
+
 		if outputFmt == "json" {
-			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			result := map[string]any{
 				"authenticated": true,
 				"email":         user.Email,
 				"external_id":   user.ExternalID,
-			})
+			}
+			if scopes != nil {
+				result["token"] = scopes
+			}
+			return json.NewEncoder(os.Stdout).Encode(result)
 		}
 
 		printSuccess("Authenticated as %s", user.Email)
+		if scopes != nil {
+			printScopes(scopes)
+		}
+		return nil
+	},
+}
+
+var authTrustCmd = &cobra.Command{
+	Use:   "trust",
+	Short: "Pin the API server's current TLS certificate",
+	Long: `Record the TLS certificate currently presented by a self-hosted API server.
+
+The CLI pins a self-hosted server's certificate the first time it connects
+and refuses to talk to it if the certificate later changes. Run this after
+the server's certificate has been legitimately replaced. The new certificate
+must still be valid for the system's trusted CAs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.UsesDefaultAPIURL() {
+			return fmt.Errorf("certificate pinning only applies to self-hosted API URLs")
+		}
+		host := tlspin.Host(cfg.APIURL)
+		if host == "" {
+			return fmt.Errorf("certificate pinning only applies to https API URLs")
+		}
+
+		store, err := tlspin.Open(config.StateDir())
+		if err != nil {
+			return err
+		}
+		fingerprint, err := fetchServerFingerprint(host)
+		if err != nil {
+			return err
+		}
+
+		previous := store.Lookup(host)
+		if previous == fingerprint {
+			printInfo("Certificate for %s is already trusted (%s)", host, fingerprint)
+			return nil
+		}
+		if err := store.Pin(host, fingerprint); err != nil {
+			return err
+		}
+
+		if previous != "" {
+			printInfo("Previous: %s", previous)
+		}
+		printSuccess("Trusted certificate for %s (%s)", host, fingerprint)
 		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authTrustCmd)
 	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authLogoutCmd)
 	authCmd.AddCommand(authStatusCmd)
+
+	authLoginCmd.Flags().BoolVar(&authValidateScopes, "validate-scopes", false, "check the token can read orgs and projects, and show its label and orgs")
+	authStatusCmd.Flags().BoolVar(&authStatusScopes, "scopes", false, "show the token's label and orgs")
+}
+
+// tokenScopes describes what the server reports about the current token.
+// The tokens API returns no scopes, so none are shown; the orgs are those
+// the token was able to list.
+type tokenScopes struct {
+	Label     string   `json:"label,omitempty"`
+	ManagedBy string   `json:"managed_by"`
+	Default   bool     `json:"default,omitempty"`
+	Orgs      []string `json:"orgs"`
+}
+
+// fetchTokenScopes identifies token among the user's tokens and confirms it
+// can list the orgs and projects it will be used with. The tokens API lists
+// only user-managed tokens, so a token missing from it was created by device
+// login.
+func fetchTokenScopes(client *api.Client, token string) (*tokenScopes, error) {
+	scopes := &tokenScopes{ManagedBy: "device"}
+
+	tokens, err := client.ListAccessTokens()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list tokens: %w", err)
+	}
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Value), []byte(token)) == 1 {
+			scopes.Label = t.Label
+			scopes.ManagedBy = "user"
+			scopes.Default = t.IsDefault
+			break
+		}
+	}
+
+	orgs, err := client.ListOrgs()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list organizations: %w", err)
+	}
+	scopes.Orgs = make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		scopes.Orgs = append(scopes.Orgs, org.Name)
+	}
+
+	if _, err := client.ListProjects(""); err != nil {
+		return nil, fmt.Errorf("cannot list projects: %w", err)
+	}
+
+	return scopes, nil
+}
+
+func printScopes(s *tokenScopes) {
+	switch {
+	case s.ManagedBy != "user":
+		printInfo("  Token:  created by device login")
+	case s.Default:
+		printInfo("  Token:  %s (default)", s.Label)
+	default:
+		printInfo("  Token:  %s", s.Label)
+	}
+	if len(s.Orgs) > 0 {
+		printInfo("  Orgs:   %s", strings.Join(s.Orgs, ", "))
+	}
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hyperclast/workspace/cli/internal/api"
 	"github.com/hyperclast/workspace/cli/internal/config"
 )
 
@@ -16,6 +17,8 @@ func resetAuthFlags() {
 	outputFmt = "text"
 	quiet = false
 	verbose = false
+	authValidateScopes = false
+	authStatusScopes = false
 }
 
 func TestAuthStatus_NotAuthenticated(t *testing.T) {
@@ -237,3 +240,82 @@ func TestAuthLogout_WhenNotAuthenticated(t *testing.T) {
 		t.Errorf("output = %q, expected 'Not currently authenticated'", string(output))
 	}
 }
+
+func TestAuthStatus_Scopes_JSON(t *testing.T) {
+	resetAuthFlags()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/me/":
+			_, _ = w.Write([]byte(`{"external_id": "usr_123", "email": "test@example.com"}`))
+		case "/users/me/tokens/":
+			_, _ = w.Write([]byte(`[{"external_id": "tok_1", "value": "other", "label": "Laptop"},
+				{"external_id": "tok_2", "value": "valid-token", "label": "CI"}]`))
+		case "/orgs/":
+			_, _ = w.Write([]byte(`[{"external_id": "org_1", "name": "Acme"}]`))
+		case "/projects/":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg = &config.Config{
+		APIURL: server.URL,
+		Token:  "valid-token",
+	}
+	outputFmt = "json"
+	authStatusScopes = true
+	defer resetAuthFlags()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := authStatusCmd.RunE(authStatusCmd, []string{})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, _ := io.ReadAll(r)
+	var result struct {
+		Token tokenScopes `json:"token"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("output is not valid JSON: %v\nraw: %s", err, string(output))
+	}
+	if result.Token.Label != "CI" || result.Token.ManagedBy != "user" || result.Token.Default {
+		t.Errorf("unexpected token scopes: %+v", result.Token)
+	}
+	if strings.Contains(string(output), `"access"`) {
+		t.Errorf("output claims an access level the server did not report: %s", output)
+	}
+	if len(result.Token.Orgs) != 1 || result.Token.Orgs[0] != "Acme" {
+		t.Errorf("orgs = %v, want [Acme]", result.Token.Orgs)
+	}
+}
+
+func TestFetchTokenScopes_ProjectsForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/me/tokens/":
+			_, _ = w.Write([]byte(`[]`))
+		case "/orgs/":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "forbidden"}`))
+		}
+	}))
+	defer server.Close()
+
+	_, err := fetchTokenScopes(api.NewClient(server.URL, "device-token"), "device-token")
+	if err == nil || !strings.Contains(err.Error(), "cannot list projects") {
+		t.Errorf("err = %v, want 'cannot list projects'", err)
+	}
+}
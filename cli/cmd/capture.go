@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	captureTitle  string
+	captureLabels []string
+	captureDryRun bool
+)
+
+var captureCmd = &cobra.Command{
+	Use:   "capture",
+	Short: "Capture piped input to a page chosen by routing rules",
+	Long: `Create a page from stdin or a file, choosing its project, labels, and
+title from the routes in the config file. The first route whose match
+pattern is found in the content wins; a route without a pattern matches
+everything. With no matching route, the default project is used.
+
+Example config:
+  routes:
+    - match: '^panic:'
+      project: proj_backend
+      labels: [sev=high]
+      title: 'Panic on {{hostname}}'
+    - match: 'FAIL|ERROR'
+      project: proj_ci
+      labels: [sev=medium]
+
+Patterns are Go regular expressions; ^ and $ match at line boundaries.
+
+Examples:
+  ./nightly.sh 2>&1 | hyperclast capture
+  hyperclast capture --file crash.log --label host=web-3
+  hyperclast capture --file crash.log --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runCapture,
+}
+
+// captureRoute is a config route with its pattern compiled.
+type captureRoute struct {
+	config.Route
+	index   int
+	pattern *regexp.Regexp
+}
+
+// compileRoutes validates and compiles the config routes.
+func compileRoutes(routes []config.Route) ([]captureRoute, error) {
+	compiled := make([]captureRoute, 0, len(routes))
+	for i, r := range routes {
+		if r.Project == "" {
+			return nil, fmt.Errorf("routes[%d]: project is required", i)
+		}
+		cr := captureRoute{Route: r, index: i}
+		if r.Match != "" {
+			re, err := regexp.Compile("(?m)" + r.Match)
+			if err != nil {
+				return nil, fmt.Errorf("routes[%d]: invalid match pattern: %w", i, err)
+			}
+			cr.pattern = re
+		}
+		compiled = append(compiled, cr)
+	}
+	return compiled, nil
+}
+
+// matchRoute returns the first route matching content.
+func matchRoute(routes []captureRoute, content string) (captureRoute, bool) {
+	for _, r := range routes {
+		if r.pattern == nil || r.pattern.MatchString(content) {
+			return r, true
+		}
+	}
+	return captureRoute{}, false
+}
+
+// describe names the route for messages.
+func (r captureRoute) describe() string {
+	if r.Match == "" {
+		return fmt.Sprintf("routes[%d] (catch-all)", r.index)
+	}
+	return fmt.Sprintf("routes[%d] (%s)", r.index, r.Match)
+}
+
+// mergeLabels appends extra to labels, dropping duplicates.
+func mergeLabels(labels, extra []string) []string {
+	var out []string
+	for _, l := range slices.Concat(labels, extra) {
+		if l = strings.TrimSpace(l); l != "" && !slices.Contains(out, l) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func runCapture(cmd *cobra.Command, args []string) error {
+	if !captureDryRun {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+	}
+
+	routes, err := compileRoutes(cfg.Routes)
+	if err != nil {
+		return fmt.Errorf("invalid routes in config: %w", err)
+	}
+
+	content, err := readContent()
+	if err != nil {
+		return err
+	}
+
+	route, matched := matchRoute(routes, content)
+	projectID := route.Project
+	via := route.describe()
+	if !matched {
+		projectID = cfg.GetDefaultProject()
+		via = "no route matched; default project"
+		if projectID == "" {
+			cleanupStdinTemp()
+			return fmt.Errorf("no route matched and no default project configured")
+		}
+		// Content is read from stdin, so there is no confirming: captures
+		// reach a protected project only through a route naming it.
+		if cfg.IsProtectedProject(projectID) && !captureDryRun {
+			cleanupStdinTemp()
+			return fmt.Errorf("no route matched and the default project %s is protected; add a route for it", projectID)
+		}
+	}
+
+	title := captureTitle
+	if title == "" && route.Title != "" {
+		title, err = expandPlaceholders(route.Title)
+		if err != nil {
+			return handleContentError(fmt.Errorf("routes[%d]: %w", route.index, err))
+		}
+	}
+	if title == "" {
+		title = generateDefaultTitle()
+	}
+
+	filetype := route.Filetype
+	if filetype == "" {
+		filetype = detectFiletype(content, "txt")
+	}
+
+	details := &api.PageDetails{
+		Content:  content,
+		Filetype: filetype,
+		Labels:   mergeLabels(route.Labels, captureLabels),
+	}
+
+	if captureDryRun {
+		cleanupStdinTemp()
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"route":      via,
+				"project_id": projectID,
+				"title":      title,
+				"filetype":   filetype,
+				"labels":     details.Labels,
+			})
+		}
+		fmt.Printf("Would create page \"%s\" in project %s via %s\n", title, projectID, via)
+		if len(details.Labels) > 0 {
+			fmt.Printf("Labels: %s\n", strings.Join(details.Labels, ", "))
+		}
+		return nil
+	}
+
+	client := newClient()
+	page, err := client.CreatePageWithDetails(projectID, title, details)
+	if err != nil {
+		return handleContentError(fmt.Errorf("failed to create page: %w", err))
+	}
+
+	cleanupStdinTemp()
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(page)
+	}
+
+	if quiet {
+		fmt.Println(page.ExternalID)
+		return nil
+	}
+
+	printSuccess("Created page \"%s\" (%s) in project %s", page.Title, page.ExternalID, projectID)
+	printInfo("  Routed by %s", via)
+	printInfo("  %s", pageURL(page.ExternalID))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(captureCmd)
+
+	captureCmd.Flags().StringVar(&pageFile, "file", "", "read content from file instead of stdin")
+	captureCmd.Flags().StringVar(&captureTitle, "title", "", "page title (default: the route's title, else a timestamp)")
+	captureCmd.Flags().StringArrayVar(&captureLabels, "label", nil, "add a label, e.g. host=web-3 (repeatable)")
+	captureCmd.Flags().BoolVar(&captureDryRun, "dry-run", false, "show where the content would go without creating a page")
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// Flags shared by the capture subcommands that read another program's
+// output.
+var (
+	capturePageID    string
+	captureProjectID string
+	captureFollow    bool
+	captureInterval  time.Duration
+)
+
+// maxTitleLength is the server's limit on page titles, in characters.
+const maxTitleLength = 100
+
+// commandCapture is an external command whose output becomes a log page.
+type commandCapture struct {
+	Name string
+	Args []string
+	// Title is the default title for a new page.
+	Title string
+	// Header is written before the output when a page is created.
+	Header string
+	// FollowArgs are added to Args with --follow.
+	FollowArgs []string
+	// MergeStderr captures the command's stderr along with its stdout,
+	// for commands that relay another program's output on both.
+	MergeStderr bool
+}
+
+// captureCommand returns the command to run; tests replace it.
+var captureCommand = func(ctx context.Context, name string, args ...string) (*exec.Cmd, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return nil, fmt.Errorf("%s not found on PATH", name)
+	}
+	return exec.CommandContext(ctx, name, args...), nil
+}
+
+// runCommandCapture runs cc and sends its output to --page, or to a new
+// page in --project. With --follow it streams output in batches until the
+// command exits or Ctrl-C.
+func runCommandCapture(cc commandCapture) error {
+	if err := requireAuth(); err != nil {
+		return err
+	}
+	if capturePageID != "" && (captureProjectID != "" || captureTitle != "") {
+		return fmt.Errorf("--page cannot be combined with --project or --title")
+	}
+	projectID := captureProjectID
+	if projectID == "" {
+		projectID = cfg.GetDefaultProject()
+	}
+	if capturePageID == "" && projectID == "" {
+		return fmt.Errorf("no project specified (use --project, --page, or set a default project)")
+	}
+	if err := resolveANSI(true); err != nil {
+		return err
+	}
+	if capturePageID == "" {
+		if err := confirmProtectedWrite(projectID, captureProjectID != "", "capture"); err != nil {
+			return err
+		}
+	}
+	title := captureTitle
+	if title == "" {
+		title = cc.Title
+	}
+
+	if captureFollow {
+		if captureInterval < minFollowInterval {
+			return fmt.Errorf("--interval must be at least %s", minFollowInterval)
+		}
+		return followCommandCapture(cc, projectID, title)
+	}
+
+	c, err := captureCommand(context.Background(), cc.Name, cc.Args...)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	if cc.MergeStderr {
+		out, err = c.CombinedOutput()
+		if err != nil && len(out) > 0 {
+			return fmt.Errorf("%s failed: %s", cc.Name, strings.TrimSpace(string(out)))
+		}
+	} else {
+		out, err = c.Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return fmt.Errorf("%s failed: %s", cc.Name, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", cc.Name, err)
+	}
+	output := filterANSI(strings.ToValidUTF8(normalizeNewlines(string(out)), "�"))
+	if strings.TrimSpace(output) == "" {
+		printInfo("No output from %s; nothing captured", cc.Name)
+		return nil
+	}
+
+	client := newClient()
+	var page *api.Page
+	if capturePageID != "" {
+		existing, err := client.GetPage(capturePageID)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if err := checkPageLock(client, existing); err != nil {
+			return err
+		}
+		if err := enforcePageQuota(newPageQuota(existing, output, "append"), false); err != nil {
+			return err
+		}
+		if page, err = client.UpdateFetchedPageContent(existing, output, "append"); err != nil {
+			return fmt.Errorf("failed to append to page: %w", err)
+		}
+	} else {
+		content := cc.Header + output
+		if int64(len(content)) > api.MaxPageBytes {
+			return fmt.Errorf("%s output is %s, over the %s per-page limit (narrow the time range)",
+				cc.Name, formatBytes(int64(len(content))), formatBytes(api.MaxPageBytes))
+		}
+		page, err = client.CreatePageWithDetails(projectID, title, &api.PageDetails{Content: content, Filetype: "log"})
+		if err != nil {
+			return fmt.Errorf("failed to create page: %w", err)
+		}
+	}
+
+	return printCommandCapture(page, countLines(output))
+}
+
+// followCommandCapture streams the command's output to the target page.
+func followCommandCapture(cc commandCapture, projectID, title string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := newClient()
+	var page *api.Page
+	var err error
+	if capturePageID != "" {
+		page, err = client.GetPage(capturePageID)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if err := checkPageLock(client, page); err != nil {
+			return err
+		}
+	} else {
+		page, err = client.CreatePageWithDetails(projectID, title, &api.PageDetails{Content: cc.Header, Filetype: "log"})
+		if err != nil {
+			return fmt.Errorf("failed to create page: %w", err)
+		}
+		printInfo("Streaming to page \"%s\" (%s)", page.Title, page.ExternalID)
+	}
+	c, err := captureCommand(ctx, cc.Name, append(cc.Args, cc.FollowArgs...)...)
+	if err != nil {
+		return err
+	}
+	stdout, err := startCapture(c, cc.MergeStderr)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", cc.Name, err)
+	}
+
+	lines, err := streamToPage(ctx, client, page, cc.Name, stdout, captureInterval, 0)
+	if c.Process != nil {
+		_ = c.Process.Kill()
+	}
+	waitErr := c.Wait()
+	if err != nil {
+		return err
+	}
+	if waitErr != nil && ctx.Err() == nil {
+		return fmt.Errorf("%s failed after %d lines: %w", cc.Name, lines, waitErr)
+	}
+
+	return printCommandCapture(page, lines)
+}
+
+// formatCapturedLines renders captured lines as the page text, replacing
+// invalid UTF-8.
+func formatCapturedLines(batch []muxLine) string {
+	var b strings.Builder
+	for _, l := range batch {
+		b.WriteString(strings.ToValidUTF8(l.Text, "�"))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// startCapture starts c and returns a reader for its output. With merge,
+// stdout and stderr share one pipe so their lines stay in order; otherwise
+// stderr passes through to ours.
+func startCapture(c *exec.Cmd, merge bool) (io.Reader, error) {
+	if !merge {
+		c.Stderr = os.Stderr
+		stdout, err := c.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		return stdout, c.Start()
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	c.Stdout, c.Stderr = w, w
+	err = c.Start()
+	// The child has its own copy of the write end; closing ours lets reads
+	// end when it exits.
+	_ = w.Close()
+	if err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func printCommandCapture(page *api.Page, lines int) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"external_id": page.ExternalID,
+			"title":       page.Title,
+			"lines":       lines,
+		})
+	}
+	if quiet {
+		fmt.Println(page.ExternalID)
+		return nil
+	}
+	printSuccess("Captured %d lines to page \"%s\" (%s)", lines, page.Title, page.ExternalID)
+	printInfo("  %s", pageURL(page.ExternalID))
+	return nil
+}
+
+// addCommandCaptureFlags adds the shared flags to a capture subcommand.
+func addCommandCaptureFlags(c *cobra.Command) {
+	c.Flags().StringVar(&capturePageID, "page", "", "append to this page instead of creating one")
+	c.Flags().StringVar(&captureProjectID, "project", "", "project for the new page (default: the default project)")
+	c.Flags().StringVar(&captureTitle, "title", "", "title for the new page")
+	c.Flags().BoolVar(&captureFollow, "follow", false, "keep streaming new output until Ctrl-C")
+	c.Flags().DurationVar(&captureInterval, "interval", 2*time.Second, "with --follow, how often to append buffered lines")
+	addKeepANSIFlag(c)
+}
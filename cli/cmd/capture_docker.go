@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	captureDockerSince string
+	captureDockerTail  string
+)
+
+var captureDockerCmd = &cobra.Command{
+	Use:   "docker <container>",
+	Short: "Capture a container's logs to a page",
+	Long: `Read a container's logs with the docker CLI and capture them as a log page,
+with the container and image in front-matter at the top of the page.
+
+Both the container's stdout and stderr are captured, with Docker's
+timestamps. Without --page a new page is created; with --follow new lines
+are appended every --interval until Ctrl-C or the container stops.
+
+Examples:
+  hyperclast capture docker web-1 --since 10m
+  hyperclast capture docker api --tail 500 --project proj_abc123
+  hyperclast capture docker worker --page page_xyz789 --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cc, err := dockerCapture(args[0])
+		if err != nil {
+			return err
+		}
+		return runCommandCapture(cc)
+	},
+}
+
+// dockerContainer is the part of docker inspect's output used for the
+// front-matter.
+type dockerContainer struct {
+	ID      string    `json:"Id"`
+	Name    string    `json:"Name"`
+	Image   string    `json:"Image"`
+	Created time.Time `json:"Created"`
+	Config  struct {
+		Image string `json:"Image"`
+	} `json:"Config"`
+	State struct {
+		Status string `json:"Status"`
+	} `json:"State"`
+}
+
+// inspectContainer looks up a container by name or ID.
+func inspectContainer(container string) (*dockerContainer, error) {
+	c, err := captureCommand(context.Background(), "docker", "inspect", "--type=container", "--format={{json .}}", container)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to inspect container: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	var info dockerContainer
+	if err := json.Unmarshal(out, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse docker inspect output: %w", err)
+	}
+	info.Name = strings.TrimPrefix(info.Name, "/")
+	return &info, nil
+}
+
+// dockerCapture builds the docker logs invocation for container.
+func dockerCapture(container string) (commandCapture, error) {
+	info, err := inspectContainer(container)
+	if err != nil {
+		return commandCapture{}, err
+	}
+
+	// Options go before the container, so --follow is added here rather
+	// than through FollowArgs.
+	args := []string{"logs", "--timestamps"}
+	if captureDockerSince != "" {
+		args = append(args, "--since="+captureDockerSince)
+	}
+	if captureDockerTail != "" {
+		args = append(args, "--tail="+captureDockerTail)
+	}
+	if captureFollow {
+		args = append(args, "--follow")
+	}
+	args = append(args, info.ID)
+
+	title := fmt.Sprintf("Docker: %s (%s)", info.Name, info.Config.Image)
+
+	return commandCapture{
+		Name:        "docker",
+		Args:        args,
+		Title:       truncateRunes(title, maxTitleLength),
+		Header:      dockerFrontMatter(info),
+		MergeStderr: true,
+	}, nil
+}
+
+// dockerFrontMatter describes the container at the top of a new page.
+func dockerFrontMatter(info *dockerContainer) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "Container: %s (%s)\n", info.Name, shortDockerID(info.ID))
+	fmt.Fprintf(&b, "Image: %s (%s)\n", info.Config.Image, shortDockerID(info.Image))
+	if !info.Created.IsZero() {
+		fmt.Fprintf(&b, "Created: %s UTC\n", info.Created.UTC().Format("2006-01-02 15:04:05"))
+	}
+	if info.State.Status != "" {
+		fmt.Fprintf(&b, "State: %s\n", info.State.Status)
+	}
+	if host, err := os.Hostname(); err == nil {
+		fmt.Fprintf(&b, "Host: %s\n", host)
+	}
+	fmt.Fprintf(&b, "Captured: %s UTC\n", time.Now().UTC().Format("2006-01-02 15:04:05"))
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+// shortDockerID abbreviates an ID the way docker ps does.
+func shortDockerID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+func init() {
+	captureCmd.AddCommand(captureDockerCmd)
+
+	captureDockerCmd.Flags().StringVar(&captureDockerSince, "since", "", "only logs since this time, e.g. 10m or 2026-03-02T14:00:00")
+	captureDockerCmd.Flags().StringVar(&captureDockerTail, "tail", "", "only the last N lines of existing logs")
+	addCommandCaptureFlags(captureDockerCmd)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+const testInspect = `{"Id":"3f2a9c0d1e4b5a6f7c8d9e0f","Name":"/web-1","Image":"sha256:abcdef0123456789ff","Created":"2026-03-02T13:00:00Z","Config":{"Image":"nginx:1.25"},"State":{"Status":"running"}}`
+
+// stubDocker answers docker inspect with testInspect and runs logsScript
+// for docker logs, recording the logs arguments.
+func stubDocker(t *testing.T, logsScript string) *[]string {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	var got []string
+	old := captureCommand
+	t.Cleanup(func() { captureCommand = old })
+	captureCommand = func(ctx context.Context, name string, args ...string) (*exec.Cmd, error) {
+		if args[0] == "inspect" {
+			return exec.CommandContext(ctx, "echo", testInspect), nil
+		}
+		got = append([]string{name}, args...)
+		return exec.CommandContext(ctx, "sh", "-c", logsScript), nil
+	}
+	return &got
+}
+
+func TestCaptureDocker_CreatesPageWithFrontMatter(t *testing.T) {
+	resetCaptureCommandFlags()
+	defer resetCaptureCommandFlags()
+	s := newCaptureServer(t)
+	args := stubDocker(t, `echo "2026-03-02T14:00:01Z GET /"; echo "2026-03-02T14:00:02Z upstream timed out" >&2`)
+	captureDockerSince = "10m"
+
+	runCaptureSubcommand(t, func() error { return captureDockerCmd.RunE(captureDockerCmd, []string{"web-1"}) })
+
+	want := "docker logs --timestamps --since=10m 3f2a9c0d1e4b5a6f7c8d9e0f"
+	if got := strings.Join(*args, " "); got != want {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+	if s.created.Title != "Docker: web-1 (nginx:1.25)" || s.created.Details.Filetype != "log" {
+		t.Errorf("created %+v", s.created)
+	}
+	content := s.created.Details.Content
+	for _, line := range []string{"---\nContainer: web-1 (3f2a9c0d1e4b)\n", "Image: nginx:1.25 (abcdef012345)\n", "State: running\n", "GET /\n", "upstream timed out\n"} {
+		if !strings.Contains(content, line) {
+			t.Errorf("content missing %q:\n%s", line, content)
+		}
+	}
+}
+
+func TestCaptureDocker_FollowAppendsWithoutFrontMatter(t *testing.T) {
+	resetCaptureCommandFlags()
+	defer resetCaptureCommandFlags()
+	s := newCaptureServer(t)
+	args := stubDocker(t, `echo one; echo two >&2`)
+	capturePageID = "page_xyz"
+	captureFollow = true
+
+	runCaptureSubcommand(t, func() error { return captureDockerCmd.RunE(captureDockerCmd, []string{"web-1"}) })
+
+	if got := strings.Join(*args, " "); got != "docker logs --timestamps --follow 3f2a9c0d1e4b5a6f7c8d9e0f" {
+		t.Errorf("ran %q", got)
+	}
+	joined := strings.Join(s.appends, "")
+	if !strings.Contains(joined, "one\n") || !strings.Contains(joined, "two\n") || strings.Contains(joined, "Container:") {
+		t.Errorf("appends = %q", s.appends)
+	}
+}
+
+func TestCaptureDocker_NoSuchContainer(t *testing.T) {
+	resetCaptureCommandFlags()
+	defer resetCaptureCommandFlags()
+	newCaptureServer(t)
+	stubCaptureCommand(t, `echo "Error: No such container: nope" >&2; exit 1`)
+
+	err := captureDockerCmd.RunE(captureDockerCmd, []string{"nope"})
+	if err == nil || !strings.Contains(err.Error(), "No such container: nope") {
+		t.Errorf("err = %v, want docker's error", err)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	captureJournalUnits    []string
+	captureJournalSince    string
+	captureJournalPriority string
+)
+
+var captureJournalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Capture systemd journal entries to a page",
+	Long: `Read journald entries with journalctl and capture them as a log page,
+for sharing service logs during incident triage.
+
+Entries are read in journalctl's short-iso format. Without --page a new page
+is created; with --follow new entries are appended every --interval until
+Ctrl-C.
+
+Examples:
+  hyperclast capture journal --unit myservice --since -1h
+  hyperclast capture journal --unit api --unit worker --since "2026-03-02 14:00"
+  hyperclast capture journal --unit myservice --page page_xyz789 --follow`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCommandCapture(journalCapture())
+	},
+}
+
+// journalCapture builds the journalctl invocation from the flags.
+func journalCapture() commandCapture {
+	args := []string{"--no-pager", "--quiet", "--output=short-iso"}
+	for _, u := range captureJournalUnits {
+		args = append(args, "--unit="+u)
+	}
+	if captureJournalSince != "" {
+		// The = form keeps relative times like -1h from parsing as flags.
+		args = append(args, "--since="+captureJournalSince)
+	}
+	if captureJournalPriority != "" {
+		args = append(args, "--priority="+captureJournalPriority)
+	}
+
+	source := "all units"
+	if len(captureJournalUnits) > 0 {
+		source = strings.Join(captureJournalUnits, ", ")
+	}
+	title := "Journal: " + source
+	if host, err := os.Hostname(); err == nil {
+		title += fmt.Sprintf(" (%s)", host)
+	}
+
+	return commandCapture{
+		Name:       "journalctl",
+		Args:       args,
+		Title:      truncateRunes(title, maxTitleLength),
+		FollowArgs: []string{"--follow"},
+	}
+}
+
+func init() {
+	captureCmd.AddCommand(captureJournalCmd)
+
+	captureJournalCmd.Flags().StringArrayVar(&captureJournalUnits, "unit", nil, "systemd unit to read (repeatable; default: all)")
+	captureJournalCmd.Flags().StringVar(&captureJournalSince, "since", "", "start time, e.g. -1h, today, or \"2026-03-02 14:00\"")
+	captureJournalCmd.Flags().StringVar(&captureJournalPriority, "priority", "", "only entries at or above this priority, e.g. warning")
+	addCommandCaptureFlags(captureJournalCmd)
+}
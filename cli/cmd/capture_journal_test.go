@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func resetCaptureCommandFlags() {
+	resetCaptureFlags()
+	capturePageID = ""
+	captureProjectID = ""
+	captureFollow = false
+	captureInterval = 2 * time.Second
+	captureJournalUnits = nil
+	captureJournalSince = ""
+	captureJournalPriority = ""
+	captureDockerSince = ""
+	captureDockerTail = ""
+}
+
+// stubCaptureCommand replaces the captured command with a shell script and
+// records the arguments it was given.
+func stubCaptureCommand(t *testing.T, script string) *[]string {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	var got []string
+	old := captureCommand
+	t.Cleanup(func() { captureCommand = old })
+	captureCommand = func(ctx context.Context, name string, args ...string) (*exec.Cmd, error) {
+		got = append([]string{name}, args...)
+		return exec.CommandContext(ctx, "sh", "-c", script), nil
+	}
+	return &got
+}
+
+// captureServer records the page created and any appends.
+type captureServer struct {
+	mu      sync.Mutex
+	created api.CreatePageRequest
+	appends []string
+}
+
+func newCaptureServer(t *testing.T) *captureServer {
+	s := &captureServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&s.created)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_new", Title: s.created.Title})
+		case http.MethodPut:
+			var req api.UpdatePageContentRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			s.appends = append(s.appends, req.Details.Content)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Title: "Incident"})
+		default:
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Title: "Incident", Details: &api.PageDetails{Content: "earlier\n"}})
+		}
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token", Defaults: config.Defaults{ProjectID: "proj_ops"}}
+	return s
+}
+
+func runCaptureSubcommand(t *testing.T, fn func() error) {
+	t.Helper()
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err := fn()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCaptureJournal_CreatesLogPage(t *testing.T) {
+	resetCaptureCommandFlags()
+	defer resetCaptureCommandFlags()
+	s := newCaptureServer(t)
+	args := stubCaptureCommand(t, `printf '2026-03-02T14:00:01+0000 web-1 myservice[42]: started\n'`)
+	captureJournalUnits = []string{"myservice"}
+	captureJournalSince = "-1h"
+
+	runCaptureSubcommand(t, func() error { return captureJournalCmd.RunE(captureJournalCmd, nil) })
+
+	want := "journalctl --no-pager --quiet --output=short-iso --unit=myservice --since=-1h"
+	if got := strings.Join(*args, " "); got != want {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+	if s.created.ProjectID != "proj_ops" || !strings.HasPrefix(s.created.Title, "Journal: myservice (") {
+		t.Errorf("created %+v, want default project and journal title", s.created)
+	}
+	if s.created.Details.Filetype != "log" || s.created.Details.Content != "2026-03-02T14:00:01+0000 web-1 myservice[42]: started\n" {
+		t.Errorf("details = %+v", s.created.Details)
+	}
+}
+
+func TestCaptureJournal_FollowAppendsToPage(t *testing.T) {
+	resetCaptureCommandFlags()
+	defer resetCaptureCommandFlags()
+	s := newCaptureServer(t)
+	args := stubCaptureCommand(t, `echo one; echo two`)
+	capturePageID = "page_xyz"
+	captureFollow = true
+
+	runCaptureSubcommand(t, func() error { return captureJournalCmd.RunE(captureJournalCmd, nil) })
+
+	if !strings.HasSuffix(strings.Join(*args, " "), "--follow") {
+		t.Errorf("ran %v, want --follow", *args)
+	}
+	if s.created.Title != "" {
+		t.Errorf("created a page with --page set")
+	}
+	if strings.Join(s.appends, "") != "one\ntwo\n" {
+		t.Errorf("appends = %q", s.appends)
+	}
+}
+
+func TestCaptureJournal_NoOutput(t *testing.T) {
+	resetCaptureCommandFlags()
+	defer resetCaptureCommandFlags()
+	s := newCaptureServer(t)
+	stubCaptureCommand(t, `true`)
+
+	runCaptureSubcommand(t, func() error { return captureJournalCmd.RunE(captureJournalCmd, nil) })
+
+	if s.created.Title != "" || len(s.appends) != 0 {
+		t.Errorf("captured empty output: %+v %q", s.created, s.appends)
+	}
+}
+
+func TestCaptureJournal_CommandFails(t *testing.T) {
+	resetCaptureCommandFlags()
+	defer resetCaptureCommandFlags()
+	newCaptureServer(t)
+	stubCaptureCommand(t, `echo "No journal files were found." >&2; exit 1`)
+
+	err := captureJournalCmd.RunE(captureJournalCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "journalctl failed: No journal files were found.") {
+		t.Errorf("err = %v, want journalctl's stderr", err)
+	}
+}
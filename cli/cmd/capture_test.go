@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func resetCaptureFlags() {
+	resetPageFlags()
+	captureTitle = ""
+	captureLabels = nil
+	captureDryRun = false
+}
+
+var testRoutes = []config.Route{
+	{Match: `^panic:`, Project: "proj_backend", Labels: []string{"sev=high"}, Title: "Panic"},
+	{Match: `FAIL|ERROR`, Project: "proj_ci", Labels: []string{"sev=medium"}, Filetype: "log"},
+}
+
+func TestMatchRoute(t *testing.T) {
+	routes, err := compileRoutes(testRoutes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"starting\npanic: nil map\n", "proj_backend"},
+		{"goroutine 1: not a panic: here\nERROR x\n", "proj_ci"},
+		{"all good\n", ""},
+	}
+	for _, tt := range tests {
+		route, ok := matchRoute(routes, tt.content)
+		if got := route.Project; got != tt.want || ok != (tt.want != "") {
+			t.Errorf("matchRoute(%q) = %q, %v; want %q", tt.content, got, ok, tt.want)
+		}
+	}
+}
+
+func TestCompileRoutes_Invalid(t *testing.T) {
+	if _, err := compileRoutes([]config.Route{{Match: "("}}); err == nil || !strings.Contains(err.Error(), "routes[0]: project is required") {
+		t.Errorf("err = %v, want missing project", err)
+	}
+	if _, err := compileRoutes([]config.Route{{Project: "p"}, {Match: "(", Project: "p"}}); err == nil || !strings.Contains(err.Error(), "routes[1]: invalid match pattern") {
+		t.Errorf("err = %v, want invalid pattern", err)
+	}
+}
+
+func runCaptureWith(t *testing.T, content string) string {
+	t.Helper()
+	pageFile = filepath.Join(t.TempDir(), "input.log")
+	if err := os.WriteFile(pageFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := captureCmd.RunE(captureCmd, nil)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output, _ := io.ReadAll(r)
+	return string(output)
+}
+
+func TestCapture_RoutesAndLabels(t *testing.T) {
+	resetCaptureFlags()
+	defer resetCaptureFlags()
+
+	var created api.CreatePageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_new", Title: created.Title})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token", Routes: testRoutes}
+	captureLabels = []string{"host=web-3", "sev=high"}
+	quiet = true
+
+	output := runCaptureWith(t, "boot\npanic: runtime error\n")
+
+	if output != "page_new\n" {
+		t.Errorf("output = %q", output)
+	}
+	if created.ProjectID != "proj_backend" || created.Title != "Panic" {
+		t.Errorf("created %+v, want the panic route's project and title", created)
+	}
+	if !slices.Equal(created.Details.Labels, []string{"sev=high", "host=web-3"}) {
+		t.Errorf("labels = %v, want route labels then --label, deduplicated", created.Details.Labels)
+	}
+}
+
+func TestCapture_DryRunFallsBackToDefaultProject(t *testing.T) {
+	resetCaptureFlags()
+	defer resetCaptureFlags()
+
+	cfg = &config.Config{Routes: testRoutes, Defaults: config.Defaults{ProjectID: "proj_default"}}
+	captureDryRun = true
+	outputFmt = "json"
+
+	output := runCaptureWith(t, "all good\n")
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got["project_id"] != "proj_default" || !strings.Contains(got["route"].(string), "no route matched") {
+		t.Errorf("dry run = %v, want default project", got)
+	}
+}
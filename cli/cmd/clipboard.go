@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+var pageClipboard bool
+
+// clipboardCommands are the commands that print the system clipboard, in
+// the order they are tried. Wayland's wl-paste comes first when a Wayland
+// session is running, since X11 tools see only XWayland's clipboard there.
+func clipboardCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbpaste"}}
+	case "windows":
+		return [][]string{{"powershell", "-NoProfile", "-Command", "Get-Clipboard -Raw"}}
+	}
+	var cmds [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		cmds = append(cmds, []string{"wl-paste", "--no-newline"})
+	}
+	return append(cmds,
+		[]string{"xclip", "-selection", "clipboard", "-o"},
+		[]string{"xsel", "--clipboard", "--output"},
+	)
+}
+
+// readClipboard returns the text on the system clipboard, using the first
+// clipboard command that is installed. It is a variable so tests can
+// substitute a fake clipboard.
+var readClipboard = func() ([]byte, error) {
+	var tried []string
+	for _, parts := range clipboardCommands() {
+		if _, err := exec.LookPath(parts[0]); err != nil {
+			tried = append(tried, parts[0])
+			continue
+		}
+		var stderr bytes.Buffer
+		c := exec.Command(parts[0], parts[1:]...)
+		c.Stderr = &stderr
+		out, err := c.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("failed to read the clipboard with %s: %s", parts[0], msg)
+			}
+			return nil, fmt.Errorf("failed to read the clipboard with %s: %w", parts[0], err)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("cannot read the clipboard: none of %s is installed", strings.Join(tried, ", "))
+}
+
+// checkClipboard refuses --clipboard with another source of content.
+func checkClipboard() error {
+	if !pageClipboard {
+		return nil
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{pageFile != "", "--file"},
+		{pageFromGitShow != "", "--from-git-show"},
+		{pageFromURL != "", "--url"},
+		{pageInteractive, "--interactive"},
+		{len(pageFiles) > 0, "--files"},
+	} {
+		if conflict.set {
+			return fmt.Errorf("--clipboard cannot be combined with %s", conflict.flag)
+		}
+	}
+	return nil
+}
+
+var errClipboardEmpty = errors.New("the clipboard is empty")
+
+// bufferClipboardToTemp copies the clipboard to a temp file, so it is read
+// and validated like buffered stdin, and kept if the upload fails.
+func bufferClipboardToTemp() (string, error) {
+	data, err := readClipboard()
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "", errClipboardEmpty
+	}
+
+	tempFile, err := config.ResolveDirs().CreateTemp("hyperclast-clipboard-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	_, writeErr := tempFile.Write(data)
+	_ = tempFile.Close()
+	if writeErr != nil {
+		_ = os.Remove(tempPath)
+		return "", fmt.Errorf("failed to buffer the clipboard: %w", writeErr)
+	}
+	return tempPath, nil
+}
+
+func init() {
+	pageNewCmd.Flags().BoolVar(&pageClipboard, "clipboard", false, "read content from the system clipboard instead of stdin")
+	pageAppendCmd.Flags().BoolVar(&pageClipboard, "clipboard", false, "read content from the system clipboard instead of stdin")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+// fakeClipboard makes readClipboard return text, or err if it is set.
+func fakeClipboard(t *testing.T, text string, err error) {
+	t.Helper()
+	orig := readClipboard
+	readClipboard = func() ([]byte, error) { return []byte(text), err }
+	t.Cleanup(func() { readClipboard = orig })
+}
+
+func TestPageNewAndAppend_Clipboard(t *testing.T) {
+	env := newCLIEnv(t)
+	fakeClipboard(t, "copied snippet\n", nil)
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--title", "Snippet", "--clipboard"))
+	if page, _ := env.server.Page(id); page.Details.Content != "copied snippet\n" {
+		t.Errorf("content = %q, want the clipboard", page.Details.Content)
+	}
+
+	fakeClipboard(t, "another\n", nil)
+	env.mustRun("page", "append", id, "--clipboard")
+	if page, _ := env.server.Page(id); page.Details.Content != "copied snippet\nanother\n" {
+		t.Errorf("content after append = %q", page.Details.Content)
+	}
+}
+
+func TestPageNew_ClipboardErrors(t *testing.T) {
+	env := newCLIEnv(t)
+
+	fakeClipboard(t, " \n", nil)
+	if _, _, err := env.run("page", "new", "--project", apitest.DefaultProjectID, "--clipboard"); !errors.Is(err, errClipboardEmpty) {
+		t.Errorf("empty clipboard = %v, want %v", err, errClipboardEmpty)
+	}
+
+	fakeClipboard(t, "", errors.New("cannot read the clipboard: none of xclip, xsel is installed"))
+	if _, _, err := env.run("page", "new", "--project", apitest.DefaultProjectID, "--clipboard"); err == nil || !strings.Contains(err.Error(), "xclip") {
+		t.Errorf("missing clipboard tool = %v", err)
+	}
+
+	fakeClipboard(t, "text", nil)
+	for _, flag := range [][]string{{"--file", "x.txt"}, {"--url", "https://example.com"}, {"--files", "*.log"}} {
+		args := append([]string{"page", "new", "--project", apitest.DefaultProjectID, "--clipboard"}, flag...)
+		if _, _, err := env.run(args...); err == nil || !strings.Contains(err.Error(), "--clipboard") {
+			t.Errorf("--clipboard %s = %v, want refused", flag[0], err)
+		}
+	}
+}
+
+func TestClipboardCommands_Wayland(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("wl-paste is only tried on Linux and BSDs")
+	}
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+	if got := clipboardCommands()[0]; !reflect.DeepEqual(got, []string{"wl-paste", "--no-newline"}) {
+		t.Errorf("first clipboard command = %q, want wl-paste", got)
+	}
+	t.Setenv("WAYLAND_DISPLAY", "")
+	if got := clipboardCommands()[0][0]; got != "xclip" {
+		t.Errorf("first clipboard command without Wayland = %q, want xclip", got)
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+const (
+	ansiReset = "\033[0m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiCyan  = "\033[36m"
+	ansiBold  = "\033[1m"
+)
+
+// colorEnabled reports whether stdout is a terminal that should receive ANSI
+// colors. NO_COLOR (https://no-color.org) disables colors unconditionally,
+// as does a Windows console that cannot process escape sequences.
+func colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd())) && enableVirtualTerminal(os.Stdout)
+}
+
+func colorize(color, s string) string {
+	if !colorEnabled() {
+		return s
+	}
+	return color + s + ansiReset
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -13,6 +15,9 @@ var completionCmd = &cobra.Command{
 
 To load completions:
 
+To install completions for your shell in one step:
+  $ hyperclast completion install
+
 Bash:
   $ source <(hyperclast completion bash)
 
@@ -41,26 +46,34 @@ Fish:
 PowerShell:
   PS> hyperclast completion powershell | Out-String | Invoke-Expression
 
-  # To load completions for every new session, add the output to your profile:
-  PS> hyperclast completion powershell >> $PROFILE`,
+  # To load completions for every new session, add the output to your profile.
+  # Create the profile first if it does not exist, and write UTF-8 explicitly
+  # (Windows PowerShell 5.1 redirection writes UTF-16, which breaks the profile):
+  PS> if (!(Test-Path $PROFILE)) { New-Item -ItemType File -Force $PROFILE }
+  PS> hyperclast completion powershell | Out-File -Append -Encoding utf8 $PROFILE`,
 	DisableFlagsInUseLine: true,
 	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
 	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		switch args[0] {
-		case "bash":
-			return rootCmd.GenBashCompletionV2(os.Stdout, true)
-		case "zsh":
-			return rootCmd.GenZshCompletion(os.Stdout)
-		case "fish":
-			return rootCmd.GenFishCompletion(os.Stdout, true)
-		case "powershell":
-			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
-		}
-		return nil
+		return genCompletion(args[0], os.Stdout)
 	},
 }
 
+// genCompletion writes the completion script for shell to w.
+func genCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(w, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	}
+	return fmt.Errorf("unsupported shell %q (must be bash, zsh, fish, or powershell)", shell)
+}
+
 func init() {
 	rootCmd.AddCommand(completionCmd)
 }
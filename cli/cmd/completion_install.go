@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var completionInstallPath string
+
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish|powershell]",
+	Short: "Install shell completions",
+	Long: `Write the completion script for your shell where the shell loads it
+from, then check that it loads. The shell is detected from $SHELL (PowerShell
+on Windows) unless given.
+
+Locations:
+  bash        Homebrew's etc/bash_completion.d, else
+              $XDG_DATA_HOME/bash-completion/completions (bash-completion 2)
+  zsh         Homebrew's share/zsh/site-functions, else the first $fpath
+              directory in your home directory, else ~/.zsh/completions
+  fish        $XDG_CONFIG_HOME/fish/completions
+  powershell  next to $PROFILE, dot-sourced from it
+
+Examples:
+  hyperclast completion install
+  hyperclast completion install zsh --path ~/.zfunc/_hyperclast`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell := ""
+		if len(args) == 1 {
+			shell = args[0]
+		} else {
+			var err error
+			if shell, err = detectShell(); err != nil {
+				return err
+			}
+		}
+
+		inst := completionInstall{Shell: shell, Path: completionInstallPath}
+		if inst.Path == "" {
+			var err error
+			if inst, err = completionTarget(shell); err != nil {
+				return err
+			}
+		}
+		return runCompletionInstall(inst)
+	},
+}
+
+// completionInstall is where a shell's completion script goes.
+type completionInstall struct {
+	Shell string `json:"shell"`
+	Path  string `json:"path"`
+	// Profile is a PowerShell profile that must dot-source Path.
+	Profile string `json:"profile,omitempty"`
+	// Hint tells the user how to make the shell look in Path, if it may not.
+	Hint string `json:"hint,omitempty"`
+}
+
+// errShellNotFound means the shell is not on PATH, so the script cannot be
+// checked.
+var errShellNotFound = errors.New("shell not found")
+
+// completionShell runs a shell command with extra environment variables and
+// returns its combined output; tests replace it.
+var completionShell = func(env []string, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", errShellNotFound
+	}
+	c := exec.Command(name, args...)
+	c.Env = append(os.Environ(), env...)
+	out, err := c.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// detectShell returns the user's shell from $SHELL.
+func detectShell() (string, error) {
+	sh := os.Getenv("SHELL")
+	if sh == "" {
+		if runtime.GOOS == "windows" {
+			return "powershell", nil
+		}
+		return "", fmt.Errorf("cannot detect your shell ($SHELL is not set); pass one of bash, zsh, fish, powershell")
+	}
+	switch name := strings.TrimSuffix(filepath.Base(sh), ".exe"); name {
+	case "bash", "zsh", "fish":
+		return name, nil
+	case "pwsh", "powershell":
+		return "powershell", nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q; pass one of bash, zsh, fish, powershell", name)
+	}
+}
+
+// completionTarget picks the install location for shell.
+func completionTarget(shell string) (completionInstall, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return completionInstall{}, fmt.Errorf("failed to find home directory: %w", err)
+	}
+	inst := completionInstall{Shell: shell}
+
+	switch shell {
+	case "bash":
+		if dir := brewDir("etc", "bash_completion.d"); dir != "" {
+			inst.Path = filepath.Join(dir, "hyperclast")
+		} else {
+			inst.Path = filepath.Join(xdgDir("XDG_DATA_HOME", home, ".local", "share"), "bash-completion", "completions", "hyperclast")
+		}
+	case "zsh":
+		dir := brewDir("share", "zsh", "site-functions")
+		if dir == "" {
+			dir = homeFpathDir(home)
+		}
+		if dir == "" {
+			dir = filepath.Join(home, ".zsh", "completions")
+			inst.Hint = fmt.Sprintf("Add to ~/.zshrc, before compinit: fpath=(%s $fpath)", dir)
+		}
+		inst.Path = filepath.Join(dir, "_hyperclast")
+	case "fish":
+		inst.Path = filepath.Join(xdgDir("XDG_CONFIG_HOME", home, ".config"), "fish", "completions", "hyperclast.fish")
+	case "powershell":
+		profile, err := powershellProfile()
+		if err != nil {
+			return completionInstall{}, err
+		}
+		inst.Profile = profile
+		inst.Path = filepath.Join(filepath.Dir(profile), "hyperclast-completion.ps1")
+	default:
+		return completionInstall{}, fmt.Errorf("unsupported shell %q", shell)
+	}
+	return inst, nil
+}
+
+// xdgDir returns $env, or home joined with the default elements.
+func xdgDir(env, home string, elem ...string) string {
+	if dir := os.Getenv(env); dir != "" {
+		return dir
+	}
+	return filepath.Join(append([]string{home}, elem...)...)
+}
+
+// brewDir returns a directory under the Homebrew prefix if Homebrew is
+// installed and the directory exists.
+func brewDir(elem ...string) string {
+	prefix, err := completionShell(nil, "brew", "--prefix")
+	if err != nil || prefix == "" {
+		return ""
+	}
+	dir := filepath.Join(append([]string{prefix}, elem...)...)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// homeFpathDir returns the first directory in zsh's default $fpath that is
+// inside home, so no root access or .zshrc change is needed.
+func homeFpathDir(home string) string {
+	out, err := completionShell(nil, "zsh", "-c", "print -l $fpath")
+	if err != nil {
+		return ""
+	}
+	for _, dir := range strings.Split(out, "\n") {
+		if rel, err := filepath.Rel(home, dir); err == nil && !strings.HasPrefix(rel, "..") && rel != "." {
+			return dir
+		}
+	}
+	return ""
+}
+
+// powershellProfile asks PowerShell for the current user's profile path.
+func powershellProfile() (string, error) {
+	for _, name := range []string{"pwsh", "powershell"} {
+		out, err := completionShell(nil, name, "-NoProfile", "-Command", "$PROFILE")
+		if err == nil && out != "" {
+			return out, nil
+		}
+	}
+	return "", fmt.Errorf("failed to find the PowerShell profile (is pwsh installed?)")
+}
+
+func runCompletionInstall(inst completionInstall) error {
+	var script bytes.Buffer
+	if err := genCompletion(inst.Shell, &script); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(inst.Path, script.Bytes()); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+	if inst.Profile != "" {
+		if err := ensureProfileSources(inst.Profile, inst.Path); err != nil {
+			return fmt.Errorf("failed to update PowerShell profile: %w", err)
+		}
+	}
+
+	verified := true
+	if err := verifyCompletion(inst); err != nil {
+		verified = false
+		if errors.Is(err, errShellNotFound) {
+			printWarning("%s is not on PATH, so the completion script was not checked", inst.Shell)
+		} else {
+			return fmt.Errorf("completion script at %s failed to load: %w", inst.Path, err)
+		}
+	}
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"shell":    inst.Shell,
+			"path":     inst.Path,
+			"profile":  inst.Profile,
+			"verified": verified,
+		})
+	}
+	if quiet {
+		fmt.Println(inst.Path)
+		return nil
+	}
+
+	printSuccess("Installed %s completions to %s", inst.Shell, inst.Path)
+	if inst.Profile != "" {
+		printInfo("  Loaded from %s", inst.Profile)
+	}
+	if inst.Hint != "" {
+		printInfo("  %s", inst.Hint)
+	}
+	printInfo("  Start a new shell to use them.")
+	return nil
+}
+
+// ensureProfileSources adds a line dot-sourcing script to a PowerShell
+// profile unless it already has one. Like the documented manual setup, it
+// writes UTF-8.
+func ensureProfileSources(profile, script string) error {
+	line := fmt.Sprintf(". '%s'", strings.ReplaceAll(script, "'", "''"))
+	data, err := os.ReadFile(profile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if bytes.Contains(data, []byte(line)) {
+		return nil
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, line+"\n"...)
+	return writeFileAtomic(profile, data)
+}
+
+// verifyCompletion loads the installed script in a fresh shell and checks
+// that it registers a completion for hyperclast.
+func verifyCompletion(inst completionInstall) error {
+	env := []string{"HYPERCLAST_COMPLETION_FILE=" + inst.Path}
+	var out string
+	var err error
+	switch inst.Shell {
+	case "bash":
+		out, err = completionShell(env, "bash", "-c", `source "$HYPERCLAST_COMPLETION_FILE" && complete -p hyperclast`)
+	case "zsh":
+		out, err = completionShell(env, "zsh", "-c", `autoload -Uz compinit && compinit -u -D && source "$HYPERCLAST_COMPLETION_FILE" && (( $+functions[_hyperclast] ))`)
+	case "fish":
+		out, err = completionShell(env, "fish", "-c", `source $HYPERCLAST_COMPLETION_FILE; and complete -c hyperclast | string length -q`)
+	case "powershell":
+		name := "pwsh"
+		if _, lookErr := exec.LookPath(name); lookErr != nil {
+			name = "powershell"
+		}
+		out, err = completionShell(env, name, "-NoProfile", "-Command", `. $env:HYPERCLAST_COMPLETION_FILE`)
+	}
+	if err != nil && !errors.Is(err, errShellNotFound) && out != "" {
+		return fmt.Errorf("%w: %s", err, out)
+	}
+	return err
+}
+
+func init() {
+	completionCmd.AddCommand(completionInstallCmd)
+
+	completionInstallCmd.Flags().StringVar(&completionInstallPath, "path", "", "write the script here instead of the detected location")
+}
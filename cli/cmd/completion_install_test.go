@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// stubCompletionShell makes every shell command fail as if not installed,
+// except those answered by fn.
+func stubCompletionShell(t *testing.T, fn func(name string, args ...string) (string, error)) {
+	old := completionShell
+	t.Cleanup(func() { completionShell = old })
+	completionShell = func(env []string, name string, args ...string) (string, error) {
+		if fn != nil {
+			return fn(name, args...)
+		}
+		return "", errShellNotFound
+	}
+}
+
+func TestDetectShell(t *testing.T) {
+	t.Setenv("SHELL", "/usr/local/bin/zsh")
+	if got, err := detectShell(); err != nil || got != "zsh" {
+		t.Errorf("detectShell() = %q, %v; want zsh", got, err)
+	}
+	t.Setenv("SHELL", "/usr/bin/pwsh")
+	if got, err := detectShell(); err != nil || got != "powershell" {
+		t.Errorf("detectShell() = %q, %v; want powershell", got, err)
+	}
+	t.Setenv("SHELL", "/bin/tcsh")
+	if _, err := detectShell(); err == nil || !strings.Contains(err.Error(), "tcsh") {
+		t.Errorf("err = %v, want unsupported tcsh", err)
+	}
+}
+
+func TestCompletionTarget(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+	stubCompletionShell(t, func(name string, args ...string) (string, error) {
+		if name == "zsh" {
+			return "/usr/share/zsh/functions\n" + filepath.Join(home, ".zfunc"), nil
+		}
+		return "", errShellNotFound
+	})
+
+	tests := map[string]string{
+		"bash": filepath.Join(home, ".local", "share", "bash-completion", "completions", "hyperclast"),
+		"zsh":  filepath.Join(home, ".zfunc", "_hyperclast"),
+		"fish": filepath.Join(home, "cfg", "fish", "completions", "hyperclast.fish"),
+	}
+	for shell, want := range tests {
+		inst, err := completionTarget(shell)
+		if err != nil || inst.Path != want || inst.Hint != "" {
+			t.Errorf("completionTarget(%s) = %+v, %v; want %s", shell, inst, err, want)
+		}
+	}
+}
+
+func TestCompletionTarget_ZshFallbackHint(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	stubCompletionShell(t, nil)
+
+	inst, err := completionTarget("zsh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inst.Path != filepath.Join(home, ".zsh", "completions", "_hyperclast") || !strings.Contains(inst.Hint, "fpath=(") {
+		t.Errorf("got %+v, want ~/.zsh/completions with an fpath hint", inst)
+	}
+}
+
+func TestCompletionInstall_Bash(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not installed")
+	}
+	defer func() { completionInstallPath, quiet = "", false }()
+	completionInstallPath = filepath.Join(t.TempDir(), "completions", "hyperclast")
+	quiet = true
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err := completionInstallCmd.RunE(completionInstallCmd, []string{"bash"})
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(completionInstallPath)
+	if err != nil || !strings.Contains(string(data), "hyperclast") {
+		t.Errorf("script not written: %v", err)
+	}
+}
+
+func TestCompletionInstall_FailsWhenScriptDoesNotLoad(t *testing.T) {
+	defer func() { completionInstallPath = "" }()
+	completionInstallPath = filepath.Join(t.TempDir(), "hyperclast.fish")
+	stubCompletionShell(t, func(name string, args ...string) (string, error) {
+		return "source: syntax error", errors.New("exit status 1")
+	})
+
+	err := completionInstallCmd.RunE(completionInstallCmd, []string{"fish"})
+	if err == nil || !strings.Contains(err.Error(), "failed to load") || !strings.Contains(err.Error(), "syntax error") {
+		t.Errorf("err = %v, want load failure with shell output", err)
+	}
+}
+
+func TestEnsureProfileSources(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), "PowerShell", "profile.ps1")
+	if err := os.MkdirAll(filepath.Dir(profile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(profile, []byte("Set-PSReadLineOption -EditMode Emacs"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err := ensureProfileSources(profile, `C:\Users\o'neil\hyperclast-completion.ps1`); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(profile)
+	want := "Set-PSReadLineOption -EditMode Emacs\n. 'C:\\Users\\o''neil\\hyperclast-completion.ps1'\n"
+	if string(data) != want {
+		t.Errorf("profile = %q, want %q", data, want)
+	}
+}
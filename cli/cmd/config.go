@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configExportNoSecrets bool
+	configImportDryRun    bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Share CLI configuration profiles",
+}
+
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the configuration as a shareable profile",
+	Long: `Write the configuration (API URL, org and project defaults, capture
+routes, and other settings) to stdout as YAML, for 'hyperclast config import'
+on another machine.
+
+Use --no-secrets to leave the token out of a profile shared with others.
+
+Examples:
+  hyperclast config export --no-secrets > team-profile.yaml
+  hyperclast config export > backup.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := cfg.Export(configExportNoSecrets)
+		if err != nil {
+			return err
+		}
+		if !configExportNoSecrets && cfg.Token != "" {
+			printWarning("the profile includes your token; use --no-secrets before sharing it")
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	},
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file|->",
+	Short: "Merge a shared profile into the configuration",
+	Long: `Merge a profile written by 'hyperclast config export' into the
+configuration. Settings in the profile replace yours, and defaults are merged
+key by key; settings it leaves out, such as the token of a --no-secrets
+profile, are kept. Use - to read the profile from stdin.
+
+Examples:
+  hyperclast config import team-profile.yaml
+  hyperclast config import team-profile.yaml --dry-run
+  curl -s https://wiki.example.com/hyperclast.yaml | hyperclast config import -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read profile: %w", err)
+		}
+
+		changed, err := cfg.Import(data)
+		if err != nil {
+			return err
+		}
+		if !configImportDryRun && len(changed) > 0 {
+			if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+		}
+
+		if outputFmt == "json" {
+			if changed == nil {
+				changed = []string{}
+			}
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"path":    cfg.Path(),
+				"changed": changed,
+				"dry_run": configImportDryRun,
+			})
+		}
+		if len(changed) == 0 {
+			printInfo("Configuration already matches the profile")
+			return nil
+		}
+		verb := "Updated"
+		if configImportDryRun {
+			verb = "Would update"
+		}
+		printSuccess("%s %d settings in %s", verb, len(changed), cfg.Path())
+		for _, name := range changed {
+			printInfo("  %s", name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+
+	configExportCmd.Flags().BoolVar(&configExportNoSecrets, "no-secrets", false, "leave the token out of the profile")
+	configImportCmd.Flags().BoolVar(&configImportDryRun, "dry-run", false, "show which settings would change without saving")
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestConfigImport_SavesUnlessDryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("api_url: https://hyperclast.com/api\ntoken: mine\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	profile := filepath.Join(dir, "team.yaml")
+	if err := os.WriteFile(profile, []byte("defaults:\n  project_id: proj_team\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HYPERCLAST_TOKEN", "")
+	t.Cleanup(func() { configImportDryRun = false; quiet = false })
+	quiet = true
+
+	for _, dryRun := range []bool{true, false} {
+		var err error
+		if cfg, err = config.Load(path); err != nil {
+			t.Fatal(err)
+		}
+		configImportDryRun = dryRun
+		if err := configImportCmd.RunE(configImportCmd, []string{profile}); err != nil {
+			t.Fatalf("dry run %v: unexpected error: %v", dryRun, err)
+		}
+		saved, _ := os.ReadFile(path)
+		if got := strings.Contains(string(saved), "proj_team"); got == dryRun {
+			t.Errorf("dry run %v: saved config = %q", dryRun, saved)
+		}
+		if !strings.Contains(string(saved), "token: mine") {
+			t.Errorf("dry run %v: import lost the token: %q", dryRun, saved)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package cmd
+
+import "os"
+
+// enableVirtualTerminal is a no-op outside Windows, where terminals
+// interpret ANSI escapes natively.
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
+
+// normalizeNewlines leaves content untouched outside Windows, so files that
+// deliberately use CRLF are uploaded byte for byte.
+func normalizeNewlines(content string) string {
+	return content
+}
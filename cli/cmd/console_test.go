@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestNormalizeNewlines(t *testing.T) {
+	in := "line 1\r\nline 2\r\n"
+	got := normalizeNewlines(in)
+
+	want := in
+	if runtime.GOOS == "windows" {
+		want = "line 1\nline 2\n"
+	}
+	if got != want {
+		t.Errorf("normalizeNewlines(%q) = %q, want %q", in, got, want)
+	}
+}
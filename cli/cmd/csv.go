@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var (
+	pageCSVSelect []string
+	pageCSVDrop   []string
+	pageCSVRename map[string]string
+)
+
+// csvColumnOpsSet reports whether any CSV column operation flag was given.
+func csvColumnOpsSet() bool {
+	return len(pageCSVSelect) > 0 || len(pageCSVDrop) > 0 || len(pageCSVRename) > 0
+}
+
+// csvDelimiter guesses the delimiter from the header line, preferring tabs
+// when they outnumber commas (matching detectFiletype's TSV support).
+func csvDelimiter(content string) rune {
+	header, _, _ := strings.Cut(content, "\n")
+	if strings.Count(header, "\t") > strings.Count(header, ",") {
+		return '\t'
+	}
+	return ','
+}
+
+func parseCSV(content string) ([][]string, rune, error) {
+	delim := csvDelimiter(content)
+	r := csv.NewReader(strings.NewReader(content))
+	r.Comma = delim
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, delim, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, delim, fmt.Errorf("CSV has no header row")
+	}
+	return records, delim, nil
+}
+
+func writeCSV(records [][]string, delim rune) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = delim
+	if err := w.WriteAll(records); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return b.String(), nil
+}
+
+// transformCSV applies column selection, removal, and renaming, in that
+// order. Selected columns are emitted in the order they were listed.
+// Unknown column names are an error so a typo never silently leaks a column
+// that was meant to be dropped.
+func transformCSV(content string, selectCols, dropCols []string, rename map[string]string) (string, error) {
+	records, delim, err := parseCSV(content)
+	if err != nil {
+		return "", err
+	}
+	header := records[0]
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, exists := index[name]; !exists {
+			index[name] = i
+		}
+	}
+
+	lookup := func(name string) (int, error) {
+		i, ok := index[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown CSV column %q (available: %s)", name, strings.Join(header, ", "))
+		}
+		return i, nil
+	}
+
+	keep := make([]int, 0, len(header))
+	if len(selectCols) > 0 {
+		for _, name := range selectCols {
+			i, err := lookup(name)
+			if err != nil {
+				return "", err
+			}
+			keep = append(keep, i)
+		}
+	} else {
+		for i := range header {
+			keep = append(keep, i)
+		}
+	}
+
+	dropped := make(map[int]bool, len(dropCols))
+	for _, name := range dropCols {
+		i, err := lookup(name)
+		if err != nil {
+			return "", err
+		}
+		dropped[i] = true
+	}
+
+	cols := keep[:0]
+	for _, i := range keep {
+		if !dropped[i] {
+			cols = append(cols, i)
+		}
+	}
+	if len(cols) == 0 {
+		return "", fmt.Errorf("CSV column operations removed every column")
+	}
+
+	for oldName := range rename {
+		if _, err := lookup(oldName); err != nil {
+			return "", err
+		}
+	}
+
+	out := make([][]string, 0, len(records))
+	for rowNum, record := range records {
+		row := make([]string, len(cols))
+		for j, i := range cols {
+			if i < len(record) {
+				row[j] = record[i]
+			}
+			if rowNum == 0 {
+				if newName, ok := rename[row[j]]; ok {
+					row[j] = newName
+				}
+			}
+		}
+		out = append(out, row)
+	}
+
+	return writeCSV(out, delim)
+}
+
+var pageNoSchema bool
+
+// maxSchemaSampleRows bounds how many data rows are inspected when inferring
+// column types, keeping inference cheap for large uploads.
+const maxSchemaSampleRows = 1000
+
+var schemaDateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"01/02/2006",
+	"Jan 2, 2006",
+}
+
+// inferCSVSchema infers a type per column from a sample of rows. A column is
+// typed only when every non-empty sampled value agrees; otherwise it is a
+// string. Returns nil if the content cannot be parsed as CSV.
+func inferCSVSchema(content string) *api.TableSchema {
+	records, _, err := parseCSV(content)
+	if err != nil {
+		return nil
+	}
+	header := records[0]
+	rows := records[1:]
+	if len(rows) > maxSchemaSampleRows {
+		rows = rows[:maxSchemaSampleRows]
+	}
+
+	schema := &api.TableSchema{Columns: make([]api.ColumnSchema, len(header))}
+	for col, name := range header {
+		schema.Columns[col] = api.ColumnSchema{Name: name, Type: inferColumnType(rows, col)}
+	}
+	return schema
+}
+
+func inferColumnType(rows [][]string, col int) string {
+	candidates := map[string]func(string) bool{
+		"number": isNumberValue,
+		"date":   isDateValue,
+		"url":    isURLValue,
+	}
+
+	seen := false
+	for _, row := range rows {
+		if col >= len(row) {
+			continue
+		}
+		value := strings.TrimSpace(row[col])
+		if value == "" {
+			continue
+		}
+		seen = true
+		for typ, matches := range candidates {
+			if !matches(value) {
+				delete(candidates, typ)
+			}
+		}
+		if len(candidates) == 0 {
+			return "string"
+		}
+	}
+
+	if !seen {
+		return "string"
+	}
+	// Order of preference if several still match (e.g. "20240601").
+	for _, typ := range []string{"number", "date", "url"} {
+		if _, ok := candidates[typ]; ok {
+			return typ
+		}
+	}
+	return "string"
+}
+
+// isNumberValue reports whether s is a finite number. ParseFloat also reads
+// NaN and Inf, which are words in a CSV.
+func isNumberValue(s string) bool {
+	f, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+func isDateValue(s string) bool {
+	for _, layout := range schemaDateLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isURLValue(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
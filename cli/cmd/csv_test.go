@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransformCSV(t *testing.T) {
+	input := "id,name,email,secret\n1,Alice,a@x.com,s1\n2,\"Bob, Jr\",b@x.com,s2\n"
+
+	tests := []struct {
+		name    string
+		sel     []string
+		drop    []string
+		rename  map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "drop column",
+			drop: []string{"secret"},
+			want: "id,name,email\n1,Alice,a@x.com\n2,\"Bob, Jr\",b@x.com\n",
+		},
+		{
+			name: "select reorders",
+			sel:  []string{"email", "id"},
+			want: "email,id\na@x.com,1\nb@x.com,2\n",
+		},
+		{
+			name:   "select, drop and rename",
+			sel:    []string{"id", "name", "secret"},
+			drop:   []string{"secret"},
+			rename: map[string]string{"name": "full_name"},
+			want:   "id,full_name\n1,Alice\n2,\"Bob, Jr\"\n",
+		},
+		{
+			name:    "unknown column",
+			drop:    []string{"sercet"},
+			wantErr: `unknown CSV column "sercet"`,
+		},
+		{
+			name:    "everything dropped",
+			sel:     []string{"id"},
+			drop:    []string{"id"},
+			wantErr: "removed every column",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transformCSV(input, tt.sel, tt.drop, tt.rename)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("transformCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformCSV_TabDelimited(t *testing.T) {
+	got, err := transformCSV("a\tb\tc\n1\t2\t3\n", nil, []string{"b"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "a\tc\n1\t3\n" {
+		t.Errorf("transformCSV() = %q, want %q", got, "a\tc\n1\t3\n")
+	}
+}
+
+func TestInferCSVSchema(t *testing.T) {
+	content := "id,price,shipped,homepage,notes,empty,ratio\n" +
+		"1,\"1,200.50\",2024-06-01,https://example.com,hello,,NaN\n" +
+		"2,3,2024-06-02T10:00:00Z,http://example.org/x,42,,Inf\n" +
+		"3,,2024-06-03,,,,-Infinity\n"
+
+	schema := inferCSVSchema(content)
+	if schema == nil {
+		t.Fatal("expected schema, got nil")
+	}
+
+	want := map[string]string{
+		"id":       "number",
+		"price":    "number",
+		"shipped":  "date",
+		"homepage": "url",
+		"notes":    "string",
+		"empty":    "string",
+		"ratio":    "string",
+	}
+	if len(schema.Columns) != len(want) {
+		t.Fatalf("expected %d columns, got %d", len(want), len(schema.Columns))
+	}
+	for _, col := range schema.Columns {
+		if col.Type != want[col.Name] {
+			t.Errorf("column %q type = %q, want %q", col.Name, col.Type, want[col.Name])
+		}
+	}
+}
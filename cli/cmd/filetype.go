@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// autoFiletype asks page new to detect the filetype from the content.
+const autoFiletype = "auto"
+
+// checkFiletype fails fast on a filetype pages cannot be rendered as, before
+// any input is read.
+func checkFiletype(filetype string) error {
+	if filetype == autoFiletype || slices.Contains(api.PageFiletypes, filetype) {
+		return nil
+	}
+	return fmt.Errorf("unsupported filetype %q (supported: %s, %s)", filetype, autoFiletype, strings.Join(api.PageFiletypes, ", "))
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/diff"
+)
+
+var (
+	pageGetFollow   bool
+	pageGetDiff     bool
+	pageGetInterval time.Duration
+)
+
+const minFollowInterval = time.Second
+
+// followPage polls fetch every interval and writes what changed since the
+// previous poll. It returns when ctx is cancelled. Fetch errors are reported
+// on stderr and polling continues, so a transient network blip does not end
+// a long-running follow session.
+func followPage(ctx context.Context, fetch func() (string, error), initial string, interval time.Duration, out io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prev := initial
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := fetch()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v (retrying)\n", err)
+			continue
+		}
+		if current == prev {
+			continue
+		}
+
+		if err := writeFollowDelta(out, prev, current); err != nil {
+			return err
+		}
+		prev = current
+	}
+}
+
+// writeFollowDelta prints the change from prev to current. Pure appends are
+// printed verbatim; any other change prints the added lines, or a colored
+// unified diff with --diff.
+func writeFollowDelta(out io.Writer, prev, current string) error {
+	script := diff.Lines(prev, current)
+
+	if outputFmt == "json" {
+		event := map[string]any{
+			"time":        time.Now().UTC().Format(time.RFC3339),
+			"added_lines": diff.Added(script),
+		}
+		if pageGetDiff {
+			event["hunks"] = diff.Hunks(script, 3)
+		}
+		return json.NewEncoder(out).Encode(event)
+	}
+
+	if pageGetDiff {
+		_, err := fmt.Fprint(out, renderDiff(diff.Hunks(script, 3)))
+		return err
+	}
+
+	if strings.HasPrefix(current, prev) {
+		_, err := fmt.Fprint(out, current[len(prev):])
+		return err
+	}
+
+	for _, line := range diff.Added(script) {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderDiff renders hunks as a unified diff body, colored when stdout is a
+// terminal.
+func renderDiff(hunks []diff.Hunk) string {
+	var b strings.Builder
+	for _, h := range hunks {
+		b.WriteString(colorize(ansiCyan, fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, h.OldLines, h.NewStart, h.NewLines)))
+		b.WriteString("\n")
+		for _, line := range h.Lines {
+			text := line.Op + line.Text
+			switch line.Kind {
+			case diff.Insert:
+				text = colorize(ansiGreen, text)
+			case diff.Delete:
+				text = colorize(ansiRed, text)
+			}
+			b.WriteString(text)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
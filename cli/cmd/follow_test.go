@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestWriteFollowDelta_Append(t *testing.T) {
+	resetPageFlags()
+
+	var out strings.Builder
+	if err := writeFollowDelta(&out, "line 1\n", "line 1\nline 2\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "line 2\n" {
+		t.Errorf("output = %q, want %q", out.String(), "line 2\n")
+	}
+}
+
+func TestWriteFollowDelta_NonAppendPrintsAddedLines(t *testing.T) {
+	resetPageFlags()
+
+	var out strings.Builder
+	if err := writeFollowDelta(&out, "a\nb\n", "header\na\nb\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "header\n" {
+		t.Errorf("output = %q, want %q", out.String(), "header\n")
+	}
+}
+
+func TestWriteFollowDelta_Diff(t *testing.T) {
+	resetPageFlags()
+	pageGetDiff = true
+
+	var out strings.Builder
+	if err := writeFollowDelta(&out, "a\nb\n", "a\nc\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := out.String()
+	if !strings.Contains(got, "-b\n") || !strings.Contains(got, "+c\n") {
+		t.Errorf("expected unified diff lines, got %q", got)
+	}
+}
+
+func TestWriteFollowDelta_JSON(t *testing.T) {
+	resetPageFlags()
+	outputFmt = "json"
+
+	var out strings.Builder
+	if err := writeFollowDelta(&out, "a\n", "a\nb\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var event map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &event); err != nil {
+		t.Fatalf("output is not valid JSON: %v\nraw: %s", err, out.String())
+	}
+	added, _ := event["added_lines"].([]any)
+	if len(added) != 1 || added[0] != "b" {
+		t.Errorf("added_lines = %v, want [b]", event["added_lines"])
+	}
+}
+
+func TestFollowPage_PrintsOnlyChanges(t *testing.T) {
+	resetPageFlags()
+
+	versions := []string{"one\n", "one\n", "one\ntwo\n", "one\ntwo\nthree\n"}
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetch := func() (string, error) {
+		v := versions[min(calls, len(versions)-1)]
+		calls++
+		if calls >= len(versions) {
+			cancel()
+		}
+		return v, nil
+	}
+
+	var out strings.Builder
+	if err := followPage(ctx, fetch, "one\n", 5*time.Millisecond, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "two\nthree\n" {
+		t.Errorf("output = %q, want %q", out.String(), "two\nthree\n")
+	}
+}
+
+func TestPageGet_DiffRequiresFollow(t *testing.T) {
+	resetPageFlags()
+	cfg = &config.Config{Token: "test-token"}
+	pageGetDiff = true
+
+	err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"})
+	if err == nil || !strings.Contains(err.Error(), "--diff requires --follow") {
+		t.Errorf("error = %v, want '--diff requires --follow'", err)
+	}
+}
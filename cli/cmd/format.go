@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// parseLongDuration parses durations like time.ParseDuration, and also
+// accepts day ("7d") and week ("2w") units, which are the natural units for
+// retention and reporting windows.
+func parseLongDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if numStr, ok := strings.CutSuffix(s, suffix); ok {
+			n, err := strconv.ParseFloat(numStr, 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(n * float64(unit)), nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q (examples: 90m, 12h, 7d, 2w)", s)
+	}
+	return d, nil
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// parseByteSize parses a byte count with an optional binary unit suffix, as
+// formatBytes writes them: "512", "64KB", "1.5 MB".
+func parseByteSize(s string) (int64, error) {
+	return config.ParseByteSize(s)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLongDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "90m", want: 90 * time.Minute},
+		{in: "12h", want: 12 * time.Hour},
+		{in: "7d", want: 7 * 24 * time.Hour},
+		{in: "1.5d", want: 36 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "abc", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "-1d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseLongDuration(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseLongDuration(%q) expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseLongDuration(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLongDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:                "0 B",
+		1023:             "1023 B",
+		1024:             "1.0 KB",
+		1536:             "1.5 KB",
+		10 * 1024 * 1024: "10.0 MB",
+	}
+	for in, want := range tests {
+		if got := formatBytes(in); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+var pageFromGitShow string
+
+// gitShowSource describes a file captured at a specific git revision.
+type gitShowSource struct {
+	Ref    string
+	Path   string
+	Commit string
+}
+
+func (s gitShowSource) metadataLine() string {
+	return fmt.Sprintf("Git: %s:%s (commit %s)", s.Ref, s.Path, s.Commit)
+}
+
+// readGitShow returns the content of a "<ref>:<path>" spec as printed by
+// git show, along with the commit the ref resolved to.
+func readGitShow(spec string) (string, gitShowSource, error) {
+	ref, path, ok := strings.Cut(spec, ":")
+	if !ok || ref == "" || path == "" {
+		return "", gitShowSource{}, fmt.Errorf("invalid --from-git-show %q (expected <ref>:<path>, e.g. v1.2.3:config/prod.yaml)", spec)
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", gitShowSource{}, fmt.Errorf("--from-git-show requires git to be installed")
+	}
+
+	commit, err := runGit("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", gitShowSource{}, fmt.Errorf("unknown git revision %q: %w", ref, err)
+	}
+
+	out, err := runGit("show", ref+":"+path)
+	if err != nil {
+		return "", gitShowSource{}, fmt.Errorf("failed to read %s at %s: %w", path, ref, err)
+	}
+
+	if int64(len(out)) > maxContentSize {
+		return "", gitShowSource{}, fmt.Errorf("content too large (%d bytes, max %d)", len(out), maxContentSize)
+	}
+	if err := validateTextContent([]byte(out)); err != nil {
+		return "", gitShowSource{}, err
+	}
+	if out == "" {
+		return "", gitShowSource{}, fmt.Errorf("no content provided")
+	}
+
+	return normalizeNewlines(out), gitShowSource{
+		Ref:    ref,
+		Path:   path,
+		Commit: strings.TrimSpace(commit),
+	}, nil
+}
+
+// runGit runs git in the current directory and returns stdout. On failure
+// the error carries git's stderr.
+func runGit(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	c := exec.Command("git", args...)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// initGitRepo creates a repository in a temp dir with config/prod.yaml
+// committed twice: "v1" tagged, then "v2" on HEAD. It changes into the repo.
+func initGitRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	git := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	git("init", "-q")
+	_ = os.MkdirAll(filepath.Join(dir, "config"), 0755)
+	_ = os.WriteFile(filepath.Join(dir, "config", "prod.yaml"), []byte("replicas: 1\n"), 0644)
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	git("tag", "v1")
+	_ = os.WriteFile(filepath.Join(dir, "config", "prod.yaml"), []byte("replicas: 3\n"), 0644)
+	git("commit", "-q", "-am", "second")
+}
+
+func TestReadGitShow(t *testing.T) {
+	initGitRepo(t)
+
+	content, src, err := readGitShow("v1:config/prod.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "replicas: 1\n" {
+		t.Errorf("content = %q, want file as of v1", content)
+	}
+	if src.Ref != "v1" || src.Path != "config/prod.yaml" || len(src.Commit) != 40 {
+		t.Errorf("unexpected source: %+v", src)
+	}
+}
+
+func TestReadGitShow_Errors(t *testing.T) {
+	initGitRepo(t)
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"config/prod.yaml", "expected <ref>:<path>"},
+		{"v1:", "expected <ref>:<path>"},
+		{"v9:config/prod.yaml", "unknown git revision"},
+		{"v1:missing.yaml", "failed to read missing.yaml at v1"},
+	}
+	for _, tt := range tests {
+		_, _, err := readGitShow(tt.spec)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("readGitShow(%q) error = %v, want %q", tt.spec, err, tt.want)
+		}
+	}
+}
+
+func TestPageNew_FromGitShow(t *testing.T) {
+	resetPageFlags()
+	initGitRepo(t)
+
+	var req api.CreatePageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_new", Title: req.Title})
+	}))
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageProjectID = "proj_abc"
+	pageFromGitShow = "v1:config/prod.yaml"
+	quiet = true
+	defer func() { quiet = false }()
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	err := runPageNew(pageNewCmd, nil)
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Title != "config/prod.yaml @ v1" {
+		t.Errorf("title = %q", req.Title)
+	}
+	content := req.Details.Content
+	if !strings.HasPrefix(content, "replicas: 1\n") {
+		t.Errorf("content should start with file at v1, got %q", content)
+	}
+	if !strings.Contains(content, "Git: v1:config/prod.yaml (commit ") {
+		t.Errorf("metadata should record the ref, got %q", content)
+	}
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// cliEnv runs real commands, flag parsing and all, against a fake API.
+type cliEnv struct {
+	t      *testing.T
+	server *apitest.Server
+	url    string
+	// stdin is what the next commands read from stdin.
+	stdin string
+}
+
+func newCLIEnv(t *testing.T) *cliEnv {
+	t.Helper()
+	server := apitest.New("integration-token")
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	t.Setenv("HYPERCLAST_TOKEN", "integration-token")
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	t.Setenv("HYPERCLAST_CONFIG", "")
+	t.Setenv("DO_NOT_TRACK", "1")
+	t.Cleanup(func() {
+		resetCommandFlags(rootCmd)
+		api.SetRequestObserver(nil)
+	})
+	return &cliEnv{t: t, server: server, url: ts.URL}
+}
+
+// run executes the CLI with args, returning what it wrote to stdout and
+// stderr. Stdin is e.stdin, empty by default, and not a terminal, as in a
+// script.
+func (e *cliEnv) run(args ...string) (string, string, error) {
+	e.t.Helper()
+	resetCommandFlags(rootCmd)
+
+	inR, inW, _ := os.Pipe()
+	go func() {
+		_, _ = io.WriteString(inW, e.stdin)
+		_ = inW.Close()
+	}()
+	oldStdin, oldStdout, oldStderr := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin = inR
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+	var stdout, stderr bytes.Buffer
+	done := make(chan struct{})
+	go func() { _, _ = io.Copy(&stdout, outR); done <- struct{}{} }()
+	go func() { _, _ = io.Copy(&stderr, errR); done <- struct{}{} }()
+
+	rootCmd.SetArgs(append([]string{"--api-url", e.url}, args...))
+	rootCmd.SetOut(errW)
+	rootCmd.SetErr(errW)
+	_, err := rootCmd.ExecuteC()
+
+	_ = outW.Close()
+	_ = errW.Close()
+	<-done
+	<-done
+	_ = inR.Close()
+	os.Stdin, os.Stdout, os.Stderr = oldStdin, oldStdout, oldStderr
+	rootCmd.SetOut(nil)
+	rootCmd.SetErr(nil)
+	return stdout.String(), stderr.String(), err
+}
+
+// mustRun is run failing the test on error; it returns stdout.
+func (e *cliEnv) mustRun(args ...string) string {
+	e.t.Helper()
+	stdout, stderr, err := e.run(args...)
+	if err != nil {
+		e.t.Fatalf("hyperclast %s: %v\nstderr: %s", strings.Join(args, " "), err, stderr)
+	}
+	return stdout
+}
+
+// resetCommandFlags returns every flag of cmd and its subcommands to its
+// default, so each run starts as a fresh process would.
+func resetCommandFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetCommandFlags(sub)
+	}
+}
+
+func TestIntegration_PageLifecycle(t *testing.T) {
+	env := newCLIEnv(t)
+	file := filepath.Join(t.TempDir(), "deploy.txt")
+	if err := os.WriteFile(file, []byte("replicas: 1\nregion: us\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	pageID := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--title", "Deploy", "--file", file))
+	if !strings.HasPrefix(pageID, "page_") {
+		t.Fatalf("page new --quiet printed %q, want a page ID", pageID)
+	}
+
+	if out := env.mustRun("page", "list"); !strings.Contains(out, pageID) || !strings.Contains(out, "Deploy") {
+		t.Errorf("page list output missing the new page:\n%s", out)
+	}
+
+	if err := os.WriteFile(file, []byte("replicas: 3\nregion: us\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	env.mustRun("page", "overwrite", pageID, "--file", file)
+	if out := env.mustRun("page", "get", pageID); out != "replicas: 3\nregion: us\n" {
+		t.Errorf("page get after overwrite = %q", out)
+	}
+
+	if out := env.mustRun("page", "history", pageID); !strings.Contains(out, "v2") || !strings.Contains(out, "v1") {
+		t.Errorf("page history output missing revisions:\n%s", out)
+	}
+	want := "-replicas: 1\n+replicas: 3\n region: us\n"
+	if out := env.mustRun("page", "diff", pageID, "--from", "1"); !strings.HasSuffix(out, want) {
+		t.Errorf("page diff --from 1 = %q, want suffix %q", out, want)
+	}
+
+	env.mustRun("page", "restore", pageID, "--revision", "v1")
+	if page, _ := env.server.Page(pageID); page.Details.Content != "replicas: 1\nregion: us\n" {
+		t.Errorf("content after restore = %q", page.Details.Content)
+	}
+
+	env.mustRun("page", "delete", pageID, "--force")
+	if _, ok := env.server.Page(pageID); ok {
+		t.Error("page still exists after page delete")
+	}
+}
+
+func TestIntegration_AppendAndPrepend(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Log", "middle\n")
+	file := filepath.Join(t.TempDir(), "line.txt")
+
+	_ = os.WriteFile(file, []byte("last\n"), 0600)
+	env.mustRun("page", "append", page.ExternalID, "--file", file)
+	_ = os.WriteFile(file, []byte("first\n"), 0600)
+	env.mustRun("page", "prepend", page.ExternalID, "--file", file)
+
+	got, _ := env.server.Page(page.ExternalID)
+	if got.Details.Content != "first\nmiddle\nlast\n" {
+		t.Errorf("content = %q", got.Details.Content)
+	}
+}
+
+func TestIntegration_OverwriteConflict(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Config", "replicas: 1\n")
+	file := filepath.Join(t.TempDir(), "config.txt")
+	_ = os.WriteFile(file, []byte("replicas: 3\n"), 0600)
+
+	var read struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(env.mustRun("page", "get", page.ExternalID, "--output", "json")), &read); err != nil || read.Version == "" {
+		t.Fatalf("page get --output json: version %q, %v", read.Version, err)
+	}
+
+	// Another client edits the page after the version was read.
+	env.server.SetPageDetails(page.ExternalID, map[string]any{"content": "replicas: 2\n"})
+	_, _, err := env.run("page", "overwrite", page.ExternalID, "--file", file, "--if-unmodified", read.Version)
+	var conflict *api.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("overwrite of a page changed since its version was read = %v, want a conflict", err)
+	}
+	if got, _ := env.server.Page(page.ExternalID); got.Details.Content != "replicas: 2\n" {
+		t.Errorf("content after conflict = %q, want the other client's edit kept", got.Details.Content)
+	}
+
+	current, _ := env.server.Page(page.ExternalID)
+	env.mustRun("page", "overwrite", page.ExternalID, "--file", file, "--if-unmodified", current.Version())
+	if got, _ := env.server.Page(page.ExternalID); got.Details.Content != "replicas: 3\n" {
+		t.Errorf("content after overwriting the current version = %q", got.Details.Content)
+	}
+}
+
+func TestIntegration_OrgAndProjectList(t *testing.T) {
+	env := newCLIEnv(t)
+	env.server.AddProject("Runbooks")
+
+	if out := env.mustRun("org", "list"); !strings.Contains(out, apitest.DefaultOrgID) {
+		t.Errorf("org list output missing the sandbox org:\n%s", out)
+	}
+	out := env.mustRun("project", "list", "--output", "json")
+	if !strings.Contains(out, apitest.DefaultProjectID) || !strings.Contains(out, "Runbooks") {
+		t.Errorf("project list output missing projects:\n%s", out)
+	}
+}
+
+func TestIntegration_WrongToken(t *testing.T) {
+	env := newCLIEnv(t)
+	t.Setenv("HYPERCLAST_TOKEN", "wrong")
+
+	_, _, err := env.run("page", "list")
+	if err == nil || !strings.Contains(err.Error(), "authentication failed") {
+		t.Fatalf("page list with a wrong token = %v, want authentication error", err)
+	}
+}
+
+func TestIntegration_ArchiveRoundTrip(t *testing.T) {
+	env := newCLIEnv(t)
+	stale := env.server.AddPage(apitest.DefaultProjectID, "Build 40", "")
+	fresh := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "")
+
+	env.mustRun("page", "archive", stale.ExternalID)
+	if out := env.mustRun("page", "list", "--format", "{{.ExternalID}}"); strings.TrimSpace(out) != fresh.ExternalID {
+		t.Errorf("page list after archive = %q, want only %s", out, fresh.ExternalID)
+	}
+	if out := env.mustRun("page", "list", "--archived", "--format", "{{.ExternalID}}"); strings.TrimSpace(out) != stale.ExternalID {
+		t.Errorf("page list --archived = %q, want only %s", out, stale.ExternalID)
+	}
+
+	env.mustRun("page", "unarchive", stale.ExternalID)
+	if out := env.mustRun("page", "list", "--archived", "--quiet"); out != "" {
+		t.Errorf("page list --archived after unarchive = %q, want nothing", out)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// exitInterrupted is the exit status of a command stopped by Ctrl-C or
+// SIGTERM, as shells report a process killed by SIGINT.
+const exitInterrupted = 130
+
+// interruptedError ends a command that was interrupted. Execute exits with
+// exitInterrupted for it, so scripts stop instead of carrying on.
+type interruptedError struct {
+	msg string
+}
+
+func (e *interruptedError) Error() string {
+	return e.msg
+}
+
+// requestContext is attached to clients made by newClient. An upload guard
+// sets it so a second interrupt can abort the request in flight.
+var requestContext context.Context
+
+// stdinBufferPath is the temp file stdin is being copied to, while it is.
+var stdinBufferPath string
+
+// uploadGuard traps SIGINT and SIGTERM while content is read and sent, so an
+// interrupt never silently loses captured output. The first interrupt lets
+// an upload in flight finish, or stops before anything is sent if the
+// input is still being read; a second one aborts the upload.
+type uploadGuard struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	signals chan os.Signal
+
+	mu          sync.Mutex
+	interrupted bool
+	uploading   bool
+}
+
+func startUploadGuard() *uploadGuard {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &uploadGuard{ctx: ctx, cancel: cancel, signals: make(chan os.Signal, 2)}
+	signal.Notify(g.signals, os.Interrupt, syscall.SIGTERM)
+	requestContext = ctx
+	go g.watch()
+	return g
+}
+
+func (g *uploadGuard) stop() {
+	signal.Stop(g.signals)
+	close(g.signals)
+	g.cancel()
+	requestContext = nil
+}
+
+func (g *uploadGuard) watch() {
+	for range g.signals {
+		g.mu.Lock()
+		first := !g.interrupted
+		g.interrupted = true
+		uploading := g.uploading
+		g.mu.Unlock()
+
+		switch {
+		case first && uploading:
+			printWarning("Interrupted; letting the upload finish (interrupt again to abort it)")
+		case first:
+			printWarning("Interrupted; nothing will be sent (interrupt again to stop reading input)")
+		case uploading:
+			g.cancel()
+		default:
+			// A blocked read of stdin cannot be cancelled, so exit from here
+			// once what was read is safe on disk.
+			if path := stdinBufferPath; path != "" {
+				printInfo("Input read so far is saved at: %s", path)
+			}
+			os.Exit(exitInterrupted)
+		}
+	}
+}
+
+// beginUpload is called once the content is ready. It fails, after saving
+// the content, if the command was interrupted while reading input.
+func (g *uploadGuard) beginUpload(content string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.interrupted {
+		saveUnsent(content)
+		return &interruptedError{msg: "interrupted before sending; nothing was uploaded"}
+	}
+	g.uploading = true
+	return nil
+}
+
+// aborted saves content and explains the risk when err comes from a
+// request cancelled by a second interrupt. It returns nil for other errors.
+func (g *uploadGuard) aborted(err error, content string) error {
+	if !errors.Is(err, context.Canceled) {
+		return nil
+	}
+	saveUnsent(content)
+	return &interruptedError{msg: "upload aborted; the server may already have applied it, so check the page before retrying"}
+}
+
+// interruptedAfterUpload reports an interrupt that arrived while an upload
+// was allowed to finish, once its output has been printed, so the caller's
+// script still stops.
+func (g *uploadGuard) interruptedAfterUpload() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.interrupted {
+		return &interruptedError{msg: "interrupted (the upload had finished; nothing was lost)"}
+	}
+	return nil
+}
+
+// saveUnsent makes sure content that was not sent survives the exit and
+// says where it is.
+func saveUnsent(content string) {
+	switch {
+	case stdinTempPath != "":
+		printRecoveryInfo(stdinTempPath)
+		stdinTempPath = ""
+	case pageFile != "":
+		printInfo("Your input is still at %s", pageFile)
+	case len(pageFiles) > 0:
+		printInfo("Your input is still in the files matched by --files")
+	default:
+		f, err := config.ResolveDirs().CreateTemp("hyperclast-unsent-*.txt")
+		if err != nil {
+			printError("could not save unsent content: %v", err)
+			return
+		}
+		_, werr := f.WriteString(content)
+		if cerr := f.Close(); werr == nil {
+			werr = cerr
+		}
+		if werr != nil {
+			printError("could not save unsent content: %v", fmt.Errorf("%s: %w", f.Name(), werr))
+			return
+		}
+		printRecoveryInfo(f.Name())
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// interruptSelf delivers SIGINT to the test process, which an active upload
+// guard catches.
+func interruptSelf(t *testing.T) {
+	t.Helper()
+	p, _ := os.FindProcess(os.Getpid())
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Errorf("failed to send interrupt: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+}
+
+// interruptServer serves page_xyz and calls onPut when the update arrives.
+func interruptServer(t *testing.T, onPut func(r *http.Request)) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			// Read the body so the server notices when the client hangs up.
+			_, _ = io.Copy(io.Discard, r.Body)
+			onPut(r)
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Title: "Log", Role: api.RoleAdmin})
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+}
+
+func TestPageAppend_SecondInterruptAbortsUpload(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("cannot send an interrupt to a process on Windows")
+	}
+	resetPageFlags()
+	defer resetPageFlags()
+
+	interruptServer(t, func(r *http.Request) {
+		interruptSelf(t)
+		interruptSelf(t)
+		<-r.Context().Done()
+	})
+	pageFile = filepath.Join(t.TempDir(), "out.log")
+	_ = os.WriteFile(pageFile, []byte("output\n"), 0644)
+	quiet = true
+
+	err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+	var interrupted *interruptedError
+	if !errors.As(err, &interrupted) || !strings.Contains(err.Error(), "aborted") {
+		t.Fatalf("err = %v, want an aborted upload", err)
+	}
+	if requestContext != nil {
+		t.Error("request context left set after the command")
+	}
+}
+
+func TestPageAppend_FirstInterruptLetsUploadFinish(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("cannot send an interrupt to a process on Windows")
+	}
+	resetPageFlags()
+	defer resetPageFlags()
+
+	interruptServer(t, func(r *http.Request) { interruptSelf(t) })
+	pageFile = filepath.Join(t.TempDir(), "out.log")
+	_ = os.WriteFile(pageFile, []byte("output\n"), 0644)
+	quiet = true
+
+	out := captureArchiveOutput(t, func() error {
+		err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+		var interrupted *interruptedError
+		if !errors.As(err, &interrupted) || !strings.Contains(err.Error(), "had finished") {
+			t.Errorf("err = %v, want an interrupt reported after the upload", err)
+		}
+		return nil
+	})
+	if out != "page_xyz\n" {
+		t.Errorf("output = %q, want the upload's normal output", out)
+	}
+}
+
+func TestUploadGuard_InterruptedWhileReading(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+
+	g := startUploadGuard()
+	defer g.stop()
+	g.signals <- os.Interrupt
+	time.Sleep(50 * time.Millisecond)
+
+	quiet = true
+	err := g.beginUpload("typed in the composer\n")
+	var interrupted *interruptedError
+	if !errors.As(err, &interrupted) {
+		t.Fatalf("err = %v, want the upload skipped", err)
+	}
+
+	saved, _ := filepath.Glob(filepath.Join(config.ResolveDirs().Cache, "hyperclast-unsent-*.txt"))
+	if len(saved) != 1 {
+		t.Fatalf("saved files = %v, want the unsent content kept", saved)
+	}
+	if data, _ := os.ReadFile(saved[0]); string(data) != "typed in the composer\n" {
+		t.Errorf("saved content = %q", data)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var pageAppendJournal bool
+
+// defaultJournalMarker is the marker line recorded when config
+// journal_marker is unset.
+const defaultJournalMarker = `[hyperclast] append {{event}} (host {{hostname}}, pid {{pid}}, {{date "2006-01-02T15:04:05Z07:00"}})`
+
+// appendJournal tracks what happened to one append so it can be recorded
+// in the page for anyone later reading interleaved multi-host logs.
+type appendJournal struct {
+	retries int
+}
+
+// clientOptions returns the command's client options with retries counted.
+func (j *appendJournal) clientOptions() api.ClientOptions {
+	opts := clientOptions()
+	opts.OnRetry = func(method, path string) { j.retries++ }
+	return opts
+}
+
+// events describes the retries and concurrent writes observed for an
+// append of content to before, which produced after. A concurrent write
+// shows up as the result differing from before's content plus ours.
+func (j *appendJournal) events(before, after *api.Page, content string) []string {
+	var events []string
+	if j.retries == 1 {
+		events = append(events, "retried once")
+	} else if j.retries > 1 {
+		events = append(events, fmt.Sprintf("retried %d times", j.retries))
+	}
+	if before.Details != nil && after.Details != nil && after.Details.Content != before.Details.Content+content {
+		events = append(events, "merged with concurrent writes")
+	}
+	return events
+}
+
+// journalMarker renders the marker line for events.
+func journalMarker(events []string, retries int) (string, error) {
+	template := cfg.GetJournalMarker()
+	if template == "" {
+		template = defaultJournalMarker
+	}
+	line, err := expandPlaceholdersWith(template, map[string]string{
+		"event":   strings.Join(events, ", "),
+		"retries": strconv.Itoa(retries),
+		"pid":     strconv.Itoa(os.Getpid()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid journal_marker: %w", err)
+	}
+	return strings.TrimRight(line, "\n"), nil
+}
+
+// recordJournal appends a marker line after an append that was retried or
+// merged with other writers' content. Failing to record it only warns: the
+// content itself was appended.
+func recordJournal(client *api.Client, j *appendJournal, before, after *api.Page, content string) {
+	events := j.events(before, after, content)
+	if len(events) == 0 {
+		return
+	}
+	line, err := journalMarker(events, j.retries)
+	if err != nil {
+		printWarning("%v", err)
+		return
+	}
+
+	marker := line + "\n"
+	if after.Details != nil && after.Details.Content != "" && !strings.HasSuffix(after.Details.Content, "\n") {
+		marker = "\n" + marker
+	}
+	if _, err := client.UpdateFetchedPageContent(before, marker, "append"); err != nil {
+		printWarning("failed to record journal marker: %v", err)
+		return
+	}
+	printDebug("Recorded journal marker: %s", line)
+}
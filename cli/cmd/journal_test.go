@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// journalServer serves a page whose content is "start\n". Each append
+// is applied after extra, simulating another host appending first, and
+// the first throttled PUTs are rejected with 429.
+func journalServer(t *testing.T, extra string, throttled int) (*httptest.Server, *[]string) {
+	content := "start\n"
+	var appends []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if throttled > 0 {
+				throttled--
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			var req api.UpdatePageContentRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			appends = append(appends, req.Details.Content)
+			content += extra + req.Details.Content
+			extra = ""
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Title:      "Fleet Log",
+			Details:    &api.PageDetails{Content: content},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &appends
+}
+
+func runJournaledAppend(t *testing.T, server *httptest.Server, marker string) {
+	t.Helper()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token", JournalMarker: marker}
+	pageAppendJournal = true
+	pageFile = filepath.Join(t.TempDir(), "host.log")
+	if err := os.WriteFile(pageFile, []byte("host-a line\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPageAppendJournal_Merged(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	server, appends := journalServer(t, "host-b line\n", 0)
+
+	runJournaledAppend(t, server, "")
+
+	if len(*appends) != 2 {
+		t.Fatalf("appends = %q, want content then marker", *appends)
+	}
+	marker := (*appends)[1]
+	if !strings.HasPrefix(marker, "[hyperclast] append merged with concurrent writes (host ") || !strings.HasSuffix(marker, ")\n") {
+		t.Errorf("marker = %q", marker)
+	}
+}
+
+func TestPageAppendJournal_RetriedWithCustomMarker(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	defer func() { requestRetries = 0 }()
+	requestRetries = 2
+	server, appends := journalServer(t, "", 2)
+
+	runJournaledAppend(t, server, "-- {{event}} ({{retries}}) --")
+
+	if len(*appends) != 2 || (*appends)[1] != "-- retried 2 times (2) --\n" {
+		t.Errorf("appends = %q, want content then custom marker", *appends)
+	}
+}
+
+func TestPageAppendJournal_QuietWhenClean(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	server, appends := journalServer(t, "", 0)
+
+	runJournaledAppend(t, server, "")
+
+	if len(*appends) != 1 {
+		t.Errorf("appends = %q, want only the content", *appends)
+	}
+}
+
+func TestJournalMarker_InvalidTemplate(t *testing.T) {
+	cfg = &config.Config{JournalMarker: "{{nope}}"}
+	if _, err := journalMarker([]string{"retried once"}, 1); err == nil || !strings.Contains(err.Error(), "journal_marker") {
+		t.Errorf("err = %v, want invalid journal_marker", err)
+	}
+}
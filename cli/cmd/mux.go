@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	muxInputs   []string
+	muxInterval time.Duration
+)
+
+// maxMuxBatchLines flushes a batch early when inputs are busy, so a single
+// append never grows unbounded.
+const maxMuxBatchLines = 1000
+
+var muxCmd = &cobra.Command{
+	Use:   "mux <page-id>",
+	Short: "Append lines from several inputs to one page",
+	Long: `Read several named files or FIFOs concurrently and append their lines
+to one page, each tagged with its input name and the time it was read.
+
+Lines are batched and appended every --interval in timestamp order. The
+command exits when every input reaches end of file; Ctrl-C flushes what has
+been read and stops.
+
+The config's quiet_hours hold batches back during those hours, and its
+upload_rate caps how fast they are sent, so a long-running mux on a laptop
+stays out of the way of video calls and metered connections.
+
+Examples:
+  mkfifo api.fifo worker.fifo
+  ./api > api.fifo & ./worker > worker.fifo &
+  hyperclast mux page_xyz789 --input api=./api.fifo --input worker=./worker.fifo`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		inputs, err := parseMuxInputs(muxInputs)
+		if err != nil {
+			return err
+		}
+		if muxInterval < minFollowInterval {
+			return fmt.Errorf("--interval must be at least %s", minFollowInterval)
+		}
+		gate, err := newUploadGate(muxInterval)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		client := newClient()
+		pageID := args[0]
+		page, err := client.GetPage(pageID)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if err := checkPageLock(client, page); err != nil {
+			return err
+		}
+		// Track the page size locally so the run stops with a clear message
+		// before a batch would be rejected for exceeding the size limit.
+		quota := newPageQuota(page, "", "append")
+		if err := enforcePageQuota(quota, false); err != nil {
+			return err
+		}
+
+		var lines int
+		ready := func(batch []muxLine) int {
+			return gate.ready(batch, quota.Current, func(b []muxLine) string { return formatMuxLines(b, inputs) })
+		}
+		err = runMux(ctx, inputs, muxInterval, ready, func(batch []muxLine) error {
+			text := formatMuxLines(batch, inputs)
+			quota.Adding = int64(len(text))
+			if quota.exceeded() {
+				return fmt.Errorf("stopping after %d lines: %s", lines, quota)
+			}
+			if _, err := client.UpdatePageContent(pageID, text, "append"); err != nil {
+				return fmt.Errorf("failed to append to page: %w", err)
+			}
+			quota.Current += quota.Adding
+			gate.spent(len(text))
+			lines += len(batch)
+			printDebug("Appended %d lines", len(batch))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		printSuccess("Appended %d lines from %d inputs to page %s", lines, len(inputs), pageID)
+		return nil
+	},
+}
+
+type muxInput struct {
+	Name string
+	Path string
+	// Reader, if set, is read instead of opening Path.
+	Reader io.Reader
+}
+
+type muxLine struct {
+	Time  time.Time
+	Input string
+	Text  string
+}
+
+// parseMuxInputs parses name=path specs, rejecting empty or duplicate names.
+func parseMuxInputs(specs []string) ([]muxInput, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("at least one --input name=path is required")
+	}
+
+	inputs := make([]muxInput, 0, len(specs))
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		name, path, ok := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid --input %q (expected name=path)", spec)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate --input name %q", name)
+		}
+		seen[name] = true
+		inputs = append(inputs, muxInput{Name: name, Path: path})
+	}
+	return inputs, nil
+}
+
+// runMux reads all inputs concurrently and calls flush with batches of lines
+// sorted by read time, every interval and once more when all inputs are
+// exhausted or ctx is cancelled. gate, if set, says how many lines of a
+// batch may go out on an interval; the rest wait for the next one. The last
+// batch is sent whole.
+func runMux(ctx context.Context, inputs []muxInput, interval time.Duration, gate func([]muxLine) int, flush func([]muxLine) error) error {
+	return runMuxLimited(ctx, inputs, interval, 0, gate, flush)
+}
+
+// runMuxLimited is runMux that also flushes a batch early once its lines
+// hold maxBytes of text, if maxBytes is positive.
+//
+// An input that fails to open or read stops the run as soon as it does,
+// after the lines already read are flushed; so does ctx being cancelled.
+func runMuxLimited(ctx context.Context, inputs []muxInput, interval time.Duration, maxBytes int64, gate func([]muxLine) int, flush func([]muxLine) error) error {
+	// Cancelled on return, so that readers still running stop sending.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan muxLine, 256)
+	errs := make(chan error, len(inputs))
+
+	var wg sync.WaitGroup
+	for _, in := range inputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := readMuxInput(ctx, in, lines); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var batch []muxLine
+	var batchBytes int64
+	full, fullBytes := maxMuxBatchLines, maxBytes
+	send := func(final bool) error {
+		if len(batch) == 0 {
+			return nil
+		}
+		sort.SliceStable(batch, func(i, j int) bool { return batch[i].Time.Before(batch[j].Time) })
+		n := len(batch)
+		if gate != nil && !final {
+			n = gate(batch)
+		}
+		// Lines held back count toward the next early flush.
+		full = len(batch) - n + maxMuxBatchLines
+		held := muxLinesSize(batch[n:])
+		fullBytes = held + maxBytes
+		if n == 0 {
+			return nil
+		}
+		err := flush(batch[:n])
+		batch = append([]muxLine(nil), batch[n:]...)
+		batchBytes = held
+		return err
+	}
+
+	// drain adds the lines already read to the batch before a final send.
+	drain := func() {
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					return
+				}
+				batch = append(batch, line)
+			default:
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				if err := send(true); err != nil {
+					return err
+				}
+				select {
+				case err := <-errs:
+					return err
+				default:
+					return nil
+				}
+			}
+			batch = append(batch, line)
+			batchBytes += int64(len(line.Text)) + 1
+			if len(batch) >= full || (maxBytes > 0 && batchBytes >= fullBytes) {
+				if err := send(false); err != nil {
+					return err
+				}
+			}
+		case <-ticker.C:
+			if err := send(false); err != nil {
+				return err
+			}
+		case err := <-errs:
+			drain()
+			if sendErr := send(true); sendErr != nil {
+				return sendErr
+			}
+			return err
+		case <-ctx.Done():
+			drain()
+			return send(true)
+		}
+	}
+}
+
+// muxLinesSize is the size of lines' text, with a newline after each.
+func muxLinesSize(lines []muxLine) int64 {
+	var n int64
+	for _, l := range lines {
+		n += int64(len(l.Text)) + 1
+	}
+	return n
+}
+
+func readMuxInput(ctx context.Context, in muxInput, out chan<- muxLine) error {
+	r := in.Reader
+	if r == nil {
+		// Opening a FIFO blocks until a writer connects.
+		f, err := os.Open(in.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open input %q: %w", in.Name, err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := muxLine{Time: time.Now(), Input: in.Name, Text: strings.TrimRight(scanner.Text(), "\r")}
+		select {
+		case out <- line:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read input %q: %w", in.Name, err)
+	}
+	return nil
+}
+
+// formatMuxLines renders lines as "[15:04:05.000] name | text", with names
+// padded so the text columns line up.
+func formatMuxLines(lines []muxLine, inputs []muxInput) string {
+	width := 0
+	for _, in := range inputs {
+		width = max(width, len(in.Name))
+	}
+
+	var b strings.Builder
+	for _, l := range lines {
+		fmt.Fprintf(&b, "[%s] %-*s | %s\n", l.Time.Format("15:04:05.000"), width, l.Input, l.Text)
+	}
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(muxCmd)
+
+	muxCmd.Flags().StringArrayVar(&muxInputs, "input", nil, "named input as name=path (repeatable)")
+	muxCmd.Flags().DurationVar(&muxInterval, "interval", 2*time.Second, "how often to append buffered lines")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestParseMuxInputs(t *testing.T) {
+	inputs, err := parseMuxInputs([]string{"api=./api.log", "worker=/tmp/a=b.log"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inputs) != 2 || inputs[1].Name != "worker" || inputs[1].Path != "/tmp/a=b.log" {
+		t.Errorf("unexpected inputs: %+v", inputs)
+	}
+
+	for _, specs := range [][]string{
+		nil,
+		{"api"},
+		{"=./api.log"},
+		{"api="},
+		{"api=a.log", "api=b.log"},
+	} {
+		if _, err := parseMuxInputs(specs); err == nil {
+			t.Errorf("parseMuxInputs(%q) expected error", specs)
+		}
+	}
+}
+
+func TestRunMux_ReadsAllInputs(t *testing.T) {
+	dir := t.TempDir()
+	apiPath := filepath.Join(dir, "api.log")
+	workerPath := filepath.Join(dir, "worker.log")
+	_ = os.WriteFile(apiPath, []byte("GET /\nGET /health\n"), 0644)
+	_ = os.WriteFile(workerPath, []byte("job 1 done\r\n"), 0644)
+
+	inputs := []muxInput{{Name: "api", Path: apiPath}, {Name: "worker", Path: workerPath}}
+	var got []muxLine
+	err := runMux(context.Background(), inputs, time.Hour, nil, func(batch []muxLine) error {
+		for i := 1; i < len(batch); i++ {
+			if batch[i].Time.Before(batch[i-1].Time) {
+				t.Error("batch not in timestamp order")
+			}
+		}
+		got = append(got, batch...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var texts []string
+	for _, l := range got {
+		texts = append(texts, l.Input+":"+l.Text)
+	}
+	joined := strings.Join(texts, ",")
+	for _, want := range []string{"api:GET /", "api:GET /health", "worker:job 1 done"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("missing %q in %q", want, joined)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("got %d lines, want 3", len(got))
+	}
+}
+
+func TestRunMux_MissingInput(t *testing.T) {
+	inputs := []muxInput{{Name: "api", Path: filepath.Join(t.TempDir(), "nope.log")}}
+	err := runMux(context.Background(), inputs, time.Hour, nil, func([]muxLine) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), `failed to open input "api"`) {
+		t.Errorf("err = %v, want open error", err)
+	}
+}
+
+func TestRunMux_InputErrorStopsRun(t *testing.T) {
+	// The other input never reaches end of file, like a FIFO with no writer.
+	pr, pw := io.Pipe()
+	defer func() { _ = pw.Close() }()
+	failing := io.MultiReader(strings.NewReader("started\n"), iotest.ErrReader(errors.New("device gone")))
+	inputs := []muxInput{{Name: "api", Reader: failing}, {Name: "worker", Reader: pr}}
+
+	var got []muxLine
+	done := make(chan error, 1)
+	go func() {
+		done <- runMux(context.Background(), inputs, time.Hour, nil, func(batch []muxLine) error {
+			got = append(got, batch...)
+			return nil
+		})
+	}()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), `failed to read input "api": device gone`) {
+			t.Errorf("err = %v, want the read error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runMux did not return after an input failed")
+	}
+	if len(got) != 1 || got[0].Text != "started" {
+		t.Errorf("flushed %+v, want the line read before the error", got)
+	}
+}
+
+func TestFormatMuxLines(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 15, 4, 5, 123000000, time.UTC)
+	inputs := []muxInput{{Name: "api"}, {Name: "worker"}}
+	out := formatMuxLines([]muxLine{
+		{Time: ts, Input: "api", Text: "GET /"},
+		{Time: ts, Input: "worker", Text: "done"},
+	}, inputs)
+
+	want := "[15:04:05.123] api    | GET /\n[15:04:05.123] worker | done\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
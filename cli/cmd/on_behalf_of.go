@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var pageOnBehalfOf string
+
+// maxOnBehalfOfLength keeps attributions short enough to show in one line.
+const maxOnBehalfOfLength = 100
+
+// onBehalfOf returns who this write is for: --on-behalf-of, else
+// $HYPERCLAST_ON_BEHALF_OF, so a shared bot token can be attributed per
+// pipeline without changing every invocation.
+func onBehalfOf() string {
+	if pageOnBehalfOf != "" {
+		return pageOnBehalfOf
+	}
+	return strings.TrimSpace(os.Getenv("HYPERCLAST_ON_BEHALF_OF"))
+}
+
+func checkOnBehalfOf() error {
+	name := onBehalfOf()
+	if n := utf8.RuneCountInString(name); n > maxOnBehalfOfLength {
+		return fmt.Errorf("--on-behalf-of is %d characters (max %d)", n, maxOnBehalfOfLength)
+	}
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return fmt.Errorf("--on-behalf-of cannot contain control characters")
+	}
+	return nil
+}
+
+// pageWrite describes a write of content for details.writes, or nil when no
+// one is named. At is precise enough for 'page history' to tell which
+// revision the write landed in.
+func pageWrite(mode, content string) *api.PageWrite {
+	name := onBehalfOf()
+	if name == "" {
+		return nil
+	}
+	return &api.PageWrite{
+		OnBehalfOf: name,
+		Mode:       mode,
+		At:         time.Now().UTC().Format(time.RFC3339Nano),
+		Bytes:      len(content),
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{pageNewCmd, pageAppendCmd, pagePrependCmd, pageOverwriteCmd} {
+		c.Flags().StringVar(&pageOnBehalfOf, "on-behalf-of", "", "record who this write is for, e.g. deploy-bot/staging (default: $HYPERCLAST_ON_BEHALF_OF)")
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestPageAppend_OnBehalfOf(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	existing := api.Page{ExternalID: "page_xyz", Title: "Deploys", Details: &api.PageDetails{
+		Content: "earlier\n",
+		Writes:  []api.PageWrite{{OnBehalfOf: "deploy-bot/prod", Mode: "append", At: "2026-10-16T09:00:00Z", Bytes: 8}},
+	}}
+	var sent api.UpdatePageContentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+		}
+		_ = json.NewEncoder(w).Encode(existing)
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	pageFile = filepath.Join(t.TempDir(), "deploy.log")
+	_ = os.WriteFile(pageFile, []byte("deployed v2\n"), 0644)
+	t.Setenv("HYPERCLAST_ON_BEHALF_OF", "ignored-when-flag-set")
+	pageOnBehalfOf = "deploy-bot/staging"
+	quiet = true
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writes := sent.Details.Writes
+	if len(writes) != 2 || writes[0].OnBehalfOf != "deploy-bot/prod" {
+		t.Fatalf("writes = %+v, want the earlier write kept first", writes)
+	}
+	if w := writes[1]; w.OnBehalfOf != "deploy-bot/staging" || w.Mode != "append" || w.Bytes != len("deployed v2\n") || w.At == "" {
+		t.Errorf("new write = %+v", w)
+	}
+}
+
+func TestOnBehalfOf_EnvAndValidation(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	t.Setenv("HYPERCLAST_ON_BEHALF_OF", " nightly-etl ")
+	if got := onBehalfOf(); got != "nightly-etl" {
+		t.Errorf("onBehalfOf = %q, want the environment value", got)
+	}
+
+	pageOnBehalfOf = "bot\nInjected: line"
+	if err := checkOnBehalfOf(); err == nil {
+		t.Error("control characters accepted")
+	}
+	pageOnBehalfOf = strings.Repeat("b", maxOnBehalfOfLength+1)
+	if err := checkOnBehalfOf(); err == nil {
+		t.Error("overlong name accepted")
+	}
+
+	pageOnBehalfOf = ""
+	t.Setenv("HYPERCLAST_ON_BEHALF_OF", "")
+	if w := pageWrite("append", "x"); w != nil {
+		t.Errorf("pageWrite = %+v, want nil when no one is named", w)
+	}
+}
+
+func TestAddPageWrite_Bounded(t *testing.T) {
+	var writes []api.PageWrite
+	for i := range api.MaxPageWrites + 5 {
+		writes = api.AddPageWrite(writes, api.PageWrite{Bytes: i})
+	}
+	if len(writes) != api.MaxPageWrites || writes[0].Bytes != 5 {
+		t.Errorf("kept %d writes starting at %d, want the newest %d", len(writes), writes[0].Bytes, api.MaxPageWrites)
+	}
+}
+
+func TestFormatLastWrite(t *testing.T) {
+	got := formatLastWrite([]api.PageWrite{
+		{OnBehalfOf: "a", Mode: "append", At: "not a time"},
+		{OnBehalfOf: "deploy-bot/staging", Mode: "prepend", At: "bad"},
+	})
+	if got != "deploy-bot/staging (prepend, bad)" {
+		t.Errorf("formatLastWrite = %q", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"text/tabwriter"
 
 	"github.com/hyperclast/workspace/cli/internal/api"
@@ -24,11 +25,12 @@ var orgListCmd = &cobra.Command{
 			return err
 		}
 
-		client := api.NewClient(cfg.APIURL, cfg.Token)
+		client := newClient()
 		orgs, err := client.ListOrgs()
 		if err != nil {
 			return err
 		}
+		fillOrgRoles(client, orgs)
 
 		if outputFmt == "json" {
 			return json.NewEncoder(os.Stdout).Encode(orgs)
@@ -40,13 +42,17 @@ var orgListCmd = &cobra.Command{
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		_, _ = fmt.Fprintln(w, "ID\tNAME\tDOMAIN")
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tDOMAIN\tROLE")
 		for _, org := range orgs {
 			defaultMark := ""
 			if org.ExternalID == cfg.GetDefaultOrg() {
 				defaultMark = " (default)"
 			}
-			_, _ = fmt.Fprintf(w, "%s\t%s%s\t%s\n", org.ExternalID, org.Name, defaultMark, org.Domain)
+			role := org.Role
+			if role == "" {
+				role = "-"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s%s\t%s\t%s\n", org.ExternalID, org.Name, defaultMark, org.Domain, role)
 		}
 		_ = w.Flush()
 
@@ -73,7 +79,7 @@ var orgCurrentCmd = &cobra.Command{
 		}
 
 		if cfg.IsAuthenticated() {
-			client := api.NewClient(cfg.APIURL, cfg.Token)
+			client := newClient()
 			orgs, err := client.ListOrgs()
 			if err == nil {
 				for _, org := range orgs {
@@ -98,7 +104,7 @@ var orgUseCmd = &cobra.Command{
 		orgID := args[0]
 
 		if cfg.IsAuthenticated() {
-			client := api.NewClient(cfg.APIURL, cfg.Token)
+			client := newClient()
 			orgs, err := client.ListOrgs()
 			if err != nil {
 				return err
@@ -137,9 +143,132 @@ var orgUseCmd = &cobra.Command{
 	},
 }
 
+var orgLeaveForce bool
+
+var orgLeaveCmd = &cobra.Command{
+	Use:   "leave <id>",
+	Short: "Leave an organization",
+	Long: `Remove yourself from an organization. You lose access to its projects
+and pages, except projects you were shared into directly.
+
+The only admin of an organization cannot leave it; make another member an
+admin first.
+
+Examples:
+  hyperclast org leave org_abc123
+  hyperclast org leave org_abc123 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		orgID := args[0]
+		client := newClient()
+		user, err := client.GetCurrentUser()
+		if err != nil {
+			return err
+		}
+		orgs, err := client.ListOrgs()
+		if err != nil {
+			return err
+		}
+		var org *api.Org
+		for i := range orgs {
+			if orgs[i].ExternalID == orgID {
+				org = &orgs[i]
+				break
+			}
+		}
+		if org == nil {
+			return fmt.Errorf("organization '%s' not found. Run 'hyperclast org list' to see available organizations", orgID)
+		}
+
+		members, err := client.ListOrgMembers(orgID)
+		if err != nil {
+			return fmt.Errorf("failed to list members: %w", err)
+		}
+		admins, isAdmin := 0, false
+		for _, m := range members {
+			if m.Role == api.OrgRoleAdmin {
+				admins++
+				isAdmin = isAdmin || m.ExternalID == user.ExternalID
+			}
+		}
+		if isAdmin && admins == 1 {
+			return fmt.Errorf("you are the only admin of \"%s\"; make another member an admin before leaving", org.Name)
+		}
+
+		if !orgLeaveForce {
+			stat, _ := os.Stdin.Stat()
+			if (stat.Mode() & os.ModeCharDevice) == 0 {
+				return fmt.Errorf("--force is required in non-interactive mode (stdin is not a terminal)")
+			}
+			fmt.Fprintf(os.Stderr, "Leave organization \"%s\" (%s)? [y/N] ", org.Name, org.ExternalID)
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "y" && confirm != "Y" {
+				printInfo("Cancelled")
+				return nil
+			}
+		}
+
+		if err := client.RemoveOrgMember(orgID, user.ExternalID); err != nil {
+			return fmt.Errorf("failed to leave organization: %w", err)
+		}
+
+		wasDefault := cfg.GetDefaultOrg() == orgID
+		if wasDefault {
+			cfg.SetDefaultOrg("")
+			if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"left":        true,
+				"external_id": org.ExternalID,
+				"name":        org.Name,
+			})
+		}
+
+		printSuccess("Left organization \"%s\" (%s)", org.Name, org.ExternalID)
+		if wasDefault {
+			printInfo("It was your default organization; run 'hyperclast org use <id>' to pick another")
+		}
+		return nil
+	},
+}
+
+// fillOrgRoles sets each org's Role to the current user's role, looking up
+// the member lists of orgs the server did not report a role for. An org
+// whose members cannot be listed is left without a role, so the listing
+// itself never fails for want of roles.
+func fillOrgRoles(client *api.Client, orgs []api.Org) {
+	if !slices.ContainsFunc(orgs, func(org api.Org) bool { return org.Role == "" }) {
+		return
+	}
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		printDebug("could not get current user for org roles: %v", err)
+		return
+	}
+	failures := forEachConcurrent(len(orgs), 4, func(i int) error {
+		role, err := client.OrgRole(orgs[i], user.ExternalID)
+		orgs[i].Role = role
+		return err
+	})
+	for i, msg := range failures {
+		printDebug("could not get role in %s: %s", orgs[i].ExternalID, msg)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(orgCmd)
 	orgCmd.AddCommand(orgListCmd)
 	orgCmd.AddCommand(orgCurrentCmd)
 	orgCmd.AddCommand(orgUseCmd)
+	orgCmd.AddCommand(orgLeaveCmd)
+	orgLeaveCmd.Flags().BoolVar(&orgLeaveForce, "force", false, "skip confirmation prompt")
 }
@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+const exportManifestName = "manifest.json"
+
+var (
+	orgExportDir         string
+	orgExportConcurrency int
+)
+
+var orgExportCmd = &cobra.Command{
+	Use:   "export <org-id>",
+	Short: "Back up every project and page in an organization",
+	Long: `Export the content of every page in every project of an organization to a
+directory, one file per page under a folder per project, plus a manifest.json
+describing what was exported.
+
+Runs are incremental: a page whose update time matches the manifest and whose
+file on disk still matches the recorded SHA-256 is skipped without being
+downloaded, and a downloaded page whose content hash is unchanged is not
+rewritten. Pages that no longer exist are dropped from the manifest but
+their files are left in place.
+
+With --index, the exported content is also added to the local search index
+used by 'hyperclast search --local'. Only pages whose content changed are
+re-indexed.
+
+Examples:
+  hyperclast org export org_abc123 --dir ./backup
+  hyperclast org export org_abc123 --dir ./backup --index
+  hyperclast org export org_abc123 --dir /var/backups/hyperclast --concurrency 8`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if orgExportDir == "" {
+			return fmt.Errorf("--dir is required")
+		}
+		if orgExportConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+
+		result, err := runOrgExport(newClient(), args[0], orgExportDir, orgExportConcurrency)
+		if err != nil {
+			return err
+		}
+		if orgExportIndex {
+			if result.Index, err = refreshSearchIndex(orgExportDir); err != nil {
+				return fmt.Errorf("export finished but the search index was not updated: %w", err)
+			}
+		}
+		return printExportResult(result)
+	},
+}
+
+// exportManifest is written to manifest.json at the root of an export.
+type exportManifest struct {
+	Version    int               `json:"version"`
+	OrgID      string            `json:"org_id"`
+	ExportedAt string            `json:"exported_at"`
+	Projects   []exportedProject `json:"projects"`
+	Pages      []exportedPage    `json:"pages"`
+}
+
+type exportedProject struct {
+	ExternalID string `json:"external_id"`
+	Name       string `json:"name"`
+	Dir        string `json:"dir"`
+}
+
+type exportedPage struct {
+	ExternalID string `json:"external_id"`
+	ProjectID  string `json:"project_id"`
+	Title      string `json:"title"`
+	Filetype   string `json:"filetype"`
+	Updated    string `json:"updated,omitempty"`
+	Path       string `json:"path"`
+	SHA256     string `json:"sha256"`
+	Bytes      int    `json:"bytes"`
+}
+
+// exportResult summarizes an export run.
+type exportResult struct {
+	Dir       string            `json:"dir"`
+	Projects  int               `json:"projects"`
+	Pages     int               `json:"pages"`
+	Written   int               `json:"written"`
+	Unchanged int               `json:"unchanged"`
+	Removed   int               `json:"removed"`
+	Failed    map[string]string `json:"failed,omitempty"`
+	Index     *indexResult      `json:"index,omitempty"`
+}
+
+type exportJob struct {
+	page      api.Page
+	projectID string
+}
+
+// runOrgExport exports every page of orgID into dir using up to concurrency
+// parallel downloads, then writes the manifest. Pages that fail are recorded
+// in the result; if any fail, their previous manifest entries are kept so
+// the next run retries them.
+func runOrgExport(client *api.Client, orgID, dir string, concurrency int) (*exportResult, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	previous, err := readExportManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]exportedPage, len(previous.Pages))
+	for _, p := range previous.Pages {
+		known[p.ExternalID] = p
+	}
+
+	projects, err := client.ListProjects(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	manifest := exportManifest{
+		Version:    1,
+		OrgID:      orgID,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Projects:   []exportedProject{},
+		Pages:      []exportedPage{},
+	}
+	var jobs []exportJob
+	listing := startProgress("list", len(projects), "projects")
+	for _, project := range projects {
+		if !isSafeExportName(project.ExternalID) {
+			return nil, fmt.Errorf("refusing to export project with unsafe ID %q", project.ExternalID)
+		}
+		pages, err := client.ListPages(project.ExternalID)
+		listing.advance(project.ExternalID, "listed", err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pages in project %s: %w", project.ExternalID, err)
+		}
+		manifest.Projects = append(manifest.Projects, exportedProject{
+			ExternalID: project.ExternalID,
+			Name:       project.Name,
+			Dir:        project.ExternalID,
+		})
+		for _, page := range pages {
+			jobs = append(jobs, exportJob{page: page, projectID: project.ExternalID})
+		}
+	}
+
+	result := &exportResult{Dir: dir, Projects: len(projects), Pages: len(jobs), Failed: map[string]string{}}
+	exporting := startProgress("export", len(jobs), "pages")
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan exportJob)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				entry, written, err := exportPage(client, dir, job, known)
+				mu.Lock()
+				status := "unchanged"
+				switch {
+				case err != nil:
+					status = "failed"
+					result.Failed[job.page.ExternalID] = err.Error()
+					if prev, ok := known[job.page.ExternalID]; ok {
+						manifest.Pages = append(manifest.Pages, prev)
+					}
+				case written:
+					status = "written"
+					result.Written++
+					manifest.Pages = append(manifest.Pages, entry)
+				default:
+					result.Unchanged++
+					manifest.Pages = append(manifest.Pages, entry)
+				}
+				mu.Unlock()
+				exporting.advance(job.page.ExternalID, status, err)
+			}
+		}()
+	}
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+
+	current := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		current[job.page.ExternalID] = true
+	}
+	for id := range known {
+		if !current[id] {
+			result.Removed++
+		}
+	}
+
+	sort.Slice(manifest.Pages, func(i, j int) bool {
+		return manifest.Pages[i].Path < manifest.Pages[j].Path
+	})
+	if err := writeExportManifest(dir, &manifest); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// exportPage brings one page's file up to date. It reports whether the file
+// was (re)written.
+func exportPage(client *api.Client, dir string, job exportJob, known map[string]exportedPage) (exportedPage, bool, error) {
+	page := job.page
+	if !isSafeExportName(page.ExternalID) {
+		return exportedPage{}, false, fmt.Errorf("unsafe page ID")
+	}
+	updated := page.Updated
+	if updated == "" {
+		updated = page.Modified
+	}
+
+	prev, seen := known[page.ExternalID]
+	if seen && updated != "" && prev.Updated == updated && prev.ProjectID == job.projectID {
+		if hash, err := hashFile(filepath.Join(dir, filepath.FromSlash(prev.Path))); err == nil && hash == prev.SHA256 {
+			prev.Title = page.Title
+			return prev, false, nil
+		}
+	}
+
+	full, err := client.GetPage(page.ExternalID)
+	if err != nil {
+		return exportedPage{}, false, err
+	}
+	content := pageContent(full)
+	filetype := page.Filetype
+	if full.Details != nil && full.Details.Filetype != "" {
+		filetype = full.Details.Filetype
+	}
+	if filetype == "" {
+		filetype = "txt"
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	entry := exportedPage{
+		ExternalID: page.ExternalID,
+		ProjectID:  job.projectID,
+		Title:      page.Title,
+		Filetype:   filetype,
+		Updated:    updated,
+		Path:       job.projectID + "/" + page.ExternalID + "." + exportExtension(filetype),
+		SHA256:     hex.EncodeToString(sum[:]),
+		Bytes:      len(content),
+	}
+
+	path := filepath.Join(dir, filepath.FromSlash(entry.Path))
+	if hash, err := hashFile(path); err == nil && hash == entry.SHA256 {
+		return entry, false, nil
+	}
+	if err := writeFileAtomic(path, []byte(content)); err != nil {
+		return exportedPage{}, false, err
+	}
+	return entry, true, nil
+}
+
+// exportExtension maps a page filetype to a file extension.
+func exportExtension(filetype string) string {
+	switch filetype {
+	case "md", "csv", "log":
+		return filetype
+	default:
+		return "txt"
+	}
+}
+
+// isSafeExportName reports whether an ID returned by the server can be used
+// as a single path component.
+func isSafeExportName(id string) bool {
+	return id != "" && id != "." && id != ".." && !strings.ContainsAny(id, `/\:`)
+}
+
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it into
+// place, so an interrupted export never leaves a truncated file behind.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func readExportManifest(dir string) (*exportManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, exportManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &exportManifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m exportManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", exportManifestName, err)
+	}
+	return &m, nil
+}
+
+func writeExportManifest(dir string, m *exportManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(dir, exportManifestName), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+func printExportResult(r *exportResult) error {
+	if outputFmt == "json" {
+		if err := json.NewEncoder(os.Stdout).Encode(r); err != nil {
+			return err
+		}
+	} else {
+		ids := make([]string, 0, len(r.Failed))
+		for id := range r.Failed {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			printError("failed to export %s: %s", id, r.Failed[id])
+		}
+		printSuccess("Exported %d pages from %d projects to %s (%d written, %d unchanged)",
+			r.Pages-len(r.Failed), r.Projects, r.Dir, r.Written, r.Unchanged)
+		if r.Removed > 0 {
+			printInfo("  %d pages no longer exist; their files were kept", r.Removed)
+		}
+		if r.Index != nil {
+			printInfo("Search index: %d pages (%d updated, %d removed)", r.Index.Indexed, r.Index.Updated, r.Index.Removed)
+		}
+	}
+
+	if len(r.Failed) > 0 {
+		return fmt.Errorf("%d pages could not be exported", len(r.Failed))
+	}
+	return nil
+}
+
+func init() {
+	orgCmd.AddCommand(orgExportCmd)
+
+	orgExportCmd.Flags().StringVar(&orgExportDir, "dir", "", "directory to write the export to (required)")
+	orgExportCmd.Flags().IntVar(&orgExportConcurrency, "concurrency", 4, "number of pages to download in parallel")
+}
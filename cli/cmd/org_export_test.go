@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// exportServer serves one org with two projects. Page contents and update
+// times can be changed between runs; page fetches are counted.
+type exportServer struct {
+	mu      sync.Mutex
+	content map[string]string
+	updated map[string]string
+	fetches map[string]int
+	fail    map[string]bool
+}
+
+func newExportServer(t *testing.T) (*exportServer, *api.Client) {
+	s := &exportServer{
+		content: map[string]string{"page_a": "alpha", "page_b": "# beta", "page_c": "x,y\n1,2\n"},
+		updated: map[string]string{"page_a": "2025-01-01T00:00:00Z", "page_b": "2025-01-01T00:00:00Z", "page_c": "2025-01-01T00:00:00Z"},
+		fetches: map[string]int{},
+		fail:    map[string]bool{},
+	}
+	filetypes := map[string]string{"page_a": "txt", "page_b": "md", "page_c": "csv"}
+	projectPages := map[string][]string{"proj_1": {"page_a", "page_b"}, "proj_2": {"page_c"}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.URL.Path == "/projects/":
+			if r.URL.Query().Get("org_id") != "org_1" {
+				t.Errorf("org_id = %q, want org_1", r.URL.Query().Get("org_id"))
+			}
+			_ = json.NewEncoder(w).Encode([]api.Project{
+				{ExternalID: "proj_1", Name: "One"},
+				{ExternalID: "proj_2", Name: "Two"},
+			})
+		case strings.HasPrefix(r.URL.Path, "/projects/"):
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/projects/"), "/")
+			var pages []api.Page
+			for _, pid := range projectPages[id] {
+				pages = append(pages, api.Page{ExternalID: pid, Title: "Title " + pid, Updated: s.updated[pid]})
+			}
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: id, Pages: pages})
+		case strings.HasPrefix(r.URL.Path, "/pages/"):
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+			s.fetches[id]++
+			if s.fail[id] {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(api.Page{
+				ExternalID: id,
+				Details:    &api.PageDetails{Content: s.content[id], Filetype: filetypes[id]},
+			})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return s, api.NewClient(server.URL, "test-token")
+}
+
+func (s *exportServer) resetFetches() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches = map[string]int{}
+}
+
+func TestRunOrgExport(t *testing.T) {
+	s, client := newExportServer(t)
+	dir := t.TempDir()
+
+	result, err := runOrgExport(client, "org_1", dir, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pages != 3 || result.Written != 3 || result.Projects != 2 {
+		t.Errorf("first run = %+v, want 3 pages written from 2 projects", result)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "proj_1", "page_b.md"))
+	if err != nil || string(data) != "# beta" {
+		t.Errorf("page_b.md = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "proj_2", "page_c.csv")); err != nil {
+		t.Errorf("expected csv export: %v", err)
+	}
+
+	manifest, err := readExportManifest(dir)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	if manifest.OrgID != "org_1" || len(manifest.Projects) != 2 || len(manifest.Pages) != 3 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	if manifest.Pages[0].Path != "proj_1/page_a.txt" || len(manifest.Pages[0].SHA256) != 64 {
+		t.Errorf("unexpected first entry: %+v", manifest.Pages[0])
+	}
+
+	t.Run("unchanged pages are not downloaded", func(t *testing.T) {
+		s.resetFetches()
+		result, err := runOrgExport(client, "org_1", dir, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Written != 0 || result.Unchanged != 3 {
+			t.Errorf("result = %+v, want all unchanged", result)
+		}
+		if len(s.fetches) != 0 {
+			t.Errorf("fetched %v, want no page downloads", s.fetches)
+		}
+	})
+
+	t.Run("updated and tampered pages are rewritten", func(t *testing.T) {
+		s.resetFetches()
+		s.content["page_a"] = "alpha v2"
+		s.updated["page_a"] = "2025-02-01T00:00:00Z"
+		_ = os.WriteFile(filepath.Join(dir, "proj_1", "page_b.md"), []byte("corrupted"), 0644)
+
+		result, err := runOrgExport(client, "org_1", dir, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Written != 2 || result.Unchanged != 1 {
+			t.Errorf("result = %+v, want 2 written and 1 unchanged", result)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "proj_1", "page_a.txt"))
+		if string(data) != "alpha v2" {
+			t.Errorf("page_a.txt = %q, want updated content", data)
+		}
+		data, _ = os.ReadFile(filepath.Join(dir, "proj_1", "page_b.md"))
+		if string(data) != "# beta" {
+			t.Errorf("page_b.md = %q, want restored content", data)
+		}
+	})
+
+	t.Run("failed pages keep their previous entry", func(t *testing.T) {
+		s.fail["page_c"] = true
+		s.updated["page_c"] = "2025-03-01T00:00:00Z"
+
+		result, err := runOrgExport(client, "org_1", dir, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Failed) != 1 || result.Failed["page_c"] == "" {
+			t.Errorf("failed = %v, want page_c", result.Failed)
+		}
+		manifest, _ := readExportManifest(dir)
+		if len(manifest.Pages) != 3 || manifest.Pages[2].Updated != "2025-01-01T00:00:00Z" {
+			t.Errorf("manifest pages = %+v, want page_c's previous entry kept", manifest.Pages)
+		}
+	})
+}
+
+func TestIsSafeExportName(t *testing.T) {
+	for _, id := range []string{"page_abc", "proj-1"} {
+		if !isSafeExportName(id) {
+			t.Errorf("isSafeExportName(%q) = false, want true", id)
+		}
+	}
+	for _, id := range []string{"", ".", "..", "../etc", `a\b`, "c:x"} {
+		if isSafeExportName(id) {
+			t.Errorf("isSafeExportName(%q) = true, want false", id)
+		}
+	}
+}
@@ -36,14 +36,22 @@ func TestOrgList_Success(t *testing.T) {
 	resetOrgFlags()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/orgs/" {
-			t.Errorf("path = %q, want /orgs/", r.URL.Path)
-		}
 		w.WriteHeader(http.StatusOK)
-		_ = json.NewEncoder(w).Encode([]api.Org{
-			{ExternalID: "org_1", Name: "Org One", Domain: "one.com"},
-			{ExternalID: "org_2", Name: "Org Two", Domain: "two.com"},
-		})
+		switch r.URL.Path {
+		case "/orgs/":
+			_ = json.NewEncoder(w).Encode([]api.Org{
+				{ExternalID: "org_1", Name: "Org One", Domain: "one.com"},
+				{ExternalID: "org_2", Name: "Org Two", Domain: "two.com"},
+			})
+		case "/users/me/":
+			_ = json.NewEncoder(w).Encode(api.User{ExternalID: "user_me"})
+		case "/orgs/org_1/members/":
+			_ = json.NewEncoder(w).Encode([]api.OrgMember{{ExternalID: "user_me", Role: "admin"}})
+		case "/orgs/org_2/members/":
+			_ = json.NewEncoder(w).Encode([]api.OrgMember{{ExternalID: "user_x", Role: "admin"}, {ExternalID: "user_me", Role: "member"}})
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
 	}))
 	defer server.Close()
 
@@ -74,6 +82,9 @@ func TestOrgList_Success(t *testing.T) {
 	if !strings.Contains(outputStr, "Org Two") {
 		t.Errorf("output should contain 'Org Two', got %q", outputStr)
 	}
+	if !strings.Contains(outputStr, "ROLE") || !strings.Contains(outputStr, "one.com  admin") || !strings.Contains(outputStr, "two.com  member") {
+		t.Errorf("output should show my role in each org, got %q", outputStr)
+	}
 }
 
 func TestOrgList_Empty(t *testing.T) {
@@ -300,3 +311,74 @@ func TestOrgUse_NotFound(t *testing.T) {
 		t.Errorf("error = %q, expected to contain 'not found'", err)
 	}
 }
+
+// leaveServer serves org_1, where user_me is an admin along with the given
+// number of other admins, and records DELETE requests.
+func leaveServer(t *testing.T, admins int) *[]string {
+	t.Helper()
+	var deletes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/users/me/":
+			_ = json.NewEncoder(w).Encode(api.User{ExternalID: "user_me"})
+		case r.URL.Path == "/orgs/":
+			_ = json.NewEncoder(w).Encode([]api.Org{{ExternalID: "org_1", Name: "Client Co"}})
+		case r.URL.Path == "/orgs/org_1/members/":
+			members := []api.OrgMember{{ExternalID: "user_me", Role: "admin"}}
+			for range admins {
+				members = append(members, api.OrgMember{ExternalID: "user_x", Role: "admin"})
+			}
+			_ = json.NewEncoder(w).Encode(members)
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	return &deletes
+}
+
+func TestOrgLeave_RemovesSelfAndClearsDefault(t *testing.T) {
+	resetOrgFlags()
+	deletes := leaveServer(t, 1)
+	cfg.SetDefaultOrg("org_1")
+	orgLeaveForce = true
+	defer func() { orgLeaveForce = false }()
+
+	output := captureArchiveOutput(t, func() error {
+		return orgLeaveCmd.RunE(orgLeaveCmd, []string{"org_1"})
+	})
+
+	if len(*deletes) != 1 || (*deletes)[0] != "/orgs/org_1/members/user_me/" {
+		t.Errorf("deletes = %v, want my own membership removed", *deletes)
+	}
+	if cfg.GetDefaultOrg() != "" {
+		t.Errorf("default org = %q, want it cleared", cfg.GetDefaultOrg())
+	}
+	if !strings.Contains(output, `Left organization "Client Co"`) {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestOrgLeave_OnlyAdmin(t *testing.T) {
+	resetOrgFlags()
+	deletes := leaveServer(t, 0)
+	orgLeaveForce = true
+	defer func() { orgLeaveForce = false }()
+
+	err := orgLeaveCmd.RunE(orgLeaveCmd, []string{"org_1"})
+	if err == nil || !strings.Contains(err.Error(), "only admin") {
+		t.Fatalf("err = %v, want an only-admin error", err)
+	}
+	if len(*deletes) != 0 {
+		t.Errorf("deletes = %v, want none", *deletes)
+	}
+
+	if err := orgLeaveCmd.RunE(orgLeaveCmd, []string{"org_other"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want not found for an org I am not in", err)
+	}
+}
@@ -2,16 +2,21 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 	"unicode/utf8"
 
 	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/jsonschema"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +31,12 @@ var (
 	pageFiletype  string
 	pageMeta      bool
 	pageSource    string
+	pageSection   string
+
+	pageUpdateForce bool
+	// pageIfUnmodified is the version, from Page.Version, that an
+	// overwrite or restore requires the page to still be at.
+	pageIfUnmodified string
 )
 
 var pageCmd = &cobra.Command{
@@ -43,6 +54,10 @@ CSV files and HTTP access logs are auto-detected:
 - CSV: displayed as sortable, filterable tables
 - Log: displayed with IP highlighting and filtering (Apache/Nginx format)
 
+For CSV pages, column types (number, date, url, string) are inferred from the
+first rows and sent as schema hints so the UI can sort numerically and render
+links. Use --no-schema to skip inference.
+
 Examples:
   # Pipe command output
   cat build.log | hyperclast page new --project proj_abc --title "Build Log"
@@ -67,81 +82,387 @@ Examples:
   echo "# Markdown" | hyperclast page new --project proj_abc --filetype md
 
   # Include metadata backmatter
-  make build | hyperclast page new --project proj_abc --meta --source "make build"`,
+  make build | hyperclast page new --project proj_abc --meta --source "make build"
+
+  # Filter and rename CSV columns on the way in
+  cat users.csv | hyperclast page new --csv-drop password_hash --csv-rename email=contact
+  cat users.csv | hyperclast page new --csv-select name,email
+
+  # Fill in a report template at capture time
+  hyperclast page new --file report.tmpl.md --substitute --title "Deploy report"
+
+  # Check the exact content and request before creating anything
+  cat users.csv | hyperclast page new --csv-drop password_hash --meta --preview
+
+  # Snapshot a file as of a git tag (the ref is recorded in metadata)
+  hyperclast page new --from-git-show v1.2.3:config/prod.yaml
+
+  # Copy a runbook from the web (the URL is recorded in metadata)
+  hyperclast page new --url https://example.com/runbook.md
+  hyperclast page new --url https://example.com/docs/deploy --to-markdown
+
+  # One page per section of a concatenated report
+  ./run-suites.sh | hyperclast page new --split-on '^=== (.+) ===$'
+
+  # One page per file, titled with the file's name
+  hyperclast page new --files 'logs/*.log'
+
+  # Expire a CI log after a week ('page gc' deletes expired pages)
+  ./ci.sh 2>&1 | hyperclast page new --title "CI #4512" --expires 7d
+
+  # Capture a snippet copied from another app
+  hyperclast page new --title "Prod trace" --clipboard
+
+  # CI reruns: don't create another copy of an identical failure log
+  ./ci.sh 2>&1 | hyperclast page new --title "CI failure" --skip-duplicate
+
+  # Idempotent daily rollup: append to today's page once it exists
+  ./rollup.sh | hyperclast page new --title "Rollup $(date +%F)" --append-if-exists
+
+  # Capture, then look at it in the browser
+  make test 2>&1 | hyperclast page new --title "Test run" --open
+
+  # Keep an investigation connected: the incident page gets a backlink
+  kubectl logs api-7f9c | hyperclast page new --title "api logs" --link-from page_xyz789
+
+  # Use the team's reviewed settings for deploy logs (presets in the config)
+  ./deploy.sh 2>&1 | hyperclast page new --preset deploy --title "Deploy v1.4"`,
 	RunE: runPageNew,
 }
 
 func runPageNew(cmd *cobra.Command, args []string) error {
-	if err := requireAuth(); err != nil {
+	if err := applyPagePreset(cmd); err != nil {
 		return err
 	}
+	if !pagePreview {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+	}
 
 	projectID := pageProjectID
 	if projectID == "" {
 		projectID = cfg.GetDefaultProject()
 	}
 
-	if projectID == "" {
+	if projectID == "" && !pagePreview {
 		printError("No project specified.")
 		printInfo("  Use --project <id> or set a default: hyperclast project use <id>")
 		printInfo("  Run 'hyperclast project list' to see available projects.")
 		cmd.SilenceErrors = true
 		return fmt.Errorf("no project specified")
 	}
+	if !pagePreview {
+		if err := confirmProtectedWrite(projectID, pageProjectID != "", "create a page"); err != nil {
+			return err
+		}
+	}
 
-	content, err := readContent()
+	if err := checkOnBehalfOf(); err != nil {
+		return err
+	}
+	splitOn, err := checkSplitOn()
+	if err != nil {
+		return err
+	}
+	if err := checkFiles(); err != nil {
+		return err
+	}
+	if err := checkClipboard(); err != nil {
+		return err
+	}
+	if err := checkTimestamps(); err != nil {
+		return err
+	}
+	budget, err := checkPageNewTail()
+	if err != nil {
+		return err
+	}
+	if err := checkPageNewANSI(); err != nil {
+		return err
+	}
+	expires, err := parseExpires(pageExpires)
 	if err != nil {
 		return err
 	}
+	if pageNewOpen && pagePreview {
+		return fmt.Errorf("--open cannot be combined with --preview")
+	}
+	if pageSkipDuplicate && pagePreview {
+		return fmt.Errorf("--skip-duplicate cannot be combined with --preview")
+	}
+	if err := checkUpsert(); err != nil {
+		return err
+	}
+	if err := checkPageNewStream(); err != nil {
+		return err
+	}
 
-	if pageMeta {
-		content = appendMetadata(content)
+	if err := checkFiletype(pageFiletype); err != nil {
+		return err
+	}
+	if streamOutput {
+		return runPageNewStream(projectID, expires)
+	}
+
+	var guard *uploadGuard
+	defer func() {
+		if guard != nil {
+			guard.stop()
+		}
+	}()
+
+	if len(pageFiles) > 0 {
+		guard = startUploadGuard()
+		return runPageNewFiles(projectID, expires, guard)
+	}
+
+	var content string
+	var gitSource *gitShowSource
+	var fetched *urlSource
+	if pageToMarkdown && pageFromURL == "" {
+		return fmt.Errorf("--to-markdown requires --url")
+	}
+	switch {
+	case pageFromGitShow != "" && pageFromURL != "":
+		return fmt.Errorf("--from-git-show and --url cannot be used together")
+	case pageFromURL != "" && pageFile != "":
+		return fmt.Errorf("--url and --file cannot be used together")
+	}
+	if pageFromGitShow != "" {
+		if pageFile != "" {
+			return fmt.Errorf("--from-git-show and --file cannot be used together")
+		}
+		var src gitShowSource
+		content, src, err = readGitShow(pageFromGitShow)
+		if err != nil {
+			return err
+		}
+		gitSource = &src
+	} else if pageFromURL != "" {
+		if pageInteractive {
+			return fmt.Errorf("--interactive cannot be combined with --url")
+		}
+		var src urlSource
+		content, src, err = readURL(pageFromURL, pageToMarkdown)
+		if err != nil {
+			return err
+		}
+		fetched = &src
+	} else {
+		compose, err := composeWanted()
+		if err != nil {
+			return err
+		}
+		if compose {
+			// Ctrl-C in the composer cancels, as its hint says, so the
+			// guard starts once the page is written.
+			content, err = composePage(os.Stdin)
+		} else {
+			guard = startUploadGuard()
+			if budget != nil {
+				content, err = readContentWith(func(path string) (string, error) { return readBudgetedFile(path, budget) })
+			} else {
+				content, err = readContent()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if guard == nil {
+		guard = startUploadGuard()
+	}
+
+	if pageSubstitute {
+		content, err = expandPlaceholders(content)
+		if err != nil {
+			return handleContentError(err)
+		}
+	}
+	content = redactContent(filterANSI(content))
+
+	if splitOn != nil {
+		return runPageNewSplit(projectID, content, splitOn, expires, guard)
+	}
+
+	filetype := pageFiletype
+	if csvColumnOpsSet() {
+		content, err = transformCSV(content, pageCSVSelect, pageCSVDrop, pageCSVRename)
+		if err != nil {
+			return handleContentError(err)
+		}
+		if filetype == autoFiletype {
+			filetype = "csv"
+		}
+	} else if filetype == autoFiletype && ansiMode == ansiToMarkdown {
+		filetype = "md"
+	} else if filetype == autoFiletype && fetched != nil && fetched.Filetype != "" {
+		filetype = fetched.Filetype
+	} else if filetype == autoFiletype {
+		filetype = detectFiletype(content, "txt")
+	}
+
+	var schema *api.TableSchema
+	if filetype == "csv" && !pageNoSchema {
+		schema = inferCSVSchema(content)
+	}
+
+	var linkFrom *api.Page
+	var related []string
+	if pageLinkFrom != "" {
+		if !pagePreview {
+			if linkFrom, err = fetchLinkSource(newClient(), pageLinkFrom); err != nil {
+				return handleContentError(err)
+			}
+		}
+		related = []string{pageLinkFrom}
+	}
+
+	var hash string
+	if pageSkipDuplicate {
+		hash = contentHash(content)
+		duplicate, err := findDuplicatePage(newClient(), projectID, content)
+		if err != nil {
+			printWarning("could not check for duplicate pages (%v); creating the page anyway", err)
+		} else if duplicate != nil {
+			cleanupStdinTemp()
+			return printSkippedDuplicate(duplicate)
+		}
+	}
+
+	// The revision is the point of a git snapshot, and the address the point
+	// of a fetched page, so always record them.
+	if gitSource != nil || fetched != nil || pageMeta {
+		var extra []string
+		if gitSource != nil {
+			extra = append(extra, gitSource.metadataLine())
+		} else if fetched != nil {
+			extra = append(extra, fetched.metadataLine())
+		}
+		if pageLinkFrom != "" {
+			extra = append(extra, relatedLine(pageLinkFrom))
+		}
+		if !expires.IsZero() {
+			extra = append(extra, expiresLine(expires))
+		}
+		content = appendMetadata(content, extra...)
 	}
 
 	title := pageTitle
+	if title == "" && gitSource != nil {
+		title = fmt.Sprintf("%s @ %s", gitSource.Path, gitSource.Ref)
+	}
+	if title == "" && fetched != nil {
+		title = fetched.Title
+	}
 	if title == "" {
 		title = generateDefaultTitle()
 	}
 
-	filetype := pageFiletype
-	if !cmd.Flags().Changed("filetype") {
-		filetype = detectFiletype(content, "txt")
+	details := &api.PageDetails{
+		Content:  content,
+		Filetype: filetype,
+		Schema:   schema,
+		Related:  related,
+		Labels:   pageLabels,
+	}
+	if !expires.IsZero() {
+		details.ExpiresAt = expires.Format(time.RFC3339)
+	}
+	details.ContentHash = hash
+	if w := pageWrite("create", content); w != nil {
+		details.Writes = []api.PageWrite{*w}
+	}
+
+	if pagePreview {
+		cleanupStdinTemp()
+		return printPagePreview(api.NewCreatePageRequest(projectID, title, details), filetypeSource(), gitSource != nil || fetched != nil || pageMeta)
 	}
 
-	client := api.NewClient(cfg.APIURL, cfg.Token)
-	page, err := client.CreatePage(projectID, title, content, filetype)
+	client := newClient()
+	if mode := upsertMode(); mode != "" {
+		target, err := findUpsertTarget(client, projectID, title)
+		if err != nil {
+			return handleContentError(err)
+		}
+		if target != nil {
+			return upsertPage(client, target, content, mode, details, linkFrom, guard)
+		}
+	}
+
+	if err := guard.beginUpload(content); err != nil {
+		return err
+	}
+
+	page, err := client.CreatePageWithDetails(projectID, title, details)
 	if err != nil {
+		if aborted := guard.aborted(err, content); aborted != nil {
+			return aborted
+		}
 		return handleContentError(fmt.Errorf("failed to create page: %w", err))
 	}
 
 	cleanupStdinTemp()
 
+	if linkFrom != nil {
+		linkFromPage(client, linkFrom, page)
+	}
+	if pageNewOpen {
+		openCreatedPage(page.ExternalID)
+	}
+
 	if outputFmt == "json" {
-		return json.NewEncoder(os.Stdout).Encode(page)
+		if err := json.NewEncoder(os.Stdout).Encode(page); err != nil {
+			return err
+		}
+		return guard.interruptedAfterUpload()
 	}
 
 	if quiet {
 		fmt.Println(page.ExternalID)
-		return nil
+		return guard.interruptedAfterUpload()
 	}
 
 	printSuccess("Created page \"%s\" (%s)", page.Title, page.ExternalID)
-	printInfo("  %s/pages/%s/", baseURL(), page.ExternalID)
+	printInfo("  %s", pageURL(page.ExternalID))
+	if linkFrom != nil {
+		printInfo("  Linked from \"%s\" (%s)", linkFrom.Title, linkFrom.ExternalID)
+	}
+	if !expires.IsZero() {
+		printInfo("  Expires %s", formatMetadataTime(details.ExpiresAt))
+	}
 
-	return nil
+	return guard.interruptedAfterUpload()
 }
 
 var pageAppendCmd = &cobra.Command{
-	Use:   "append <page-id>",
+	Use:   "append <page-id>[,<page-id>...]",
 	Short: "Append content to an existing page",
 	Long: `Append content to the end of an existing page.
 
+Several pages, given as arguments, separated by commas, or with --also,
+all receive the same content. They are written concurrently and each
+one's result is reported; the command fails if any of them failed.
+
 Examples:
   echo "New log entry" | hyperclast page append page_xyz789
-  cat more-logs.txt | hyperclast page append page_xyz789 --meta --source "tail -f logs"`,
-	Args: cobra.ExactArgs(1),
+  cat more-logs.txt | hyperclast page append page_xyz789 --meta --source "tail -f logs"
+
+  # Wrap the appended content in named anchors for later extraction
+  ./deploy.sh 2>&1 | hyperclast page append page_xyz789 --section "deploy 2024-06-01"
+  hyperclast page get page_xyz789 --section "deploy 2024-06-01"
+
+  # Land one deploy log in the service's page and a global one
+  ./deploy.sh 2>&1 | hyperclast page append page_svc_deploys,page_all_deploys
+  ./deploy.sh 2>&1 | hyperclast page append page_svc_deploys --also page_all_deploys`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runPageUpdate(args[0], "append")
+		pageIDs, err := appendTargets(args)
+		if err != nil {
+			return err
+		}
+		return runPageUpdate(pageIDs, "append")
 	},
 }
 
@@ -154,7 +475,7 @@ Examples:
   echo "Header info" | hyperclast page prepend page_xyz789`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runPageUpdate(args[0], "prepend")
+		return runPageUpdate(args, "prepend")
 	},
 }
 
@@ -163,43 +484,117 @@ var pageOverwriteCmd = &cobra.Command{
 	Short: "Replace all content of an existing page",
 	Long: `Replace all content of an existing page.
 
+To keep from discarding someone else's change, pass the version 'page get
+--output json' reported with --if-unmodified: if the page was written since,
+nothing is written and the command fails with a conflict.
+
 Examples:
-  cat updated-config.txt | hyperclast page overwrite page_xyz789`,
+  cat updated-config.txt | hyperclast page overwrite page_xyz789
+
+  # Edit a page without clobbering concurrent edits
+  version=$(hyperclast page get page_xyz789 --output json | jq -r .version)
+  hyperclast page get page_xyz789 | sed 's/replicas: 1/replicas: 3/' |
+    hyperclast page overwrite page_xyz789 --if-unmodified "$version"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runPageUpdate(args[0], "overwrite")
+		return runPageUpdate(args, "overwrite")
 	},
 }
 
-func runPageUpdate(pageID string, mode string) error {
+// runPageUpdate writes the input to the pages in mode. Only appends go to
+// more than one page.
+func runPageUpdate(pageIDs []string, mode string) error {
 	if err := requireAuth(); err != nil {
 		return err
 	}
 
-	content, err := readContent()
+	if err := checkOnBehalfOf(); err != nil {
+		return err
+	}
+	if err := checkClipboard(); err != nil {
+		return err
+	}
+	if err := checkTimestamps(); err != nil {
+		return err
+	}
+	if err := resolveANSI(false); err != nil {
+		return err
+	}
+
+	guard := startUploadGuard()
+	defer guard.stop()
+
+	var budget *appendBudget
+	if mode == "append" {
+		var err error
+		if budget, err = newAppendBudget(); err != nil {
+			return err
+		}
+	}
+
+	var content string
+	var err error
+	if budget != nil {
+		content, err = readContentWith(func(path string) (string, error) { return readBudgetedFile(path, budget) })
+	} else {
+		content, err = readContent()
+	}
 	if err != nil {
 		return err
 	}
+	content = filterANSI(content)
+
+	if pageSubstitute {
+		content, err = expandPlaceholders(content)
+		if err != nil {
+			return handleContentError(err)
+		}
+	}
 
 	if pageMeta {
 		content = appendMetadata(content)
 	}
 
-	client := api.NewClient(cfg.APIURL, cfg.Token)
-	page, err := client.UpdatePageContent(pageID, content, mode)
+	if pageSection != "" {
+		content = wrapSection(content, pageSection)
+		if mode == "append" {
+			content = "\n" + content
+		}
+	}
+
+	if err := guard.beginUpload(content); err != nil {
+		return err
+	}
+
+	if len(pageIDs) > 1 {
+		return runAppendFanOut(pageIDs, content, guard)
+	}
+	existing, page, err := updatePage(pageIDs[0], content, mode)
 	if err != nil {
-		return handleContentError(fmt.Errorf("failed to update page: %w", err))
+		if aborted := guard.aborted(err, content); aborted != nil {
+			return aborted
+		}
+		return handleContentError(err)
 	}
 
 	cleanupStdinTemp()
+	return printPageUpdate(existing, page, content, mode, guard)
+}
 
+// printPageUpdate reports a write of content to a page in mode, with the
+// page before and after it.
+func printPageUpdate(existing, page *api.Page, content, mode string, guard *uploadGuard) error {
+	ack := newWriteAck(existing, page, content, mode)
 	if outputFmt == "json" {
-		return json.NewEncoder(os.Stdout).Encode(page)
+		if err := json.NewEncoder(os.Stdout).Encode(pageUpdateOutput{Page: page, Ack: ack}); err != nil {
+			return err
+		}
+		return guard.interruptedAfterUpload()
 	}
 
 	if quiet {
 		fmt.Println(page.ExternalID)
-		return nil
+		return guard.interruptedAfterUpload()
 	}
 
 	var verb string
@@ -213,30 +608,81 @@ func runPageUpdate(pageID string, mode string) error {
 	}
 
 	printSuccess("%s page \"%s\" (%s)", verb, page.Title, page.ExternalID)
-	return nil
+	printWriteAck(ack)
+	return guard.interruptedAfterUpload()
+}
+
+// updatePage writes content to a page in mode, recording a journal marker
+// for an append with --journal, and returns the page before and after.
+// With --if-unmodified the write fails unless the page is still at that
+// version.
+func updatePage(pageID, content, mode string) (existing, page *api.Page, err error) {
+	client := newClient()
+	var journal *appendJournal
+	if pageAppendJournal && mode == "append" {
+		journal = &appendJournal{}
+		client = api.NewClientWithOptions(cfg.APIURL, cfg.Token, journal.clientOptions())
+	}
+	existing, err = client.GetPage(pageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	if err := checkPageLock(client, existing); err != nil {
+		return nil, nil, err
+	}
+	if pageIfUnmodified != "" && existing.Version() != pageIfUnmodified {
+		return nil, nil, fmt.Errorf("%w (now at version %s); check its changes with 'hyperclast page get %s', or re-run without --if-unmodified to overwrite them",
+			&api.ConflictError{PageID: pageID}, existing.Version(), pageID)
+	}
+	if err := enforcePageQuota(newPageQuota(existing, content, mode), pageUpdateForce); err != nil {
+		return nil, nil, err
+	}
+
+	if journal != nil {
+		journal.retries = 0
+	}
+	page, err = client.UpdateFetchedPageContentAs(existing, content, mode, pageWrite(mode, content))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update page: %w", err)
+	}
+	if journal != nil {
+		recordJournal(client, journal, existing, page, content)
+	}
+	return existing, page, nil
 }
 
 var stdinTempPath string
 
 func readContent() (string, error) {
+	return readContentWith(readAndValidateFile)
+}
+
+// readContentWith is readContent with read loading the file or buffered
+// stdin.
+func readContentWith(read func(path string) (string, error)) (string, error) {
 	stdinTempPath = ""
 
 	if pageFile != "" {
-		return readAndValidateFile(pageFile)
+		return read(pageFile)
 	}
 
-	stat, _ := os.Stdin.Stat()
-	if (stat.Mode() & os.ModeCharDevice) != 0 {
-		printError("No content provided. Pipe content or use --file <path>")
-		return "", fmt.Errorf("no content provided")
+	var tempPath string
+	var err error
+	if pageClipboard {
+		tempPath, err = bufferClipboardToTemp()
+	} else {
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) != 0 {
+			printError("No content provided. Pipe content or use --file <path>")
+			return "", fmt.Errorf("no content provided")
+		}
+		tempPath, err = bufferStdinToTemp()
 	}
-
-	tempPath, err := bufferStdinToTemp()
 	if err != nil {
 		return "", err
 	}
 
-	content, err := readAndValidateFile(tempPath)
+	content, err := read(tempPath)
 	if err != nil {
 		printRecoveryInfo(tempPath)
 		return "", err
@@ -261,13 +707,23 @@ func handleContentError(err error) error {
 }
 
 func bufferStdinToTemp() (string, error) {
-	tempFile, err := os.CreateTemp("", "hyperclast-stdin-*.txt")
+	if lineTimestamps != "" {
+		return bufferReaderToTemp(timestampReader(os.Stdin))
+	}
+	return bufferReaderToTemp(os.Stdin)
+}
+
+// bufferReaderToTemp copies r to a temp file, as stdin is buffered.
+func bufferReaderToTemp(r io.Reader) (string, error) {
+	tempFile, err := config.ResolveDirs().CreateTemp("hyperclast-stdin-*.txt")
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
 	tempPath := tempFile.Name()
 
-	_, copyErr := io.Copy(tempFile, os.Stdin)
+	stdinBufferPath = tempPath
+	_, copyErr := io.Copy(tempFile, r)
+	stdinBufferPath = ""
 	_ = tempFile.Close()
 	if copyErr != nil {
 		_ = os.Remove(tempPath)
@@ -296,7 +752,7 @@ func readAndValidateFile(path string) (string, error) {
 		return "", err
 	}
 
-	content := string(data)
+	content := normalizeNewlines(string(data))
 	if content == "" {
 		return "", fmt.Errorf("no content provided")
 	}
@@ -352,14 +808,26 @@ func reconstructCommand() string {
 	return strings.Join(parts, " ")
 }
 
-func appendMetadata(content string) string {
+// metadataStart opens the metadata backmatter appendMetadata adds, which
+// ends with a line of "---".
+const metadataStart = "\n\n---\nCaptured by Hyperclast CLI\n"
+
+// appendMetadata adds the metadata backmatter to content. Extra lines are
+// written after the source.
+func appendMetadata(content string, extra ...string) string {
 	hostname, _ := os.Hostname()
 	cwd, _ := os.Getwd()
 
-	meta := "\n\n---\nCaptured by Hyperclast CLI\n"
+	meta := metadataStart
 	if pageSource != "" {
 		meta += fmt.Sprintf("Source: %s\n", pageSource)
 	}
+	if name := onBehalfOf(); name != "" {
+		meta += fmt.Sprintf("On behalf of: %s\n", name)
+	}
+	for _, line := range extra {
+		meta += line + "\n"
+	}
 	meta += fmt.Sprintf("Time: %s\n", time.Now().UTC().Format("2006-01-02 15:04:05 UTC"))
 	if hostname != "" {
 		meta += fmt.Sprintf("Host: %s\n", hostname)
@@ -372,12 +840,20 @@ func appendMetadata(content string) string {
 	return content + meta
 }
 
-var pageListProjectID string
+var (
+	pageListProjectID  string
+	pageListJSONSchema bool
+	pageListShowStatus bool
+)
 
 var pageListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List pages",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if pageListJSONSchema {
+			return printSchema(jsonschema.For([]api.Page{}, "page list"))
+		}
+
 		if !cfg.IsAuthenticated() {
 			return fmt.Errorf("not authenticated. Run 'hyperclast auth login' first")
 		}
@@ -387,69 +863,273 @@ var pageListCmd = &cobra.Command{
 			projectID = cfg.GetDefaultProject()
 		}
 
-		client := api.NewClient(cfg.APIURL, cfg.Token)
-		pages, err := client.ListPages(projectID)
+		if err := checkPageListFlags(); err != nil {
+			return err
+		}
+		if err := checkPageListPaging(); err != nil {
+			return err
+		}
+		if err := checkPageListTrashed(); err != nil {
+			return err
+		}
+		if err := checkListSort(); err != nil {
+			return err
+		}
+
+		client := newClient()
+		if pageListFollow {
+			return followPageList(client, projectID)
+		}
+		pages, next, err := fetchPageList(client, projectID)
 		if err != nil {
 			return err
 		}
+		if err := renderPageList(os.Stdout, pages); err != nil {
+			return err
+		}
+		printNextCursor(next)
+		return nil
+	},
+}
 
-		if outputFmt == "json" {
-			return json.NewEncoder(os.Stdout).Encode(pages)
+// fetchPageList gets the pages 'page list' shows and the next window's cursor.
+func fetchPageList(client *api.Client, projectID string) ([]api.Page, string, error) {
+	var pages []api.Page
+	var next string
+	if pageListTrashed {
+		trashed, err := listTrashedPages(projectID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list the trash: %w", err)
+		}
+		pages = trashed
+	} else {
+		active, archived, window, err := listPagesForList(client, projectID)
+		if err != nil {
+			return nil, "", err
+		}
+		pages, next = active, window
+		if pageListArchived {
+			pages = archived
 		}
+	}
 
-		if len(pages) == 0 {
-			printInfo("No pages found")
-			return nil
+	if pageListUpdatedWithin > 0 {
+		pages = pagesUpdatedWithin(pages, time.Now().Add(-pageListUpdatedWithin))
+	}
+
+	if len(pageListTags) > 0 {
+		tagged, err := filterPagesByTags(client, pages, pageListTags)
+		if err != nil {
+			return nil, "", err
 		}
+		pages = tagged
+	}
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		_, _ = fmt.Fprintln(w, "ID\tTITLE\tUPDATED")
-		for _, page := range pages {
-			updated := page.Updated
-			if updated == "" {
-				updated = page.Modified
-			}
-			if t, err := time.Parse(time.RFC3339, updated); err == nil {
-				updated = t.Format("Jan 2, 2006 3:04 PM")
-			}
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", page.ExternalID, page.Title, updated)
+	if pageListShowStatus && projectID != "" {
+		if err := fillPageStatuses(client, pages); err != nil {
+			return nil, "", err
 		}
-		_ = w.Flush()
+	}
+	sortListing(pages, pageSortFields)
+	return pages, next, nil
+}
 
+// renderPageList writes pages as JSON, with --format, or as a table.
+func renderPageList(out io.Writer, pages []api.Page) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(out).Encode(pages)
+	}
+	if pageListFormat != "" {
+		return formatPageList(out, pages, pageListFormat)
+	}
+
+	if len(pages) == 0 {
+		if !quiet {
+			_, _ = fmt.Fprintln(out, "No pages found")
+		}
 		return nil
-	},
+	}
+
+	showStatus := hasPageStatus(pages)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if showStatus {
+		_, _ = fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tUPDATED")
+	} else {
+		_, _ = fmt.Fprintln(w, "ID\tTITLE\tUPDATED")
+	}
+	for _, page := range pages {
+		updated := pageUpdated(&page)
+		if t, err := time.Parse(time.RFC3339, updated); err == nil {
+			updated = t.Format("Jan 2, 2006 3:04 PM")
+		}
+		if showStatus {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", page.ExternalID, page.Title, formatPageStatus(&page), updated)
+		} else {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", page.ExternalID, page.Title, updated)
+		}
+	}
+	return w.Flush()
 }
 
+var pageGetSection string
+
 var pageGetCmd = &cobra.Command{
-	Use:   "get <page-id>",
+	Use:   "get <page-id>...",
 	Short: "Get page content",
-	Long:  `Get the content of a page and output it to stdout.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Get the content of a page and output it to stdout.
+
+With --follow, keeps polling the page after printing it and prints content
+as it is added, like tail -f. Changes other than appends print the added
+lines, or a unified diff with --diff. Press Ctrl-C to stop.
+
+Examples:
+  hyperclast page get page_xyz789
+  hyperclast page get page_xyz789 --section "deploy 2024-06-01"
+  hyperclast page get page_xyz789 --follow
+  hyperclast page get page_xyz789 --follow --diff --interval 5s
+  hyperclast page get page_xyz789 --metadata-only
+  hyperclast page get page_xyz789 --no-pager
+  hyperclast page get page_xyz789 --render
+  hyperclast page get page_xyz789 --table --max-rows 20
+  hyperclast page get page_xyz789 --html > incident.html
+  hyperclast page get page_xyz789 --pdf > incident.pdf
+  hyperclast page get page_xyz789 --out incident        # incident.md for a markdown page
+  hyperclast page get page_a page_b --dir ./pages
+  hyperclast page get --dir ./backup --project proj_abc123`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if !cfg.IsAuthenticated() {
 			return fmt.Errorf("not authenticated. Run 'hyperclast auth login' first")
 		}
 
+		if pageGetDiff && !pageGetFollow {
+			return fmt.Errorf("--diff requires --follow")
+		}
+		if pageGetFollow && pageGetInterval < minFollowInterval {
+			return fmt.Errorf("--interval must be at least %s", minFollowInterval)
+		}
+
+		if pageGetMetadataOnly && (pageGetSection != "" || pageGetFollow) {
+			return fmt.Errorf("--metadata-only cannot be combined with --section or --follow")
+		}
+		if err := checkPageGetOut(args); err != nil {
+			return err
+		}
+		if err := checkPageGetRender(); err != nil {
+			return err
+		}
+		if err := checkPageGetTable(cmd); err != nil {
+			return err
+		}
+
+		format, err := renderFormat()
+		if err != nil {
+			return err
+		}
+
+		client := newClient()
+		if pageGetDir != "" {
+			return runPageGetDir(cmd, client, args)
+		}
 		pageID := args[0]
 
-		client := api.NewClient(cfg.APIURL, cfg.Token)
+		if pageGetMetadataOnly {
+			return runPageMetadata(client, pageID)
+		}
+		if format != "" {
+			return runPageRender(client, pageID, format)
+		}
+		if streamsPageGet() {
+			return streamPageGet(client, pageID)
+		}
 		page, err := client.GetPage(pageID)
 		if err != nil {
 			return fmt.Errorf("failed to get page: %w", err)
 		}
 
+		content, err := pageGetContent(page)
+		if err != nil {
+			return err
+		}
+		if pageGetOut != "" {
+			return writePageOut(page.ExternalID, []byte(content), pageFileExtension(filetypeOf(page)))
+		}
+		if outputFmt != "json" {
+			if err := guardTerminalOutput(content, pageGetForce); err != nil {
+				return err
+			}
+		}
+		if pageGetRender {
+			return pageOutput(func(w io.Writer) error { return printRenderedMarkdown(w, page, content) })
+		}
+		if pageGetTable {
+			return pageOutput(func(w io.Writer) error { return printCSVTable(w, page, content) })
+		}
+
 		if outputFmt == "json" {
-			return json.NewEncoder(os.Stdout).Encode(page)
+			var v any = struct {
+				*api.Page
+				// Version is what --if-unmodified takes.
+				Version string `json:"version,omitempty"`
+			}{page, page.Version()}
+			if pageGetSection != "" {
+				v = map[string]any{
+					"external_id": page.ExternalID,
+					"section":     pageGetSection,
+					"content":     content,
+				}
+			}
+			if err := json.NewEncoder(os.Stdout).Encode(v); err != nil {
+				return err
+			}
+		} else if !pageGetFollow {
+			return pageOutput(func(w io.Writer) error {
+				_, err := io.WriteString(w, content)
+				return err
+			})
+		} else {
+			fmt.Print(content)
 		}
 
-		if page.Details != nil && page.Details.Content != "" {
-			fmt.Print(page.Details.Content)
+		if !pageGetFollow {
+			return nil
 		}
 
-		return nil
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fetch := func() (string, error) {
+			page, err := client.GetPage(pageID)
+			if err != nil {
+				return "", err
+			}
+			return pageGetContent(page)
+		}
+		return followPage(ctx, fetch, content, pageGetInterval, os.Stdout)
 	},
 }
 
+// pageGetContent returns the page content, narrowed to --section if set.
+func pageGetContent(page *api.Page) (string, error) {
+	content := ""
+	if page.Details != nil {
+		content = page.Details.Content
+	}
+	if pageGetSection == "" {
+		return content, nil
+	}
+
+	section, err := extractSection(content, pageGetSection)
+	if err != nil {
+		if names := listSections(content); len(names) > 0 {
+			printInfo("Available sections: %s", strings.Join(names, ", "))
+		}
+		return "", err
+	}
+	return section, nil
+}
+
 var pageDeleteForce bool
 
 var pageDeleteCmd = &cobra.Command{
@@ -468,12 +1148,15 @@ Examples:
 
 		pageID := args[0]
 
-		client := api.NewClient(cfg.APIURL, cfg.Token)
+		client := newClient()
 
 		page, err := client.GetPage(pageID)
 		if err != nil {
 			return fmt.Errorf("failed to get page: %w", err)
 		}
+		if !page.CanDelete() {
+			return &api.PermissionError{Action: "delete page", Role: page.Role, Needs: "creator"}
+		}
 
 		if !pageDeleteForce {
 			stat, _ := os.Stdin.Stat()
@@ -685,23 +1368,52 @@ func init() {
 	pageNewCmd.Flags().StringVar(&pageProjectID, "project", "", "project ID")
 	pageNewCmd.Flags().StringVar(&pageTitle, "title", "", "page title (defaults to timestamp)")
 	pageNewCmd.Flags().StringVar(&pageFile, "file", "", "read content from file instead of stdin")
-	pageNewCmd.Flags().StringVar(&pageFiletype, "filetype", "txt", "file type: txt, md, csv, log (auto-detected if not set)")
+	pageNewCmd.Flags().StringVar(&pageFiletype, "filetype", autoFiletype, "file type: auto, txt, md, csv, or log")
 	pageNewCmd.Flags().BoolVar(&pageMeta, "meta", false, "append metadata backmatter to content")
 	pageNewCmd.Flags().StringVar(&pageSource, "source", "", "source description for metadata")
+	pageNewCmd.Flags().BoolVar(&pageSubstitute, "substitute", false, "expand {{env \"NAME\"}}, {{date}}, {{git.sha}} and other placeholders in the content")
+	pageNewCmd.Flags().StringSliceVar(&pageCSVSelect, "csv-select", nil, "keep only these CSV columns, in this order")
+	pageNewCmd.Flags().StringSliceVar(&pageCSVDrop, "csv-drop", nil, "remove these CSV columns")
+	pageNewCmd.Flags().StringToStringVar(&pageCSVRename, "csv-rename", nil, "rename CSV columns (old=new)")
+	pageNewCmd.Flags().BoolVar(&pageNoSchema, "no-schema", false, "don't send inferred column types for CSV pages")
+	pageNewCmd.Flags().BoolVar(&pagePreview, "preview", false, "print the content and request that would be sent, then exit without creating the page")
+	pageNewCmd.Flags().StringVar(&pageFromGitShow, "from-git-show", "", "capture a file at a git revision (<ref>:<path>)")
+	pageNewCmd.Flags().StringVar(&pageFromURL, "url", "", "create the page from the content at this http or https URL")
+	pageNewCmd.Flags().BoolVar(&pageToMarkdown, "to-markdown", false, "with --url, convert an HTML page to Markdown")
+	pageNewCmd.Flags().StringVar(&pageLinkFrom, "link-from", "", "append a backlink to the new page on this page and record the relation")
 
 	pageAppendCmd.Flags().StringVar(&pageFile, "file", "", "read content from file instead of stdin")
 	pageAppendCmd.Flags().BoolVar(&pageMeta, "meta", false, "append metadata backmatter to content")
 	pageAppendCmd.Flags().StringVar(&pageSource, "source", "", "source description for metadata")
+	pageAppendCmd.Flags().BoolVar(&pageSubstitute, "substitute", false, "expand {{env \"NAME\"}}, {{date}}, {{git.sha}} and other placeholders in the content")
+	pageAppendCmd.Flags().BoolVar(&pageUpdateForce, "force", false, "send even if the page would exceed the server's size limit")
+	pageAppendCmd.Flags().StringVar(&pageSection, "section", "", "wrap content in named section anchors")
+	pageAppendCmd.Flags().BoolVar(&pageAppendJournal, "journal", false, "record a marker line if the append was retried or merged with concurrent writes")
 
 	pagePrependCmd.Flags().StringVar(&pageFile, "file", "", "read content from file instead of stdin")
 	pagePrependCmd.Flags().BoolVar(&pageMeta, "meta", false, "append metadata backmatter to content")
 	pagePrependCmd.Flags().StringVar(&pageSource, "source", "", "source description for metadata")
+	pagePrependCmd.Flags().BoolVar(&pageSubstitute, "substitute", false, "expand {{env \"NAME\"}}, {{date}}, {{git.sha}} and other placeholders in the content")
+	pagePrependCmd.Flags().BoolVar(&pageUpdateForce, "force", false, "send even if the page would exceed the server's size limit")
+	pagePrependCmd.Flags().StringVar(&pageSection, "section", "", "wrap content in named section anchors")
 
 	pageOverwriteCmd.Flags().StringVar(&pageFile, "file", "", "read content from file instead of stdin")
 	pageOverwriteCmd.Flags().BoolVar(&pageMeta, "meta", false, "append metadata backmatter to content")
 	pageOverwriteCmd.Flags().StringVar(&pageSource, "source", "", "source description for metadata")
+	pageOverwriteCmd.Flags().BoolVar(&pageSubstitute, "substitute", false, "expand {{env \"NAME\"}}, {{date}}, {{git.sha}} and other placeholders in the content")
+	pageOverwriteCmd.Flags().BoolVar(&pageUpdateForce, "force", false, "send even if the page would exceed the server's size limit")
+	pageOverwriteCmd.Flags().StringVar(&pageIfUnmodified, "if-unmodified", "", "write only if the page is still at this version, from 'page get --output json'")
 
 	pageListCmd.Flags().StringVar(&pageListProjectID, "project", "", "filter by project ID")
+	pageListCmd.Flags().BoolVar(&pageListShowStatus, "show-status", false, "show each page's status when listing a project")
+	pageListCmd.Flags().BoolVar(&pageListJSONSchema, "json-schema", false, "print the JSON Schema of the --output json result and exit")
+
+	pageGetCmd.Flags().StringVar(&pageGetSection, "section", "", "print only the named section")
+	pageGetCmd.Flags().BoolVar(&pageGetFollow, "follow", false, "keep polling and print new content as it arrives")
+	pageGetCmd.Flags().BoolVar(&pageGetDiff, "diff", false, "with --follow, print changes as a unified diff")
+	pageGetCmd.Flags().DurationVar(&pageGetInterval, "interval", 2*time.Second, "polling interval for --follow")
+	pageGetCmd.Flags().BoolVar(&pageGetForce, "force", false, "print to a terminal even if the content looks binary or has extremely long lines")
+	pageGetCmd.Flags().BoolVar(&pageGetMetadataOnly, "metadata-only", false, "print title, filetype, size, timestamps, and revision count instead of the content")
 
 	pageDeleteCmd.Flags().BoolVar(&pageDeleteForce, "force", false, "skip confirmation prompt")
 }
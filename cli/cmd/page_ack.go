@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// writeAck tells the user where an append or prepend landed, so teammates
+// can be pointed at exactly the new section.
+type writeAck struct {
+	Bytes     int    `json:"bytes"`
+	Lines     int    `json:"lines"`
+	Offset    int    `json:"offset"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	URL       string `json:"url"`
+}
+
+// pageUpdateOutput is the JSON written by append and prepend: the page, plus
+// the acknowledgment under "ack".
+type pageUpdateOutput struct {
+	*api.Page
+	Ack *writeAck `json:"ack,omitempty"`
+}
+
+// newWriteAck locates content in the page after an append or prepend. The
+// server's merged content is used when it returned one, so appends made by
+// others in between do not skew the offset; otherwise the result is
+// computed from the content fetched before the write.
+func newWriteAck(before, after *api.Page, content, mode string) *writeAck {
+	if content == "" || (mode != "append" && mode != "prepend") {
+		return nil
+	}
+
+	merged := pageContent(after)
+	offset := -1
+	switch {
+	case mode == "prepend" && strings.HasPrefix(merged, content):
+		offset = 0
+	case mode == "append" && strings.HasSuffix(merged, content):
+		offset = len(merged) - len(content)
+	}
+	if offset < 0 {
+		existing := pageContent(before)
+		if mode == "append" {
+			merged, offset = existing+content, len(existing)
+		} else {
+			merged, offset = content+existing, 0
+		}
+	}
+
+	start := strings.Count(merged[:offset], "\n") + 1
+	end := start + strings.Count(strings.TrimSuffix(content, "\n"), "\n")
+	fragment := fmt.Sprintf("#L%d", start)
+	if end > start {
+		fragment += fmt.Sprintf("-L%d", end)
+	}
+	return &writeAck{
+		Bytes:     len(merged),
+		Lines:     countLines(merged),
+		Offset:    offset,
+		StartLine: start,
+		EndLine:   end,
+		URL:       pageURL(after.ExternalID) + fragment,
+	}
+}
+
+func printWriteAck(ack *writeAck) {
+	if ack == nil {
+		return
+	}
+	lines := fmt.Sprintf("line %d", ack.StartLine)
+	if ack.EndLine > ack.StartLine {
+		lines = fmt.Sprintf("lines %d-%d", ack.StartLine, ack.EndLine)
+	}
+	printInfo("  Page is now %s (%d lines); new content at byte %d, %s", formatBytes(int64(ack.Bytes)), ack.Lines, ack.Offset, lines)
+	printInfo("  %s", ack.URL)
+}
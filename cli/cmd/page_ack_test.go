@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func testPage(content string) *api.Page {
+	return &api.Page{ExternalID: "page_xyz", Details: &api.PageDetails{Content: content}}
+}
+
+func TestNewWriteAck(t *testing.T) {
+	cfg = &config.Config{APIURL: "https://hc.example/api"}
+
+	ack := newWriteAck(testPage("one\ntwo\n"), testPage("one\ntwo\nthree\nfour\n"), "three\nfour\n", "append")
+	want := writeAck{Bytes: 19, Lines: 4, Offset: 8, StartLine: 3, EndLine: 4, URL: pageURL("page_xyz") + "#L3-L4"}
+	if ack == nil || *ack != want {
+		t.Errorf("append ack = %+v, want %+v", ack, want)
+	}
+
+	// Someone else appended in between: the server's content wins.
+	ack = newWriteAck(testPage("one\n"), testPage("one\nother\nmine\n"), "mine\n", "append")
+	if ack.Offset != 10 || ack.StartLine != 3 || !strings.HasSuffix(ack.URL, "#L3") {
+		t.Errorf("concurrent append ack = %+v, want line 3 at byte 10", ack)
+	}
+
+	// No content in the response: fall back to what was fetched before.
+	ack = newWriteAck(testPage("old\n"), &api.Page{ExternalID: "page_xyz"}, "new\n", "prepend")
+	if ack.Offset != 0 || ack.StartLine != 1 || ack.EndLine != 1 || ack.Bytes != 8 {
+		t.Errorf("prepend ack = %+v", ack)
+	}
+
+	if ack := newWriteAck(testPage("x"), testPage("y"), "y", "overwrite"); ack != nil {
+		t.Errorf("overwrite ack = %+v, want none", ack)
+	}
+}
+
+func TestPageAppend_JSONIncludesAck(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := api.Page{ExternalID: "page_xyz", Title: "Log", Role: api.RoleAdmin, Details: &api.PageDetails{Content: "a\n"}}
+		if r.Method == http.MethodPut {
+			page.Details.Content = "a\nb\n"
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	pageFile = filepath.Join(t.TempDir(), "b.txt")
+	_ = os.WriteFile(pageFile, []byte("b\n"), 0644)
+	outputFmt = "json"
+
+	out := captureArchiveOutput(t, func() error {
+		return pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+	})
+	var got struct {
+		ExternalID string   `json:"external_id"`
+		Ack        writeAck `json:"ack"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("bad JSON %q: %v", out, err)
+	}
+	if got.ExternalID != "page_xyz" || got.Ack.Offset != 2 || got.Ack.StartLine != 2 || got.Ack.Bytes != 4 {
+		t.Errorf("output = %+v, want the page fields plus an ack at line 2", got)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+var pageAppendAlso []string
+
+// appendFanOutConcurrency is how many pages 'page append' writes at once.
+const appendFanOutConcurrency = 4
+
+// appendTargets returns the pages to append to: the arguments, split on
+// commas, then --also, without duplicates.
+func appendTargets(args []string) ([]string, error) {
+	var ids []string
+	for _, arg := range append(slices.Clone(args), pageAppendAlso...) {
+		for _, id := range strings.Split(arg, ",") {
+			if id = strings.TrimSpace(id); id != "" && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no page ID given")
+	}
+	return ids, nil
+}
+
+// appendResult is the outcome of appending to one of several pages.
+type appendResult struct {
+	ExternalID string    `json:"external_id"`
+	Title      string    `json:"title,omitempty"`
+	Ack        *writeAck `json:"ack,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// runAppendFanOut appends content to every page concurrently and reports
+// each page's result. The input is kept for a retry if any append failed.
+func runAppendFanOut(pageIDs []string, content string, guard *uploadGuard) error {
+	results := make([]appendResult, len(pageIDs))
+	errs := make([]error, len(pageIDs))
+	appending := startProgress("append", len(pageIDs), "pages")
+	failures := forEachConcurrent(len(pageIDs), min(len(pageIDs), appendFanOutConcurrency), func(i int) error {
+		results[i].ExternalID = pageIDs[i]
+		existing, page, err := updatePage(pageIDs[i], content, "append")
+		status := "appended"
+		if err != nil {
+			status = "failed"
+			errs[i] = err
+		} else {
+			results[i].Title = page.Title
+			results[i].Ack = newWriteAck(existing, page, content, "append")
+		}
+		appending.advance(pageIDs[i], status, err)
+		return err
+	})
+	for i, msg := range failures {
+		results[i].Error = msg
+	}
+
+	if outputFmt == "json" {
+		if err := json.NewEncoder(os.Stdout).Encode(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch {
+			case r.Error != "":
+				printWarning("page %s: %s", r.ExternalID, r.Error)
+			case quiet:
+				fmt.Println(r.ExternalID)
+			default:
+				printSuccess("Appended to page \"%s\" (%s)", r.Title, r.ExternalID)
+				printWriteAck(r.Ack)
+			}
+		}
+	}
+
+	for _, err := range errs {
+		if aborted := guard.aborted(err, content); aborted != nil {
+			return aborted
+		}
+	}
+	if len(failures) > 0 {
+		return handleContentError(fmt.Errorf("%d of %d appends failed", len(failures), len(pageIDs)))
+	}
+	cleanupStdinTemp()
+	return guard.interruptedAfterUpload()
+}
+
+func init() {
+	pageAppendCmd.Flags().StringArrayVar(&pageAppendAlso, "also", nil, "also append to this page (repeatable)")
+}
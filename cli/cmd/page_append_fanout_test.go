@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestAppendTargets(t *testing.T) {
+	defer func() { pageAppendAlso = nil }()
+	pageAppendAlso = []string{"page_all", "page_b"}
+
+	got, err := appendTargets([]string{"page_a, page_b", "page_c,"})
+	want := []string{"page_a", "page_b", "page_c", "page_all"}
+	if err != nil || !slices.Equal(got, want) {
+		t.Errorf("appendTargets() = %v, %v; want %v", got, err, want)
+	}
+}
+
+func TestPageAppend_FanOut(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	var mu sync.Mutex
+	written := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+		if id == "page_gone" {
+			http.Error(w, `{"detail":"Not found"}`, http.StatusNotFound)
+			return
+		}
+		page := api.Page{ExternalID: id, Title: "Deploys", Role: api.RoleAdmin, Details: &api.PageDetails{Content: "a\n"}}
+		if r.Method == http.MethodPut {
+			var req api.UpdatePageContentRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			written[id] = req.Details.Content
+			mu.Unlock()
+			page.Details.Content += req.Details.Content
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+
+	pageFile = filepath.Join(t.TempDir(), "deploy.log")
+	_ = os.WriteFile(pageFile, []byte("deployed v2\n"), 0644)
+	pageAppendAlso = []string{"page_gone"}
+	outputFmt = "json"
+
+	r, w, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = w
+	err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_svc,page_all"})
+	_ = w.Close()
+	os.Stdout = stdout
+	data, _ := io.ReadAll(r)
+	out := string(data)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 appends failed") {
+		t.Errorf("err = %v, want the failed page reported", err)
+	}
+	if written["page_svc"] != "deployed v2\n" || written["page_all"] != "deployed v2\n" {
+		t.Errorf("written = %v, want both pages appended to", written)
+	}
+
+	var results []appendResult
+	if jerr := json.Unmarshal([]byte(out), &results); jerr != nil {
+		t.Fatalf("bad JSON %q: %v", out, jerr)
+	}
+	if len(results) != 3 || results[0].ExternalID != "page_svc" || results[0].Ack == nil ||
+		results[2].ExternalID != "page_gone" || !strings.Contains(results[2].Error, "404") {
+		t.Errorf("results = %+v, want one per page in order, with the failure's error", results)
+	}
+}
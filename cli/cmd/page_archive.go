@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// archiveFolderName is the top-level folder archived pages are moved into.
+// Using a folder keeps archived pages out of the way in the web app too.
+const archiveFolderName = "Archive"
+
+var pageListArchived bool
+
+var pageArchiveCmd = &cobra.Command{
+	Use:   "archive <page-id>...",
+	Short: "Archive pages without deleting them",
+	Long: `Move pages into their project's "Archive" folder, which is created if
+needed. Archived pages are hidden from 'page list' (use --archived to see
+them) and skipped by 'project prune', but keep their content and links.
+
+Examples:
+  hyperclast page archive page_xyz789
+  hyperclast page archive page_abc123 page_def456
+  hyperclast page list --archived`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		return runPageArchive(newClient(), args, true)
+	},
+}
+
+var pageUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <page-id>...",
+	Short: "Restore archived pages",
+	Long: `Move archived pages out of the "Archive" folder back to the project root.
+
+Examples:
+  hyperclast page unarchive page_xyz789`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		return runPageArchive(newClient(), args, false)
+	},
+}
+
+// runPageArchive archives (or unarchives) pages, one move request per
+// project. Pages already in the requested state are skipped.
+func runPageArchive(client *api.Client, pageIDs []string, archive bool) error {
+	var projectOrder []string
+	byProject := make(map[string][]*api.Page)
+	for _, id := range pageIDs {
+		page, err := client.GetPage(id)
+		if err != nil {
+			return fmt.Errorf("failed to get page %s: %w", id, err)
+		}
+		if page.ProjectID == "" {
+			return fmt.Errorf("cannot determine the project of page %s", id)
+		}
+		if _, ok := byProject[page.ProjectID]; !ok {
+			projectOrder = append(projectOrder, page.ProjectID)
+		}
+		byProject[page.ProjectID] = append(byProject[page.ProjectID], page)
+	}
+
+	moved := []string{}
+	skipped := []string{}
+	for _, projectID := range projectOrder {
+		project, err := client.GetProject(projectID)
+		if err != nil {
+			return fmt.Errorf("failed to get project %s: %w", projectID, err)
+		}
+		folderID := archiveFolderID(project)
+
+		var ids []string
+		for _, page := range byProject[projectID] {
+			isArchived := folderID != "" && page.FolderID == folderID
+			if isArchived == archive {
+				skipped = append(skipped, page.ExternalID)
+			} else {
+				ids = append(ids, page.ExternalID)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		target := ""
+		if archive {
+			if folderID == "" {
+				folder, err := client.CreateFolder(projectID, archiveFolderName)
+				if err != nil {
+					return fmt.Errorf("failed to create %s folder: %w", archiveFolderName, err)
+				}
+				folderID = folder.ExternalID
+			}
+			target = folderID
+		}
+		if err := client.MovePages(projectID, ids, target); err != nil {
+			return fmt.Errorf("failed to move pages: %w", err)
+		}
+		moved = append(moved, ids...)
+	}
+
+	verb, state := "Unarchived", "not archived"
+	if archive {
+		verb, state = "Archived", "already archived"
+	}
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"moved":   moved,
+			"skipped": skipped,
+		})
+	}
+
+	if quiet {
+		for _, id := range moved {
+			fmt.Println(id)
+		}
+		return nil
+	}
+
+	for _, id := range skipped {
+		printInfo("Page %s is %s", id, state)
+	}
+	if len(moved) > 0 {
+		printSuccess("%s %d page(s)", verb, len(moved))
+	}
+	return nil
+}
+
+// archiveFolderID returns the ID of a project's top-level Archive folder,
+// or "" if it has none.
+func archiveFolderID(project *api.Project) string {
+	for _, f := range project.Folders {
+		if f.ParentID == "" && f.Name == archiveFolderName {
+			return f.ExternalID
+		}
+	}
+	return ""
+}
+
+// listPagesByArchive lists pages like client.ListPages, split into active
+// and archived pages.
+func listPagesByArchive(client *api.Client, projectID string) (active, archived []api.Page, err error) {
+	var pages []api.Page
+	archiveFolders := make(map[string]string)
+	if projectID != "" {
+		project, err := client.GetProject(projectID)
+		if err != nil {
+			return nil, nil, err
+		}
+		pages = project.Pages
+		archiveFolders[projectID] = archiveFolderID(project)
+	} else {
+		pages, err = client.ListPages("")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return splitArchivedPages(client, projectID, pages, archiveFolders)
+}
+
+// splitArchivedPages splits pages of projectID, or of any project if it is
+// empty, into active and archived pages. archiveFolders caches each
+// project's Archive folder ID; listings across projects look it up only for
+// projects that have pages in folders.
+func splitArchivedPages(client *api.Client, projectID string, pages []api.Page, archiveFolders map[string]string) (active, archived []api.Page, err error) {
+	active, archived = []api.Page{}, []api.Page{}
+	for _, page := range pages {
+		pageProject := page.ProjectID
+		if projectID != "" {
+			pageProject = projectID
+		}
+		if page.FolderID == "" || pageProject == "" {
+			active = append(active, page)
+			continue
+		}
+
+		folderID, ok := archiveFolders[pageProject]
+		if !ok {
+			project, err := client.GetProject(pageProject)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get project %s: %w", pageProject, err)
+			}
+			folderID = archiveFolderID(project)
+			archiveFolders[pageProject] = folderID
+		}
+		if folderID != "" && page.FolderID == folderID {
+			archived = append(archived, page)
+		} else {
+			active = append(active, page)
+		}
+	}
+	return active, archived, nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageArchiveCmd)
+	pageCmd.AddCommand(pageUnarchiveCmd)
+
+	pageListCmd.Flags().BoolVar(&pageListArchived, "archived", false, "list only archived pages")
+}
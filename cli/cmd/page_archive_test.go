@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// archiveServer serves one project whose pages live in the given folders
+// and records folder creation and page moves.
+type archiveServer struct {
+	folders      []api.Folder
+	pageFolders  map[string]string
+	createdNames []string
+	moves        []map[string]any
+}
+
+func newArchiveServer(t *testing.T, s *archiveServer) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/pages/page_"):
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: id, ProjectID: "proj_abc", FolderID: s.pageFolders[id]})
+		case r.Method == http.MethodGet && r.URL.Path == "/pages/":
+			var pages []api.Page
+			for _, id := range []string{"page_a", "page_b", "page_c"} {
+				pages = append(pages, api.Page{ExternalID: id, ProjectID: "proj_abc", FolderID: s.pageFolders[id]})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": pages})
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/proj_abc/":
+			var pages []api.Page
+			for _, id := range []string{"page_a", "page_b", "page_c"} {
+				pages = append(pages, api.Page{ExternalID: id, FolderID: s.pageFolders[id]})
+			}
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: "proj_abc", Pages: pages, Folders: s.folders})
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/proj_abc/folders/":
+			var req api.CreateFolderRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			s.createdNames = append(s.createdNames, req.Name)
+			folder := api.Folder{ExternalID: "fold_new", Name: req.Name}
+			s.folders = append(s.folders, folder)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(folder)
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/proj_abc/folders/move-pages/":
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			s.moves = append(s.moves, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{"moved": 1})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func captureArchiveOutput(t *testing.T, fn func() error) string {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := fn()
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output, _ := io.ReadAll(r)
+	return string(output)
+}
+
+func TestPageArchive_CreatesFolderAndMoves(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	s := &archiveServer{pageFolders: map[string]string{}}
+	server := newArchiveServer(t, s)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	outputFmt = "json"
+
+	output := captureArchiveOutput(t, func() error {
+		return pageArchiveCmd.RunE(pageArchiveCmd, []string{"page_a", "page_b"})
+	})
+
+	if len(s.createdNames) != 1 || s.createdNames[0] != archiveFolderName {
+		t.Errorf("created folders %v, want one %q", s.createdNames, archiveFolderName)
+	}
+	if len(s.moves) != 1 || s.moves[0]["folder_id"] != "fold_new" {
+		t.Fatalf("moves = %v, want one move into the new folder", s.moves)
+	}
+	if ids, _ := s.moves[0]["page_ids"].([]any); len(ids) != 2 {
+		t.Errorf("moved %v, want both pages in one request", s.moves[0]["page_ids"])
+	}
+	if !strings.Contains(output, `"moved":["page_a","page_b"]`) {
+		t.Errorf("output = %s, want both pages moved", output)
+	}
+}
+
+func TestPageArchive_SkipsArchivedPages(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	s := &archiveServer{
+		folders:     []api.Folder{{ExternalID: "fold_arch", Name: "Archive"}},
+		pageFolders: map[string]string{"page_a": "fold_arch"},
+	}
+	server := newArchiveServer(t, s)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	quiet = true
+
+	output := captureArchiveOutput(t, func() error {
+		return pageArchiveCmd.RunE(pageArchiveCmd, []string{"page_a", "page_b"})
+	})
+
+	if len(s.createdNames) != 0 {
+		t.Errorf("created folders %v, want the existing one reused", s.createdNames)
+	}
+	if output != "page_b\n" {
+		t.Errorf("output = %q, want only the moved page", output)
+	}
+}
+
+func TestPageUnarchive_MovesToRoot(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	s := &archiveServer{
+		folders:     []api.Folder{{ExternalID: "fold_arch", Name: "Archive"}},
+		pageFolders: map[string]string{"page_a": "fold_arch"},
+	}
+	server := newArchiveServer(t, s)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	quiet = true
+
+	captureArchiveOutput(t, func() error {
+		return pageUnarchiveCmd.RunE(pageUnarchiveCmd, []string{"page_a"})
+	})
+
+	if len(s.moves) != 1 {
+		t.Fatalf("moves = %v, want one", s.moves)
+	}
+	if folderID, ok := s.moves[0]["folder_id"]; !ok || folderID != nil {
+		t.Errorf("folder_id = %v, want explicit null for the project root", folderID)
+	}
+}
+
+func TestListPagesByArchive(t *testing.T) {
+	s := &archiveServer{
+		folders: []api.Folder{
+			{ExternalID: "fold_arch", Name: "Archive"},
+			{ExternalID: "fold_nested", ParentID: "fold_docs", Name: "Archive"},
+		},
+		pageFolders: map[string]string{"page_a": "fold_arch", "page_c": "fold_nested"},
+	}
+	server := newArchiveServer(t, s)
+	client := api.NewClient(server.URL, "test-token")
+
+	for _, projectID := range []string{"proj_abc", ""} {
+		active, archived, err := listPagesByArchive(client, projectID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(archived) != 1 || archived[0].ExternalID != "page_a" {
+			t.Errorf("project %q: archived = %v, want page_a", projectID, archived)
+		}
+		if len(active) != 2 || active[0].ExternalID != "page_b" || active[1].ExternalID != "page_c" {
+			t.Errorf("project %q: active = %v, want page_b and page_c", projectID, active)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageAttachFile     string
+	pageAttachName     string
+	pageAttachmentsOut string
+)
+
+var pageAttachCmd = &cobra.Command{
+	Use:   "attach <page-id> --file <path>",
+	Short: "Attach a file to a page",
+	Long: `Attach a file to a page: a screenshot, a core dump, or any other artifact
+that belongs with a capture but is not text. The file is uploaded to the
+page's project and a link to it is appended to the page, as the web app's
+editor does, so 'page attachments' lists the files a page links to. The
+server limits the file's size (10 MB by default).
+
+Examples:
+  hyperclast page attach page_xyz789 --file screenshot.png
+  hyperclast page attach page_xyz789 --file core.12345 --name api-core.dump`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		f, err := os.Open(pageAttachFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", pageAttachFile, err)
+		}
+		defer func() { _ = f.Close() }()
+		info, err := f.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", pageAttachFile, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", pageAttachFile)
+		}
+		if info.Size() == 0 {
+			return fmt.Errorf("%s is empty", pageAttachFile)
+		}
+		head := make([]byte, 512)
+		n, _ := io.ReadFull(f, head)
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to read %s: %w", pageAttachFile, err)
+		}
+
+		name := pageAttachName
+		if name == "" {
+			name = filepath.Base(pageAttachFile)
+		}
+
+		client := newClient()
+		page, err := client.GetPage(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if !page.CanEdit() {
+			return &api.PermissionError{Action: "attach files to page", Role: page.Role, Needs: "editor"}
+		}
+		if err := checkPageLock(client, page); err != nil {
+			return err
+		}
+		file, err := uploadFile(client, page.ProjectID, name, attachmentContentType(name, head[:n]), f, info.Size())
+		if err != nil {
+			return fmt.Errorf("failed to attach %s: %w", name, err)
+		}
+
+		line := fileReference(file) + "\n"
+		if page.Details != nil && page.Details.Content != "" && !strings.HasSuffix(page.Details.Content, "\n") {
+			line = "\n" + line
+		}
+		if _, err := client.UpdateFetchedPageContent(page, line, "append"); err != nil {
+			return fmt.Errorf("uploaded %s (%s), but failed to link it from the page: %w", name, file.ExternalID, err)
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(file)
+		}
+		if quiet {
+			fmt.Println(file.ExternalID)
+			return nil
+		}
+		printSuccess("Attached %s (%s) to \"%s\" (%s)", file.Filename, formatBytes(file.SizeBytes), page.Title, file.ExternalID)
+		return nil
+	},
+}
+
+// uploadFile uploads size bytes from r as a file in a project: it creates
+// the upload, sends the bytes to storage, and finalizes it unless storage
+// does. An upload whose bytes could not be sent is marked as failed.
+func uploadFile(client *api.Client, projectID, name, contentType string, r io.Reader, size int64) (*api.File, error) {
+	upload, err := client.CreateFileUpload(projectID, name, contentType, size)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.UploadFileData(upload, r, size); err != nil {
+		if failErr := client.FailFileUpload(upload.File.ExternalID); failErr != nil {
+			printDebug("Failed to mark upload %s as failed: %v", upload.File.ExternalID, failErr)
+		}
+		return nil, err
+	}
+	if upload.WebhookEnabled {
+		return &upload.File, nil
+	}
+	return client.FinalizeFileUpload(upload.File.ExternalID)
+}
+
+// previewableImageTypes are the image types the web app's editor shows
+// inline, so they are linked as images.
+var previewableImageTypes = map[string]bool{
+	"image/jpeg": true, "image/png": true, "image/gif": true,
+	"image/webp": true, "image/svg+xml": true, "image/avif": true,
+}
+
+// fileReference is the markdown link to file that the web app's editor
+// inserts for an upload.
+func fileReference(file *api.File) string {
+	ref := fmt.Sprintf("[%s](%s)", file.Filename, file.Link)
+	if previewableImageTypes[file.ContentType] {
+		ref = "!" + ref
+	}
+	return ref
+}
+
+// fileLinkPattern matches a markdown link to a file's permanent download
+// URL, /files/{project_id}/{file_id}/{access_token}/, capturing the file ID.
+var fileLinkPattern = regexp.MustCompile(`\[[^\]]+\]\((?:https?://[^/)\s]+)?/files/[^/)\s]+/([^/)\s]+)/[^/)\s]+/?\)`)
+
+// linkedFiles returns the files page content links to, in order and
+// without duplicates. Files that are gone are left out.
+func linkedFiles(client *api.Client, content string) ([]api.File, error) {
+	var files []api.File
+	seen := make(map[string]bool)
+	for _, m := range fileLinkPattern.FindAllStringSubmatch(content, -1) {
+		id := m[1]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		file, err := client.GetFile(id)
+		if err != nil {
+			if errors.Is(err, api.ErrFileNotFound) {
+				printDebug("Skipping link to file %s: %v", id, err)
+				continue
+			}
+			return nil, err
+		}
+		files = append(files, *file)
+	}
+	return files, nil
+}
+
+// pageAttachments returns the files a page links to.
+func pageAttachments(client *api.Client, pageID string) ([]api.File, error) {
+	page, err := client.GetPage(pageID)
+	if err != nil {
+		return nil, err
+	}
+	content := ""
+	if page.Details != nil {
+		content = page.Details.Content
+	}
+	return linkedFiles(client, content)
+}
+
+// attachmentContentType is the MIME type for a file: from its extension
+// when the system knows it, else sniffed from its first bytes.
+func attachmentContentType(name string, head []byte) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	return http.DetectContentType(head)
+}
+
+var pageAttachmentsCmd = &cobra.Command{
+	Use:   "attachments",
+	Short: "List and download a page's attachments",
+}
+
+var pageAttachmentsListCmd = &cobra.Command{
+	Use:   "list <page-id>",
+	Short: "List a page's attachments",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		attachments, err := pageAttachments(newClient(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list attachments: %w", err)
+		}
+
+		if outputFmt == "json" {
+			if attachments == nil {
+				attachments = []api.File{}
+			}
+			return json.NewEncoder(os.Stdout).Encode(attachments)
+		}
+		if len(attachments) == 0 {
+			printInfo("No attachments on page %s", args[0])
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tTYPE\tSIZE\tADDED")
+		for _, a := range attachments {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.ExternalID, a.Filename, a.ContentType, formatBytes(a.SizeBytes), formatMetadataTime(a.Created))
+		}
+		return w.Flush()
+	},
+}
+
+var pageAttachmentsDownloadCmd = &cobra.Command{
+	Use:   "download <page-id> <attachment-id|filename> [--out <path>]",
+	Short: "Download one of a page's attachments",
+	Long: `Download an attachment, given by ID or by filename, to a file named after
+it in the current directory, or to --out. "--out -" writes it to stdout.
+
+Without --out, an existing file is never overwritten.
+
+Examples:
+  hyperclast page attachments download page_xyz789 screenshot.png
+  hyperclast page attachments download page_xyz789 att_123 --out /tmp/core.dump
+  hyperclast page attachments download page_xyz789 trace.json --out - | jq .`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageAttachmentsOut == "-" && outputFmt == "json" {
+			return fmt.Errorf("--output json cannot be combined with --out -, since the attachment is written to stdout")
+		}
+
+		client := newClient()
+		attachments, err := pageAttachments(client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list attachments: %w", err)
+		}
+		attachment, err := findAttachment(attachments, args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		path := pageAttachmentsOut
+		switch {
+		case path == "-":
+			if stdoutIsTerminal() && !isTextContentType(attachment.ContentType) {
+				return fmt.Errorf("refusing to print %s (%s) to a terminal; use --out, or redirect the output to a file", attachment.Filename, attachment.ContentType)
+			}
+			_, err := client.DownloadFile(attachment.ExternalID, os.Stdout)
+			return err
+		case path == "":
+			path = filepath.Base(attachment.Filename)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists; use --out to choose where to save the attachment", path)
+			}
+		}
+
+		var buf bytes.Buffer
+		n, err := client.DownloadFile(attachment.ExternalID, &buf)
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"external_id": attachment.ExternalID,
+				"filename":    attachment.Filename,
+				"path":        path,
+				"bytes":       n,
+			})
+		}
+		if quiet {
+			fmt.Println(path)
+			return nil
+		}
+		printSuccess("Downloaded %s to %s (%s)", attachment.Filename, path, formatBytes(n))
+		return nil
+	},
+}
+
+// findAttachment picks the attachment with ID ref, else the one named ref.
+// Several with that name are an error listing their IDs.
+func findAttachment(attachments []api.File, pageID, ref string) (*api.File, error) {
+	var named []api.File
+	for i, a := range attachments {
+		if a.ExternalID == ref {
+			return &attachments[i], nil
+		}
+		if a.Filename == ref {
+			named = append(named, a)
+		}
+	}
+	switch len(named) {
+	case 0:
+		return nil, fmt.Errorf("page %s has no attachment %q (run 'hyperclast page attachments list %s')", pageID, ref, pageID)
+	case 1:
+		return &named[0], nil
+	}
+	msg := fmt.Sprintf("%d attachments are named %q; use an attachment ID instead:", len(named), ref)
+	for _, a := range named {
+		msg += fmt.Sprintf("\n  %s  %s  %s", a.ExternalID, formatBytes(a.SizeBytes), formatMetadataTime(a.Created))
+	}
+	return nil, fmt.Errorf("%s", msg)
+}
+
+// isTextContentType reports whether a MIME type is text a terminal can show.
+func isTextContentType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json", "application/xml", "application/yaml", "application/x-yaml":
+		return true
+	}
+	return strings.HasPrefix(mediaType, "text/")
+}
+
+func init() {
+	pageCmd.AddCommand(pageAttachCmd)
+	pageCmd.AddCommand(pageAttachmentsCmd)
+	pageAttachmentsCmd.AddCommand(pageAttachmentsListCmd)
+	pageAttachmentsCmd.AddCommand(pageAttachmentsDownloadCmd)
+
+	pageAttachCmd.Flags().StringVar(&pageAttachFile, "file", "", "file to attach")
+	_ = pageAttachCmd.MarkFlagRequired("file")
+	pageAttachCmd.Flags().StringVar(&pageAttachName, "name", "", "name to store the file under (default: its base name)")
+	pageAttachmentsDownloadCmd.Flags().StringVar(&pageAttachmentsOut, "out", "", "save to this path, or - for stdout (default: the attachment's name in the current directory)")
+}
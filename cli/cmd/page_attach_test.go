@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageAttach_UploadListDownload(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Crash", "")
+	dir := t.TempDir()
+	t.Chdir(dir)
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	if err := os.WriteFile(filepath.Join(dir, "screenshot.png"), png, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	id := strings.TrimSpace(env.mustRun("page", "attach", page.ExternalID, "--file", "screenshot.png", "--quiet"))
+	if got := env.server.Files(apitest.DefaultProjectID)["screenshot.png"]; !bytes.Equal(got, png) {
+		t.Fatalf("stored file = %q, want the file byte for byte", got)
+	}
+	linked, _ := env.server.Page(page.ExternalID)
+	if want := "![screenshot.png](/files/" + apitest.DefaultProjectID + "/" + id + "/"; !strings.HasPrefix(linked.Details.Content, want) {
+		t.Errorf("page content = %q, want an image link to the file", linked.Details.Content)
+	}
+
+	out := env.mustRun("page", "attachments", "list", page.ExternalID)
+	if !strings.Contains(out, id) || !strings.Contains(out, "screenshot.png") {
+		t.Errorf("attachments list = %q, want the attachment", out)
+	}
+
+	if _, _, err := env.run("page", "attachments", "download", page.ExternalID, "screenshot.png"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("download over an existing file = %v, want it refused", err)
+	}
+	env.mustRun("page", "attachments", "download", page.ExternalID, id, "--out", "copy.png")
+	if got, _ := os.ReadFile(filepath.Join(dir, "copy.png")); !bytes.Equal(got, png) {
+		t.Errorf("downloaded %q, want the attachment byte for byte", got)
+	}
+}
+
+func TestPageAttach_RefusesLockedPage(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Crash", "")
+	lockByOther(env, page.ExternalID)
+	file := filepath.Join(t.TempDir(), "core.dump")
+	_ = os.WriteFile(file, []byte{0, 1, 2}, 0644)
+
+	_, _, err := env.run("page", "attach", page.ExternalID, "--file", file)
+	if err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Fatalf("attach to a page locked by another user = %v", err)
+	}
+	if n := len(env.server.Files(apitest.DefaultProjectID)); n != 0 {
+		t.Errorf("%d files stored, want none", n)
+	}
+}
+
+func TestPageAttachmentsDownload_ByName(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Crash", "")
+	file := filepath.Join(t.TempDir(), "trace.json")
+	_ = os.WriteFile(file, []byte("{}\n"), 0644)
+	env.mustRun("page", "attach", page.ExternalID, "--file", file)
+	env.mustRun("page", "attach", page.ExternalID, "--file", file)
+
+	_, _, err := env.run("page", "attachments", "download", page.ExternalID, "trace.json", "--out", "-")
+	if err == nil || !strings.Contains(err.Error(), "2 attachments are named") {
+		t.Errorf("download of an ambiguous name = %v", err)
+	}
+	_, _, err = env.run("page", "attachments", "download", page.ExternalID, "missing.txt")
+	if err == nil || !strings.Contains(err.Error(), `has no attachment "missing.txt"`) {
+		t.Errorf("download of a missing name = %v", err)
+	}
+}
+
+func TestPageAttachments_OnlyLinkedFiles(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Crash", "before")
+	other := env.server.AddPage(apitest.DefaultProjectID, "Other", "")
+	file := filepath.Join(t.TempDir(), "trace.txt")
+	_ = os.WriteFile(file, []byte("trace\n"), 0644)
+	env.mustRun("page", "attach", page.ExternalID, "--file", file)
+	env.mustRun("page", "attach", other.ExternalID, "--file", file, "--name", "other.txt")
+
+	got, _ := env.server.Page(page.ExternalID)
+	if !strings.HasPrefix(got.Details.Content, "before\n[trace.txt](") {
+		t.Errorf("content = %q, want the link on a line of its own", got.Details.Content)
+	}
+	out := env.mustRun("page", "attachments", "list", page.ExternalID)
+	if !strings.Contains(out, "trace.txt") || strings.Contains(out, "other.txt") {
+		t.Errorf("attachments list = %q, want only the file the page links to", out)
+	}
+}
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageBulkProjectID   string
+	pageBulkMatch       string
+	pageBulkReplace     string
+	pageBulkAdd         []string
+	pageBulkRemove      []string
+	pageBulkDryRun      bool
+	pageBulkForce       bool
+	pageBulkConcurrency int
+)
+
+var pageBulkRenameCmd = &cobra.Command{
+	Use:   "bulk-rename",
+	Short: "Rename many pages with a regular expression",
+	Long: `Rename every page in a project whose title matches --match, replacing it
+with --replace. The replacement may refer to groups in the pattern with $1,
+$2, or ${name}. Archived pages are left alone.
+
+Use --dry-run first to see each old and new title. Prompts for confirmation
+unless --force is used. Only a page's creator can rename it.
+
+Examples:
+  hyperclast page bulk-rename --project proj_abc --match '^Build (\d+)$' --replace 'CI Build $1' --dry-run
+  hyperclast page bulk-rename --match '^untitled$' --replace 'Notes' --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, pages, err := bulkPages(cmd)
+		if err != nil {
+			return err
+		}
+		re := regexp.MustCompile(pageBulkMatch)
+
+		var changes []bulkChange
+		for _, page := range pages {
+			title := re.ReplaceAllString(page.Title, pageBulkReplace)
+			if title == page.Title {
+				continue
+			}
+			if strings.TrimSpace(title) == "" {
+				return fmt.Errorf("new title for %s (%q) would be empty", page.ExternalID, page.Title)
+			}
+			if n := utf8.RuneCountInString(title); n > maxTitleLength {
+				return fmt.Errorf("new title for %s would be %d characters (max %d): %s", page.ExternalID, n, maxTitleLength, title)
+			}
+			changes = append(changes, bulkChange{ExternalID: page.ExternalID, Title: page.Title, From: page.Title, To: title})
+		}
+
+		return runBulkChanges(changes, "Rename", "Renamed", []string{"ID", "OLD TITLE", "NEW TITLE"}, func(c bulkChange) error {
+			_, err := client.RenamePage(c.ExternalID, c.To)
+			return err
+		})
+	},
+}
+
+var pageBulkLabelCmd = &cobra.Command{
+	Use:   "bulk-label",
+	Short: "Add or remove labels on many pages",
+	Long: `Add or remove labels on every page in a project whose title matches
+--match, or on every page if --match is not given. Archived pages are left
+alone, as are pages whose labels would not change.
+
+Use --dry-run first to see each page's labels before and after. Prompts for
+confirmation unless --force is used.
+
+Examples:
+  hyperclast page bulk-label --project proj_abc --match '^Build ' --add ci --dry-run
+  hyperclast page bulk-label --match 'staging' --add env=staging --remove env=prod --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(pageBulkAdd) == 0 && len(pageBulkRemove) == 0 {
+			return fmt.Errorf("specify --add and/or --remove")
+		}
+		client, pages, err := bulkPages(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Project listings omit details, so each page is fetched for its
+		// current labels.
+		current := make([][]string, len(pages))
+		failures := forEachConcurrent(len(pages), pageBulkConcurrency, func(i int) error {
+			full, err := client.GetPage(pages[i].ExternalID)
+			if err != nil {
+				return err
+			}
+			if full.Details != nil {
+				current[i] = full.Details.Labels
+			}
+			return nil
+		})
+		for i := range pages {
+			if msg, failed := failures[i]; failed {
+				return fmt.Errorf("failed to get page %s: %s", pages[i].ExternalID, msg)
+			}
+		}
+
+		var changes []bulkChange
+		labels := make(map[string][]string)
+		for i, page := range pages {
+			next := relabel(current[i], pageBulkAdd, pageBulkRemove)
+			if slices.Equal(next, current[i]) {
+				continue
+			}
+			labels[page.ExternalID] = next
+			changes = append(changes, bulkChange{
+				ExternalID: page.ExternalID,
+				Title:      page.Title,
+				From:       strings.Join(current[i], ", "),
+				To:         strings.Join(next, ", "),
+			})
+		}
+
+		return runBulkChanges(changes, "Relabel", "Relabeled", []string{"ID", "TITLE", "OLD LABELS", "NEW LABELS"}, func(c bulkChange) error {
+			_, err := client.SetPageDetails(c.ExternalID, map[string]any{"labels": labels[c.ExternalID]})
+			return err
+		})
+	},
+}
+
+// bulkChange is one page a bulk command would change, with its value before
+// and after.
+type bulkChange struct {
+	ExternalID string `json:"external_id"`
+	Title      string `json:"title"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+// bulkPages checks the shared bulk flags and returns the active pages in the
+// project whose titles match --match.
+func bulkPages(cmd *cobra.Command) (*api.Client, []api.Page, error) {
+	if err := requireAuth(); err != nil {
+		return nil, nil, err
+	}
+	if pageBulkConcurrency < 1 {
+		return nil, nil, fmt.Errorf("--concurrency must be at least 1")
+	}
+	re, err := regexp.Compile(pageBulkMatch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid --match: %w", err)
+	}
+
+	projectID := pageBulkProjectID
+	if projectID == "" {
+		projectID = cfg.GetDefaultProject()
+	}
+	if projectID == "" {
+		printError("No project specified.")
+		printInfo("  Use --project <id> or set a default: hyperclast project use <id>")
+		cmd.SilenceErrors = true
+		return nil, nil, fmt.Errorf("no project specified")
+	}
+	if !pageBulkDryRun {
+		if err := confirmProtectedWrite(projectID, pageBulkProjectID != "", "change pages"); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	client := newClient()
+	pages, _, err := listPagesByArchive(client, projectID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+
+	var matched []api.Page
+	for _, page := range pages {
+		if re.MatchString(page.Title) {
+			matched = append(matched, page)
+		}
+	}
+	return client, matched, nil
+}
+
+// relabel adds and removes labels, keeping the existing order and dropping
+// duplicates.
+func relabel(labels, add, remove []string) []string {
+	out := []string{}
+	for _, l := range mergeLabels(labels, add) {
+		if !slices.Contains(remove, l) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// forEachConcurrent calls fn for 0..n-1 on up to concurrency goroutines and
+// returns the errors by index.
+func forEachConcurrent(n, concurrency int, fn func(i int) error) map[int]string {
+	failures := make(map[int]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan int)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				if err := fn(i); err != nil {
+					mu.Lock()
+					failures[i] = err.Error()
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range n {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+	return failures
+}
+
+// runBulkChanges previews changes, confirms them unless --force, applies them
+// concurrently, and reports the result. verb names the action in the prompt
+// ("Rename") and done in the summary ("Renamed").
+func runBulkChanges(changes []bulkChange, verb, done string, headers []string, apply func(bulkChange) error) error {
+	if pageBulkDryRun || len(changes) == 0 {
+		return printBulkReport(changes, nil, true, done, headers)
+	}
+
+	if !pageBulkForce {
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) == 0 {
+			return fmt.Errorf("--force is required in non-interactive mode (stdin is not a terminal)")
+		}
+		if err := printBulkTable(changes, headers); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s %d pages? [y/N] ", verb, len(changes))
+		var confirm string
+		_, _ = fmt.Scanln(&confirm)
+		if confirm != "y" && confirm != "Y" {
+			printInfo("Cancelled")
+			return nil
+		}
+	}
+
+	applying := startProgress(strings.ToLower(verb), len(changes), "pages")
+	byIndex := forEachConcurrent(len(changes), pageBulkConcurrency, func(i int) error {
+		err := apply(changes[i])
+		status := "updated"
+		if err != nil {
+			status = "failed"
+		}
+		applying.advance(changes[i].ExternalID, status, err)
+		return err
+	})
+	failures := make(map[string]string, len(byIndex))
+	for i, msg := range byIndex {
+		failures[changes[i].ExternalID] = msg
+		printDebug("Failed to update %s: %s", changes[i].ExternalID, msg)
+	}
+
+	return printBulkReport(changes, failures, false, done, headers)
+}
+
+// printBulkTable prints one row per change. Rename tables have no separate
+// title column, since the old title is the title.
+func printBulkTable(changes []bulkChange, headers []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, c := range changes {
+		if len(headers) == 3 {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", c.ExternalID, c.From, c.To)
+		} else {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.ExternalID, c.Title, c.From, c.To)
+		}
+	}
+	return w.Flush()
+}
+
+func printBulkReport(changes []bulkChange, failures map[string]string, dryRun bool, done string, headers []string) error {
+	updated := 0
+	if !dryRun {
+		updated = len(changes) - len(failures)
+	}
+
+	if outputFmt == "json" {
+		if changes == nil {
+			changes = []bulkChange{}
+		}
+		result := map[string]any{
+			"dry_run": dryRun,
+			"matched": changes,
+			"updated": updated,
+		}
+		if len(failures) > 0 {
+			result["failed"] = failures
+		}
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+
+	if len(changes) == 0 {
+		printInfo("No pages to change")
+		return nil
+	}
+
+	if dryRun {
+		if err := printBulkTable(changes, headers); err != nil {
+			return err
+		}
+		printInfo("\nDry run: would update %d pages", len(changes))
+		return nil
+	}
+
+	for id, msg := range failures {
+		printError("failed to update %s: %s", id, msg)
+	}
+	if quiet {
+		for _, c := range changes {
+			if _, failed := failures[c.ExternalID]; !failed {
+				fmt.Println(c.ExternalID)
+			}
+		}
+	} else {
+		printSuccess("%s %d of %d pages", done, updated, len(changes))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d pages could not be updated", len(failures))
+	}
+	return nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageBulkRenameCmd)
+	pageCmd.AddCommand(pageBulkLabelCmd)
+
+	for _, c := range []*cobra.Command{pageBulkRenameCmd, pageBulkLabelCmd} {
+		c.Flags().StringVar(&pageBulkProjectID, "project", "", "project ID (default: from config)")
+		c.Flags().BoolVar(&pageBulkDryRun, "dry-run", false, "show what would change without changing it")
+		c.Flags().BoolVar(&pageBulkForce, "force", false, "skip confirmation prompt")
+		c.Flags().IntVar(&pageBulkConcurrency, "concurrency", 4, "number of pages to update at once")
+	}
+	pageBulkRenameCmd.Flags().StringVar(&pageBulkMatch, "match", "", "regular expression matched against titles")
+	pageBulkRenameCmd.Flags().StringVar(&pageBulkReplace, "replace", "", "replacement title; $1 or ${name} refers to a group in --match")
+	_ = pageBulkRenameCmd.MarkFlagRequired("match")
+	_ = pageBulkRenameCmd.MarkFlagRequired("replace")
+	pageBulkLabelCmd.Flags().StringVar(&pageBulkMatch, "match", "", "only pages whose titles match this regular expression")
+	pageBulkLabelCmd.Flags().StringArrayVar(&pageBulkAdd, "add", nil, "label to add (repeatable)")
+	pageBulkLabelCmd.Flags().StringArrayVar(&pageBulkRemove, "remove", nil, "label to remove (repeatable)")
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func resetPageBulkFlags() {
+	pageBulkProjectID = ""
+	pageBulkMatch = ""
+	pageBulkReplace = ""
+	pageBulkAdd = nil
+	pageBulkRemove = nil
+	pageBulkDryRun = false
+	pageBulkForce = false
+	pageBulkConcurrency = 4
+	outputFmt = "text"
+	quiet = false
+}
+
+// bulkServer serves a project of build pages and a note, and records each
+// PUT body by page ID.
+func bulkServer(t *testing.T, puts map[string]map[string]any) *httptest.Server {
+	t.Helper()
+	pages := map[string]api.Page{
+		"page_1":    {ExternalID: "page_1", Title: "Build 41", Details: &api.PageDetails{Labels: []string{"ci"}}},
+		"page_2":    {ExternalID: "page_2", Title: "Build 42", Details: &api.PageDetails{Labels: []string{"old"}}},
+		"page_note": {ExternalID: "page_note", Title: "Notes on Build 42"},
+	}
+	var mu sync.Mutex
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/projects/proj_abc/" {
+			var summaries []api.Page
+			for _, id := range []string{"page_1", "page_2", "page_note"} {
+				p := pages[id]
+				p.Details = nil
+				summaries = append(summaries, p)
+			}
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: "proj_abc", Pages: summaries})
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+		page := pages[id]
+		page.Role = api.RoleAdmin
+		if r.Method == http.MethodPut {
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			puts[id] = body
+			mu.Unlock()
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func runBulkJSON(t *testing.T, run func() error) map[string]any {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := run()
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, _ := io.ReadAll(r)
+	var result map[string]any
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("output is not valid JSON: %v\nraw: %s", err, output)
+	}
+	return result
+}
+
+func TestPageBulkRename_DryRun(t *testing.T) {
+	resetPageBulkFlags()
+	puts := map[string]map[string]any{}
+	server := bulkServer(t, puts)
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageBulkProjectID = "proj_abc"
+	pageBulkMatch = `^Build (\d+)$`
+	pageBulkReplace = "CI Build $1"
+	pageBulkDryRun = true
+	outputFmt = "json"
+	defer resetPageBulkFlags()
+
+	result := runBulkJSON(t, func() error { return pageBulkRenameCmd.RunE(pageBulkRenameCmd, nil) })
+
+	if len(puts) != 0 {
+		t.Errorf("dry run updated %v", puts)
+	}
+	matched := result["matched"].([]any)
+	if len(matched) != 2 {
+		t.Fatalf("matched = %v, want the two build pages", matched)
+	}
+	first := matched[0].(map[string]any)
+	if first["from"] != "Build 41" || first["to"] != "CI Build 41" {
+		t.Errorf("first change = %v", first)
+	}
+}
+
+func TestPageBulkRename_Applies(t *testing.T) {
+	resetPageBulkFlags()
+	puts := map[string]map[string]any{}
+	server := bulkServer(t, puts)
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageBulkProjectID = "proj_abc"
+	pageBulkMatch = `^Build (\d+)$`
+	pageBulkReplace = "CI Build $1"
+	pageBulkForce = true
+	pageBulkConcurrency = 2
+	outputFmt = "json"
+	defer resetPageBulkFlags()
+
+	result := runBulkJSON(t, func() error { return pageBulkRenameCmd.RunE(pageBulkRenameCmd, nil) })
+
+	if result["updated"] != float64(2) {
+		t.Errorf("updated = %v, want 2", result["updated"])
+	}
+	if len(puts) != 2 || puts["page_2"]["title"] != "CI Build 42" {
+		t.Fatalf("puts = %v", puts)
+	}
+	if _, ok := puts["page_2"]["details"]; ok {
+		t.Errorf("rename sent details: %v", puts["page_2"])
+	}
+}
+
+func TestPageBulkRename_RejectsLongTitle(t *testing.T) {
+	resetPageBulkFlags()
+	puts := map[string]map[string]any{}
+	server := bulkServer(t, puts)
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageBulkProjectID = "proj_abc"
+	pageBulkMatch = `^Build`
+	pageBulkReplace = strings.Repeat("x", maxTitleLength)
+	pageBulkForce = true
+	defer resetPageBulkFlags()
+
+	err := pageBulkRenameCmd.RunE(pageBulkRenameCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "max 100") {
+		t.Fatalf("err = %v, want a title length error", err)
+	}
+	if len(puts) != 0 {
+		t.Errorf("pages were renamed before the check: %v", puts)
+	}
+}
+
+func TestPageBulkLabel_AddRemove(t *testing.T) {
+	resetPageBulkFlags()
+	puts := map[string]map[string]any{}
+	server := bulkServer(t, puts)
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageBulkProjectID = "proj_abc"
+	pageBulkMatch = `^Build `
+	pageBulkAdd = []string{"ci"}
+	pageBulkRemove = []string{"old"}
+	pageBulkForce = true
+	outputFmt = "json"
+	defer resetPageBulkFlags()
+
+	result := runBulkJSON(t, func() error { return pageBulkLabelCmd.RunE(pageBulkLabelCmd, nil) })
+
+	// page_1 already has exactly ci, so only page_2 changes.
+	if result["updated"] != float64(1) {
+		t.Errorf("updated = %v, want 1", result["updated"])
+	}
+	details, _ := puts["page_2"]["details"].(map[string]any)
+	labels, _ := details["labels"].([]any)
+	if len(puts) != 1 || !slices.Equal(labels, []any{"ci"}) {
+		t.Fatalf("puts = %v, want page_2 relabeled [ci]", puts)
+	}
+}
+
+func TestRelabel(t *testing.T) {
+	got := relabel([]string{"a", "b"}, []string{"c", "a"}, []string{"b"})
+	if !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("relabel = %v, want [a c]", got)
+	}
+	if got := relabel(nil, nil, []string{"x"}); got == nil || len(got) != 0 {
+		t.Errorf("relabel of nothing = %#v, want empty", got)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"golang.org/x/term"
+)
+
+var pageInteractive bool
+
+// stdinIsTerminal reports whether a person is typing at stdin. It is a
+// variable so tests can pretend one is.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// composeWanted reports whether 'page new' should open the composer: with
+// --interactive, or when there is no --file and stdin is a terminal, so
+// there is nothing to read.
+func composeWanted() (bool, error) {
+	terminal := stdinIsTerminal()
+
+	if pageInteractive {
+		if pageFile != "" {
+			return false, fmt.Errorf("--interactive cannot be combined with --file")
+		}
+		if pageFromGitShow != "" {
+			return false, fmt.Errorf("--interactive cannot be combined with --from-git-show")
+		}
+		if !terminal {
+			return false, fmt.Errorf("--interactive requires a terminal (stdin is not a terminal)")
+		}
+		return true, nil
+	}
+	return pageFile == "" && pageFromGitShow == "" && pageFromURL == "" && !pageClipboard && lineTimestamps == "" && tailMaxBytes == "" && tailLines == 0 && terminal, nil
+}
+
+// pageTemplate returns the configured page template with placeholders
+// expanded, or "" if none is configured. {{title}} is the --title flag.
+func pageTemplate() (string, error) {
+	template := cfg.GetPageTemplate()
+	if template == "" {
+		return "", nil
+	}
+	text, err := expandPlaceholdersWith(template, map[string]string{"title": pageTitle})
+	if err != nil {
+		return "", fmt.Errorf("invalid page_template: %w", err)
+	}
+	return normalizeNewlines(text), nil
+}
+
+// composePage lets the user write a new page's content. With $EDITOR set the
+// editor opens on the template; otherwise lines are read from in until EOF,
+// after the template. Leaving the template unchanged, or writing nothing,
+// creates no page.
+func composePage(in io.Reader) (string, error) {
+	template, err := pageTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	var content string
+	if os.Getenv("EDITOR") != "" {
+		content, err = composeInEditor(template)
+	} else {
+		content, err = composeInline(in, template)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(content) == "" || strings.TrimSpace(content) == strings.TrimSpace(template) {
+		printInfo("Nothing written; no page created")
+		return "", fmt.Errorf("no content provided")
+	}
+	return content, nil
+}
+
+// composeInEditor opens $EDITOR on a scratch file holding template. The file
+// is kept, and its path printed, if the content cannot be used.
+func composeInEditor(template string) (string, error) {
+	ext := "txt"
+	if pageFiletype != autoFiletype {
+		ext = pageFiletype
+	}
+	tempFile, err := config.ResolveDirs().CreateTemp("hyperclast-new-*." + ext)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	_, writeErr := tempFile.WriteString(template)
+	_ = tempFile.Close()
+	if writeErr != nil {
+		_ = os.Remove(tempPath)
+		return "", fmt.Errorf("failed to write temp file: %w", writeErr)
+	}
+
+	if err := launchEditor(tempPath); err != nil {
+		printInfo("Your draft is saved at: %s", tempPath)
+		return "", err
+	}
+
+	data, err := os.ReadFile(tempPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read draft: %w", err)
+	}
+	if len(data) > maxContentSize {
+		printInfo("Your draft is saved at: %s", tempPath)
+		return "", fmt.Errorf("content too large (%d bytes, max %d)", len(data), maxContentSize)
+	}
+	if err := validateTextContent(data); err != nil {
+		printInfo("Your draft is saved at: %s", tempPath)
+		return "", err
+	}
+
+	_ = os.Remove(tempPath)
+	return normalizeNewlines(string(data)), nil
+}
+
+// composeInline reads the page from in until EOF (Ctrl-D), showing the
+// template first. The typed lines follow the template.
+func composeInline(in io.Reader, template string) (string, error) {
+	fmt.Fprintln(os.Stderr, "Type the page, then press Ctrl-D on an empty line to create it (Ctrl-C to cancel).")
+	if template != "" {
+		fmt.Fprint(os.Stderr, template)
+		if !strings.HasSuffix(template, "\n") {
+			template += "\n"
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(in, maxContentSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	if len(template)+len(data) > maxContentSize {
+		return "", fmt.Errorf("content too large (max %d bytes)", maxContentSize)
+	}
+	if err := validateTextContent(data); err != nil {
+		return "", err
+	}
+	if len(data) == 0 {
+		return template, nil
+	}
+	return template + normalizeNewlines(string(data)), nil
+}
+
+func init() {
+	pageNewCmd.Flags().BoolVar(&pageInteractive, "interactive", false, "compose the page in $EDITOR or inline (the default when stdin is a terminal and there is no --file)")
+}
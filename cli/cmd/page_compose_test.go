@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestComposePage_EditorStartsFromTemplate(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	t.Setenv("EDITOR", "fake")
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	cfg = &config.Config{PageTemplate: "# {{title}}\n\n"}
+	pageTitle = "Standup"
+
+	var opened string
+	fakeEditor(t, func(c string) string {
+		opened = c
+		return c + "- shipped the importer\n"
+	})
+
+	content, err := composePage(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opened != "# Standup\n\n" {
+		t.Errorf("editor opened on %q, want the expanded template", opened)
+	}
+	if content != "# Standup\n\n- shipped the importer\n" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestComposePage_UnchangedTemplateCreatesNothing(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	t.Setenv("EDITOR", "fake")
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	cfg = &config.Config{PageTemplate: "# Notes\n"}
+	fakeEditor(t, func(c string) string { return c })
+
+	if _, err := composePage(strings.NewReader("")); err == nil || !strings.Contains(err.Error(), "no content") {
+		t.Errorf("err = %v, want no content provided", err)
+	}
+}
+
+func TestComposePage_Inline(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	t.Setenv("EDITOR", "")
+	cfg = &config.Config{PageTemplate: "Date: {{date \"2006\"}}"}
+
+	oldStderr := os.Stderr
+	os.Stderr, _ = os.Open(os.DevNull)
+	defer func() { os.Stderr = oldStderr }()
+
+	content, err := composePage(strings.NewReader("first line\nsecond line\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(content, "\n")
+	if !strings.HasPrefix(lines[0], "Date: 2") || lines[1] != "first line" || lines[2] != "second line" {
+		t.Errorf("content = %q, want template line then typed lines", content)
+	}
+}
+
+func TestComposeWanted(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	orig := stdinIsTerminal
+	defer func() { stdinIsTerminal = orig }()
+
+	stdinIsTerminal = func() bool { return true }
+	if ok, err := composeWanted(); !ok || err != nil {
+		t.Errorf("terminal without --file: got %v, %v; want compose", ok, err)
+	}
+	pageFile = "notes.txt"
+	if ok, _ := composeWanted(); ok {
+		t.Error("composer opened despite --file")
+	}
+	pageInteractive = true
+	if _, err := composeWanted(); err == nil || !strings.Contains(err.Error(), "--file") {
+		t.Errorf("err = %v, want --interactive/--file conflict", err)
+	}
+
+	pageFile = ""
+	stdinIsTerminal = func() bool { return false }
+	if _, err := composeWanted(); err == nil || !strings.Contains(err.Error(), "requires a terminal") {
+		t.Errorf("err = %v, want terminal error", err)
+	}
+	pageInteractive = false
+	if ok, _ := composeWanted(); ok {
+		t.Error("composer opened with piped stdin")
+	}
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageCSVTitle     string
+	pageCSVProjectID string
+	pageCSVSource    string
+	pageCSVOn        string
+	pageCSVLeft      bool
+)
+
+var pageCSVUnionCmd = &cobra.Command{
+	Use:   "csv-union <page-id> <page-id>...",
+	Short: "Combine CSV pages with the same columns into a new page",
+	Long: `Stack the rows of several CSV pages into a new CSV page. Every page must
+have the same columns; they may be in a different order, and the new page
+uses the first page's order.
+
+Examples:
+  hyperclast page csv-union page_team_a page_team_b page_team_c --title "All teams Q3"
+  hyperclast page csv-union page_eu page_us --source-column region`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		client := newClient()
+		tables, err := fetchCSVPages(client, args)
+		if err != nil {
+			return err
+		}
+		records, err := unionCSV(tables, pageCSVSource)
+		if err != nil {
+			return err
+		}
+
+		titles := make([]string, len(tables))
+		for i, t := range tables {
+			titles[i] = t.page.Title
+		}
+		return createCSVPage(client, tables, records, "Union: "+strings.Join(titles, ", "))
+	},
+}
+
+var pageCSVJoinCmd = &cobra.Command{
+	Use:   "csv-join <left-page-id> <right-page-id>",
+	Short: "Join two CSV pages on a key column into a new page",
+	Long: `Join the rows of two CSV pages that share a value in the --on column,
+and save the result as a new CSV page. The new page has the left page's
+columns followed by the right page's, without repeating the key.
+
+Rows without a match are dropped, or kept from the left page with --left.
+A right column with the same name as a left one gets a "_2" suffix.
+
+Examples:
+  hyperclast page csv-join page_users page_plans --on user_id --title "Users with plans"
+  hyperclast page csv-join page_hosts page_owners --on hostname --left`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageCSVOn == "" {
+			return fmt.Errorf("--on is required")
+		}
+		client := newClient()
+		tables, err := fetchCSVPages(client, args)
+		if err != nil {
+			return err
+		}
+		records, err := joinCSV(tables[0], tables[1], pageCSVOn, pageCSVLeft)
+		if err != nil {
+			return err
+		}
+		return createCSVPage(client, tables, records, fmt.Sprintf("%s + %s on %s", tables[0].page.Title, tables[1].page.Title, pageCSVOn))
+	},
+}
+
+// csvTable is a fetched CSV page and its parsed records, header first.
+type csvTable struct {
+	page    *api.Page
+	records [][]string
+	delim   rune
+}
+
+func (t csvTable) header() []string {
+	return t.records[0]
+}
+
+// fetchCSVPages fetches and parses each page, failing on any page that is
+// not CSV.
+func fetchCSVPages(client *api.Client, pageIDs []string) ([]csvTable, error) {
+	tables := make([]csvTable, 0, len(pageIDs))
+	for _, id := range pageIDs {
+		page, err := client.GetPage(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get page %s: %w", id, err)
+		}
+		content := pageContent(page)
+		filetype := page.Filetype
+		if page.Details != nil && page.Details.Filetype != "" {
+			filetype = page.Details.Filetype
+		}
+		if filetype != "csv" && detectFiletype(content, filetype) != "csv" {
+			return nil, fmt.Errorf("page %s (%q) is not a CSV page", id, page.Title)
+		}
+		records, delim, err := parseCSV(content)
+		if err != nil {
+			return nil, fmt.Errorf("page %s: %w", id, err)
+		}
+		tables = append(tables, csvTable{page: page, records: records, delim: delim})
+	}
+	return tables, nil
+}
+
+// unionCSV stacks the tables' rows under the first table's header. Columns
+// are matched by name. With sourceColumn set, each row also records the
+// title of the page it came from.
+func unionCSV(tables []csvTable, sourceColumn string) ([][]string, error) {
+	header := tables[0].header()
+	if sourceColumn != "" && slices.Contains(header, sourceColumn) {
+		return nil, fmt.Errorf("--source-column %q is already a column", sourceColumn)
+	}
+
+	out := [][]string{header}
+	if sourceColumn != "" {
+		out[0] = append(slices.Clone(header), sourceColumn)
+	}
+	for _, t := range tables {
+		order, err := columnOrder(header, t.header())
+		if err != nil {
+			return nil, fmt.Errorf("page %s has different columns: %w", t.page.ExternalID, err)
+		}
+		for _, record := range t.records[1:] {
+			row := make([]string, len(header), len(out[0]))
+			for j, i := range order {
+				if i < len(record) {
+					row[j] = record[i]
+				}
+			}
+			if sourceColumn != "" {
+				row = append(row, t.page.Title)
+			}
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+// columnOrder maps each column in want to its index in have. The two must
+// hold the same column names.
+func columnOrder(want, have []string) ([]int, error) {
+	index := make(map[string]int, len(have))
+	for i, name := range have {
+		index[name] = i
+	}
+
+	var missing, extra []string
+	order := make([]int, len(want))
+	for j, name := range want {
+		i, ok := index[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		order[j] = i
+	}
+	for _, name := range have {
+		if !slices.Contains(want, name) {
+			extra = append(extra, name)
+		}
+	}
+
+	switch {
+	case len(missing) > 0 && len(extra) > 0:
+		return nil, fmt.Errorf("missing %s; extra %s", strings.Join(missing, ", "), strings.Join(extra, ", "))
+	case len(missing) > 0:
+		return nil, fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	case len(extra) > 0:
+		return nil, fmt.Errorf("extra %s", strings.Join(extra, ", "))
+	}
+	return order, nil
+}
+
+// joinCSV joins left and right on the key column. Every pair of rows with
+// equal keys produces a row; with keepLeft, left rows without a match are
+// kept with empty right columns.
+func joinCSV(left, right csvTable, key string, keepLeft bool) ([][]string, error) {
+	lk := slices.Index(left.header(), key)
+	rk := slices.Index(right.header(), key)
+	if lk < 0 {
+		return nil, fmt.Errorf("page %s has no column %q (available: %s)", left.page.ExternalID, key, strings.Join(left.header(), ", "))
+	}
+	if rk < 0 {
+		return nil, fmt.Errorf("page %s has no column %q (available: %s)", right.page.ExternalID, key, strings.Join(right.header(), ", "))
+	}
+
+	header := slices.Clone(left.header())
+	var rightCols []int
+	for i, name := range right.header() {
+		if i == rk {
+			continue
+		}
+		rightCols = append(rightCols, i)
+		if slices.Contains(left.header(), name) {
+			name += "_2"
+		}
+		header = append(header, name)
+	}
+
+	matches := make(map[string][][]string)
+	for _, record := range right.records[1:] {
+		if rk < len(record) {
+			matches[record[rk]] = append(matches[record[rk]], record)
+		}
+	}
+
+	width := len(left.header())
+	out := [][]string{header}
+	for _, record := range left.records[1:] {
+		base := make([]string, width)
+		copy(base, record)
+		found := matches[base[lk]]
+		if len(found) == 0 {
+			if keepLeft {
+				out = append(out, append(base, make([]string, len(rightCols))...))
+			}
+			continue
+		}
+		for _, match := range found {
+			row := slices.Clone(base)
+			for _, i := range rightCols {
+				value := ""
+				if i < len(match) {
+					value = match[i]
+				}
+				row = append(row, value)
+			}
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+// createCSVPage saves records as a new CSV page in the --project, the
+// default project, or the first source page's project, and reports it.
+func createCSVPage(client *api.Client, sources []csvTable, records [][]string, defaultTitle string) error {
+	content, err := writeCSV(records, sources[0].delim)
+	if err != nil {
+		return err
+	}
+	if len(content) > maxContentSize {
+		return fmt.Errorf("combined content too large (%d bytes, max %d)", len(content), maxContentSize)
+	}
+
+	projectID := pageCSVProjectID
+	if projectID == "" {
+		projectID = cfg.GetDefaultProject()
+	}
+	if projectID == "" {
+		projectID = sources[0].page.ProjectID
+	}
+	if projectID == "" {
+		return fmt.Errorf("no project specified; use --project <id>")
+	}
+	if err := confirmProtectedWrite(projectID, pageCSVProjectID != "", "create a page"); err != nil {
+		return err
+	}
+
+	title := pageCSVTitle
+	if title == "" {
+		title = truncateRunes(defaultTitle, maxTitleLength)
+	}
+
+	details := &api.PageDetails{Content: content, Filetype: "csv"}
+	if !pageNoSchema {
+		details.Schema = inferCSVSchema(content)
+	}
+	page, err := client.CreatePageWithDetails(projectID, title, details)
+	if err != nil {
+		return fmt.Errorf("failed to create page: %w", err)
+	}
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(page)
+	}
+	if quiet {
+		fmt.Println(page.ExternalID)
+		return nil
+	}
+	printSuccess("Created page \"%s\" (%s) with %d rows from %d pages", page.Title, page.ExternalID, len(records)-1, len(sources))
+	printInfo("  %s", pageURL(page.ExternalID))
+	return nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageCSVUnionCmd)
+	pageCmd.AddCommand(pageCSVJoinCmd)
+
+	for _, c := range []*cobra.Command{pageCSVUnionCmd, pageCSVJoinCmd} {
+		c.Flags().StringVar(&pageCSVTitle, "title", "", "title of the new page (default: from the source titles)")
+		c.Flags().StringVar(&pageCSVProjectID, "project", "", "project for the new page (default: from config, or the first page's project)")
+		c.Flags().BoolVar(&pageNoSchema, "no-schema", false, "don't send inferred column types")
+	}
+	pageCSVUnionCmd.Flags().StringVar(&pageCSVSource, "source-column", "", "add a column with the title of each row's source page")
+	pageCSVJoinCmd.Flags().StringVar(&pageCSVOn, "on", "", "key column present in both pages")
+	pageCSVJoinCmd.Flags().BoolVar(&pageCSVLeft, "left", false, "keep left rows that have no match")
+	_ = pageCSVJoinCmd.MarkFlagRequired("on")
+}
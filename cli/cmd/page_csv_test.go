@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func resetPageCSVFlags() {
+	pageCSVTitle = ""
+	pageCSVProjectID = ""
+	pageCSVSource = ""
+	pageCSVOn = ""
+	pageCSVLeft = false
+	pageNoSchema = false
+	outputFmt = "text"
+	quiet = false
+}
+
+func testCSVTable(id, content string) csvTable {
+	records, delim, err := parseCSV(content)
+	if err != nil {
+		panic(err)
+	}
+	return csvTable{page: &api.Page{ExternalID: id, Title: strings.ToUpper(id)}, records: records, delim: delim}
+}
+
+func TestUnionCSV_MatchesColumnsByName(t *testing.T) {
+	a := testCSVTable("a", "name,count\nx,1\n")
+	b := testCSVTable("b", "count,name\n2,y\n")
+
+	got, err := unionCSV([]csvTable{a, b}, "team")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]string{{"name", "count", "team"}, {"x", "1", "A"}, {"y", "2", "B"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("union = %v, want %v", got, want)
+	}
+}
+
+func TestUnionCSV_DifferentColumns(t *testing.T) {
+	a := testCSVTable("a", "name,count\nx,1\n")
+	b := testCSVTable("b", "name,total\ny,2\n")
+
+	_, err := unionCSV([]csvTable{a, b}, "")
+	if err == nil || !strings.Contains(err.Error(), "missing count; extra total") {
+		t.Errorf("err = %v, want a column mismatch naming both columns", err)
+	}
+}
+
+func TestJoinCSV(t *testing.T) {
+	users := testCSVTable("users", "id,name\n1,ann\n2,bob\n3,cy\n")
+	plans := testCSVTable("plans", "id,plan,name\n1,pro,Ann A\n1,team,Ann A\n2,free,Bob B\n")
+
+	got, err := joinCSV(users, plans, "id", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][]string{
+		{"id", "name", "plan", "name_2"},
+		{"1", "ann", "pro", "Ann A"},
+		{"1", "ann", "team", "Ann A"},
+		{"2", "bob", "free", "Bob B"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("join = %v, want %v", got, want)
+	}
+
+	got, _ = joinCSV(users, plans, "id", true)
+	if last := got[len(got)-1]; !reflect.DeepEqual(last, []string{"3", "cy", "", ""}) {
+		t.Errorf("left join last row = %v, want cy with empty plan columns", last)
+	}
+
+	if _, err := joinCSV(users, plans, "email", false); err == nil || !strings.Contains(err.Error(), "no column \"email\"") {
+		t.Errorf("err = %v, want unknown key column", err)
+	}
+}
+
+func TestPageCSVUnion_CreatesPage(t *testing.T) {
+	resetPageCSVFlags()
+	defer resetPageCSVFlags()
+
+	pages := map[string]api.Page{
+		"page_a": {ExternalID: "page_a", Title: "Team A", ProjectID: "proj_src", Details: &api.PageDetails{Filetype: "csv", Content: "name,count\nx,1\n"}},
+		"page_b": {ExternalID: "page_b", Title: "Team B", ProjectID: "proj_src", Details: &api.PageDetails{Filetype: "csv", Content: "name,count\ny,2\n"}},
+		"page_t": {ExternalID: "page_t", Title: "Notes", Details: &api.PageDetails{Filetype: "txt", Content: "hello"}},
+	}
+	var created api.CreatePageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_new", Title: created.Title})
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+		_ = json.NewEncoder(w).Encode(pages[id])
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	quiet = true
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err := pageCSVUnionCmd.RunE(pageCSVUnionCmd, []string{"page_a", "page_b"})
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if created.ProjectID != "proj_src" || created.Title != "Union: Team A, Team B" {
+		t.Errorf("created %q in %q, want the default title in the source project", created.Title, created.ProjectID)
+	}
+	if created.Details.Content != "name,count\nx,1\ny,2\n" || created.Details.Filetype != "csv" {
+		t.Errorf("details = %+v", created.Details)
+	}
+	if created.Details.Schema == nil {
+		t.Error("no schema sent for the combined page")
+	}
+
+	err = pageCSVUnionCmd.RunE(pageCSVUnionCmd, []string{"page_a", "page_t"})
+	if err == nil || !strings.Contains(err.Error(), "not a CSV page") {
+		t.Errorf("err = %v, want a non-CSV page error", err)
+	}
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var pageSkipDuplicate bool
+
+// duplicateWindow is how many of a project's most recently updated pages
+// 'page new --skip-duplicate' compares with.
+const duplicateWindow = 50
+
+// contentHash is the value recorded in details.content_hash for content.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// findDuplicatePage returns the most recently updated of the project's
+// last duplicateWindow pages whose content is content, or nil if there is
+// none. A recorded content hash or size that differs rules a page out
+// without its content. Any other page is compared by its content, from
+// the listing or fetched, since the hash recorded at creation says nothing
+// of writes since. A page that cannot be fetched is reported and passed
+// over.
+func findDuplicatePage(client *api.Client, projectID, content string) (*api.Page, error) {
+	var pages []api.Page
+	for page, err := range client.AllPages(projectID, api.DefaultPageBatch) {
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	recent := pagesUpdatedWithin(pages, time.Time{})
+	if len(recent) > duplicateWindow {
+		recent = recent[:duplicateWindow]
+	}
+
+	hash := contentHash(content)
+	for i := range recent {
+		page := &recent[i]
+		if same, known := sameContent(page.Details, hash, len(content)); known && !same {
+			continue
+		}
+		full := page
+		if page.Details == nil || page.Details.ContentSize > 0 {
+			var err error
+			if full, err = client.GetPage(page.ExternalID); err != nil {
+				printWarning("could not compare with page %s: %v", page.ExternalID, err)
+				continue
+			}
+		}
+		if hasContent(full, content) {
+			return full, nil
+		}
+	}
+	return nil, nil
+}
+
+// hasContent reports whether page's content is content as 'page new'
+// would have created it: as is, or followed by the metadata backmatter
+// alone, which differs from run to run.
+func hasContent(page *api.Page, content string) bool {
+	rest, ok := strings.CutPrefix(pageContent(page), content)
+	if !ok || rest == "" {
+		return ok
+	}
+	meta, ok := strings.CutPrefix(rest, metadataStart)
+	return ok && strings.Index(meta, "\n---") == len(meta)-len("\n---")
+}
+
+// sameContent compares details with content of hash and size, without the
+// content itself. known is false when details hold neither a content hash
+// nor a size that rules a match out. A matching hash only says the page
+// was created with the content, so callers confirm it with the content.
+func sameContent(d *api.PageDetails, hash string, size int) (same, known bool) {
+	switch {
+	case d == nil:
+		return false, false
+	case d.ContentHash != "":
+		return d.ContentHash == hash, true
+	case d.ContentSize > 0 && d.ContentSize != int64(size):
+		return false, true
+	}
+	return false, false
+}
+
+// printSkippedDuplicate reports that no page was created because page
+// already has the content.
+func printSkippedDuplicate(page *api.Page) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"external_id": page.ExternalID,
+			"title":       page.Title,
+			"skipped":     true,
+		})
+	}
+	if quiet {
+		fmt.Println(page.ExternalID)
+		return nil
+	}
+	printInfo("Skipped: page \"%s\" (%s) already has this content", page.Title, page.ExternalID)
+	printInfo("  %s", pageURL(page.ExternalID))
+	return nil
+}
+
+func init() {
+	pageNewCmd.Flags().BoolVar(&pageSkipDuplicate, "skip-duplicate", false, "create nothing if one of the project's 50 most recent pages has the same content, and print that page's ID")
+}
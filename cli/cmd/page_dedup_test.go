@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageNew_SkipDuplicate(t *testing.T) {
+	env := newCLIEnv(t)
+	existing := env.server.AddPage(apitest.DefaultProjectID, "CI failure", "FAIL: TestLogin\n")
+	env.server.AddPage(apitest.DefaultProjectID, "Other", "FAIL: TestLogout\n")
+	file := filepath.Join(t.TempDir(), "ci.log")
+	client := api.NewClient(env.url, "integration-token")
+	countPages := func() int {
+		pages, err := client.ListPages(apitest.DefaultProjectID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(pages)
+	}
+
+	// An identical page created without the flag is found by its content.
+	_ = os.WriteFile(file, []byte("FAIL: TestLogin\n"), 0600)
+	out := env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--file", file, "--skip-duplicate")
+	if strings.TrimSpace(out) != existing.ExternalID || countPages() != 2 {
+		t.Errorf("duplicate: printed %q with %d pages, want %s and no new page", out, countPages(), existing.ExternalID)
+	}
+
+	// New content is created with its hash, which later runs match even
+	// though --meta made the stored content differ.
+	_ = os.WriteFile(file, []byte("FAIL: TestSignup\n"), 0600)
+	created := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--file", file, "--skip-duplicate", "--meta"))
+	if page, _ := env.server.Page(created); page.Details.ContentHash != contentHash("FAIL: TestSignup\n") {
+		t.Errorf("content_hash = %q", page.Details.ContentHash)
+	}
+	out = env.mustRun("page", "new", "--project", apitest.DefaultProjectID, "--file", file, "--skip-duplicate", "--meta")
+	if !strings.Contains(out, "Skipped") || !strings.Contains(out, created) || countPages() != 3 {
+		t.Errorf("rerun with --meta: printed %q with %d pages, want %s reused", out, countPages(), created)
+	}
+
+	// Without the flag, a copy is created.
+	env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--file", file)
+	if countPages() != 4 {
+		t.Errorf("without --skip-duplicate: %d pages, want 4", countPages())
+	}
+}
+
+func TestPageNew_SkipDuplicateIgnoresStaleHash(t *testing.T) {
+	env := newCLIEnv(t)
+	file := filepath.Join(t.TempDir(), "ci.log")
+	_ = os.WriteFile(file, []byte("FAIL: TestLogin\n"), 0600)
+	args := []string{"page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--file", file, "--skip-duplicate"}
+	first := strings.TrimSpace(env.mustRun(args...))
+
+	// The page keeps the hash it was created with after it is appended to.
+	env.stdin = "FAIL: TestLogout\n"
+	env.mustRun("page", "append", first)
+	env.stdin = ""
+
+	second := strings.TrimSpace(env.mustRun(args...))
+	if second == first {
+		t.Errorf("content matched page %s, which was appended to since it was created", first)
+	}
+}
+
+func TestSameContent(t *testing.T) {
+	hash := contentHash("abc")
+	tests := []struct {
+		name        string
+		details     *api.PageDetails
+		same, known bool
+	}{
+		{"no details", nil, false, false},
+		{"same hash", &api.PageDetails{ContentHash: hash}, true, true},
+		{"other hash", &api.PageDetails{ContentHash: contentHash("abd"), ContentSize: 3}, false, true},
+		{"other size", &api.PageDetails{ContentSize: 4}, false, true},
+		{"same size", &api.PageDetails{ContentSize: 3}, false, false},
+	}
+	for _, tt := range tests {
+		if same, known := sameContent(tt.details, hash, 3); same != tt.same || known != tt.known {
+			t.Errorf("%s: sameContent = %v, %v, want %v, %v", tt.name, same, known, tt.same, tt.known)
+		}
+	}
+}
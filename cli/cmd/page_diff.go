@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/diff"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultDiffWidth is the side-by-side width when stdout is not a terminal.
+const defaultDiffWidth = 160
+
+var (
+	pageDiffSideBySide bool
+	pageDiffContext    int
+	pageDiffWidth      int
+	pageDiffFrom       string
+	pageDiffTo         string
+)
+
+var pageDiffCmd = &cobra.Command{
+	Use:   "diff <page-id> (<other-page-id> | --from <rev> [--to <rev>])",
+	Short: "Compare the content of two pages, or two revisions of one",
+	Long: `Print the differences between two pages' content, from the first page to
+the second, as a unified diff or side by side.
+
+With --from and --to, compare two revisions of one page instead. A
+revision is its number (12 or v12), its ID, or "current" for the page as it
+is now, which --to defaults to.
+
+With --output json, the result is emitted as structured hunks for tooling.
+
+Examples:
+  hyperclast page diff page_lastweek page_thisweek
+  hyperclast page diff page_lastweek page_thisweek --side-by-side
+  hyperclast page diff page_a page_b --output json | jq '.hunks | length'
+  hyperclast page diff page_capture --from v40 --to v52
+  hyperclast page diff page_capture --from v40`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageDiffContext < 0 {
+			return fmt.Errorf("--context must not be negative")
+		}
+
+		client := newClient()
+		if pageDiffFrom != "" || pageDiffTo != "" {
+			return diffRevisions(client, args)
+		}
+		if len(args) != 2 {
+			return fmt.Errorf("give two page IDs, or one with --from and --to to compare its revisions")
+		}
+		from, err := client.GetPage(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get page %s: %w", args[0], err)
+		}
+		to, err := client.GetPage(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to get page %s: %w", args[1], err)
+		}
+
+		return writePageDiff(os.Stdout, from, to)
+	},
+}
+
+// diffRevisions compares the --from and --to revisions of the page args
+// names.
+func diffRevisions(client *api.Client, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("--from and --to compare revisions of one page; give a single page ID")
+	}
+	if pageDiffFrom == "" {
+		return fmt.Errorf("--to requires --from")
+	}
+	to := pageDiffTo
+	if to == "" {
+		to = "current"
+	}
+
+	pageID := args[0]
+	fromRev, err := resolveRevision(client, pageID, pageDiffFrom)
+	if err != nil {
+		return err
+	}
+	toRev, err := resolveRevision(client, pageID, to)
+	if err != nil {
+		return err
+	}
+	return writeDiff(os.Stdout, fromRev.source(pageID), toRev.source(pageID), pageDiffContext, "Revisions are identical", map[string]any{"page_id": pageID})
+}
+
+func writePageDiff(out io.Writer, from, to *api.Page) error {
+	return writeDiff(out, pageDiffSource(from), pageDiffSource(to), pageDiffContext, "Pages are identical", nil)
+}
+
+// diffSource is one side of a diff: the name in its header, how JSON
+// output describes it, and its content.
+type diffSource struct {
+	name    string
+	side    any
+	content string
+}
+
+func pageDiffSource(p *api.Page) diffSource {
+	return diffSource{name: fmt.Sprintf("%s (%s)", p.Title, p.ExternalID), side: diffSide(p), content: pageContent(p)}
+}
+
+// writeDiff writes the diff from one source to another as JSON, side by
+// side, or unified with context lines around each change, printing
+// identical when there is no difference. JSON output includes the fields
+// of extra.
+func writeDiff(out io.Writer, from, to diffSource, context int, identical string, extra map[string]any) error {
+	script := diff.Lines(from.content, to.content)
+	hunks := diff.Hunks(script, context)
+	added, deleted := diff.Stat(script)
+
+	if outputFmt == "json" {
+		if hunks == nil {
+			hunks = []diff.Hunk{}
+		}
+		result := map[string]any{
+			"from":      from.side,
+			"to":        to.side,
+			"identical": len(hunks) == 0,
+			"added":     added,
+			"deleted":   deleted,
+			"hunks":     hunks,
+		}
+		maps.Copy(result, extra)
+		return json.NewEncoder(out).Encode(result)
+	}
+
+	if len(hunks) == 0 {
+		printInfo("%s", identical)
+		return nil
+	}
+
+	if pageDiffSideBySide {
+		_, err := fmt.Fprint(out, renderSideBySide(from.name, to.name, hunks, diffWidth()))
+		return err
+	}
+
+	_, err := fmt.Fprint(out,
+		colorize(ansiBold, "--- "+from.name)+"\n"+
+			colorize(ansiBold, "+++ "+to.name)+"\n"+
+			renderDiff(hunks))
+	return err
+}
+
+func diffSide(p *api.Page) map[string]string {
+	return map[string]string{"external_id": p.ExternalID, "title": p.Title}
+}
+
+func diffWidth() int {
+	if pageDiffWidth > 0 {
+		return pageDiffWidth
+	}
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	return defaultDiffWidth
+}
+
+// renderSideBySide renders hunks in two columns, marking rows like sdiff:
+// "|" for a changed line, "<" for a deleted one, and ">" for an inserted one.
+// Runs of deletions are paired with the insertions that follow them.
+func renderSideBySide(fromName, toName string, hunks []diff.Hunk, width int) string {
+	col := (width - 3) / 2
+	if col < 10 {
+		col = 10
+	}
+
+	var b strings.Builder
+	row := func(left, mark, right, color string) {
+		line := strings.TrimRight(fitColumn(left, col)+" "+mark+" "+truncateRunes(right, col), " ")
+		if color != "" {
+			line = colorize(color, line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(colorize(ansiBold, strings.TrimRight(fitColumn(fromName, col)+"   "+truncateRunes(toName, col), " ")))
+	b.WriteString("\n")
+	for _, h := range hunks {
+		b.WriteString(colorize(ansiCyan, fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, h.OldLines, h.NewStart, h.NewLines)))
+		b.WriteString("\n")
+
+		lines := h.Lines
+		for i := 0; i < len(lines); {
+			if lines[i].Kind == diff.Equal {
+				row(lines[i].Text, " ", lines[i].Text, "")
+				i++
+				continue
+			}
+
+			var dels, ins []string
+			for ; i < len(lines) && lines[i].Kind == diff.Delete; i++ {
+				dels = append(dels, lines[i].Text)
+			}
+			for ; i < len(lines) && lines[i].Kind == diff.Insert; i++ {
+				ins = append(ins, lines[i].Text)
+			}
+			for j := 0; j < len(dels) || j < len(ins); j++ {
+				switch {
+				case j < len(dels) && j < len(ins):
+					row(dels[j], "|", ins[j], ansiCyan)
+				case j < len(dels):
+					row(dels[j], "<", "", ansiRed)
+				default:
+					row("", ">", ins[j], ansiGreen)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// fitColumn truncates or pads s to exactly n runes, expanding tabs so
+// columns stay aligned.
+func fitColumn(s string, n int) string {
+	s = truncateRunes(s, n)
+	return s + strings.Repeat(" ", n-utf8.RuneCountInString(s))
+}
+
+func truncateRunes(s string, n int) string {
+	s = strings.ReplaceAll(s, "\t", "    ")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
+
+func init() {
+	pageCmd.AddCommand(pageDiffCmd)
+
+	pageDiffCmd.Flags().BoolVar(&pageDiffSideBySide, "side-by-side", false, "show the pages in two columns instead of a unified diff")
+	pageDiffCmd.Flags().IntVar(&pageDiffContext, "context", 3, "number of unchanged lines to show around each change")
+	pageDiffCmd.Flags().IntVar(&pageDiffWidth, "width", 0, "total width for --side-by-side (default: terminal width, or 160)")
+	pageDiffCmd.Flags().StringVar(&pageDiffFrom, "from", "", "compare this revision of the page: its number (12 or v12), ID, or current")
+	pageDiffCmd.Flags().StringVar(&pageDiffTo, "to", "", "with --from, the revision to compare it to (default: current)")
+}
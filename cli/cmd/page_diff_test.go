@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func resetPageDiffFlags() {
+	pageDiffSideBySide = false
+	pageDiffContext = 3
+	pageDiffWidth = 0
+	pageDiffFrom = ""
+	pageDiffTo = ""
+	outputFmt = "text"
+	quiet = false
+}
+
+func diffPages() (*api.Page, *api.Page) {
+	from := &api.Page{ExternalID: "page_a", Title: "Week 1", Details: &api.PageDetails{Content: "replicas: 1\nregion: us\nlog: info\n"}}
+	to := &api.Page{ExternalID: "page_b", Title: "Week 2", Details: &api.PageDetails{Content: "replicas: 3\nregion: us\nlog: info\ncache: on\n"}}
+	return from, to
+}
+
+func TestWritePageDiff_Unified(t *testing.T) {
+	resetPageDiffFlags()
+	from, to := diffPages()
+
+	var out bytes.Buffer
+	if err := writePageDiff(&out, from, to); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "--- Week 1 (page_a)\n+++ Week 2 (page_b)\n@@ -1,3 +1,4 @@\n-replicas: 1\n+replicas: 3\n region: us\n log: info\n+cache: on\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWritePageDiff_SideBySide(t *testing.T) {
+	resetPageDiffFlags()
+	pageDiffSideBySide = true
+	pageDiffWidth = 43
+	from, to := diffPages()
+
+	var out bytes.Buffer
+	if err := writePageDiff(&out, from, to); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"Week 1 (page_a)        Week 2 (page_b)",
+		"@@ -1,3 +1,4 @@",
+		"replicas: 1          | replicas: 3",
+		"region: us             region: us",
+		"log: info              log: info",
+		"                     > cache: on",
+		"",
+	}, "\n")
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWritePageDiff_JSON(t *testing.T) {
+	resetPageDiffFlags()
+	defer resetPageDiffFlags()
+	outputFmt = "json"
+	from, to := diffPages()
+
+	var out bytes.Buffer
+	if err := writePageDiff(&out, from, to); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		From      map[string]string `json:"from"`
+		Identical bool              `json:"identical"`
+		Added     int               `json:"added"`
+		Deleted   int               `json:"deleted"`
+		Hunks     []struct {
+			OldStart int `json:"old_start"`
+			Lines    []struct {
+				Op   string `json:"op"`
+				Text string `json:"text"`
+			} `json:"lines"`
+		} `json:"hunks"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Identical || got.Added != 2 || got.Deleted != 1 || got.From["external_id"] != "page_a" {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+	if len(got.Hunks) != 1 || got.Hunks[0].Lines[0].Op != "-" || got.Hunks[0].Lines[0].Text != "replicas: 1" {
+		t.Errorf("unexpected hunks: %+v", got.Hunks)
+	}
+}
+
+func TestPageDiff_FetchesBothPages(t *testing.T) {
+	resetPageDiffFlags()
+	defer resetPageDiffFlags()
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_x", Details: &api.PageDetails{Content: "same\n"}})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	outputFmt = "json"
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := pageDiffCmd.RunE(pageDiffCmd, []string{"page_a", "page_b"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, _ := io.ReadAll(r)
+	if !strings.Contains(string(output), `"identical":true`) || !strings.Contains(string(output), `"hunks":[]`) {
+		t.Errorf("output = %s, want identical with empty hunks", output)
+	}
+	if strings.Join(paths, " ") != "/pages/page_a/ /pages/page_b/" {
+		t.Errorf("requested %v, want both pages in order", paths)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("héllo wörld", 6); got != "héllo…" {
+		t.Errorf("truncateRunes = %q", got)
+	}
+	if got := fitColumn("a\tb", 8); got != "a    b  " {
+		t.Errorf("fitColumn = %q", got)
+	}
+}
+
+func TestPageDiff_Revisions(t *testing.T) {
+	resetPageDiffFlags()
+	defer resetPageDiffFlags()
+	server := historyServer(t)
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageDiffFrom = "v1"
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := pageDiffCmd.RunE(pageDiffCmd, []string{"page_x"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, _ := io.ReadAll(r)
+	want := "--- page_x v1 (Mar 3, 2026 2:04 PM)\n+++ page_x current\n@@ -1,2 +1,2 @@\n-replicas: 1\n-region: us\n+replicas: 3\n+region: eu\n"
+	if string(output) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", output, want)
+	}
+}
+
+func TestPageDiff_RevisionArgs(t *testing.T) {
+	resetPageDiffFlags()
+	defer resetPageDiffFlags()
+	cfg = &config.Config{Token: "test-token"}
+
+	pageDiffTo = "v2"
+	if err := pageDiffCmd.RunE(pageDiffCmd, []string{"page_x"}); err == nil || !strings.Contains(err.Error(), "--to requires --from") {
+		t.Errorf("err = %v, want --from required", err)
+	}
+	pageDiffFrom = "v1"
+	if err := pageDiffCmd.RunE(pageDiffCmd, []string{"page_x", "page_y"}); err == nil || !strings.Contains(err.Error(), "single page ID") {
+		t.Errorf("err = %v, want one page ID", err)
+	}
+	resetPageDiffFlags()
+	if err := pageDiffCmd.RunE(pageDiffCmd, []string{"page_x"}); err == nil || !strings.Contains(err.Error(), "give two page IDs") {
+		t.Errorf("err = %v, want two page IDs", err)
+	}
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var pageEditCmd = &cobra.Command{
+	Use:   "edit <page-id>",
+	Short: "Edit a page in your editor",
+	Long: `Open a page's content in $EDITOR (vi, or notepad on Windows, if unset) and
+save it back in overwrite mode when the editor exits. Nothing is sent if the
+content was not changed.
+
+Before saving, the page is fetched again. If someone else changed it while
+you were editing, you can merge their changes (a 3-way merge via
+'git merge-file'; conflicts are reopened in your editor), overwrite them,
+or abort and keep your edits in a temp file.
+
+Examples:
+  hyperclast page edit page_xyz789
+  EDITOR="code --wait" hyperclast page edit page_xyz789`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) == 0 {
+			return fmt.Errorf("page edit requires a terminal")
+		}
+
+		return runPageEdit(newClient(), args[0], os.Stdin)
+	},
+}
+
+// launchEditor opens path in the user's editor. It is a variable so tests
+// can substitute a fake editor.
+var launchEditor = func(path string) error {
+	editor := editorCommand()
+	parts := strings.Fields(editor)
+
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
+
+// editorCommand is $EDITOR, or the platform's basic editor when it is unset.
+func editorCommand() string {
+	if editor := strings.TrimSpace(os.Getenv("EDITOR")); editor != "" {
+		return editor
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+var errEditAborted = errors.New("edit aborted")
+
+func runPageEdit(client *api.Client, pageID string, in io.Reader) error {
+	page, err := client.GetPage(pageID)
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	if !page.CanEdit() {
+		return &api.PermissionError{Action: "edit page", Role: page.Role, Needs: "editor"}
+	}
+	if err := checkPageLock(client, page); err != nil {
+		return err
+	}
+	base := pageContent(page)
+
+	// The extension lets the editor pick syntax highlighting.
+	filetype := ""
+	if page.Details != nil {
+		filetype = page.Details.Filetype
+	}
+	tempFile, err := config.ResolveDirs().CreateTemp("hyperclast-edit-*." + exportExtension(filetype))
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	_, writeErr := tempFile.WriteString(base)
+	_ = tempFile.Close()
+	if writeErr != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to write temp file: %w", writeErr)
+	}
+
+	reader := bufio.NewReader(in)
+	for {
+		if err := launchEditor(tempPath); err != nil {
+			printInfo("Your edits are saved at: %s", tempPath)
+			return err
+		}
+
+		editedData, err := os.ReadFile(tempPath)
+		if err != nil {
+			return fmt.Errorf("failed to read edited content: %w", err)
+		}
+		edited := []byte(normalizeNewlines(string(editedData)))
+		if string(edited) == normalizeNewlines(base) {
+			_ = os.Remove(tempPath)
+			return printEditResult(page, false)
+		}
+		if err := validateTextContent(edited); err != nil {
+			printInfo("Your edits are saved at: %s", tempPath)
+			return err
+		}
+
+		remotePage, err := client.GetPage(pageID)
+		if err != nil {
+			printInfo("Your edits are saved at: %s", tempPath)
+			return fmt.Errorf("failed to re-check page before saving: %w", err)
+		}
+		remote := pageContent(remotePage)
+
+		if remote != base {
+			choice, err := promptConflict(reader, remotePage)
+			if err != nil {
+				printInfo("Your edits are saved at: %s", tempPath)
+				return err
+			}
+
+			switch choice {
+			case "merge":
+				merged, clean, err := mergeContent(string(edited), base, remote)
+				if err != nil {
+					printInfo("Your edits are saved at: %s", tempPath)
+					return err
+				}
+				if err := os.WriteFile(tempPath, []byte(merged), 0600); err != nil {
+					return fmt.Errorf("failed to write merged content: %w", err)
+				}
+				base = remote
+				if !clean {
+					printInfo("Merge has conflicts; reopening editor to resolve them")
+					continue
+				}
+				edited = []byte(merged)
+			case "overwrite":
+				// Saved below, over the remote change.
+			case "abort":
+				printInfo("Your edits are saved at: %s", tempPath)
+				return errEditAborted
+			}
+		}
+
+		updated, err := client.UpdateFetchedPageContent(remotePage, string(edited), "overwrite")
+		if err != nil {
+			printInfo("Your edits are saved at: %s", tempPath)
+			return fmt.Errorf("failed to save page: %w", err)
+		}
+		_ = os.Remove(tempPath)
+		return printEditResult(updated, true)
+	}
+}
+
+func printEditResult(page *api.Page, saved bool) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(page)
+	}
+	if quiet {
+		fmt.Println(page.ExternalID)
+		return nil
+	}
+	if !saved {
+		printInfo("No changes made")
+		return nil
+	}
+	printSuccess("Saved page \"%s\" (%s)", page.Title, page.ExternalID)
+	return nil
+}
+
+func pageContent(page *api.Page) string {
+	if page.Details == nil {
+		return ""
+	}
+	return page.Details.Content
+}
+
+// promptConflict asks how to handle a page that changed remotely during an
+// edit. Returns "merge", "overwrite", or "abort".
+func promptConflict(reader *bufio.Reader, remote *api.Page) (string, error) {
+	changed := remote.Modified
+	if changed == "" {
+		changed = remote.Updated
+	}
+	fmt.Fprintf(os.Stderr, "Page \"%s\" was changed by someone else while you were editing", remote.Title)
+	if changed != "" {
+		fmt.Fprintf(os.Stderr, " (modified %s)", changed)
+	}
+	fmt.Fprintln(os.Stderr, ".")
+
+	for {
+		fmt.Fprint(os.Stderr, "[m]erge, [o]verwrite, or [a]bort? ")
+		line, err := reader.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "m", "merge":
+			return "merge", nil
+		case "o", "overwrite":
+			return "overwrite", nil
+		case "a", "abort":
+			return "abort", nil
+		}
+		if err != nil {
+			return "abort", nil
+		}
+	}
+}
+
+// mergeContent performs a 3-way merge of ours and theirs against base using
+// git merge-file. clean is false when the result contains conflict markers.
+func mergeContent(ours, base, theirs string) (merged string, clean bool, err error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", false, fmt.Errorf("merge requires git to be installed")
+	}
+
+	dir, err := config.ResolveDirs().MkdirTemp("hyperclast-merge-*")
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	paths := make([]string, 3)
+	for i, content := range []string{ours, base, theirs} {
+		paths[i] = filepath.Join(dir, strconv.Itoa(i))
+		if err := os.WriteFile(paths[i], []byte(content), 0600); err != nil {
+			return "", false, fmt.Errorf("failed to write merge input: %w", err)
+		}
+	}
+
+	c := exec.Command("git", "merge-file", "-p",
+		"-L", "yours", "-L", "base", "-L", "theirs",
+		paths[0], paths[1], paths[2])
+	out, err := c.Output()
+	if err != nil {
+		// git merge-file exits with the number of conflicts (capped at 127);
+		// negative or larger codes indicate a real failure.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+			return string(out), false, nil
+		}
+		return "", false, fmt.Errorf("git merge-file failed: %w", err)
+	}
+	return string(out), true, nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageEditCmd)
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// editServer serves a single page whose content can be changed between
+// requests to simulate a concurrent edit.
+type editServer struct {
+	content string
+	puts    []string
+	onGet   func(count int)
+	gets    int
+}
+
+func (s *editServer) handler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		s.gets++
+		if s.onGet != nil {
+			s.onGet(s.gets)
+		}
+	case "PUT":
+		var req api.UpdatePageContentRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.puts = append(s.puts, req.Details.Content)
+		s.content = req.Details.Content
+	}
+	_ = json.NewEncoder(w).Encode(api.Page{
+		ExternalID: "page_xyz",
+		Title:      "Notes",
+		Details:    &api.PageDetails{Content: s.content},
+	})
+}
+
+func fakeEditor(t *testing.T, edit func(string) string) {
+	t.Helper()
+	orig := launchEditor
+	launchEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(edit(string(data))), 0600)
+	}
+	t.Cleanup(func() { launchEditor = orig })
+}
+
+func TestPageEdit_SavesWhenUnchangedRemotely(t *testing.T) {
+	s := &editServer{content: "line 1\n"}
+	server := httptest.NewServer(http.HandlerFunc(s.handler))
+	defer server.Close()
+	fakeEditor(t, func(c string) string { return c + "line 2\n" })
+
+	err := runPageEdit(api.NewClient(server.URL, "test-token"), "page_xyz", strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.puts) != 1 || s.puts[0] != "line 1\nline 2\n" {
+		t.Errorf("puts = %q, want one save of edited content", s.puts)
+	}
+}
+
+func TestPageEdit_NoChanges(t *testing.T) {
+	s := &editServer{content: "line 1\n"}
+	server := httptest.NewServer(http.HandlerFunc(s.handler))
+	defer server.Close()
+	fakeEditor(t, func(c string) string { return c })
+
+	err := runPageEdit(api.NewClient(server.URL, "test-token"), "page_xyz", strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.puts) != 0 {
+		t.Errorf("expected no save, got %q", s.puts)
+	}
+}
+
+func TestPageEdit_ConflictOverwrite(t *testing.T) {
+	s := &editServer{content: "line 1\n"}
+	s.onGet = func(count int) {
+		if count == 2 {
+			s.content = "line 1\nremote\n"
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(s.handler))
+	defer server.Close()
+	fakeEditor(t, func(c string) string { return c + "local\n" })
+
+	err := runPageEdit(api.NewClient(server.URL, "test-token"), "page_xyz", strings.NewReader("o\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.puts) != 1 || s.puts[0] != "line 1\nlocal\n" {
+		t.Errorf("puts = %q, want local content", s.puts)
+	}
+}
+
+func TestPageEdit_ConflictAbort(t *testing.T) {
+	s := &editServer{content: "line 1\n"}
+	s.onGet = func(count int) {
+		if count == 2 {
+			s.content = "line 1\nremote\n"
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(s.handler))
+	defer server.Close()
+	fakeEditor(t, func(c string) string { return c + "local\n" })
+
+	err := runPageEdit(api.NewClient(server.URL, "test-token"), "page_xyz", strings.NewReader("a\n"))
+	if !errors.Is(err, errEditAborted) {
+		t.Fatalf("err = %v, want errEditAborted", err)
+	}
+	if len(s.puts) != 0 {
+		t.Errorf("expected no save, got %q", s.puts)
+	}
+}
+
+func TestPageEdit_ConflictMerge(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	s := &editServer{content: "a\nb\nc\n"}
+	s.onGet = func(count int) {
+		if count == 2 {
+			s.content = "remote\na\nb\nc\n"
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(s.handler))
+	defer server.Close()
+	fakeEditor(t, func(c string) string { return c + "local\n" })
+
+	err := runPageEdit(api.NewClient(server.URL, "test-token"), "page_xyz", strings.NewReader("m\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "remote\na\nb\nc\nlocal\n"
+	if len(s.puts) != 1 || s.puts[0] != want {
+		t.Errorf("puts = %q, want %q", s.puts, want)
+	}
+}
+
+func TestMergeContent_Conflict(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	merged, clean, err := mergeContent("a\nmine\n", "a\nbase\n", "a\ntheirs\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clean {
+		t.Error("expected conflicting merge")
+	}
+	for _, marker := range []string{"<<<<<<< yours", "=======", ">>>>>>> theirs"} {
+		if !strings.Contains(merged, marker) {
+			t.Errorf("merged output missing %q:\n%s", marker, merged)
+		}
+	}
+}
+
+func TestPageEdit_TempFileExtensionAndJSON(t *testing.T) {
+	s := &editServer{content: "a,b\n"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Details: &api.PageDetails{Content: s.content, Filetype: "csv"}})
+			return
+		}
+		s.handler(w, r)
+	}))
+	defer server.Close()
+
+	var editedPath string
+	fakeEditor(t, func(c string) string { return c + "1,2\n" })
+	edit := launchEditor
+	launchEditor = func(path string) error {
+		editedPath = path
+		return edit(path)
+	}
+
+	outputFmt = "json"
+	defer func() { outputFmt = "text" }()
+	out := captureArchiveOutput(t, func() error {
+		return runPageEdit(api.NewClient(server.URL, "test-token"), "page_xyz", strings.NewReader(""))
+	})
+
+	if !strings.HasSuffix(editedPath, ".csv") {
+		t.Errorf("edited %s, want a .csv temp file for a CSV page", editedPath)
+	}
+	var page api.Page
+	if err := json.Unmarshal([]byte(out), &page); err != nil || page.ExternalID != "page_xyz" {
+		t.Errorf("output = %q, want the saved page as JSON", out)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("EDITOR", " code --wait ")
+	if got := editorCommand(); got != "code --wait" {
+		t.Errorf("editorCommand = %q, want $EDITOR", got)
+	}
+	t.Setenv("EDITOR", "")
+	if got := editorCommand(); got != "vi" && got != "notepad" {
+		t.Errorf("editorCommand = %q, want the platform fallback", got)
+	}
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageExpires   string
+	pageGCProject string
+	pageGCDryRun  bool
+	pageGCForce   bool
+)
+
+// parseExpires turns an --expires duration into the time it ends, from
+// now. It returns the zero time for an empty duration.
+func parseExpires(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	d, err := parseLongDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--expires: %w", err)
+	}
+	if d <= 0 {
+		return time.Time{}, fmt.Errorf("--expires must be a positive duration")
+	}
+	return time.Now().Add(d).UTC().Truncate(time.Second), nil
+}
+
+// expiresLine is the metadata line recording when a page expires.
+func expiresLine(at time.Time) string {
+	return "Expires: " + at.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+// pageExpiry returns when a page expires, or the zero time if it never
+// does or its expiry cannot be read.
+func pageExpiry(page *api.Page) time.Time {
+	if page.Details == nil || page.Details.ExpiresAt == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, page.Details.ExpiresAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+var pageGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete pages and revoke share links past their expiry",
+	Long: `Delete pages created with 'page new --expires' whose expiry has passed, so
+transient captures such as CI logs do not accumulate, and revoke links
+created with 'page share --expires' whose expiry has passed. Servers that
+expire pages themselves delete them on their own; on others, and for share
+links, run this from a scheduled job.
+
+Only pages you can delete are deleted, and only links on pages you can edit
+are revoked. Use --dry-run first to see what would be done. Prompts for
+confirmation before deleting unless --force is used; expired links are
+revoked without asking.
+
+Examples:
+  hyperclast page gc --dry-run
+  hyperclast page gc --project proj_abc123 --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		client := newClient()
+		expired, shares, err := findExpired(client, pageGCProject, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if pageGCDryRun || len(expired)+len(shares) == 0 {
+			return printGCReport(expired, shares, nil, true)
+		}
+
+		if len(expired) > 0 && !pageGCForce {
+			stat, _ := os.Stdin.Stat()
+			if (stat.Mode() & os.ModeCharDevice) == 0 {
+				return fmt.Errorf("--force is required in non-interactive mode (stdin is not a terminal)")
+			}
+			if err := printGCTable(expired); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Delete %d expired pages? [y/N] ", len(expired))
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "y" && confirm != "Y" {
+				printInfo("Cancelled")
+				if len(shares) == 0 {
+					return nil
+				}
+				expired = nil
+			}
+		}
+
+		failures := make(map[string]string)
+		for _, p := range shares {
+			page := &api.Page{ExternalID: p.ExternalID, Details: &api.PageDetails{ShareExpiresAt: p.ExpiresAt}}
+			if err := revokeShareLink(client, page); err != nil {
+				failures[p.ExternalID] = "failed to revoke the share link: " + err.Error()
+				printDebug("Failed to revoke the share link to %s: %v", p.ExternalID, err)
+			}
+		}
+		deleting := startProgress("delete", len(expired), "pages")
+		for _, p := range expired {
+			err := client.DeletePage(p.ExternalID)
+			status := "deleted"
+			if err != nil {
+				status = "failed"
+				failures[p.ExternalID] = "failed to delete: " + err.Error()
+				printDebug("Failed to delete %s: %v", p.ExternalID, err)
+			}
+			deleting.advance(p.ExternalID, status, err)
+		}
+
+		return printGCReport(expired, shares, failures, false)
+	},
+}
+
+// expiredPage is a page, or a page's share link, whose expiry has passed.
+type expiredPage struct {
+	ExternalID string `json:"external_id"`
+	Title      string `json:"title"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+// findExpired returns the pages in a project, or all projects when
+// projectID is empty, that the user can delete and that expired before
+// now, and the pages the user can edit whose share link expired before
+// now. Listings omit details, so each page's metadata is fetched.
+func findExpired(client *api.Client, projectID string, now time.Time) (pages, shares []expiredPage, err error) {
+	for page, err := range client.AllPages(projectID, 0) {
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list pages: %w", err)
+		}
+		if !page.CanEdit() && !page.CanDelete() {
+			continue
+		}
+		meta, err := client.GetPageMetadata(page.ExternalID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get page %s: %w", page.ExternalID, err)
+		}
+		if at := pageExpiry(meta); meta.CanDelete() && !at.IsZero() && !at.After(now) {
+			pages = append(pages, expiredPage{
+				ExternalID: meta.ExternalID,
+				Title:      meta.Title,
+				ExpiresAt:  meta.Details.ExpiresAt,
+			})
+		}
+		if at, err := time.Parse(time.RFC3339, shareExpiry(meta)); err == nil && meta.CanEdit() && meta.AccessCode != "" && !at.After(now) {
+			shares = append(shares, expiredPage{
+				ExternalID: meta.ExternalID,
+				Title:      meta.Title,
+				ExpiresAt:  meta.Details.ShareExpiresAt,
+			})
+		}
+	}
+	return pages, shares, nil
+}
+
+func printGCTable(expired []expiredPage) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTITLE\tEXPIRED")
+	for _, p := range expired {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", p.ExternalID, p.Title, formatMetadataTime(p.ExpiresAt))
+	}
+	return w.Flush()
+}
+
+// printGCReport reports what 'page gc' did, or would do with dryRun.
+// failures maps page IDs to what went wrong with them.
+func printGCReport(expired, shares []expiredPage, failures map[string]string, dryRun bool) error {
+	deleted, revoked := 0, 0
+	if !dryRun {
+		deleted = countDone(expired, failures)
+		revoked = countDone(shares, failures)
+	}
+
+	if outputFmt == "json" {
+		if expired == nil {
+			expired = []expiredPage{}
+		}
+		if shares == nil {
+			shares = []expiredPage{}
+		}
+		result := map[string]any{
+			"dry_run":        dryRun,
+			"expired":        expired,
+			"deleted":        deleted,
+			"expired_shares": shares,
+			"revoked":        revoked,
+		}
+		if len(failures) > 0 {
+			result["failed"] = failures
+		}
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+
+	if len(expired)+len(shares) == 0 {
+		printInfo("No expired pages or share links")
+		return nil
+	}
+
+	if dryRun {
+		if len(expired) > 0 {
+			if err := printGCTable(expired); err != nil {
+				return err
+			}
+			printInfo("\nDry run: would delete %d expired pages", len(expired))
+		}
+		if len(shares) > 0 {
+			if len(expired) > 0 {
+				fmt.Println()
+			}
+			if err := printGCTable(shares); err != nil {
+				return err
+			}
+			printInfo("\nDry run: would revoke %d expired share links", len(shares))
+		}
+		return nil
+	}
+
+	for id, msg := range failures {
+		printError("%s: %s", id, msg)
+	}
+	if len(shares) > 0 {
+		printSuccess("Revoked %d of %d expired share links", revoked, len(shares))
+	}
+	if len(expired) > 0 {
+		printSuccess("Deleted %d of %d expired pages", deleted, len(expired))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d pages could not be collected", len(failures))
+	}
+	return nil
+}
+
+// countDone counts the pages that have no entry in failures.
+func countDone(pages []expiredPage, failures map[string]string) int {
+	n := 0
+	for _, p := range pages {
+		if _, failed := failures[p.ExternalID]; !failed {
+			n++
+		}
+	}
+	return n
+}
+
+func init() {
+	pageCmd.AddCommand(pageGCCmd)
+
+	pageNewCmd.Flags().StringVar(&pageExpires, "expires", "", "delete the page after this period (e.g. 12h, 7d, 2w); see 'page gc'")
+	pageGCCmd.Flags().StringVar(&pageGCProject, "project", "", "only collect pages in this project (default: all projects)")
+	pageGCCmd.Flags().BoolVar(&pageGCDryRun, "dry-run", false, "show what would be deleted and revoked without doing it")
+	pageGCCmd.Flags().BoolVar(&pageGCForce, "force", false, "skip confirmation prompt")
+}
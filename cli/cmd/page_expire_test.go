@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageNew_Expires(t *testing.T) {
+	env := newCLIEnv(t)
+	path := filepath.Join(t.TempDir(), "ci.log")
+	if err := os.WriteFile(path, []byte("build ok\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now()
+	id := strings.TrimSpace(env.mustRun("page", "new", "--project", apitest.DefaultProjectID, "--file", path, "--expires", "7d", "--meta", "--quiet"))
+	page, _ := env.server.Page(id)
+	at, err := time.Parse(time.RFC3339, page.Details.ExpiresAt)
+	if err != nil {
+		t.Fatalf("expires_at = %q: %v", page.Details.ExpiresAt, err)
+	}
+	if d := at.Sub(before); d < 7*24*time.Hour-time.Second || d > 7*24*time.Hour+time.Minute {
+		t.Errorf("expires_at = %s, want 7 days from now", at)
+	}
+	if !strings.Contains(page.Details.Content, "\n"+expiresLine(at)+"\n") {
+		t.Errorf("content = %q, want the expiry in the metadata", page.Details.Content)
+	}
+
+	for _, bad := range []string{"soon", "0s"} {
+		if _, _, err := env.run("page", "new", "--project", apitest.DefaultProjectID, "--file", path, "--expires", bad); err == nil || !strings.Contains(err.Error(), "--expires") {
+			t.Errorf("--expires %s = %v, want an error naming the flag", bad, err)
+		}
+	}
+}
+
+func TestPageGC(t *testing.T) {
+	env := newCLIEnv(t)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	expired := env.server.AddPage(apitest.DefaultProjectID, "old ci log", "")
+	env.server.SetPageDetails(expired.ExternalID, map[string]any{"expires_at": past})
+	fresh := env.server.AddPage(apitest.DefaultProjectID, "new ci log", "")
+	env.server.SetPageDetails(fresh.ExternalID, map[string]any{"expires_at": future})
+	kept := env.server.AddPage(apitest.DefaultProjectID, "notes", "")
+	shared := env.server.AddPage(apitest.DefaultProjectID, "someone else's log", "")
+	env.server.SetPageDetails(shared.ExternalID, map[string]any{"expires_at": past})
+	env.server.SetPageRole(shared.ExternalID, "editor")
+
+	out := env.mustRun("page", "gc", "--dry-run", "--output", "json")
+	var report struct {
+		Expired []expiredPage `json:"expired"`
+		Deleted int           `json:"deleted"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("output %q: %v", out, err)
+	}
+	if len(report.Expired) != 1 || report.Expired[0].ExternalID != expired.ExternalID || report.Deleted != 0 {
+		t.Errorf("dry run = %+v, want only %s expired and nothing deleted", report, expired.ExternalID)
+	}
+
+	if _, _, err := env.run("page", "gc"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("page gc without --force = %v, want it to require --force", err)
+	}
+
+	env.mustRun("page", "gc", "--project", apitest.DefaultProjectID, "--force")
+	if _, ok := env.server.Page(expired.ExternalID); ok {
+		t.Error("expired page not deleted")
+	}
+	for _, p := range []string{fresh.ExternalID, kept.ExternalID, shared.ExternalID} {
+		if _, ok := env.server.Page(p); !ok {
+			t.Errorf("page %s deleted, want only expired pages the user can delete removed", p)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageExportFormat string
+	pageExportOut    string
+)
+
+var pageExportCmd = &cobra.Command{
+	Use:   "export <page-id> [--format html|pdf] [--out <file>]",
+	Short: "Export a page as a standalone HTML or PDF document",
+	Long: `Export a page as a standalone document for sharing outside Hyperclast:
+markdown laid out with headings, lists, and tables, CSV as a table, and
+logs and other text as is.
+
+The server renders the page as in the web app when it can; otherwise the
+CLI renders it. The format defaults to the extension of --out, else HTML.
+Without --out the document is written to stdout, which must be redirected
+for a PDF.
+
+Examples:
+  hyperclast page export page_xyz789 --out report.html
+  hyperclast page export page_xyz789 --format pdf --out report
+  hyperclast page export page_xyz789 --format html | mail -s "Incident" team@example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		format, path, err := pageExportTarget()
+		if err != nil {
+			return err
+		}
+		if path == "" && format == "pdf" && stdoutIsTerminal() {
+			return fmt.Errorf("refusing to print a PDF to a terminal; use --out, or redirect the output to a file")
+		}
+
+		body, err := renderPage(newClient(), args[0], format)
+		if err != nil {
+			return err
+		}
+		if path == "" {
+			_, err = os.Stdout.Write(body)
+			return err
+		}
+		if err := writeFileAtomic(path, body); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"external_id": args[0],
+				"format":      format,
+				"path":        path,
+				"bytes":       len(body),
+			})
+		}
+		if quiet {
+			fmt.Println(path)
+			return nil
+		}
+		printSuccess("Exported page %s to %s (%s)", args[0], path, formatBytes(int64(len(body))))
+		return nil
+	},
+}
+
+// pageExportTarget returns the format and the file to export to, "" for
+// stdout. The format comes from --format, else the extension of --out;
+// an --out without an extension gets the format's.
+func pageExportTarget() (format, path string, err error) {
+	format = strings.ToLower(pageExportFormat)
+	if format != "" && format != "html" && format != "pdf" {
+		return "", "", fmt.Errorf("invalid --format %q: must be html or pdf", pageExportFormat)
+	}
+	if pageExportOut == "" {
+		if outputFmt == "json" {
+			return "", "", fmt.Errorf("--output json requires --out, since the document is written to stdout")
+		}
+		if format == "" {
+			format = "html"
+		}
+		return format, "", nil
+	}
+
+	ext := ""
+	switch strings.ToLower(filepath.Ext(pageExportOut)) {
+	case ".html", ".htm":
+		ext = "html"
+	case ".pdf":
+		ext = "pdf"
+	case "":
+		if format == "" {
+			format = "html"
+		}
+		return format, pageExportOut + "." + format, nil
+	}
+	switch {
+	case format == "" && ext == "":
+		return "", "", fmt.Errorf("cannot tell the format from %s; use --format html or --format pdf", pageExportOut)
+	case format == "":
+		format = ext
+	case ext != "" && ext != format:
+		return "", "", fmt.Errorf("--out %s does not match --format %s", pageExportOut, format)
+	}
+	return format, pageExportOut, nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageExportCmd)
+
+	pageExportCmd.Flags().StringVar(&pageExportFormat, "format", "", "document format: html or pdf (default: from --out, else html)")
+	pageExportCmd.Flags().StringVar(&pageExportOut, "out", "", "write the document to this file instead of stdout")
+}
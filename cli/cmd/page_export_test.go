@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageExport_FormatFromOut(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Incident", "## Timeline\n\n- deploy\n")
+	dir := t.TempDir()
+
+	out := env.mustRun("page", "export", page.ExternalID, "--out", filepath.Join(dir, "report.pdf"), "--output", "json")
+	var result struct {
+		Format string `json:"format"`
+		Path   string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if result.Format != "pdf" {
+		t.Errorf("format = %q, want pdf from the .pdf extension", result.Format)
+	}
+	data, err := os.ReadFile(result.Path)
+	if err != nil || !strings.HasPrefix(string(data), "%PDF-") {
+		t.Errorf("report.pdf = %.20q, %v; want a PDF", data, err)
+	}
+
+	env.mustRun("page", "export", page.ExternalID, "--format", "html", "--out", filepath.Join(dir, "report"))
+	data, err = os.ReadFile(filepath.Join(dir, "report.html"))
+	if err != nil || !strings.Contains(string(data), "<title>Incident</title>") {
+		t.Errorf("report.html = %q, %v; want an HTML document", data, err)
+	}
+}
+
+func TestPageExport_Stdout(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Incident", "deploy <ok>\n")
+
+	out := env.mustRun("page", "export", page.ExternalID)
+	if !strings.HasPrefix(out, "<!DOCTYPE html>") || !strings.Contains(out, "<pre>deploy &lt;ok&gt;\n</pre>") {
+		t.Errorf("export to stdout = %q, want HTML by default", out)
+	}
+}
+
+func TestPageExportTarget(t *testing.T) {
+	defer func() { pageExportFormat, pageExportOut = "", "" }()
+	tests := []struct {
+		format, out          string
+		wantFormat, wantPath string
+		wantErr              string
+	}{
+		{"", "", "html", "", ""},
+		{"PDF", "", "pdf", "", ""},
+		{"", "r.HTM", "html", "r.HTM", ""},
+		{"pdf", "r", "pdf", "r.pdf", ""},
+		{"pdf", "r.html", "", "", "does not match"},
+		{"", "r.txt", "", "", "cannot tell the format"},
+		{"docx", "", "", "", "must be html or pdf"},
+	}
+	for _, tt := range tests {
+		pageExportFormat, pageExportOut = tt.format, tt.out
+		format, path, err := pageExportTarget()
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q %q: err = %v, want %q", tt.format, tt.out, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || format != tt.wantFormat || path != tt.wantPath {
+			t.Errorf("%q %q = %q, %q, %v; want %q, %q", tt.format, tt.out, format, path, err, tt.wantFormat, tt.wantPath)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var pageFiles []string
+
+// extensionFiletypes maps file extensions to the filetype of pages created
+// from them with --files. Other files have their filetype detected.
+var extensionFiletypes = map[string]string{
+	".txt": "txt", ".text": "txt",
+	".md": "md", ".markdown": "md",
+	".csv": "csv", ".tsv": "csv", ".log": "log",
+}
+
+// checkFiles refuses flags that only make sense for a single page.
+func checkFiles() error {
+	if len(pageFiles) == 0 {
+		return nil
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{pageFile != "", "--file"},
+		{pageTitle != "", "--title (each page is titled with its file's name)"},
+		{pageFromGitShow != "", "--from-git-show"},
+		{pageFromURL != "", "--url"},
+		{pageInteractive, "--interactive"},
+		{pageLinkFrom != "", "--link-from"},
+		{pagePreview, "--preview"},
+		{pageNewOpen, "--open"},
+		{pageSkipDuplicate, "--skip-duplicate"},
+		{upsertMode() != "", "--append-if-exists or --overwrite-if-exists"},
+		{pageSplitOn != "", "--split-on"},
+		{csvColumnOpsSet(), "--csv-select, --csv-drop, and --csv-rename"},
+	} {
+		if conflict.set {
+			return fmt.Errorf("--files cannot be combined with %s", conflict.flag)
+		}
+	}
+	return nil
+}
+
+// matchFiles expands the --files patterns into regular files, in order and
+// without duplicates. A pattern that matches no file is an error.
+func matchFiles(patterns []string) ([]string, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --files pattern %q: %w", pattern, err)
+		}
+		sort.Strings(matches)
+		found := false
+		for _, path := range matches {
+			if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			found = true
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("--files %q matched no files", pattern)
+		}
+	}
+	return paths, nil
+}
+
+// fileDrafts reads each file into a page draft titled with its name, or
+// its path where names repeat. Every file is read before anything is
+// created, so one that cannot be read stops the command.
+func fileDrafts(paths []string) ([]pageDraft, error) {
+	names := make(map[string]int)
+	for _, path := range paths {
+		names[filepath.Base(path)]++
+	}
+
+	drafts := make([]pageDraft, len(paths))
+	for i, path := range paths {
+		content, err := readAndValidateFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if pageSubstitute {
+			if content, err = expandPlaceholders(content); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+		title := filepath.Base(path)
+		if names[title] > 1 {
+			title = filepath.ToSlash(path)
+		}
+		drafts[i] = pageDraft{
+			Title:   title,
+			Content: redactContent(filterANSI(content)),
+			Meta:    []string{"File: " + path},
+		}
+		if pageFiletype == autoFiletype {
+			drafts[i].Filetype = extensionFiletypes[strings.ToLower(filepath.Ext(path))]
+		}
+	}
+	return drafts, nil
+}
+
+// runPageNewFiles creates one page per file matched by --files.
+func runPageNewFiles(projectID string, expires time.Time, guard *uploadGuard) error {
+	paths, err := matchFiles(pageFiles)
+	if err != nil {
+		return err
+	}
+	drafts, err := fileDrafts(paths)
+	if err != nil {
+		return err
+	}
+	return createPages(projectID, drafts, "", expires, guard)
+}
+
+func init() {
+	pageNewCmd.Flags().StringArrayVar(&pageFiles, "files", nil, "create one page per file matching this glob, titled with the file's name (repeatable)")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+// writeFiles creates files relative to a temp dir it changes into.
+func writeFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for name, content := range files {
+		_ = os.MkdirAll(filepath.Dir(name), 0755)
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPageNew_Files(t *testing.T) {
+	env := newCLIEnv(t)
+	writeFiles(t, map[string]string{
+		"logs/api.log":      "GET / 200\n",
+		"logs/worker.log":   "job done\n",
+		"logs/notes.md":     "# Notes\n",
+		"old/api.log":       "GET / 500\n",
+		"logs/archive/a.gz": "",
+	})
+
+	out := env.mustRun("page", "new", "--project", apitest.DefaultProjectID, "--files", "logs/*.log", "--files", "logs/*.md", "--output", "json")
+	var results []createdPage
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("output %q: %v", out, err)
+	}
+	var titles []string
+	for _, r := range results {
+		titles = append(titles, r.Title+":"+r.Filetype)
+	}
+	if got := strings.Join(titles, ","); got != "api.log:log,worker.log:log,notes.md:md" {
+		t.Errorf("created %s, want a page per file titled by name, typed by extension", got)
+	}
+	page, _ := env.server.Page(results[0].ExternalID)
+	if page.Details.Content != "GET / 200\n" {
+		t.Errorf("content = %q, want the file's", page.Details.Content)
+	}
+
+	out = env.mustRun("page", "new", "--project", apitest.DefaultProjectID, "--files", "*/api.log", "--quiet")
+	for _, id := range strings.Fields(out) {
+		if p, _ := env.server.Page(id); !strings.HasSuffix(p.Title, "/api.log") {
+			t.Errorf("title = %q, want the path where file names repeat", p.Title)
+		}
+	}
+}
+
+func TestPageNew_FilesErrors(t *testing.T) {
+	env := newCLIEnv(t)
+	writeFiles(t, map[string]string{"a.log": "ok\n", "b.bin": "\x00\x01"})
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--files", "*.txt"}, `--files "*.txt" matched no files`},
+		{[]string{"--files", "*"}, "b.bin: binary data detected"},
+		{[]string{"--files", "*.log", "--title", "Logs"}, "cannot be combined with --title"},
+	}
+	for _, tt := range tests {
+		_, _, err := env.run(append([]string{"page", "new", "--project", apitest.DefaultProjectID}, tt.args...)...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("page new %s = %v, want %q", strings.Join(tt.args, " "), err, tt.want)
+		}
+	}
+	var pages []json.RawMessage
+	_ = json.Unmarshal([]byte(env.mustRun("page", "list", "--project", apitest.DefaultProjectID, "--output", "json")), &pages)
+	if len(pages) != 0 {
+		t.Errorf("%d pages created by failed commands, want none", len(pages))
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/render"
+	"golang.org/x/term"
+)
+
+var (
+	pageGetRender bool
+	pageGetPlain  bool
+)
+
+const (
+	// defaultRenderWidth is the width --render lays markdown out to when
+	// stdout is not a terminal.
+	defaultRenderWidth = 80
+	// maxRenderWidth keeps lines readable on very wide terminals.
+	maxRenderWidth = 120
+)
+
+// checkPageGetRender validates --render and --plain against the other page
+// get flags.
+func checkPageGetRender() error {
+	if pageGetPlain && !pageGetRender {
+		return fmt.Errorf("--plain requires --render")
+	}
+	if !pageGetRender {
+		return nil
+	}
+	if pageGetHTML || pageGetPDF || pageGetFollow || pageGetMetadataOnly || pageGetOut != "" || pageGetDir != "" {
+		return fmt.Errorf("--render cannot be combined with --html, --pdf, --follow, --metadata-only, --out, or --dir")
+	}
+	if outputFmt == "json" {
+		return fmt.Errorf("--render cannot be combined with --output json")
+	}
+	return nil
+}
+
+// renderWidth is the terminal's width, up to maxRenderWidth, or
+// defaultRenderWidth when stdout is not a terminal.
+func renderWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return min(w, maxRenderWidth)
+	}
+	return defaultRenderWidth
+}
+
+// printRenderedMarkdown writes a markdown page's content laid out for the
+// terminal to w, styled when colors are enabled and --plain is not set.
+func printRenderedMarkdown(w io.Writer, page *api.Page, content string) error {
+	if ft := filetypeOf(page); ft != "md" {
+		return fmt.Errorf("--render needs a markdown page; %s is %s", page.ExternalID, ft)
+	}
+	_, err := fmt.Fprint(w, render.Terminal(content, renderWidth(), colorEnabled() && !pageGetPlain))
+	return err
+}
+
+func init() {
+	pageGetCmd.Flags().BoolVar(&pageGetRender, "render", false, "lay a markdown page out for reading in the terminal, wrapped to its width")
+	pageGetCmd.Flags().BoolVar(&pageGetPlain, "plain", false, "with --render, print without colors or other styles")
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageGetOut       string
+	pageGetDir       string
+	pageGetProjectID string
+)
+
+// pageGetDirConcurrency is how many pages 'page get --dir' fetches at once.
+const pageGetDirConcurrency = 4
+
+// checkPageGetOut validates --out, --dir, and --project against the other
+// page get flags and the arguments.
+func checkPageGetOut(args []string) error {
+	if pageGetOut != "" && pageGetDir != "" {
+		return fmt.Errorf("--out cannot be combined with --dir")
+	}
+	if (pageGetOut != "" || pageGetDir != "") && (pageGetFollow || pageGetMetadataOnly) {
+		return fmt.Errorf("--out and --dir cannot be combined with --follow or --metadata-only")
+	}
+	if pageGetDir == "" {
+		if pageGetProjectID != "" {
+			return fmt.Errorf("--project requires --dir")
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("accepts 1 page ID, received %d (use --dir to get several)", len(args))
+		}
+		return nil
+	}
+	if pageGetSection != "" || pageGetHTML || pageGetPDF {
+		return fmt.Errorf("--dir cannot be combined with --section, --html, or --pdf")
+	}
+	if len(args) > 0 && pageGetProjectID != "" {
+		return fmt.Errorf("give page IDs or --project, not both")
+	}
+	return nil
+}
+
+// pageFileExtension is the file extension for a page's content.
+func pageFileExtension(filetype string) string {
+	switch filetype {
+	case "json", "yaml":
+		return filetype
+	default:
+		return exportExtension(filetype)
+	}
+}
+
+// filetypeOf is the page's filetype, "txt" if the server sent none.
+func filetypeOf(page *api.Page) string {
+	if page.Details != nil && page.Details.Filetype != "" {
+		return page.Details.Filetype
+	}
+	if page.Filetype != "" {
+		return page.Filetype
+	}
+	return "txt"
+}
+
+// writePageOut writes a page's content to --out, adding ext to a path that
+// has no extension.
+func writePageOut(pageID string, data []byte, ext string) error {
+	path := pageGetOut
+	if filepath.Ext(path) == "" {
+		path += "." + ext
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"external_id": pageID,
+			"path":        path,
+			"bytes":       len(data),
+		})
+	}
+	printSuccess("Wrote page %s to %s (%s)", pageID, path, formatBytes(int64(len(data))))
+	return nil
+}
+
+// pageGetFile is a page written by 'page get --dir'.
+type pageGetFile struct {
+	ExternalID string `json:"external_id"`
+	Title      string `json:"title"`
+	Path       string `json:"path,omitempty"`
+	Bytes      int    `json:"bytes"`
+	Error      string `json:"error,omitempty"`
+}
+
+// runPageGetDir writes pages to --dir as <page-id>.<ext>: the pages in ids,
+// or else every page of --project or the default project.
+func runPageGetDir(cmd *cobra.Command, client *api.Client, ids []string) error {
+	if len(ids) == 0 {
+		projectID := pageGetProjectID
+		if projectID == "" {
+			projectID = cfg.GetDefaultProject()
+		}
+		if projectID == "" {
+			printError("No pages specified.")
+			printInfo("  Give page IDs, or use --project <id> or set a default: hyperclast project use <id>")
+			cmd.SilenceErrors = true
+			return fmt.Errorf("no pages specified")
+		}
+		pages, err := client.ListPages(projectID)
+		if err != nil {
+			return fmt.Errorf("failed to list pages: %w", err)
+		}
+		for _, p := range pages {
+			ids = append(ids, p.ExternalID)
+		}
+	}
+
+	if err := os.MkdirAll(pageGetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", pageGetDir, err)
+	}
+
+	files := make([]pageGetFile, len(ids))
+	fetching := startProgress("get", len(ids), "pages")
+	failures := forEachConcurrent(len(ids), pageGetDirConcurrency, func(i int) error {
+		files[i].ExternalID = ids[i]
+		err := getPageToDir(client, &files[i])
+		status := "written"
+		if err != nil {
+			status = "failed"
+		}
+		fetching.advance(ids[i], status, err)
+		return err
+	})
+	for i, msg := range failures {
+		files[i].Error = msg
+	}
+
+	if outputFmt == "json" {
+		if err := json.NewEncoder(os.Stdout).Encode(files); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range files {
+			if f.Error != "" {
+				printWarning("page %s: %s", f.ExternalID, f.Error)
+				continue
+			}
+			printInfo("  %s  %s", f.Path, f.Title)
+		}
+		printSuccess("Wrote %d of %d pages to %s", len(files)-len(failures), len(files), pageGetDir)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d pages could not be written", len(failures), len(files))
+	}
+	return nil
+}
+
+// getPageToDir fetches f's page and writes it to --dir, filling in f.
+func getPageToDir(client *api.Client, f *pageGetFile) error {
+	if !isSafeExportName(f.ExternalID) {
+		return fmt.Errorf("unsafe page ID")
+	}
+	page, err := client.GetPage(f.ExternalID)
+	if err != nil {
+		return err
+	}
+	content := pageContent(page)
+	path := filepath.Join(pageGetDir, f.ExternalID+"."+pageFileExtension(filetypeOf(page)))
+	if err := writeFileAtomic(path, []byte(content)); err != nil {
+		return err
+	}
+	f.Title, f.Path, f.Bytes = page.Title, path, len(content)
+	return nil
+}
+
+func init() {
+	pageGetCmd.Flags().StringVar(&pageGetOut, "out", "", "write the content to this file; an extension matching the filetype is added if it has none")
+	pageGetCmd.Flags().StringVar(&pageGetDir, "dir", "", "write the pages given, or those of --project, to this directory as <page-id>.<ext>")
+	pageGetCmd.Flags().StringVar(&pageGetProjectID, "project", "", "with --dir and no page IDs, get every page of this project (default: from config)")
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// newGetOutServer serves a markdown page_md and a CSV page_csv, both in
+// proj_abc, and 404 for anything else.
+func newGetOutServer(t *testing.T) {
+	t.Helper()
+	pages := map[string]api.Page{
+		"page_md":  {ExternalID: "page_md", Title: "Notes", Details: &api.PageDetails{Content: "# Notes\n", Filetype: "md"}},
+		"page_csv": {ExternalID: "page_csv", Title: "Data", Details: &api.PageDetails{Content: "a,b\n1,2\n", Filetype: "csv"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/projects/proj_abc/" {
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: "proj_abc", Pages: []api.Page{pages["page_md"], pages["page_csv"]}})
+			return
+		}
+		page, ok := pages[strings.Trim(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+}
+
+func TestPageGet_OutAddsExtension(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newGetOutServer(t)
+	dir := t.TempDir()
+	quiet = true
+
+	for out, want := range map[string]string{"notes": "notes.md", "notes.txt": "notes.txt"} {
+		pageGetOut = filepath.Join(dir, out)
+		captureArchiveOutput(t, func() error { return pageGetCmd.RunE(pageGetCmd, []string{"page_md"}) })
+		data, err := os.ReadFile(filepath.Join(dir, want))
+		if err != nil || string(data) != "# Notes\n" {
+			t.Errorf("--out %s: %s = %q, %v; want the page content", out, want, data, err)
+		}
+	}
+}
+
+func TestPageGet_Dir(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newGetOutServer(t)
+	quiet = true
+
+	pageGetDir, pageGetProjectID = t.TempDir(), "proj_abc"
+	captureArchiveOutput(t, func() error { return pageGetCmd.RunE(pageGetCmd, nil) })
+	for name, want := range map[string]string{"page_md.md": "# Notes\n", "page_csv.csv": "a,b\n1,2\n"} {
+		if data, err := os.ReadFile(filepath.Join(pageGetDir, name)); err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+
+	pageGetDir, pageGetProjectID = t.TempDir(), ""
+	outputFmt = "json"
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := pageGetCmd.RunE(pageGetCmd, []string{"page_csv", "page_gone"})
+	_ = w.Close()
+	os.Stdout = oldStdout
+	data, _ := io.ReadAll(r)
+	out := string(data)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 pages") {
+		t.Errorf("err = %v, want one failed page", err)
+	}
+	var files []pageGetFile
+	if jerr := json.Unmarshal([]byte(out), &files); jerr != nil || len(files) != 2 || files[0].Bytes != 8 || files[1].Error == "" {
+		t.Errorf("JSON output = %s, want page_csv written and page_gone failed", out)
+	}
+}
+
+func TestCheckPageGetOut(t *testing.T) {
+	defer resetPageFlags()
+	for _, tc := range []struct {
+		name  string
+		args  []string
+		setup func()
+	}{
+		{"several IDs without --dir", []string{"a", "b"}, func() {}},
+		{"--project without --dir", []string{"a"}, func() { pageGetProjectID = "proj" }},
+		{"--out with --follow", []string{"a"}, func() { pageGetOut, pageGetFollow = "x", true }},
+		{"--dir with --section", nil, func() { pageGetDir, pageGetSection = "d", "s" }},
+		{"--dir with IDs and --project", []string{"a"}, func() { pageGetDir, pageGetProjectID = "d", "proj" }},
+	} {
+		resetPageFlags()
+		tc.setup()
+		if err := checkPageGetOut(tc.args); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// streamsPageGet reports whether 'page get' can copy the content to stdout
+// as it arrives. Everything that needs the whole page first (JSON output,
+// --section, --follow, --render, --table, --out, and the terminal guard) buffers it
+// instead.
+func streamsPageGet() bool {
+	return outputFmt != "json" && pageGetSection == "" && !pageGetFollow && !pageGetRender && !pageGetTable &&
+		pageGetOut == "" && !stdoutIsTerminal()
+}
+
+// streamPageGet prints a page's content to stdout as it is received, in
+// constant memory.
+func streamPageGet(client *api.Client, pageID string) error {
+	n, err := client.StreamPageContent(pageID, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	printDebug("Streamed %s of page %s", formatBytes(n), pageID)
+	return nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageGetTable        bool
+	pageGetMaxRows      int
+	pageGetMaxCellWidth int
+)
+
+// checkPageGetTable validates --table, --max-rows, and --max-col-width against
+// the other page get flags.
+func checkPageGetTable(cmd *cobra.Command) error {
+	if !pageGetTable {
+		if cmd.Flags().Changed("max-rows") || cmd.Flags().Changed("max-col-width") {
+			return fmt.Errorf("--max-rows and --max-col-width require --table")
+		}
+		return nil
+	}
+	if pageGetRender {
+		return fmt.Errorf("--table cannot be combined with --render")
+	}
+	if pageGetHTML || pageGetPDF || pageGetFollow || pageGetMetadataOnly || pageGetSection != "" || pageGetOut != "" || pageGetDir != "" {
+		return fmt.Errorf("--table cannot be combined with --html, --pdf, --follow, --metadata-only, --section, --out, or --dir")
+	}
+	if outputFmt == "json" {
+		return fmt.Errorf("--table cannot be combined with --output json")
+	}
+	if pageGetMaxRows < 0 || pageGetMaxCellWidth < 0 {
+		return fmt.Errorf("--max-rows and --max-col-width cannot be negative")
+	}
+	return nil
+}
+
+// printCSVTable writes a CSV page to w as an aligned table: the header and up to
+// --max-rows rows, cells cut to --max-col-width, and numeric columns aligned
+// right. On a terminal the table is also narrowed to fit its width.
+func printCSVTable(w io.Writer, page *api.Page, content string) error {
+	if ft := filetypeOf(page); ft != "csv" {
+		return fmt.Errorf("--table needs a CSV page; %s is %s", page.ExternalID, ft)
+	}
+	records, _, err := parseCSV(content)
+	if err != nil {
+		return err
+	}
+
+	body := records[1:]
+	hidden := 0
+	if pageGetMaxRows > 0 && len(body) > pageGetMaxRows {
+		hidden = len(body) - pageGetMaxRows
+		body = body[:pageGetMaxRows]
+	}
+
+	rows := make([][]string, 0, len(body)+1)
+	for _, record := range append([][]string{records[0]}, body...) {
+		row := make([]string, len(record))
+		for c, value := range record {
+			row[c] = render.Cell(value)
+		}
+		rows = append(rows, row)
+	}
+	opts := render.TableOptions{
+		Right:        numericColumns(body, len(records[0])),
+		MaxCellWidth: pageGetMaxCellWidth,
+		Styled:       colorEnabled(),
+	}
+	if stdoutIsTerminal() {
+		opts.Width = renderWidth()
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(render.Table(rows, opts), "\n")); err != nil {
+		return err
+	}
+	if hidden > 0 {
+		fmt.Fprintf(os.Stderr, "Showing %d of %d rows; use --max-rows 0 to show all\n", len(body), len(records)-1)
+	}
+	return nil
+}
+
+// numericColumns marks the columns whose non-empty values are all numbers.
+func numericColumns(rows [][]string, cols int) []bool {
+	right := make([]bool, cols)
+	for c := range right {
+		seen := false
+		right[c] = true
+		for _, row := range rows {
+			if c >= len(row) || strings.TrimSpace(row[c]) == "" {
+				continue
+			}
+			seen = true
+			if !isNumberValue(strings.TrimSpace(row[c])) {
+				right[c] = false
+				break
+			}
+		}
+		right[c] = right[c] && seen
+	}
+	return right
+}
+
+func init() {
+	pageGetCmd.Flags().BoolVar(&pageGetTable, "table", false, "print a CSV page as an aligned table")
+	pageGetCmd.Flags().IntVar(&pageGetMaxRows, "max-rows", 50, "with --table, show at most this many rows (0 for all)")
+	pageGetCmd.Flags().IntVar(&pageGetMaxCellWidth, "max-col-width", 40, "with --table, cut cells longer than this many characters (0 for no limit)")
+}
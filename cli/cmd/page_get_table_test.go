@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func newCSVPageServer(t *testing.T, filetype, content string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Details:    &api.PageDetails{Content: content, Filetype: filetype},
+		})
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+}
+
+func TestPageGet_Table(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newCSVPageServer(t, "csv", "region,total,note\neu,1200,\"two\nlines\"\nus,85,ok\napac,7,ok\n")
+	pageGetTable = true
+	pageGetMaxRows = 2
+
+	out, err := runPageGetCapture(t)
+	want := "region  total  note\n──────  ─────  ─────────\neu       1200  two lines\nus         85  ok\n"
+	if err != nil || out != want {
+		t.Errorf("got\n%s%v\nwant\n%s", out, err, want)
+	}
+}
+
+func TestPageGet_TableNeedsCSV(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newCSVPageServer(t, "md", "# notes")
+	pageGetTable = true
+
+	if _, err := runPageGetCapture(t); err == nil || !strings.Contains(err.Error(), "needs a CSV page") {
+		t.Errorf("err = %v, want a markdown page refused", err)
+	}
+}
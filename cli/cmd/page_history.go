@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageHistoryLimit   int
+	pageHistoryStat    bool
+	pageHistoryContext int
+)
+
+var pageHistoryCmd = &cobra.Command{
+	Use:   "history <page-id>",
+	Short: "List a page's revisions",
+	Long: `List the revisions the server keeps of a page (its rewind history),
+newest first, with when each was made, who edited it, its size, and the
+lines it changed. Writes made with --on-behalf-of are shown against the
+revision they landed in.
+
+Revision history can be disabled on the server, in which case these
+commands fail with a not-found error.
+
+Examples:
+  hyperclast page history page_xyz789
+  hyperclast page history page_xyz789 --limit 0 --output json
+  hyperclast page history diff page_xyz789 v12 v14`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageHistoryLimit < 0 {
+			return fmt.Errorf("--limit must not be negative")
+		}
+
+		client := newClient()
+		var revisions []api.RevisionSummary
+		total := 0
+		for offset := 0; ; offset += revisionPageSize {
+			batch := revisionPageSize
+			if pageHistoryLimit > 0 {
+				batch = min(batch, pageHistoryLimit-len(revisions))
+			}
+			list, err := client.ListPageRevisions(args[0], batch, offset)
+			if err != nil {
+				return fmt.Errorf("failed to list revisions: %w", err)
+			}
+			revisions = append(revisions, list.Items[:min(len(list.Items), batch)]...)
+			total = list.Count
+			if len(list.Items) < batch || len(revisions) >= total || len(revisions) == pageHistoryLimit {
+				break
+			}
+		}
+		page, err := client.GetPage(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		var writes []api.PageWrite
+		if page.Details != nil {
+			writes = page.Details.Writes
+		}
+
+		entries := attributeWrites(revisions, writes, len(revisions) >= total)
+		if err := writeRevisionList(os.Stdout, entries); err != nil {
+			return err
+		}
+		if len(revisions) < total && !quiet {
+			fmt.Fprintf(os.Stderr, "Showing %d of %d revisions; use --limit 0 to show all\n", len(revisions), total)
+		}
+		return nil
+	},
+}
+
+// revisionPageSize is how many revisions 'page history' requests at a time.
+const revisionPageSize = 100
+
+// revisionEntry is a revision as 'page history' lists it, with who the
+// attributed writes that landed in it were made for.
+type revisionEntry struct {
+	api.RevisionSummary
+	OnBehalfOf []string `json:"on_behalf_of,omitempty"`
+}
+
+// attributeWrites pairs revisions, newest first, with the page's
+// details.writes: a write belongs to the oldest revision created at or
+// after it, and after the revision before that. complete says revisions
+// reach back to the page's first revision; otherwise the oldest one listed
+// gets no writes, since those made before it cannot be told apart.
+func attributeWrites(revisions []api.RevisionSummary, writes []api.PageWrite, complete bool) []revisionEntry {
+	entries := make([]revisionEntry, len(revisions))
+	for i, r := range revisions {
+		entries[i].RevisionSummary = r
+	}
+	for _, w := range writes {
+		at, err := time.Parse(time.RFC3339, w.At)
+		if err != nil || w.OnBehalfOf == "" {
+			continue
+		}
+		for i := len(revisions) - 1; i >= 0; i-- {
+			created, err := time.Parse(time.RFC3339, revisions[i].Created)
+			if err != nil || at.After(created) {
+				continue
+			}
+			if i == len(revisions)-1 && !complete {
+				break
+			}
+			if !slices.Contains(entries[i].OnBehalfOf, w.OnBehalfOf) {
+				entries[i].OnBehalfOf = append(entries[i].OnBehalfOf, w.OnBehalfOf)
+			}
+			break
+		}
+	}
+	return entries
+}
+
+// writeRevisionList writes revisions as JSON or as a table. The table has an
+// ON BEHALF OF column only when some revision has attributed writes.
+func writeRevisionList(out io.Writer, revisions []revisionEntry) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(out).Encode(revisions)
+	}
+	if len(revisions) == 0 {
+		if !quiet {
+			_, _ = fmt.Fprintln(out, "No revisions found")
+		}
+		return nil
+	}
+
+	attributed := slices.ContainsFunc(revisions, func(r revisionEntry) bool { return len(r.OnBehalfOf) > 0 })
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if attributed {
+		_, _ = fmt.Fprintln(w, "REV\tCREATED\tEDITORS\tON BEHALF OF\tSIZE\tCHANGES\tLABEL")
+	} else {
+		_, _ = fmt.Fprintln(w, "REV\tCREATED\tEDITORS\tSIZE\tCHANGES\tLABEL")
+	}
+	for _, r := range revisions {
+		editors := strings.Join(r.Editors, ", ")
+		if editors == "" {
+			editors = "-"
+		}
+		if attributed {
+			onBehalfOf := strings.Join(r.OnBehalfOf, ", ")
+			if onBehalfOf == "" {
+				onBehalfOf = "-"
+			}
+			editors += "\t" + onBehalfOf
+		}
+		label := r.Label
+		if r.Compacted && label == "" {
+			label = fmt.Sprintf("(%d compacted)", r.CompactedFrom)
+		}
+		_, _ = fmt.Fprintf(w, "v%d\t%s\t%s\t%s\t+%d -%d\t%s\n", r.Number, formatMetadataTime(r.Created),
+			editors, formatBytes(r.Bytes), r.LinesAdded, r.LinesDeleted, label)
+	}
+	return w.Flush()
+}
+
+var pageHistoryDiffCmd = &cobra.Command{
+	Use:   "diff <page-id> <rev1> <rev2>",
+	Short: "Compare two revisions of a page",
+	Long: `Print the differences between two revisions of a page, from rev1 to rev2,
+as a unified diff, or with --stat just the lines added and deleted.
+
+A revision is its number (12 or v12), its ID, or "current" for the page as
+it is now.
+
+Examples:
+  hyperclast page history diff page_xyz789 v12 v14
+  hyperclast page history diff page_xyz789 v14 current --stat
+  hyperclast page history diff page_xyz789 v12 v14 --output json | jq .added`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageHistoryContext < 0 {
+			return fmt.Errorf("--context must not be negative")
+		}
+
+		client := newClient()
+		pageID := args[0]
+		from, err := resolveRevision(client, pageID, args[1])
+		if err != nil {
+			return err
+		}
+		to, err := resolveRevision(client, pageID, args[2])
+		if err != nil {
+			return err
+		}
+		return writeRevisionDiff(os.Stdout, pageID, from, to)
+	},
+}
+
+// revisionSide is one side of a revision diff.
+type revisionSide struct {
+	Ref        string `json:"ref"`
+	ExternalID string `json:"external_id,omitempty"`
+	Number     int    `json:"number,omitempty"`
+	Title      string `json:"title"`
+	Label      string `json:"label,omitempty"`
+	Created    string `json:"created,omitempty"`
+	Bytes      int    `json:"bytes"`
+
+	content string
+}
+
+// name identifies the side in diff headers: "v12 (Mar 3, 2026 2:04 PM)".
+func (s *revisionSide) name() string {
+	if s.Number == 0 {
+		return s.Ref
+	}
+	name := fmt.Sprintf("v%d (%s)", s.Number, formatMetadataTime(s.Created))
+	if s.Label != "" {
+		name += " " + s.Label
+	}
+	return name
+}
+
+// source is the side as one side of a diff of pageID.
+func (s *revisionSide) source(pageID string) diffSource {
+	return diffSource{name: pageID + " " + s.name(), side: s, content: s.content}
+}
+
+// resolveRevision fetches the revision ref names: a number, with or
+// without a leading "v", a revision ID, or "current".
+func resolveRevision(client *api.Client, pageID, ref string) (*revisionSide, error) {
+	if ref == "current" {
+		page, err := client.GetPage(pageID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get page: %w", err)
+		}
+		content := pageContent(page)
+		return &revisionSide{Ref: ref, Title: page.Title, Bytes: len(content), content: content}, nil
+	}
+
+	revisionID := ref
+	if n, err := strconv.Atoi(strings.TrimPrefix(ref, "v")); err == nil {
+		summary, err := client.FindRevision(pageID, n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find revision %s: %w", ref, err)
+		}
+		revisionID = summary.ExternalID
+	}
+	revision, err := client.GetRevision(pageID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %s: %w", ref, err)
+	}
+	return &revisionSide{
+		Ref:        ref,
+		ExternalID: revision.ExternalID,
+		Number:     revision.Number,
+		Title:      revision.Title,
+		Label:      revision.Label,
+		Created:    revision.Created,
+		Bytes:      len(revision.Content),
+		content:    revision.Content,
+	}, nil
+}
+
+func writeRevisionDiff(out io.Writer, pageID string, from, to *revisionSide) error {
+	if !pageHistoryStat {
+		return writeDiff(out, from.source(pageID), to.source(pageID), pageHistoryContext, "Revisions are identical", map[string]any{"page_id": pageID})
+	}
+
+	added, deleted := diff.Stat(diff.Lines(from.content, to.content))
+	if outputFmt == "json" {
+		return json.NewEncoder(out).Encode(map[string]any{
+			"page_id":   pageID,
+			"from":      from,
+			"to":        to,
+			"identical": added == 0 && deleted == 0,
+			"added":     added,
+			"deleted":   deleted,
+		})
+	}
+	_, err := fmt.Fprintf(out, "%s → %s: %s, %s (%s → %s)\n", from.name(), to.name(),
+		colorize(ansiGreen, fmt.Sprintf("+%d", added)), colorize(ansiRed, fmt.Sprintf("-%d", deleted)),
+		formatBytes(int64(from.Bytes)), formatBytes(int64(to.Bytes)))
+	return err
+}
+
+func init() {
+	pageCmd.AddCommand(pageHistoryCmd)
+	pageHistoryCmd.AddCommand(pageHistoryDiffCmd)
+
+	pageHistoryCmd.Flags().IntVar(&pageHistoryLimit, "limit", 20, "list at most this many revisions, newest first (0 for all)")
+
+	pageHistoryDiffCmd.Flags().BoolVar(&pageHistoryStat, "stat", false, "print only the number of lines added and deleted")
+	pageHistoryDiffCmd.Flags().IntVar(&pageHistoryContext, "context", 3, "number of unchanged lines to show around each change")
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func resetPageHistoryFlags() {
+	pageHistoryLimit = 20
+	pageHistoryStat = false
+	pageHistoryContext = 3
+	outputFmt = "text"
+	quiet = false
+}
+
+// historyServer serves page_x with revisions v2 and v1 and its current
+// content.
+func historyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	revisions := map[string]api.Revision{
+		"rw_1": {RevisionSummary: api.RevisionSummary{ExternalID: "rw_1", Number: 1, Bytes: 23, Editors: []string{"ana"}, LinesAdded: 2, Created: "2026-03-03T14:04:00Z"}, Content: "replicas: 1\nregion: us\n"},
+		"rw_2": {RevisionSummary: api.RevisionSummary{ExternalID: "rw_2", Number: 2, Bytes: 23, Editors: []string{"ana", "deploy-bot"}, LinesAdded: 1, LinesDeleted: 1, Label: "bot", Created: "2026-03-04T09:30:00Z"}, Content: "replicas: 3\nregion: us\n"},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := r.URL.Path; {
+		case path == "/pages/page_x/":
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_x", Details: &api.PageDetails{
+				Content: "replicas: 3\nregion: eu\n",
+				Writes:  []api.PageWrite{{OnBehalfOf: "deploy-bot/staging", Mode: "overwrite", At: "2026-03-04T09:29:58.5Z", Bytes: 23}},
+			}})
+		case path == "/pages/page_x/rewind/":
+			items := []api.RevisionSummary{revisions["rw_2"].RevisionSummary, revisions["rw_1"].RevisionSummary}
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			start, end := min(offset, len(items)), min(offset+limit, len(items))
+			_ = json.NewEncoder(w).Encode(api.RevisionList{Items: items[start:end], Count: len(items)})
+		case strings.HasPrefix(path, "/pages/page_x/rewind/"):
+			revision, ok := revisions[strings.Trim(strings.TrimPrefix(path, "/pages/page_x/rewind/"), "/")]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(revision)
+		default:
+			t.Errorf("unexpected path %s", path)
+		}
+	}))
+}
+
+func runPageHistoryDiff(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return runPageHistory(t, pageHistoryDiffCmd, args...)
+}
+
+func runPageHistory(t *testing.T, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	server := historyServer(t)
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := cmd.RunE(cmd, args)
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	output, _ := io.ReadAll(r)
+	return string(output), err
+}
+
+func TestPageHistoryDiff_Unified(t *testing.T) {
+	resetPageHistoryFlags()
+
+	output, err := runPageHistoryDiff(t, "page_x", "v1", "rw_2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "--- page_x v1 (Mar 3, 2026 2:04 PM)\n+++ page_x v2 (Mar 4, 2026 9:30 AM) bot\n@@ -1,2 +1,2 @@\n-replicas: 1\n+replicas: 3\n region: us\n"
+	if output != want {
+		t.Errorf("got:\n%s\nwant:\n%s", output, want)
+	}
+}
+
+func TestPageHistoryDiff_StatAgainstCurrent(t *testing.T) {
+	resetPageHistoryFlags()
+	pageHistoryStat = true
+	defer resetPageHistoryFlags()
+
+	output, err := runPageHistoryDiff(t, "page_x", "2", "current")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "v2 (Mar 4, 2026 9:30 AM) bot → current: +1, -1 (23 B → 23 B)\n"
+	if output != want {
+		t.Errorf("got %q, want %q", output, want)
+	}
+}
+
+func TestPageHistoryDiff_JSON(t *testing.T) {
+	resetPageHistoryFlags()
+	outputFmt = "json"
+	defer resetPageHistoryFlags()
+
+	output, err := runPageHistoryDiff(t, "page_x", "v2", "v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		From      revisionSide `json:"from"`
+		Identical bool         `json:"identical"`
+		Hunks     []any        `json:"hunks"`
+	}
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !got.Identical || got.Hunks == nil || len(got.Hunks) != 0 || got.From.ExternalID != "rw_2" || got.From.Number != 2 {
+		t.Errorf("unexpected result: %s", output)
+	}
+}
+
+func TestPageHistoryDiff_MissingRevision(t *testing.T) {
+	resetPageHistoryFlags()
+
+	_, err := runPageHistoryDiff(t, "page_x", "v1", "v5")
+	if err == nil || !strings.Contains(err.Error(), "no revision v5") {
+		t.Errorf("err = %v, want no revision v5", err)
+	}
+}
+
+func TestWriteRevisionDiff_Identical(t *testing.T) {
+	resetPageHistoryFlags()
+	side := &revisionSide{Ref: "current", content: "same\n"}
+
+	var out bytes.Buffer
+	if err := writeRevisionDiff(&out, "page_x", side, side); err != nil || out.Len() != 0 {
+		t.Errorf("got %q, %v; want nothing on stdout", out.String(), err)
+	}
+}
+
+func TestPageHistory_List(t *testing.T) {
+	resetPageHistoryFlags()
+
+	output, err := runPageHistory(t, pageHistoryCmd, "page_x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"REV  CREATED              EDITORS          ON BEHALF OF        SIZE  CHANGES  LABEL",
+		"v2   Mar 4, 2026 9:30 AM  ana, deploy-bot  deploy-bot/staging  23 B  +1 -1    bot",
+		"v1   Mar 3, 2026 2:04 PM  ana              -                   23 B  +2 -0    ",
+		"",
+	}, "\n")
+	if output != want {
+		t.Errorf("got:\n%s\nwant:\n%s", output, want)
+	}
+}
+
+func TestPageHistory_LimitJSON(t *testing.T) {
+	resetPageHistoryFlags()
+	pageHistoryLimit = 1
+	outputFmt = "json"
+	defer resetPageHistoryFlags()
+
+	output, err := runPageHistory(t, pageHistoryCmd, "page_x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []revisionEntry
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got) != 1 || got[0].ExternalID != "rw_2" || got[0].Number != 2 || len(got[0].Editors) != 2 {
+		t.Errorf("got %+v, want only the newest revision", got)
+	}
+	if got[0].OnBehalfOf != nil {
+		t.Errorf("on_behalf_of = %v, want none without the revision before it", got[0].OnBehalfOf)
+	}
+}
+
+func TestAttributeWrites(t *testing.T) {
+	revisions := []api.RevisionSummary{
+		{Number: 3, Created: "2026-03-05T10:00:00.300Z"},
+		{Number: 2, Created: "2026-03-05T10:00:00.100Z"},
+		{Number: 1, Created: "2026-03-04T09:00:00Z"},
+	}
+	writes := []api.PageWrite{
+		{OnBehalfOf: "etl", At: "2026-03-04T08:59:00Z"},
+		{OnBehalfOf: "etl", At: "2026-03-05T10:00:00.05Z"},
+		{OnBehalfOf: "deploy-bot", At: "2026-03-05T10:00:00.2Z"},
+		{OnBehalfOf: "deploy-bot", At: "2026-03-05T10:00:00.25Z"},
+		{OnBehalfOf: "late", At: "2026-03-05T11:00:00Z"},
+	}
+
+	got := attributeWrites(revisions, writes, true)
+	for i, want := range [][]string{{"deploy-bot"}, {"etl"}, {"etl"}} {
+		if !slices.Equal(got[i].OnBehalfOf, want) {
+			t.Errorf("v%d on behalf of %v, want %v", got[i].Number, got[i].OnBehalfOf, want)
+		}
+	}
+
+	got = attributeWrites(revisions, writes, false)
+	if got[2].OnBehalfOf != nil {
+		t.Errorf("oldest of an incomplete list on behalf of %v, want none", got[2].OnBehalfOf)
+	}
+}
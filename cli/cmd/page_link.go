@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var pageLinkFrom string
+
+// pageURL is the web address of a page.
+func pageURL(pageID string) string {
+	return fmt.Sprintf("%s/pages/%s/", baseURL(), pageID)
+}
+
+// relatedLine is the metadata and backlink line pointing at pageID.
+func relatedLine(pageID string) string {
+	return "Related: " + pageURL(pageID)
+}
+
+// fetchLinkSource gets the page named by --link-from, checking it can take
+// a backlink before anything is created.
+func fetchLinkSource(client *api.Client, pageID string) (*api.Page, error) {
+	page, err := client.GetPage(pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get --link-from page: %w", err)
+	}
+	if !page.CanEdit() {
+		return nil, &api.PermissionError{Action: "link from page", Role: page.Role, Needs: "editor"}
+	}
+	if err := checkPageLock(client, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// linkFromPage appends a backlink to page on from and records page among
+// from's related pages. The new page already exists by now, so a failure
+// is only a warning.
+func linkFromPage(client *api.Client, from *api.Page, page *api.Page) {
+	line := relatedLine(page.ExternalID) + "\n"
+	if from.Details != nil && from.Details.Content != "" && !strings.HasSuffix(from.Details.Content, "\n") {
+		line = "\n" + line
+	}
+	if _, err := client.AppendRelated(from, line, page.ExternalID); err != nil {
+		printWarning("Created the page, but failed to link it from %s: %v", from.ExternalID, err)
+		return
+	}
+	printDebug("Linked from %s", from.ExternalID)
+}
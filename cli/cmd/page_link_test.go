@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestPageNew_LinkFrom(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	var created api.CreatePageRequest
+	var backlink api.UpdatePageContentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(api.Page{
+				ExternalID: "page_incident",
+				Title:      "Incident",
+				Role:       "editor",
+				Details:    &api.PageDetails{Content: "timeline", Filetype: "md", Related: []string{"page_old"}},
+			})
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_logs", Title: created.Title})
+		case http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&backlink)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_incident", Title: "Incident"})
+		}
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL + "/api", Token: "test-token"}
+
+	pageFile = filepath.Join(t.TempDir(), "api.log")
+	_ = os.WriteFile(pageFile, []byte("panic: nil map\n"), 0644)
+	pageProjectID = "proj_ops"
+	pageLinkFrom = "page_incident"
+	pageMeta = true
+	quiet = true
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err := pageNewCmd.RunE(pageNewCmd, nil)
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := created.Details.Related; len(got) != 1 || got[0] != "page_incident" {
+		t.Errorf("new page related = %v, want [page_incident]", got)
+	}
+	if !strings.Contains(created.Details.Content, "Related: "+server.URL+"/pages/page_incident/\n") {
+		t.Errorf("backmatter missing relation:\n%s", created.Details.Content)
+	}
+	if backlink.Mode != "append" || backlink.Details.Content != "\nRelated: "+server.URL+"/pages/page_logs/\n" {
+		t.Errorf("backlink = %+v", backlink)
+	}
+	if got := backlink.Details.Related; strings.Join(got, ",") != "page_old,page_logs" || backlink.Details.Filetype != "md" {
+		t.Errorf("backlink details = %+v", backlink.Details)
+	}
+}
+
+func TestPageNew_LinkFromNeedsEditor(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	created := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			created = true
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_incident", Title: "Incident", Role: "viewer"})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL + "/api", Token: "test-token"}
+
+	pageFile = filepath.Join(t.TempDir(), "api.log")
+	_ = os.WriteFile(pageFile, []byte("panic\n"), 0644)
+	pageProjectID = "proj_ops"
+	pageLinkFrom = "page_incident"
+
+	err := pageNewCmd.RunE(pageNewCmd, nil)
+	if err == nil || created {
+		t.Errorf("err = %v, created = %v; want failure before creating", err, created)
+	}
+}
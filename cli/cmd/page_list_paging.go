@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var (
+	pageListLimit  int
+	pageListPage   int
+	pageListCursor string
+	pageListAll    bool
+)
+
+// checkPageListPaging validates --limit, --page, --cursor, and --all.
+func checkPageListPaging() error {
+	if pageListLimit < 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+	if pageListPage < 0 {
+		return fmt.Errorf("--page must be positive")
+	}
+	windowed := pageListPage > 0 || pageListCursor != ""
+	switch {
+	case windowed && pageListLimit == 0:
+		return fmt.Errorf("--page and --cursor require --limit")
+	case pageListPage > 0 && pageListCursor != "":
+		return fmt.Errorf("--page cannot be combined with --cursor")
+	case windowed && pageListAll:
+		return fmt.Errorf("--all cannot be combined with --page or --cursor")
+	case pageListFollow && pageListLimit > 0 && !pageListAll:
+		return fmt.Errorf("--follow lists every page; --limit cannot be combined with it unless --all is set")
+	}
+	if _, err := pageListOffset(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// pageListOffset returns the offset of the first page --page or --cursor
+// asks for.
+func pageListOffset() (int, error) {
+	if pageListPage > 0 {
+		return (pageListPage - 1) * pageListLimit, nil
+	}
+	if pageListCursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(pageListCursor)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid --cursor %q: use the cursor printed by a previous 'page list --limit'", pageListCursor)
+	}
+	return offset, nil
+}
+
+// listPagesForList lists the pages 'page list' shows, split like
+// listPagesByArchive. With --limit and without --all it fetches only the
+// window asked for, and returns the cursor of the next one, or "" if it was
+// the last.
+func listPagesForList(client *api.Client, projectID string) (active, archived []api.Page, next string, err error) {
+	if pageListLimit == 0 || (pageListAll && projectID != "") {
+		active, archived, err = listPagesByArchive(client, projectID)
+		return active, archived, "", err
+	}
+
+	if pageListAll {
+		var pages []api.Page
+		for page, err := range client.AllPages("", pageListLimit) {
+			if err != nil {
+				return nil, nil, "", err
+			}
+			pages = append(pages, page)
+		}
+		active, archived, err = splitArchivedPages(client, "", pages, make(map[string]string))
+		return active, archived, "", err
+	}
+
+	offset, err := pageListOffset()
+	if err != nil {
+		return nil, nil, "", err
+	}
+	list, err := client.ListPagesRange(projectID, pageListLimit, offset)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if end := offset + len(list.Items); len(list.Items) > 0 && end < list.Count {
+		next = strconv.Itoa(end)
+	}
+	active, archived, err = splitArchivedPages(client, projectID, list.Items, make(map[string]string))
+	return active, archived, next, err
+}
+
+// printNextCursor tells the user how to list the window after this one.
+// It goes to stderr so that --format and --output json stay parseable.
+func printNextCursor(next string) {
+	if next != "" && !quiet {
+		fmt.Fprintf(os.Stderr, "More pages: use --limit %d --cursor %s for the next %d\n", pageListLimit, next, pageListLimit)
+	}
+}
+
+func init() {
+	pageListCmd.Flags().IntVar(&pageListLimit, "limit", 0, "list at most this many pages; with --all, fetch them this many at a time")
+	pageListCmd.Flags().IntVar(&pageListPage, "page", 0, "with --limit, list this window of results, counting from 1")
+	pageListCmd.Flags().StringVar(&pageListCursor, "cursor", "", "with --limit, continue from the cursor printed by a previous listing")
+	pageListCmd.Flags().BoolVar(&pageListAll, "all", false, "list every page, fetching --limit at a time (the default without --limit)")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// newPagingServer serves five pages across projects, paginated like the
+// server's /pages/ endpoint, and counts the requests made.
+func newPagingServer(t *testing.T) *int {
+	t.Helper()
+	requests := new(int)
+	var pages []api.Page
+	for i := 1; i <= 5; i++ {
+		pages = append(pages, api.Page{ExternalID: "page_" + strconv.Itoa(i), Title: "Page " + strconv.Itoa(i)})
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		start, end := min(offset, len(pages)), min(offset+limit, len(pages))
+		_ = json.NewEncoder(w).Encode(api.PageList{Items: pages[start:end], Count: len(pages)})
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	return requests
+}
+
+// runPageList runs 'page list --output json' and returns the listed page
+// IDs and what was written to stderr.
+func runPageList(t *testing.T) (ids, stderr string) {
+	t.Helper()
+	outputFmt = "json"
+	errR, errW, _ := os.Pipe()
+	oldStderr := os.Stderr
+	os.Stderr = errW
+	out := captureArchiveOutput(t, func() error { return pageListCmd.RunE(pageListCmd, nil) })
+	_ = errW.Close()
+	os.Stderr = oldStderr
+	errOut, _ := io.ReadAll(errR)
+
+	var pages []api.Page
+	if err := json.Unmarshal([]byte(out), &pages); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out, err)
+	}
+	var got []string
+	for _, p := range pages {
+		got = append(got, p.ExternalID)
+	}
+	return strings.Join(got, ","), string(errOut)
+}
+
+func TestPageList_LimitAndCursor(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newPagingServer(t)
+
+	pageListLimit = 2
+	ids, stderr := runPageList(t)
+	if ids != "page_1,page_2" || !strings.Contains(stderr, "--cursor 2") {
+		t.Errorf("first window: pages %s, stderr %q; want page_1,page_2 and a cursor", ids, stderr)
+	}
+
+	pageListCursor = "4"
+	ids, stderr = runPageList(t)
+	if ids != "page_5" || stderr != "" {
+		t.Errorf("last window: pages %s, stderr %q; want page_5 and no cursor", ids, stderr)
+	}
+
+	pageListCursor, pageListPage = "", 2
+	if ids, _ = runPageList(t); ids != "page_3,page_4" {
+		t.Errorf("--page 2: pages %s, want page_3,page_4", ids)
+	}
+}
+
+func TestPageList_AllInBatches(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	requests := newPagingServer(t)
+
+	pageListLimit, pageListAll = 2, true
+	ids, _ := runPageList(t)
+	if ids != "page_1,page_2,page_3,page_4,page_5" || *requests != 3 {
+		t.Errorf("pages %s in %d requests, want all five in 3", ids, *requests)
+	}
+}
+
+func TestCheckPageListPaging(t *testing.T) {
+	defer resetPageFlags()
+	for _, tc := range []struct {
+		name  string
+		setup func()
+	}{
+		{"page without limit", func() { pageListPage = 2 }},
+		{"page and cursor", func() { pageListLimit, pageListPage, pageListCursor = 2, 2, "4" }},
+		{"all with cursor", func() { pageListLimit, pageListAll, pageListCursor = 2, true, "4" }},
+		{"bad cursor", func() { pageListLimit, pageListCursor = 2, "next" }},
+		{"follow with window", func() { pageListLimit, pageListFollow = 2, true }},
+	} {
+		resetPageFlags()
+		tc.setup()
+		if err := checkPageListPaging(); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
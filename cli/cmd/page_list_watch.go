@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var (
+	pageListUpdatedWithin time.Duration
+	pageListFollow        bool
+	pageListInterval      time.Duration
+	pageListFormat        string
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+func checkPageListFlags() error {
+	if pageListUpdatedWithin < 0 {
+		return fmt.Errorf("--updated-within must be positive")
+	}
+	if pageListFollow && pageListInterval < minFollowInterval {
+		return fmt.Errorf("--interval must be at least %s", minFollowInterval)
+	}
+	if pageListFormat != "" {
+		if outputFmt == "json" {
+			return fmt.Errorf("--format cannot be combined with --output json")
+		}
+		if _, err := parsePageListFormat(pageListFormat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pageUpdated returns the page's last update time as sent by the server.
+func pageUpdated(page *api.Page) string {
+	if page.Updated != "" {
+		return page.Updated
+	}
+	return page.Modified
+}
+
+// pagesUpdatedWithin keeps pages updated at or after since, most recent
+// first. Pages without a parsable update time are dropped.
+func pagesUpdatedWithin(pages []api.Page, since time.Time) []api.Page {
+	type dated struct {
+		page api.Page
+		at   time.Time
+	}
+	var recent []dated
+	for _, p := range pages {
+		at, err := time.Parse(time.RFC3339, pageUpdated(&p))
+		if err == nil && !at.Before(since) {
+			recent = append(recent, dated{p, at})
+		}
+	}
+	slices.SortStableFunc(recent, func(a, b dated) int { return b.at.Compare(a.at) })
+
+	out := make([]api.Page, len(recent))
+	for i, d := range recent {
+		out[i] = d.page
+	}
+	return out
+}
+
+// pageListFuncs are the functions available to --format templates.
+var pageListFuncs = template.FuncMap{
+	// ago turns an RFC 3339 timestamp into a relative time like "5m ago".
+	"ago": func(s string) string {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return s
+		}
+		return formatAgo(time.Since(t))
+	},
+	// local formats an RFC 3339 timestamp in local time with a Go layout.
+	"local": func(layout, s string) string {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return s
+		}
+		return t.Local().Format(layout)
+	},
+}
+
+func parsePageListFormat(format string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(pageListFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format: %w", err)
+	}
+	return tmpl, nil
+}
+
+// formatPageList writes one line per page from a Go template over the page,
+// e.g. '{{.ExternalID}} {{.Title}} {{.Updated}}'.
+func formatPageList(out io.Writer, pages []api.Page, format string) error {
+	tmpl, err := parsePageListFormat(format)
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		if page.Updated == "" {
+			page.Updated = page.Modified
+		}
+		var line bytes.Buffer
+		if err := tmpl.Execute(&line, page); err != nil {
+			return fmt.Errorf("invalid --format: %w", err)
+		}
+		if _, err := fmt.Fprintln(out, strings.TrimRight(line.String(), "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatAgo renders a duration as a short relative time.
+func formatAgo(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// followPageList re-lists pages every --interval until Ctrl-C. On a terminal
+// the screen is redrawn each time, like watch(1); otherwise a listing is
+// printed only when it changes.
+func followPageList(client *api.Client, projectID string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	redraw := stdoutIsTerminal() && outputFmt != "json"
+	ticker := time.NewTicker(pageListInterval)
+	defer ticker.Stop()
+
+	var prev string
+	for {
+		pages, _, err := fetchPageList(client, projectID)
+		if err != nil {
+			printWarning("%v (retrying)", err)
+		} else {
+			var buf bytes.Buffer
+			if err := renderPageList(&buf, pages); err != nil {
+				return err
+			}
+			if redraw {
+				fmt.Print(clearScreen + pageListHeader() + "\n\n" + buf.String())
+			} else if buf.String() != prev {
+				fmt.Print(buf.String())
+			}
+			prev = buf.String()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pageListHeader describes a live listing, like watch(1)'s title line.
+func pageListHeader() string {
+	header := fmt.Sprintf("Every %s: hyperclast page list", pageListInterval)
+	if pageListUpdatedWithin > 0 {
+		header += fmt.Sprintf(" --updated-within %s", pageListUpdatedWithin)
+	}
+	return header + "    " + time.Now().Format("15:04:05")
+}
+
+func init() {
+	pageListCmd.Flags().DurationVar(&pageListUpdatedWithin, "updated-within", 0, "only pages updated within this long, most recent first, e.g. 1h")
+	pageListCmd.Flags().BoolVar(&pageListFollow, "follow", false, "keep refreshing the list until Ctrl-C")
+	pageListCmd.Flags().DurationVar(&pageListInterval, "interval", 5*time.Second, "refresh interval for --follow")
+	pageListCmd.Flags().StringVar(&pageListFormat, "format", "", "print each page with a Go template, e.g. '{{.Title}} {{.Updated | ago}}'")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestPagesUpdatedWithin(t *testing.T) {
+	now := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	pages := []api.Page{
+		{ExternalID: "page_old", Updated: now.Add(-3 * time.Hour).Format(time.RFC3339)},
+		{ExternalID: "page_a", Updated: now.Add(-50 * time.Minute).Format(time.RFC3339)},
+		{ExternalID: "page_b", Modified: now.Add(-5 * time.Minute).Format(time.RFC3339)},
+		{ExternalID: "page_undated"},
+	}
+
+	got := pagesUpdatedWithin(pages, now.Add(-time.Hour))
+	if len(got) != 2 || got[0].ExternalID != "page_b" || got[1].ExternalID != "page_a" {
+		t.Errorf("got %+v, want page_b then page_a", got)
+	}
+}
+
+func TestFormatPageList(t *testing.T) {
+	pages := []api.Page{
+		{ExternalID: "page_a", Title: "Deploy", Modified: time.Now().Add(-90 * time.Minute).UTC().Format(time.RFC3339)},
+	}
+	var buf bytes.Buffer
+	if err := formatPageList(&buf, pages, "{{.ExternalID}} {{.Title}} {{.Updated | ago}}"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "page_a Deploy 1h ago\n" {
+		t.Errorf("got %q", got)
+	}
+
+	if err := formatPageList(&buf, pages, "{{.Nope}}"); err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Errorf("err = %v, want invalid --format", err)
+	}
+}
+
+func TestPageList_UpdatedWithinFormat(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	recent := time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+	stale := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": []api.Page{
+			{ExternalID: "page_stale", Title: "Stale", Updated: stale},
+			{ExternalID: "page_recent", Title: "Recent", Updated: recent},
+		}})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageListUpdatedWithin = time.Hour
+	pageListFormat = "{{.Title}} {{.Updated}}"
+
+	r, w, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = w
+	err := pageListCmd.RunE(pageListCmd, nil)
+	_ = w.Close()
+	os.Stdout = stdout
+	out, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != "Recent "+recent+"\n" {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestPageList_FormatRejectsJSON(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	cfg = &config.Config{APIURL: "http://127.0.0.1:0", Token: "test-token"}
+	pageListFormat = "{{.Title}}"
+	outputFmt = "json"
+
+	if err := pageListCmd.RunE(pageListCmd, nil); err == nil || !strings.Contains(err.Error(), "--format") {
+		t.Errorf("err = %v, want --format conflict", err)
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageLockReason string
+	pageLockSteal  bool
+)
+
+var pageLockCmd = &cobra.Command{
+	Use:   "lock <page-id>",
+	Short: "Lock a page against writes by others",
+	Long: `Lock a page so that other users' writes (append, prepend, overwrite,
+edit, restore, mux, and capture --page) are refused with an error naming
+you, until you run 'page unlock'. Your own writes are unaffected.
+
+A page locked by someone else can be taken over with --steal, which
+requires admin access to the page.
+
+Examples:
+  hyperclast page lock page_xyz789 --reason "deploy in progress"
+  hyperclast page lock page_xyz789 --steal`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		client := newClient()
+		page, me, err := fetchLockedPage(client, args[0])
+		if err != nil {
+			return err
+		}
+
+		lock := api.PageLock{UserID: me.ExternalID, Email: me.Email, At: time.Now().UTC().Format(time.RFC3339), Reason: pageLockReason}
+		previous := pageLock(page)
+		if previous != nil && previous.UserID == me.ExternalID && !cmd.Flags().Changed("reason") {
+			return printLockResult(page, previous, false, "Page \"%s\" (%s) is already locked by you", page.Title, page.ExternalID)
+		}
+		if err := checkSteal(page, previous, me); err != nil {
+			return err
+		}
+
+		if page, err = client.SetPageDetails(page.ExternalID, map[string]any{"lock": lock}); err != nil {
+			return fmt.Errorf("failed to lock page: %w", err)
+		}
+		if previous != nil && previous.UserID != me.ExternalID {
+			printWarning("Took over the lock held by %s since %s", previous.Email, formatMetadataTime(previous.At))
+		}
+		return printLockResult(page, &lock, true, "Locked page \"%s\" (%s)", page.Title, page.ExternalID)
+	},
+}
+
+var pageUnlockCmd = &cobra.Command{
+	Use:   "unlock <page-id>",
+	Short: "Release a page's lock",
+	Long: `Release the lock on a page. A lock held by someone else can only be
+released with --steal, which requires admin access to the page.
+
+Examples:
+  hyperclast page unlock page_xyz789`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		client := newClient()
+		page, me, err := fetchLockedPage(client, args[0])
+		if err != nil {
+			return err
+		}
+
+		previous := pageLock(page)
+		if previous == nil {
+			return printLockResult(page, nil, false, "Page \"%s\" (%s) is not locked", page.Title, page.ExternalID)
+		}
+		if err := checkSteal(page, previous, me); err != nil {
+			return err
+		}
+
+		if page, err = client.SetPageDetails(page.ExternalID, map[string]any{"lock": nil}); err != nil {
+			return fmt.Errorf("failed to unlock page: %w", err)
+		}
+		if previous.UserID != me.ExternalID {
+			printWarning("Released the lock held by %s since %s", previous.Email, formatMetadataTime(previous.At))
+		}
+		return printLockResult(page, nil, true, "Unlocked page \"%s\" (%s)", page.Title, page.ExternalID)
+	},
+}
+
+// fetchLockedPage gets a page's metadata and the current user, who the
+// lock is checked against.
+func fetchLockedPage(client *api.Client, pageID string) (*api.Page, *api.User, error) {
+	page, err := client.GetPageMetadata(pageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	me, err := client.GetCurrentUser()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	return page, me, nil
+}
+
+// checkSteal allows changing a lock held by someone else only with --steal
+// and admin access to the page.
+func checkSteal(page *api.Page, lock *api.PageLock, me *api.User) error {
+	if lock == nil || lock.UserID == me.ExternalID {
+		return nil
+	}
+	if !pageLockSteal {
+		return fmt.Errorf("%w since %s; use --steal to take it over (admins only)",
+			&api.LockedError{PageID: page.ExternalID, Lock: *lock}, formatMetadataTime(lock.At))
+	}
+	if page.Role != api.RoleAdmin {
+		return &api.PermissionError{Action: "steal the lock", Role: page.Role, Needs: "admin"}
+	}
+	return nil
+}
+
+func pageLock(page *api.Page) *api.PageLock {
+	if page.Details == nil {
+		return nil
+	}
+	return page.Details.Lock
+}
+
+func printLockResult(page *api.Page, lock *api.PageLock, changed bool, format string, a ...any) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"page_id": page.ExternalID,
+			"title":   page.Title,
+			"lock":    lock,
+			"changed": changed,
+		})
+	}
+	if quiet {
+		fmt.Println(page.ExternalID)
+		return nil
+	}
+	if changed {
+		printSuccess(format, a...)
+	} else {
+		printInfo(format, a...)
+	}
+	return nil
+}
+
+// checkPageLock refuses a write to page when another user holds its lock.
+// The current user is only looked up for locked pages, so writes to
+// unlocked pages cost no extra request.
+func checkPageLock(client *api.Client, page *api.Page) error {
+	lock := pageLock(page)
+	if lock == nil {
+		return nil
+	}
+	me, err := client.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("page %s is locked, and checking by whom failed: %w", page.ExternalID, err)
+	}
+	if lock.UserID == me.ExternalID {
+		return nil
+	}
+	return fmt.Errorf("%w since %s; ask them to run 'hyperclast page unlock %s', or take the lock with 'hyperclast page lock %s --steal' (admins only)",
+		&api.LockedError{PageID: page.ExternalID, Lock: *lock}, formatMetadataTime(lock.At), page.ExternalID, page.ExternalID)
+}
+
+func init() {
+	pageCmd.AddCommand(pageLockCmd)
+	pageCmd.AddCommand(pageUnlockCmd)
+
+	pageLockCmd.Flags().StringVar(&pageLockReason, "reason", "", "why the page is locked, shown to others whose writes are refused")
+	for _, c := range []*cobra.Command{pageLockCmd, pageUnlockCmd} {
+		c.Flags().BoolVar(&pageLockSteal, "steal", false, "take over or release a lock held by someone else (requires admin access)")
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+// lockByOther locks page as a user other than the sandbox user.
+func lockByOther(env *cliEnv, pageID string) {
+	env.server.SetPageDetails(pageID, map[string]any{
+		"lock": api.PageLock{UserID: "user_other", Email: "ops@sandbox.local", At: "2026-10-01T12:00:00Z", Reason: "deploy"},
+	})
+}
+
+func TestPageLock_OwnLockAllowsWrites(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Deploy log", "start\n")
+	file := filepath.Join(t.TempDir(), "line.txt")
+	_ = os.WriteFile(file, []byte("more\n"), 0600)
+
+	out := env.mustRun("page", "lock", page.ExternalID, "--reason", "deploy", "--output", "json")
+	var result struct {
+		Lock    *api.PageLock `json:"lock"`
+		Changed bool          `json:"changed"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if !result.Changed || result.Lock == nil || result.Lock.UserID != apitest.UserID || result.Lock.Reason != "deploy" {
+		t.Errorf("lock result = %+v, want changed and held by %s", result, apitest.UserID)
+	}
+
+	env.mustRun("page", "append", page.ExternalID, "--file", file)
+	env.mustRun("page", "unlock", page.ExternalID)
+
+	got, _ := env.server.Page(page.ExternalID)
+	if got.Details.Lock != nil || got.Details.Content != "start\nmore\n" {
+		t.Errorf("details = %+v, want unlocked with the append applied", got.Details)
+	}
+}
+
+func TestPageLock_RefusesWritesByOthers(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Deploy log", "start\n")
+	lockByOther(env, page.ExternalID)
+	file := filepath.Join(t.TempDir(), "line.txt")
+	_ = os.WriteFile(file, []byte("more\n"), 0600)
+
+	for _, args := range [][]string{
+		{"page", "append", page.ExternalID, "--file", file},
+		{"page", "overwrite", page.ExternalID, "--file", file},
+		{"page", "unlock", page.ExternalID},
+		{"page", "lock", page.ExternalID},
+	} {
+		_, _, err := env.run(args...)
+		if err == nil || !strings.Contains(err.Error(), "locked by ops@sandbox.local (deploy)") {
+			t.Errorf("%s = %v, want locked error", strings.Join(args[:2], " "), err)
+		}
+	}
+
+	got, _ := env.server.Page(page.ExternalID)
+	if got.Details.Content != "start\n" {
+		t.Errorf("content = %q, want it untouched", got.Details.Content)
+	}
+}
+
+func TestPageLock_Steal(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Deploy log", "")
+	lockByOther(env, page.ExternalID)
+
+	_, stderr, err := env.run("page", "lock", page.ExternalID, "--steal")
+	if err != nil {
+		t.Fatalf("lock --steal: %v", err)
+	}
+	if !strings.Contains(stderr, "ops@sandbox.local") {
+		t.Errorf("stderr = %q, want a warning naming the previous holder", stderr)
+	}
+	got, _ := env.server.Page(page.ExternalID)
+	if got.Details.Lock == nil || got.Details.Lock.UserID != apitest.UserID {
+		t.Errorf("lock = %+v, want held by %s", got.Details.Lock, apitest.UserID)
+	}
+}
+
+func TestPageLock_StealNeedsAdmin(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Deploy log", "")
+	lockByOther(env, page.ExternalID)
+	env.server.SetPageRole(page.ExternalID, api.RoleEditor)
+
+	_, _, err := env.run("page", "unlock", page.ExternalID, "--steal")
+	if err == nil || !strings.Contains(err.Error(), "steal the lock") {
+		t.Fatalf("unlock --steal as editor = %v, want permission error", err)
+	}
+	if got, _ := env.server.Page(page.ExternalID); got.Details.Lock == nil {
+		t.Error("lock was released by a non-admin")
+	}
+}
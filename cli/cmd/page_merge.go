@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var pageMergeTrashSources bool
+
+var pageMergeCmd = &cobra.Command{
+	Use:   "merge <target-id> <source-id>...",
+	Short: "Append several pages into one",
+	Long: `Append the content of source pages to a target page, in the order given,
+to consolidate captures fragmented across pages. Each source is wrapped in
+a section named after its title, so it can be read back with
+'page get --section'.
+
+Every source is fetched before the target is written, so a missing source
+changes nothing. With --trash-sources, the sources are moved to the trash
+once the target is written; 'page restore-trashed' brings them back.
+
+Examples:
+  hyperclast page merge page_incident page_logs_1 page_logs_2 page_logs_3
+  hyperclast page merge page_incident page_logs_1 page_logs_2 --trash-sources
+  hyperclast page get page_incident --section "api logs"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		targetID := args[0]
+		var sourceIDs []string
+		for _, id := range args[1:] {
+			if id == targetID {
+				return fmt.Errorf("page %s cannot be merged into itself", id)
+			}
+			if !slices.Contains(sourceIDs, id) {
+				sourceIDs = append(sourceIDs, id)
+			}
+		}
+
+		client := newClient()
+		target, err := client.GetPage(targetID)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if filetypeOf(target) == "csv" {
+			return fmt.Errorf("page %s is a CSV page; merge CSV pages with 'hyperclast page csv-union' instead", targetID)
+		}
+		sources := make([]*api.Page, len(sourceIDs))
+		for i, id := range sourceIDs {
+			if sources[i], err = client.GetPage(id); err != nil {
+				return fmt.Errorf("failed to get page %s: %w", id, err)
+			}
+		}
+
+		content := mergedSections(pageContent(target), sources)
+		_, page, err := updatePage(targetID, content, "append")
+		if err != nil {
+			return handleContentError(err)
+		}
+
+		var trashed []string
+		var trashErrs []string
+		if pageMergeTrashSources {
+			for _, source := range sources {
+				if _, err := trashPage(client, source.ExternalID); err != nil {
+					trashErrs = append(trashErrs, err.Error())
+					continue
+				}
+				trashed = append(trashed, source.ExternalID)
+			}
+		}
+
+		if outputFmt == "json" {
+			if trashed == nil {
+				trashed = []string{}
+			}
+			if err := json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"external_id": page.ExternalID,
+				"title":       page.Title,
+				"merged":      sourceIDs,
+				"trashed":     trashed,
+			}); err != nil {
+				return err
+			}
+		} else if quiet {
+			fmt.Println(page.ExternalID)
+		} else {
+			merged := fmt.Sprintf("%d pages", len(sources))
+			if len(sources) == 1 {
+				merged = "1 page"
+			}
+			printSuccess("Merged %s into \"%s\" (%s)", merged, page.Title, page.ExternalID)
+			printInfo("  %s", pageURL(page.ExternalID))
+			if len(trashed) > 0 {
+				printInfo("  Moved %s to the trash; undo with 'hyperclast page restore-trashed %s'", strings.Join(trashed, ", "), strings.Join(trashed, " "))
+			}
+		}
+
+		for _, msg := range trashErrs {
+			printWarning("%s", msg)
+		}
+		if len(trashErrs) > 0 {
+			return fmt.Errorf("%d of %d sources could not be trashed", len(trashErrs), len(sources))
+		}
+		return nil
+	},
+}
+
+// mergedSections is what page merge appends to a page with content existing:
+// each source wrapped in a section named after its title, starting on a
+// line of its own.
+func mergedSections(existing string, sources []*api.Page) string {
+	var b strings.Builder
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		b.WriteString("\n")
+	}
+	for _, source := range sources {
+		b.WriteString(wrapSection(pageContent(source), source.Title))
+	}
+	return b.String()
+}
+
+func init() {
+	pageCmd.AddCommand(pageMergeCmd)
+
+	pageMergeCmd.Flags().BoolVar(&pageMergeTrashSources, "trash-sources", false, "move the source pages to the trash once merged")
+	pageMergeCmd.Flags().BoolVar(&pageUpdateForce, "force", false, "merge even if the target would exceed the page size limit")
+}
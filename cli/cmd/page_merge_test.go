@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageMerge(t *testing.T) {
+	env := newCLIEnv(t)
+	target := env.server.AddPage(apitest.DefaultProjectID, "Incident", "timeline")
+	a := env.server.AddPage(apitest.DefaultProjectID, "api logs", "api down\n")
+	b := env.server.AddPage(apitest.DefaultProjectID, "db logs", "db slow\n")
+
+	env.mustRun("page", "merge", target.ExternalID, a.ExternalID, b.ExternalID, a.ExternalID)
+
+	got, _ := env.server.Page(target.ExternalID)
+	want := "timeline\n" + wrapSection("api down\n", "api logs") + wrapSection("db slow\n", "db logs")
+	if got.Details.Content != want {
+		t.Errorf("merged content = %q, want %q", got.Details.Content, want)
+	}
+	if out := env.mustRun("page", "get", target.ExternalID, "--section", "db logs"); out != "db slow\n" {
+		t.Errorf("page get --section = %q, want the merged source", out)
+	}
+	if _, ok := env.server.Page(a.ExternalID); !ok {
+		t.Error("source trashed without --trash-sources")
+	}
+}
+
+func TestPageMerge_TrashSources(t *testing.T) {
+	env := newCLIEnv(t)
+	target := env.server.AddPage(apitest.DefaultProjectID, "Incident", "")
+	a := env.server.AddPage(apitest.DefaultProjectID, "api logs", "api down\n")
+
+	env.mustRun("page", "merge", target.ExternalID, a.ExternalID, "--trash-sources")
+	if _, ok := env.server.Page(a.ExternalID); ok {
+		t.Error("source not trashed with --trash-sources")
+	}
+	if out := env.mustRun("page", "list", "--trashed", "--format", "{{.ExternalID}}"); out != a.ExternalID+"\n" {
+		t.Errorf("page list --trashed = %q, want the source", out)
+	}
+}
+
+func TestPageMerge_Refusals(t *testing.T) {
+	env := newCLIEnv(t)
+	target := env.server.AddPage(apitest.DefaultProjectID, "Incident", "timeline\n")
+	a := env.server.AddPage(apitest.DefaultProjectID, "api logs", "api down\n")
+	csv := env.server.AddPage(apitest.DefaultProjectID, "users", "name\nann\n")
+	env.server.SetPageDetails(csv.ExternalID, map[string]any{"filetype": "csv"})
+	locked := env.server.AddPage(apitest.DefaultProjectID, "Locked", "")
+	lockByOther(env, locked.ExternalID)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{target.ExternalID, target.ExternalID}, "cannot be merged into itself"},
+		{[]string{target.ExternalID, a.ExternalID, "page_missing"}, "failed to get page page_missing"},
+		{[]string{csv.ExternalID, a.ExternalID}, "page csv-union"},
+		{[]string{locked.ExternalID, a.ExternalID, "--trash-sources"}, "locked"},
+	}
+	for _, tt := range tests {
+		_, _, err := env.run(append([]string{"page", "merge"}, tt.args...)...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("page merge %s = %v, want %q", strings.Join(tt.args, " "), err, tt.want)
+		}
+	}
+	if got, _ := env.server.Page(target.ExternalID); got.Details.Content != "timeline\n" {
+		t.Errorf("target changed by a refused merge: %q", got.Details.Content)
+	}
+	if _, ok := env.server.Page(a.ExternalID); !ok {
+		t.Error("source trashed although the merge into a locked page was refused")
+	}
+}
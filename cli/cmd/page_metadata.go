@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var pageGetMetadataOnly bool
+
+// pageMetadata is what 'page get --metadata-only' reports about a page.
+type pageMetadata struct {
+	ExternalID string          `json:"external_id"`
+	Title      string          `json:"title"`
+	Filetype   string          `json:"filetype"`
+	Bytes      int64           `json:"bytes"`
+	Lines      *int            `json:"lines,omitempty"`
+	Created    string          `json:"created,omitempty"`
+	Updated    string          `json:"updated,omitempty"`
+	ProjectID  string          `json:"project_id,omitempty"`
+	FolderID   string          `json:"folder_id,omitempty"`
+	Role       string          `json:"role,omitempty"`
+	Status     string          `json:"status,omitempty"`
+	Icon       string          `json:"icon,omitempty"`
+	Labels     []string        `json:"labels,omitempty"`
+	Related    []string        `json:"related,omitempty"`
+	Writes     []api.PageWrite `json:"writes,omitempty"`
+	Revisions  *int            `json:"revisions,omitempty"`
+}
+
+// newPageMetadata summarizes page. Line counts are only known when the
+// server sent the content anyway.
+func newPageMetadata(page *api.Page) pageMetadata {
+	m := pageMetadata{
+		ExternalID: page.ExternalID,
+		Title:      page.Title,
+		Filetype:   "txt",
+		Created:    page.Created,
+		Updated:    page.Updated,
+		ProjectID:  page.ProjectID,
+		FolderID:   page.FolderID,
+		Role:       page.Role,
+	}
+	if m.Updated == "" {
+		m.Updated = page.Modified
+	}
+	if d := page.Details; d != nil {
+		if d.Filetype != "" {
+			m.Filetype = d.Filetype
+		}
+		m.Status, m.Icon, m.Labels, m.Related = d.Status, d.Icon, d.Labels, d.Related
+		m.Writes = d.Writes
+		m.Bytes = d.ContentSize
+		if d.Content != "" {
+			m.Bytes = int64(len(d.Content))
+			lines := countLines(d.Content)
+			m.Lines = &lines
+		}
+	}
+	return m
+}
+
+// runPageMetadata prints a page's metadata without its content.
+func runPageMetadata(client *api.Client, pageID string) error {
+	page, err := client.GetPageMetadata(pageID)
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	m := newPageMetadata(page)
+
+	// Revision history can be disabled on the server; leave the count out
+	// rather than failing.
+	if n, err := client.CountRevisions(pageID); err == nil {
+		m.Revisions = &n
+	} else {
+		printDebug("Revision count unavailable: %v", err)
+	}
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(m)
+	}
+
+	size := formatBytes(m.Bytes)
+	if m.Lines != nil {
+		size += fmt.Sprintf(" (%d lines)", *m.Lines)
+	}
+	rows := [][2]string{
+		{"ID", m.ExternalID},
+		{"Title", m.Title},
+		{"Filetype", m.Filetype},
+		{"Size", size},
+		{"Created", formatMetadataTime(m.Created)},
+		{"Updated", formatMetadataTime(m.Updated)},
+		{"Project", m.ProjectID},
+		{"Folder", m.FolderID},
+		{"Role", m.Role},
+		{"Status", strings.TrimSpace(m.Icon + " " + m.Status)},
+		{"Labels", strings.Join(m.Labels, ", ")},
+		{"Related", strings.Join(m.Related, ", ")},
+		{"Last write", formatLastWrite(m.Writes)},
+	}
+	if m.Revisions != nil {
+		rows = append(rows, [2]string{"Revisions", fmt.Sprint(*m.Revisions)})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		if row[1] != "" {
+			_, _ = fmt.Fprintf(w, "%s:\t%s\n", row[0], row[1])
+		}
+	}
+	return w.Flush()
+}
+
+// formatLastWrite describes the most recent attributed write, e.g.
+// "deploy-bot/staging (append, Oct 17, 2026 3:04 PM)".
+func formatLastWrite(writes []api.PageWrite) string {
+	if len(writes) == 0 {
+		return ""
+	}
+	w := writes[len(writes)-1]
+	return fmt.Sprintf("%s (%s, %s)", w.OnBehalfOf, w.Mode, formatMetadataTime(w.At))
+}
+
+func formatMetadataTime(s string) string {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Format("Jan 2, 2006 3:04 PM")
+	}
+	return s
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func runMetadataOnly(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageGetMetadataOnly = true
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output, _ := io.ReadAll(r)
+	return string(output)
+}
+
+func TestPageGetMetadataOnly_JSON(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	outputFmt = "json"
+
+	var pageQuery string
+	output := runMetadataOnly(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pages/page_xyz/":
+			pageQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(api.Page{
+				ExternalID: "page_xyz",
+				Title:      "Build Log",
+				Updated:    "2025-12-30T14:45:00Z",
+				Details:    &api.PageDetails{Filetype: "log", ContentSize: 2048},
+			})
+		case "/pages/page_xyz/rewind/":
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{}, "count": 7})
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	})
+
+	if pageQuery != "omit=content" {
+		t.Errorf("page query = %q, want omit=content", pageQuery)
+	}
+	var got pageMetadata
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Bytes != 2048 || got.Filetype != "log" || got.Lines != nil || got.Revisions == nil || *got.Revisions != 7 {
+		t.Errorf("unexpected metadata: %+v", got)
+	}
+	if strings.Contains(output, "content") {
+		t.Errorf("output includes content: %s", output)
+	}
+}
+
+func TestPageGetMetadataOnly_ContentSentAnyway(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	output := runMetadataOnly(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pages/page_xyz/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Title:      "Build Log",
+			Details:    &api.PageDetails{Content: "secret line one\nsecret line two\n"},
+		})
+	})
+
+	if strings.Contains(output, "secret") {
+		t.Errorf("output includes content:\n%s", output)
+	}
+	if !strings.Contains(output, "Size:      32 B (2 lines)") {
+		t.Errorf("output missing size:\n%s", output)
+	}
+	if strings.Contains(output, "Revisions") {
+		t.Errorf("output shows revisions although history is unavailable:\n%s", output)
+	}
+}
+
+func TestPageGetMetadataOnly_RejectsFollow(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	cfg = &config.Config{APIURL: "http://unused", Token: "test-token"}
+	pageGetMetadataOnly = true
+	pageGetFollow = true
+
+	err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"})
+	if err == nil || !strings.Contains(err.Error(), "--metadata-only") {
+		t.Errorf("err = %v, want --metadata-only conflict", err)
+	}
+}
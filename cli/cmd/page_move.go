@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var pageMoveProjectID string
+
+var pageMoveCmd = &cobra.Command{
+	Use:   "move <page-id> --project <project-id>",
+	Short: "Move a page to another project",
+	Long: `Move a page to another project in the same organization, for pages
+created under the wrong project. The title and content are unchanged; the
+page leaves any folder it was in.
+
+Pages cannot move between organizations. To copy one across, pipe it:
+  hyperclast page get page_xyz789 | hyperclast page new --project proj_other --title "..."
+
+Examples:
+  hyperclast page move page_xyz789 --project proj_abc123
+  hyperclast page move page_xyz789 --project proj_abc123 --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		pageID := args[0]
+		client := newClient()
+		page, err := client.GetPageMetadata(pageID)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+
+		moved := page
+		if page.ProjectID != pageMoveProjectID {
+			target, err := client.GetProject(pageMoveProjectID)
+			if err != nil {
+				return fmt.Errorf("failed to get project %s: %w", pageMoveProjectID, err)
+			}
+			if page.OrgID != "" && target.Org.ExternalID != page.OrgID {
+				return fmt.Errorf("cannot move page %s to project %s: the page is in organization %s and the project in %s, and pages cannot move between organizations",
+					pageID, target.ExternalID, page.OrgID, target.Org.ExternalID)
+			}
+
+			if moved, err = client.MovePage(pageID, page.Title, target.ExternalID); err != nil {
+				return fmt.Errorf("failed to move page: %w", err)
+			}
+			if moved.ProjectID != target.ExternalID {
+				return fmt.Errorf("the server did not move page %s; it predates moving pages between projects", pageID)
+			}
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(moved)
+		}
+		if quiet {
+			fmt.Println(moved.ExternalID)
+			return nil
+		}
+		if page.ProjectID == pageMoveProjectID {
+			printInfo("Page %s is already in project %s", moved.ExternalID, pageMoveProjectID)
+			return nil
+		}
+		printSuccess("Moved page \"%s\" (%s) from project %s to %s", moved.Title, moved.ExternalID, page.ProjectID, moved.ProjectID)
+		return nil
+	},
+}
+
+func init() {
+	pageCmd.AddCommand(pageMoveCmd)
+	pageMoveCmd.Flags().StringVar(&pageMoveProjectID, "project", "", "project to move the page to")
+	_ = pageMoveCmd.MarkFlagRequired("project")
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// moveServer serves page_xyz in proj_a (org_1), and projects proj_b (org_1)
+// and proj_c (org_2). It moves the page on PUT unless ignoreMove is set,
+// as servers without move support do.
+func moveServer(t *testing.T, ignoreMove bool) (body *map[string]any, puts *int) {
+	t.Helper()
+	body, puts = &map[string]any{}, new(int)
+	page := api.Page{ExternalID: "page_xyz", Title: "Deploy log", ProjectID: "proj_a", OrgID: "org_1"}
+	orgs := map[string]string{"proj_a": "org_1", "proj_b": "org_1", "proj_c": "org_2"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/projects/"):
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/projects/"), "/")
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: id, Org: api.Org{ExternalID: orgs[id]}})
+		case r.Method == http.MethodPut:
+			*puts++
+			_ = json.NewDecoder(r.Body).Decode(body)
+			if !ignoreMove {
+				page.ProjectID, _ = (*body)["project_id"].(string)
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			_ = json.NewEncoder(w).Encode(page)
+		}
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	return body, puts
+}
+
+func runPageMove(t *testing.T, project string) error {
+	t.Helper()
+	resetPageFlags()
+	t.Cleanup(resetPageFlags)
+	pageMoveProjectID = project
+	quiet = true
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+	return pageMoveCmd.RunE(pageMoveCmd, []string{"page_xyz"})
+}
+
+func TestPageMove_SendsProjectAndTitle(t *testing.T) {
+	body, puts := moveServer(t, false)
+
+	if err := runPageMove(t, "proj_b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *puts != 1 || len(*body) != 2 || (*body)["project_id"] != "proj_b" || (*body)["title"] != "Deploy log" {
+		t.Errorf("sent %d updates, last %v; want one with only title and project_id", *puts, *body)
+	}
+
+	if err := runPageMove(t, "proj_b"); err != nil || *puts != 1 {
+		t.Errorf("moving to the current project: err = %v, updates = %d; want a no-op", err, *puts)
+	}
+}
+
+func TestPageMove_RejectsCrossOrg(t *testing.T) {
+	_, puts := moveServer(t, false)
+
+	err := runPageMove(t, "proj_c")
+	if err == nil || !strings.Contains(err.Error(), "between organizations") {
+		t.Fatalf("err = %v, want a cross-organization error", err)
+	}
+	if *puts != 0 {
+		t.Error("sent an update for a cross-organization move")
+	}
+}
+
+func TestPageMove_ServerIgnoresProject(t *testing.T) {
+	moveServer(t, true)
+
+	err := runPageMove(t, "proj_b")
+	if err == nil || !strings.Contains(err.Error(), "did not move") {
+		t.Fatalf("err = %v, want an error saying the page was not moved", err)
+	}
+}
+
+func TestPageMove_Integration(t *testing.T) {
+	env := newCLIEnv(t)
+	target := env.server.AddProject("Runbooks")
+	page := env.server.AddPage(apitest.DefaultProjectID, "Deploy log", "ok\n")
+
+	env.mustRun("page", "move", page.ExternalID, "--project", target.ExternalID)
+	if got, _ := env.server.Page(page.ExternalID); got.ProjectID != target.ExternalID {
+		t.Errorf("page is in %s, want %s", got.ProjectID, target.ExternalID)
+	}
+
+	shared := env.server.AddPage(apitest.DefaultProjectID, "Shared", "ok\n")
+	env.server.SetPageRole(shared.ExternalID, api.RoleEditor)
+	_, _, err := env.run("page", "move", shared.ExternalID, "--project", target.ExternalID)
+	if err == nil || !strings.Contains(err.Error(), "Only the creator") {
+		t.Errorf("moving a page you did not create = %v, want the server's refusal", err)
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+var pageNewOpen bool
+
+// openBrowser opens url in the user's web browser without waiting for it.
+// It is a variable so tests can substitute a fake browser.
+var openBrowser = func(url string) error {
+	parts := browserCommand()
+	c := exec.Command(parts[0], append(parts[1:], url)...)
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("browser %q failed: %w", strings.Join(parts, " "), err)
+	}
+	return c.Process.Release()
+}
+
+// browserCommand is $BROWSER, or the platform's opener when it is unset.
+func browserCommand() []string {
+	if browser := strings.Fields(os.Getenv("BROWSER")); len(browser) > 0 {
+		return browser
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"open"}
+	case "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler"}
+	default:
+		return []string{"xdg-open"}
+	}
+}
+
+// openCreatedPage opens a page made by 'page new --open'. The page exists
+// whatever happens, so failing to open it is only a warning.
+func openCreatedPage(pageID string) {
+	url := pageURL(pageID)
+	if err := openBrowser(url); err != nil {
+		printWarning("could not open the page in a browser: %v; it is at %s", err, url)
+	}
+}
+
+func init() {
+	pageNewCmd.Flags().BoolVar(&pageNewOpen, "open", false, "open the new page in a web browser ($BROWSER, else the system default)")
+}
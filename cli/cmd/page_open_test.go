@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+// fakeBrowser records the URLs opened, failing with err if it is set.
+func fakeBrowser(t *testing.T, err error) *[]string {
+	t.Helper()
+	var opened []string
+	orig := openBrowser
+	openBrowser = func(url string) error {
+		opened = append(opened, url)
+		return err
+	}
+	t.Cleanup(func() { openBrowser = orig })
+	return &opened
+}
+
+func TestPageNew_Open(t *testing.T) {
+	env := newCLIEnv(t)
+	opened := fakeBrowser(t, nil)
+	file := filepath.Join(t.TempDir(), "build.log")
+	_ = os.WriteFile(file, []byte("ok\n"), 0600)
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--file", file, "--open"))
+	if want := []string{pageURL(id)}; !reflect.DeepEqual(*opened, want) {
+		t.Errorf("opened %q, want %q", *opened, want)
+	}
+
+	*opened = nil
+	env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--file", file)
+	if len(*opened) != 0 {
+		t.Errorf("opened %q without --open", *opened)
+	}
+}
+
+func TestPageNew_OpenFailureIsWarning(t *testing.T) {
+	env := newCLIEnv(t)
+	fakeBrowser(t, os.ErrNotExist)
+	file := filepath.Join(t.TempDir(), "build.log")
+	_ = os.WriteFile(file, []byte("ok\n"), 0600)
+
+	_, stderr, err := env.run("page", "new", "--project", apitest.DefaultProjectID, "--file", file, "--open")
+	if err != nil {
+		t.Fatalf("page new --open with no browser failed: %v", err)
+	}
+	if !strings.Contains(stderr, "could not open the page") {
+		t.Errorf("stderr = %q, want a warning", stderr)
+	}
+}
+
+func TestPageNew_OpenConflicts(t *testing.T) {
+	env := newCLIEnv(t)
+	fakeBrowser(t, nil)
+	for _, args := range [][]string{
+		{"--preview"},
+		{"--files", "*.log"},
+		{"--split-on", "^==="},
+	} {
+		args = append([]string{"page", "new", "--project", apitest.DefaultProjectID, "--open"}, args...)
+		if _, _, err := env.run(args...); err == nil || !strings.Contains(err.Error(), "--open") {
+			t.Errorf("%v = %v, want --open refused", args, err)
+		}
+	}
+}
+
+func TestBrowserCommand(t *testing.T) {
+	t.Setenv("BROWSER", "firefox --new-tab")
+	if got := browserCommand(); !reflect.DeepEqual(got, []string{"firefox", "--new-tab"}) {
+		t.Errorf("browserCommand() = %q", got)
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var pagePinCmd = &cobra.Command{
+	Use:   "pin <page-id>...",
+	Short: "Pin pages for 'page pinned'",
+	Long: `Pin pages you use often, such as the dashboards you update daily, so
+'page pinned' lists them in one command. Pins are kept in your config, so
+they are yours alone.
+
+Examples:
+  hyperclast page pin page_xyz789
+  hyperclast page pinned`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		client := newClient()
+
+		// Check every page exists before pinning any.
+		var pages []*api.Page
+		for _, id := range args {
+			page, err := client.GetPageMetadata(id)
+			if err != nil {
+				return fmt.Errorf("failed to get page %s: %w", id, err)
+			}
+			pages = append(pages, page)
+		}
+
+		var pinned []string
+		for _, page := range pages {
+			if !cfg.PinPage(page.ExternalID) {
+				pinText("Page \"%s\" (%s) is already pinned", page.Title, page.ExternalID)
+				continue
+			}
+			pinned = append(pinned, page.ExternalID)
+			pinText("✓ Pinned page \"%s\" (%s)", page.Title, page.ExternalID)
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		return printPinResult("pinned", pinned)
+	},
+}
+
+var pageUnpinCmd = &cobra.Command{
+	Use:   "unpin <page-id>...",
+	Short: "Unpin pages",
+	Long: `Remove pages from 'page pinned'. Pages are not fetched, so pins of
+deleted pages can be removed too.
+
+Examples:
+  hyperclast page unpin page_xyz789`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var unpinned []string
+		for _, id := range args {
+			if !cfg.UnpinPage(id) {
+				pinText("Page %s is not pinned", id)
+				continue
+			}
+			unpinned = append(unpinned, id)
+			pinText("✓ Unpinned page %s", id)
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		return printPinResult("unpinned", unpinned)
+	},
+}
+
+var pagePinnedCmd = &cobra.Command{
+	Use:   "pinned",
+	Short: "List pinned pages",
+	Long: `List the pages pinned with 'page pin', in the order they were pinned.
+Output matches 'page list', including --format.
+
+A pinned page that can no longer be fetched, because it was deleted or
+access was revoked, is reported with a warning and left out.
+
+Examples:
+  hyperclast page pinned
+  hyperclast page pinned --format '{{.ExternalID}} {{.Title}}'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if err := checkPageListFlags(); err != nil {
+			return err
+		}
+		if len(cfg.PinnedPages) == 0 && outputFmt != "json" && pageListFormat == "" {
+			printInfo("No pinned pages. Pin one with 'hyperclast page pin <page-id>'")
+			return nil
+		}
+
+		client := newClient()
+		pages := []api.Page{}
+		for _, id := range cfg.PinnedPages {
+			page, err := client.GetPageMetadata(id)
+			if err != nil {
+				printWarning("Could not get pinned page %s: %v", id, err)
+				printWarning("  Unpin it with 'hyperclast page unpin %s'", id)
+				continue
+			}
+			pages = append(pages, *page)
+		}
+		return renderPageList(os.Stdout, pages)
+	},
+}
+
+// pinText prints a line of text output as pages are pinned or unpinned.
+// JSON and --quiet output are printed at the end, by printPinResult.
+func pinText(format string, a ...any) {
+	if outputFmt != "json" && !quiet {
+		fmt.Printf(format+"\n", a...)
+	}
+}
+
+// printPinResult reports the pages a pin command changed, under key in JSON,
+// or their IDs with --quiet.
+func printPinResult(key string, ids []string) error {
+	if ids == nil {
+		ids = []string{}
+	}
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{key: ids})
+	}
+	if quiet {
+		for _, id := range ids {
+			fmt.Println(id)
+		}
+	}
+	return nil
+}
+
+func init() {
+	pageCmd.AddCommand(pagePinCmd)
+	pageCmd.AddCommand(pageUnpinCmd)
+	pageCmd.AddCommand(pagePinnedCmd)
+
+	pagePinnedCmd.Flags().StringVar(&pageListFormat, "format", "", "format each page with a Go template, as in 'page list'")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPagePin_PinnedListsInPinOrder(t *testing.T) {
+	env := newCLIEnv(t)
+	a := env.server.AddPage(apitest.DefaultProjectID, "Dashboard", "")
+	b := env.server.AddPage(apitest.DefaultProjectID, "Oncall", "")
+	env.server.AddPage(apitest.DefaultProjectID, "Scratch", "")
+
+	env.mustRun("page", "pin", b.ExternalID)
+	env.mustRun("page", "pin", a.ExternalID, b.ExternalID)
+
+	out := env.mustRun("page", "pinned", "--format", "{{.ExternalID}}")
+	if want := b.ExternalID + "\n" + a.ExternalID + "\n"; out != want {
+		t.Errorf("page pinned = %q, want %q", out, want)
+	}
+
+	out = env.mustRun("page", "unpin", b.ExternalID, "page_missing", "--output", "json")
+	var result struct {
+		Unpinned []string `json:"unpinned"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if strings.Join(result.Unpinned, ",") != b.ExternalID {
+		t.Errorf("unpinned = %v, want only %s", result.Unpinned, b.ExternalID)
+	}
+	if out := env.mustRun("page", "pinned", "--quiet"); strings.Contains(out, b.ExternalID) {
+		t.Errorf("page pinned after unpin = %q", out)
+	}
+}
+
+func TestPagePin_UnknownPageIsNotPinned(t *testing.T) {
+	env := newCLIEnv(t)
+	a := env.server.AddPage(apitest.DefaultProjectID, "Dashboard", "")
+
+	if _, _, err := env.run("page", "pin", a.ExternalID, "page_missing"); err == nil {
+		t.Fatal("pinning a missing page succeeded")
+	}
+	if out := env.mustRun("page", "pinned"); !strings.Contains(out, "No pinned pages") {
+		t.Errorf("page pinned = %q, want nothing pinned", out)
+	}
+}
+
+func TestPagePin_PinnedWarnsAboutDeletedPages(t *testing.T) {
+	env := newCLIEnv(t)
+	a := env.server.AddPage(apitest.DefaultProjectID, "Dashboard", "")
+	b := env.server.AddPage(apitest.DefaultProjectID, "Old", "")
+	env.mustRun("page", "pin", a.ExternalID, b.ExternalID)
+	env.mustRun("page", "delete", b.ExternalID, "--force")
+
+	stdout, stderr, err := env.run("page", "pinned", "--format", "{{.Title}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdout != "Dashboard\n" || !strings.Contains(stderr, "page unpin "+b.ExternalID) {
+		t.Errorf("stdout = %q, stderr = %q; want Dashboard and an unpin hint", stdout, stderr)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pagePreset string
+	pageLabels []string
+	pageRedact bool
+)
+
+// applyPagePreset fills in the 'page new' flags that --preset names and
+// the command line leaves unset.
+func applyPagePreset(cmd *cobra.Command) error {
+	if pagePreset == "" {
+		return nil
+	}
+	preset, err := cfg.Preset(pagePreset)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	setString := func(name string, v *string, value string) {
+		if value != "" && !flags.Changed(name) {
+			*v = value
+		}
+	}
+	setBool := func(name string, v *bool, value bool) {
+		if value && !flags.Changed(name) {
+			*v = true
+		}
+	}
+	setString("project", &pageProjectID, preset.Project)
+	setString("title", &pageTitle, preset.Title)
+	setString("filetype", &pageFiletype, preset.Filetype)
+	setString("source", &pageSource, preset.Source)
+	setBool("meta", &pageMeta, preset.Meta)
+	setBool("substitute", &pageSubstitute, preset.Substitute)
+	setBool("redact", &pageRedact, preset.Redact)
+	pageLabels = mergeLabels(preset.Labels, pageLabels)
+	printDebug("Using preset %q", pagePreset)
+	return nil
+}
+
+// redactContent applies --redact to content, noting on stderr how many
+// secrets were removed.
+func redactContent(content string) string {
+	if !pageRedact {
+		return content
+	}
+	content, n := redactSecrets(content)
+	if n > 0 && !quiet {
+		fmt.Fprintf(os.Stderr, "Redacted %d secrets\n", n)
+	}
+	return content
+}
+
+func init() {
+	pageNewCmd.Flags().StringVar(&pagePreset, "preset", "", "use the flags of a preset from the config's presets")
+	pageNewCmd.Flags().StringArrayVar(&pageLabels, "label", nil, "add a label, e.g. team=ops (repeatable)")
+	pageNewCmd.Flags().BoolVar(&pageRedact, "redact", false, "replace passwords, tokens, API keys, and private keys in the content with [REDACTED]")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+var pageRenameCmd = &cobra.Command{
+	Use:   "rename <page-id> <new-title>",
+	Short: "Change a page's title",
+	Long: `Change the title of a page. Only the title is sent; the content and
+details are left as they are, so renaming a large page is cheap.
+
+The server only lets a page's creator change its title.
+
+Examples:
+  hyperclast page rename page_xyz789 "Deploy log (prod)"
+  hyperclast page rename page_xyz789 "Q3 notes" --output json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		pageID := args[0]
+		title, err := checkPageTitle(args[1])
+		if err != nil {
+			return err
+		}
+
+		client := newClient()
+		current, err := client.GetPageMetadata(pageID)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+
+		page := current
+		if current.Title != title {
+			if page, err = client.RenamePage(pageID, title); err != nil {
+				return fmt.Errorf("failed to rename page: %w", err)
+			}
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(page)
+		}
+		if quiet {
+			fmt.Println(page.ExternalID)
+			return nil
+		}
+		if current.Title == title {
+			printInfo("Page %s is already titled \"%s\"", page.ExternalID, title)
+			return nil
+		}
+		printSuccess("Renamed page \"%s\" to \"%s\" (%s)", current.Title, page.Title, page.ExternalID)
+		return nil
+	},
+}
+
+// checkPageTitle trims title and rejects titles the server would refuse or
+// that would break one-line listings.
+func checkPageTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", fmt.Errorf("title cannot be empty")
+	}
+	if n := utf8.RuneCountInString(title); n > maxTitleLength {
+		return "", fmt.Errorf("title is %d characters (max %d)", n, maxTitleLength)
+	}
+	if strings.ContainsFunc(title, unicode.IsControl) {
+		return "", fmt.Errorf("title cannot contain control characters")
+	}
+	return title, nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageRenameCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestPageRename_SendsTitleOnly(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	var body map[string]any
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := "Deploy log"
+		if r.Method == http.MethodPut {
+			puts++
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			title, _ = body["title"].(string)
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Title: title})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	quiet = true
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err := pageRenameCmd.RunE(pageRenameCmd, []string{"page_xyz", "  Deploy log (prod) "})
+	if err == nil {
+		err = pageRenameCmd.RunE(pageRenameCmd, []string{"page_xyz", "Deploy log"})
+	}
+	os.Stdout = oldStdout
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if puts != 1 {
+		t.Errorf("sent %d updates, want 1 (renaming to the current title is a no-op)", puts)
+	}
+	if len(body) != 1 || body["title"] != "Deploy log (prod)" {
+		t.Errorf("body = %v, want only the trimmed title", body)
+	}
+}
+
+func TestCheckPageTitle(t *testing.T) {
+	for _, title := range []string{" ", "two\nlines", strings.Repeat("t", maxTitleLength+1)} {
+		if _, err := checkPageTitle(title); err == nil {
+			t.Errorf("checkPageTitle(%q) accepted", title)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/render"
+)
+
+var (
+	pageGetHTML bool
+	pageGetPDF  bool
+)
+
+// renderFormat returns the format requested with --html or --pdf, or "".
+func renderFormat() (string, error) {
+	format := ""
+	switch {
+	case pageGetHTML && pageGetPDF:
+		return "", fmt.Errorf("--html and --pdf cannot be used together")
+	case pageGetHTML:
+		format = "html"
+	case pageGetPDF:
+		format = "pdf"
+	default:
+		return "", nil
+	}
+	if pageGetSection != "" || pageGetFollow || pageGetMetadataOnly {
+		return "", fmt.Errorf("--%s cannot be combined with --section, --follow, or --metadata-only", format)
+	}
+	if outputFmt == "json" {
+		return "", fmt.Errorf("--%s cannot be combined with --output json", format)
+	}
+	return format, nil
+}
+
+// runPageRender prints a page rendered as format.
+func runPageRender(client *api.Client, pageID, format string) error {
+	body, err := renderPage(client, pageID, format)
+	if err != nil {
+		return err
+	}
+	if pageGetOut != "" {
+		return writePageOut(pageID, body, format)
+	}
+	if err := guardTerminalOutput(string(body), pageGetForce); err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(body)
+	return err
+}
+
+// renderPage returns a page rendered as format, html or pdf, by the server.
+// Servers that cannot render send the raw page, which is then rendered
+// locally.
+func renderPage(client *api.Client, pageID, format string) ([]byte, error) {
+	rendered, err := client.RenderPage(pageID, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	switch {
+	case format == "html" && rendered.ContentType == "text/html":
+		return rendered.Body, nil
+	case format == "pdf" && rendered.ContentType == "application/pdf":
+		return rendered.Body, nil
+	}
+
+	printDebug("Server sent %s instead of %s; rendering locally", rendered.ContentType, strings.ToUpper(format))
+	page, err := client.GetPage(pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	content, filetype := "", "txt"
+	if d := page.Details; d != nil {
+		content = d.Content
+		if d.Filetype != "" {
+			filetype = d.Filetype
+		}
+	}
+	if format == "pdf" {
+		return render.PDF(page.Title, content, filetype), nil
+	}
+	return []byte(render.HTML(page.Title, content, filetype)), nil
+}
+
+func init() {
+	pageGetCmd.Flags().BoolVar(&pageGetHTML, "html", false, "print the page rendered as a standalone HTML document")
+	pageGetCmd.Flags().BoolVar(&pageGetPDF, "pdf", false, "print the page rendered as PDF (redirect to a file)")
+}
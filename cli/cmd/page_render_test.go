@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// newRenderServer serves downloads with contentType and body, and the page
+// itself as markdown.
+func newRenderServer(t *testing.T, contentType, body string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/download/") {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = io.WriteString(w, body)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Title:      "Incident",
+			Details:    &api.PageDetails{Content: "## Timeline\n\n- **14:02** deploy", Filetype: "md"},
+		})
+	}))
+	t.Cleanup(server.Close)
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+}
+
+func runPageGetCapture(t *testing.T) (string, error) {
+	t.Helper()
+	r, w, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = w
+	err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"})
+	_ = w.Close()
+	os.Stdout = stdout
+	out, _ := io.ReadAll(r)
+	return string(out), err
+}
+
+func TestPageGet_HTMLFromServer(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newRenderServer(t, "text/html; charset=utf-8", "<html>server</html>")
+	pageGetHTML = true
+
+	out, err := runPageGetCapture(t)
+	if err != nil || out != "<html>server</html>" {
+		t.Errorf("got %q, %v; want the server's HTML", out, err)
+	}
+}
+
+func TestPageGet_HTMLRenderedLocally(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newRenderServer(t, "text/markdown; charset=utf-8", "# Incident\n")
+	pageGetHTML = true
+
+	out, err := runPageGetCapture(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"<title>Incident</title>", "<h2>Timeline</h2>", "<li><strong>14:02</strong> deploy</li>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPageGet_PDF(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	oldIsTerminal := stdoutIsTerminal
+	defer func() { stdoutIsTerminal = oldIsTerminal }()
+	stdoutIsTerminal = func() bool { return false }
+	newRenderServer(t, "application/pdf", "%PDF-1.7\x00\xff")
+	pageGetPDF = true
+
+	out, err := runPageGetCapture(t)
+	if err != nil || out != "%PDF-1.7\x00\xff" {
+		t.Errorf("got %q, %v; want the PDF bytes", out, err)
+	}
+}
+
+func TestPageGet_PDFRenderedLocally(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	oldIsTerminal := stdoutIsTerminal
+	defer func() { stdoutIsTerminal = oldIsTerminal }()
+	stdoutIsTerminal = func() bool { return false }
+	newRenderServer(t, "text/markdown", "# Incident\n")
+	pageGetPDF = true
+
+	out, err := runPageGetCapture(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"%PDF-1.4", "/Title (Incident)", "(## Timeline) Tj"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPageGet_RenderConflicts(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	cfg = &config.Config{Token: "test-token"}
+	pageGetHTML = true
+	pageGetFollow = true
+
+	if err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"}); err == nil || !strings.Contains(err.Error(), "--html cannot be combined") {
+		t.Errorf("err = %v, want conflict", err)
+	}
+}
+
+func TestPageGet_RenderMarkdown(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	newRenderServer(t, "text/plain", "")
+	pageGetRender = true
+
+	out, err := runPageGetCapture(t)
+	if err != nil || out != "## Timeline\n\n  • 14:02 deploy\n" {
+		t.Errorf("got %q, %v; want the markdown laid out without styles", out, err)
+	}
+
+	pageGetRender, pageGetPlain = false, true
+	if _, err := runPageGetCapture(t); err == nil || !strings.Contains(err.Error(), "--plain requires --render") {
+		t.Errorf("err = %v, want --plain rejected without --render", err)
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// searchResultLimit is how many pages the server's title search returns.
+// A full result may leave out exact matches, so the lookup falls back to
+// listing every page.
+const searchResultLimit = 10
+
+// titleLookup is a command whose first page ID can be given as --title.
+type titleLookup struct {
+	cmd *cobra.Command
+	// scoped adds --project, narrowing the lookup to one project. Commands
+	// whose --project means something else look through every page.
+	scoped bool
+	// trash looks among trashed pages, for commands that act on the trash.
+	trash bool
+}
+
+// allowTitleLookup adds --title to lookup.cmd. When it is set, the page with
+// that title is looked up and its ID passed to the command as its first
+// argument, so the command's own argument checks and RunE see an ID.
+func allowTitleLookup(lookup titleLookup) {
+	cmd := lookup.cmd
+	cmd.Flags().String("title", "", "use the page with this title instead of a page ID")
+	if lookup.scoped {
+		cmd.Flags().String("project", "", "with --title, look for the page only in this project")
+	}
+
+	validate, run := cmd.Args, cmd.RunE
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("title") {
+			if lookup.scoped && cmd.Flags().Changed("project") {
+				return fmt.Errorf("--project requires --title")
+			}
+			if validate == nil {
+				return nil
+			}
+			return validate(cmd, args)
+		}
+		if validate == nil {
+			return nil
+		}
+		if err := validate(cmd, append([]string{"<title>"}, args...)); err != nil {
+			return fmt.Errorf("%w (--title counts as the first page ID)", err)
+		}
+		return nil
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("title") {
+			return run(cmd, args)
+		}
+		title, _ := cmd.Flags().GetString("title")
+		if strings.TrimSpace(title) == "" {
+			return fmt.Errorf("--title must not be empty")
+		}
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		projectID := ""
+		if lookup.scoped {
+			projectID, _ = cmd.Flags().GetString("project")
+		}
+		page, err := resolvePageTitle(newClient(), title, projectID, lookup)
+		if err != nil {
+			return err
+		}
+		printDebug("Resolved title %q to page %s", title, page.ExternalID)
+		return run(cmd, append([]string{page.ExternalID}, args...))
+	}
+}
+
+// resolvePageTitle finds the one page titled title, in projectID if set.
+// Titles are matched exactly, or ignoring case when nothing matches
+// exactly; several matches are an error listing them.
+func resolvePageTitle(client *api.Client, title, projectID string, lookup titleLookup) (*api.Page, error) {
+	matches, err := findPagesTitled(client, title, projectID, lookup)
+	if err != nil {
+		return nil, err
+	}
+	if err := titleMatchError(matches, title, projectID, lookup); err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// findPagesTitled returns the pages titled title, in projectID if set,
+// matched as by resolvePageTitle.
+func findPagesTitled(client *api.Client, title, projectID string, lookup titleLookup) ([]api.Page, error) {
+	var candidates []api.Page
+	var err error
+	switch {
+	case lookup.trash:
+		candidates, err = listTrashedPages(projectID)
+	case projectID != "":
+		candidates, err = client.ListPages(projectID)
+	default:
+		candidates, err = client.SearchPages(title, "")
+		if err == nil && len(candidates) >= searchResultLimit {
+			candidates, err = client.ListPages("")
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up page titled %q: %w", title, err)
+	}
+
+	matches := pagesTitled(candidates, title, func(a, b string) bool { return a == b })
+	if len(matches) == 0 {
+		matches = pagesTitled(candidates, title, strings.EqualFold)
+	}
+	return matches, nil
+}
+
+// titleMatchError explains why matches, found for title, are not exactly
+// one page, or returns nil if they are.
+func titleMatchError(matches []api.Page, title, projectID string, lookup titleLookup) error {
+	where := ""
+	switch {
+	case lookup.trash && projectID != "":
+		where = fmt.Sprintf(" in the trash of project %s", projectID)
+	case lookup.trash:
+		where = " in the trash"
+	case projectID != "":
+		where = fmt.Sprintf(" in project %s", projectID)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no page titled %q%s", title, where)
+	case 1:
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d pages are titled %q%s; use a page ID instead", len(matches), title, where)
+	if lookup.scoped && projectID == "" {
+		b.WriteString(", or narrow the lookup with --project")
+	}
+	b.WriteString(":")
+	for _, page := range matches {
+		fmt.Fprintf(&b, "\n  %s  %s  %s", page.ExternalID, page.ProjectID, formatMetadataTime(pageUpdated(&page)))
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+func pagesTitled(pages []api.Page, title string, equal func(a, b string) bool) []api.Page {
+	var matches []api.Page
+	for _, page := range pages {
+		if equal(page.Title, title) && !slices.ContainsFunc(matches, func(p api.Page) bool { return p.ExternalID == page.ExternalID }) {
+			matches = append(matches, page)
+		}
+	}
+	return matches
+}
+
+func init() {
+	for _, lookup := range []titleLookup{
+		{cmd: muxCmd, scoped: true},
+		{cmd: urlCmd, scoped: true},
+		{cmd: pageAppendCmd, scoped: true},
+		{cmd: pagePrependCmd, scoped: true},
+		{cmd: pageOverwriteCmd, scoped: true},
+		{cmd: pageDeleteCmd, scoped: true},
+		{cmd: pageArchiveCmd, scoped: true},
+		{cmd: pageAttachCmd, scoped: true},
+		{cmd: pageAttachmentsListCmd, scoped: true},
+		{cmd: pageAttachmentsDownloadCmd, scoped: true},
+		{cmd: pageUnarchiveCmd, scoped: true},
+		{cmd: pageDiffCmd, scoped: true},
+		{cmd: pageEditCmd, scoped: true},
+		{cmd: pageExportCmd, scoped: true},
+		{cmd: pageHistoryCmd, scoped: true},
+		{cmd: pageHistoryDiffCmd, scoped: true},
+		{cmd: pageLockCmd, scoped: true},
+		{cmd: pageMergeCmd, scoped: true},
+		{cmd: pageUnlockCmd, scoped: true},
+		{cmd: pagePinCmd, scoped: true},
+		{cmd: pageUnpinCmd, scoped: true},
+		{cmd: pageRenameCmd, scoped: true},
+		{cmd: pageRestoreCmd, scoped: true},
+		{cmd: pageSetStatusCmd, scoped: true},
+		{cmd: pageShareCmd, scoped: true},
+		{cmd: pageTailCmd, scoped: true},
+		{cmd: pageWatchCmd, scoped: true},
+		{cmd: pageTagAddCmd, scoped: true},
+		{cmd: pageTagRmCmd, scoped: true},
+		{cmd: pageTagListCmd, scoped: true},
+		{cmd: pageTrashCmd, scoped: true},
+		{cmd: pageRestoreTrashedCmd, scoped: true, trash: true},
+		// --project already means something else on these.
+		{cmd: pageGetCmd},
+		{cmd: pageMoveCmd},
+		{cmd: pagePurgeCmd, trash: true},
+		{cmd: projectDefaultPageCmd},
+	} {
+		allowTitleLookup(lookup)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestTitleLookup_ResolvesExactTitle(t *testing.T) {
+	env := newCLIEnv(t)
+	env.server.AddPage(apitest.DefaultProjectID, "Build Log (old)", "old\n")
+	page := env.server.AddPage(apitest.DefaultProjectID, "Build Log", "current\n")
+
+	if out := env.mustRun("page", "get", "--title", "Build Log"); out != "current\n" {
+		t.Errorf("page get --title = %q, want the exactly titled page", out)
+	}
+	env.mustRun("page", "rename", "--title", "build log", "Deploy Log")
+	if got, _ := env.server.Page(page.ExternalID); got.Title != "Deploy Log" {
+		t.Errorf("title after rename = %q, want a case-insensitive match renamed", got.Title)
+	}
+}
+
+func TestTitleLookup_AmbiguousTitle(t *testing.T) {
+	env := newCLIEnv(t)
+	other := env.server.AddProject("Staging")
+	a := env.server.AddPage(apitest.DefaultProjectID, "Build Log", "")
+	b := env.server.AddPage(other.ExternalID, "Build Log", "")
+
+	_, _, err := env.run("page", "lock", "--title", "Build Log")
+	if err == nil || !strings.Contains(err.Error(), "2 pages are titled") ||
+		!strings.Contains(err.Error(), a.ExternalID) || !strings.Contains(err.Error(), "--project") {
+		t.Fatalf("lock of an ambiguous title = %v, want an error listing the pages", err)
+	}
+
+	env.mustRun("page", "tag", "add", "--title", "Build Log", "--project", other.ExternalID, "staging")
+	if got, _ := env.server.Page(b.ExternalID); strings.Join(got.Details.Labels, ",") != "staging" {
+		t.Errorf("labels of the page in %s = %v", other.ExternalID, got.Details.Labels)
+	}
+}
+
+func TestTitleLookup_NotFound(t *testing.T) {
+	env := newCLIEnv(t)
+	env.server.AddPage(apitest.DefaultProjectID, "Build Log", "")
+
+	_, _, err := env.run("page", "history", "--title", "Deploy Log", "--project", apitest.DefaultProjectID)
+	if err == nil || !strings.Contains(err.Error(), `no page titled "Deploy Log" in project `+apitest.DefaultProjectID) {
+		t.Fatalf("history of a missing title = %v", err)
+	}
+}
+
+func TestTitleLookup_FallsBackToFullListing(t *testing.T) {
+	env := newCLIEnv(t)
+	want := env.server.AddPage(apitest.DefaultProjectID, "Log", "")
+	for range searchResultLimit + 2 {
+		env.server.AddPage(apitest.DefaultProjectID, "Log of a build", "")
+	}
+
+	out := env.mustRun("url", "--title", "Log")
+	if !strings.Contains(out, want.ExternalID) {
+		t.Errorf("url --title = %q, want the page %s past the search limit", out, want.ExternalID)
+	}
+}
+
+func TestTitleLookup_Trash(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Scratch", "")
+
+	env.mustRun("page", "trash", "--title", "Scratch")
+	if _, ok := env.server.Page(page.ExternalID); ok {
+		t.Fatal("page not trashed by page trash --title")
+	}
+	env.mustRun("page", "restore-trashed", "--title", "Scratch")
+	if out := env.mustRun("page", "list", "--format", "{{.Title}}"); out != "Scratch\n" {
+		t.Errorf("page list after restore-trashed --title = %q", out)
+	}
+}
+
+func TestTitleLookup_FlagChecks(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Scratch", "")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"page", "lock", page.ExternalID, "--project", apitest.DefaultProjectID}, "--project requires --title"},
+		{[]string{"page", "lock", page.ExternalID, "--title", "Scratch"}, "--title counts as the first page ID"},
+		{[]string{"page", "lock", "--title", " "}, "--title must not be empty"},
+	}
+	for _, tt := range tests {
+		_, _, err := env.run(tt.args...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s = %v, want %q", strings.Join(tt.args, " "), err, tt.want)
+		}
+	}
+}
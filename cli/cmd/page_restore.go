@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hyperclast/workspace/cli/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageRestoreRevision string
+	pageRestoreDryRun   bool
+)
+
+var pageRestoreCmd = &cobra.Command{
+	Use:   "restore <page-id> --revision <n>",
+	Short: "Restore a page to a previous revision",
+	Long: `Write a previous revision of a page back as its current content, in
+overwrite mode. The content being replaced stays in the page's history, so
+a restore can itself be undone.
+
+The revision is its number (12 or v12) or its ID; 'hyperclast page history'
+lists them. Use --dry-run to see what would change without writing.
+
+Examples:
+  hyperclast page restore page_xyz789 --revision 12 --dry-run
+  hyperclast page restore page_xyz789 --revision v12`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageRestoreRevision == "current" {
+			return fmt.Errorf("--revision must name a previous revision, not current")
+		}
+		if err := checkOnBehalfOf(); err != nil {
+			return err
+		}
+
+		client := newClient()
+		pageID := args[0]
+		revision, err := resolveRevision(client, pageID, pageRestoreRevision)
+		if err != nil {
+			return err
+		}
+		current, err := client.GetPage(pageID)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+
+		script := diff.Lines(pageContent(current), revision.content)
+		added, deleted := diff.Stat(script)
+		unchanged := added == 0 && deleted == 0
+
+		if pageRestoreDryRun || unchanged {
+			if outputFmt == "json" {
+				return json.NewEncoder(os.Stdout).Encode(map[string]any{
+					"page_id":   pageID,
+					"title":     current.Title,
+					"revision":  revision,
+					"dry_run":   pageRestoreDryRun,
+					"restored":  false,
+					"unchanged": unchanged,
+					"added":     added,
+					"deleted":   deleted,
+				})
+			}
+			if unchanged {
+				printInfo("Page \"%s\" (%s) already matches %s; nothing to restore", current.Title, pageID, revision.name())
+				return nil
+			}
+			printInfo("Would restore page \"%s\" (%s) to %s: %s, %s (%s → %s)", current.Title, pageID, revision.name(),
+				colorize(ansiGreen, fmt.Sprintf("+%d", added)), colorize(ansiRed, fmt.Sprintf("-%d", deleted)),
+				formatBytes(int64(len(pageContent(current)))), formatBytes(int64(revision.Bytes)))
+			if !quiet {
+				fmt.Print(renderDiff(diff.Hunks(script, 3)))
+			}
+			return nil
+		}
+
+		_, page, err := updatePage(pageID, revision.content, "overwrite")
+		if err != nil {
+			return err
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"page_id":   page.ExternalID,
+				"title":     page.Title,
+				"revision":  revision,
+				"dry_run":   false,
+				"restored":  true,
+				"unchanged": false,
+				"added":     added,
+				"deleted":   deleted,
+			})
+		}
+		if quiet {
+			fmt.Println(page.ExternalID)
+			return nil
+		}
+		printSuccess("Restored page \"%s\" (%s) to %s", page.Title, page.ExternalID, revision.name())
+		printInfo("  %d lines added, %d deleted; the replaced content is kept in the page history", added, deleted)
+		return nil
+	},
+}
+
+func init() {
+	pageCmd.AddCommand(pageRestoreCmd)
+
+	pageRestoreCmd.Flags().StringVar(&pageRestoreRevision, "revision", "", "revision to restore: its number (12 or v12) or ID")
+	pageRestoreCmd.Flags().BoolVar(&pageRestoreDryRun, "dry-run", false, "show what would be restored without writing")
+	pageRestoreCmd.Flags().BoolVar(&pageUpdateForce, "force", false, "send even if the page would exceed the server's size limit")
+	pageRestoreCmd.Flags().StringVar(&pageIfUnmodified, "if-unmodified", "", "restore only if the page is still at this version, from 'page get --output json'")
+	_ = pageRestoreCmd.MarkFlagRequired("revision")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// runPageRestore restores page_x, whose v3 differs from its current
+// content, and returns stdout and the content written, if any.
+func runPageRestore(t *testing.T, revision string) (string, *api.UpdatePageContentRequest, error) {
+	t.Helper()
+	var written *api.UpdatePageContentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/pages/page_x/rewind/":
+			_ = json.NewEncoder(w).Encode(api.RevisionList{Items: []api.RevisionSummary{{ExternalID: "rw_3", Number: 3}}, Count: 1})
+		case r.URL.Path == "/pages/page_x/rewind/rw_3/":
+			_ = json.NewEncoder(w).Encode(api.Revision{
+				RevisionSummary: api.RevisionSummary{ExternalID: "rw_3", Number: 3, Created: "2026-03-03T14:04:00Z"},
+				Content:         "replicas: 1\nregion: us\n",
+			})
+		case r.URL.Path == "/pages/page_x/" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_x", Title: "Config", Details: &api.PageDetails{Content: "replicas: 3\nregion: us\n"}})
+		case r.URL.Path == "/pages/page_x/" && r.Method == http.MethodPut:
+			written = &api.UpdatePageContentRequest{}
+			_ = json.NewDecoder(r.Body).Decode(written)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_x", Title: "Config", Details: written.Details})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	pageRestoreRevision = revision
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := pageRestoreCmd.RunE(pageRestoreCmd, []string{"page_x"})
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	output, _ := io.ReadAll(r)
+	return string(output), written, err
+}
+
+func resetPageRestoreFlags() {
+	pageRestoreRevision = ""
+	pageRestoreDryRun = false
+	outputFmt = "text"
+	quiet = false
+}
+
+func TestPageRestore_Overwrites(t *testing.T) {
+	resetPageRestoreFlags()
+	defer resetPageRestoreFlags()
+
+	output, written, err := runPageRestore(t, "v3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if written == nil || written.Mode != "overwrite" || written.Details.Content != "replicas: 1\nregion: us\n" {
+		t.Fatalf("wrote %+v, want v3's content in overwrite mode", written)
+	}
+	if !strings.Contains(output, `Restored page "Config" (page_x) to v3`) || !strings.Contains(output, "1 lines added, 1 deleted") {
+		t.Errorf("output = %q", output)
+	}
+}
+
+func TestPageRestore_DryRun(t *testing.T) {
+	resetPageRestoreFlags()
+	defer resetPageRestoreFlags()
+	pageRestoreDryRun = true
+
+	output, written, err := runPageRestore(t, "rw_3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if written != nil {
+		t.Errorf("dry run wrote %+v", written)
+	}
+	want := `Would restore page "Config" (page_x) to v3 (Mar 3, 2026 2:04 PM): +1, -1 (23 B → 23 B)` + "\n" +
+		"@@ -1,2 +1,2 @@\n-replicas: 3\n+replicas: 1\n region: us\n"
+	if output != want {
+		t.Errorf("got:\n%s\nwant:\n%s", output, want)
+	}
+}
+
+func TestPageRestore_RejectsCurrent(t *testing.T) {
+	resetPageRestoreFlags()
+	defer resetPageRestoreFlags()
+	cfg = &config.Config{Token: "test-token"}
+	pageRestoreRevision = "current"
+
+	if err := pageRestoreCmd.RunE(pageRestoreCmd, []string{"page_x"}); err == nil || !strings.Contains(err.Error(), "not current") {
+		t.Errorf("err = %v, want current refused", err)
+	}
+}
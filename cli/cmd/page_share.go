@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageShareExpires string
+	pageShareRevoke  bool
+)
+
+var pageShareCmd = &cobra.Command{
+	Use:   "share <page-id>",
+	Short: "Create or revoke a public link to a page",
+	Long: `Create a public link to a page, which anyone with it can read without an
+account, so a log can be handed to a vendor without adding them to the org.
+A page has at most one link: creating one revokes the link it had, and
+--revoke stops it working.
+
+The server keeps links until they are revoked. With --expires, the expiry
+is recorded on the page and 'page gc' revokes the link once it has passed,
+so run 'page gc' from a scheduled job.
+
+Sharing requires edit access to the page.
+
+Examples:
+  hyperclast page share page_xyz789
+  hyperclast page share page_xyz789 --expires 24h
+  hyperclast page share page_xyz789 --revoke`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageShareRevoke && pageShareExpires != "" {
+			return fmt.Errorf("--revoke and --expires cannot be used together")
+		}
+		expires, err := parseExpires(pageShareExpires)
+		if err != nil {
+			return err
+		}
+
+		client := newClient()
+		page, err := client.GetPageMetadata(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if !page.CanEdit() {
+			action := "share page"
+			if pageShareRevoke {
+				action = "revoke the share link"
+			}
+			return &api.PermissionError{Action: action, Role: page.Role, Needs: "editor"}
+		}
+
+		if pageShareRevoke {
+			if page.AccessCode == "" {
+				return fmt.Errorf("page %s has no share link", page.ExternalID)
+			}
+			if err := revokeShareLink(client, page); err != nil {
+				return fmt.Errorf("failed to revoke the share link: %w", err)
+			}
+			if outputFmt == "json" {
+				return json.NewEncoder(os.Stdout).Encode(map[string]any{
+					"external_id": page.ExternalID,
+					"revoked":     true,
+				})
+			}
+			printSuccess("Revoked the share link to \"%s\" (%s)", page.Title, page.ExternalID)
+			return nil
+		}
+
+		// The server returns a page's existing code, so the old one is
+		// removed first for the new link to replace it.
+		if page.AccessCode != "" {
+			if err := client.RemoveAccessCode(page.ExternalID); err != nil {
+				return fmt.Errorf("failed to revoke the previous share link: %w", err)
+			}
+		}
+		code, err := client.CreateAccessCode(page.ExternalID)
+		if err != nil {
+			return fmt.Errorf("failed to share page: %w", err)
+		}
+		var expiresAt any
+		if !expires.IsZero() {
+			expiresAt = expires.Format(time.RFC3339)
+		}
+		if expiresAt != nil || shareExpiry(page) != "" {
+			if _, err := client.SetPageDetails(page.ExternalID, map[string]any{"share_expires_at": expiresAt}); err != nil {
+				// A link whose expiry is not recorded would never be
+				// revoked, so it is not handed out.
+				if expiresAt != nil {
+					_ = client.RemoveAccessCode(page.ExternalID)
+				}
+				return fmt.Errorf("failed to record the link's expiry: %w", err)
+			}
+		}
+		url := sharedPageURL(code)
+
+		if outputFmt == "json" {
+			out, _ := expiresAt.(string)
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"external_id": page.ExternalID,
+				"url":         url,
+				"expires_at":  out,
+			})
+		}
+		if quiet {
+			fmt.Println(url)
+			return nil
+		}
+		printSuccess("Shared \"%s\" (%s); anyone with this link can read it:", page.Title, page.ExternalID)
+		fmt.Printf("  %s\n", url)
+		if expiresAt != nil {
+			printInfo("  Expires %s, revoked by the first 'hyperclast page gc' after that", formatMetadataTime(expiresAt.(string)))
+		} else {
+			printInfo("  Works until revoked with 'hyperclast page share %s --revoke'", page.ExternalID)
+		}
+		return nil
+	},
+}
+
+// sharedPageURL is the public web address of a page shared with code.
+func sharedPageURL(code string) string {
+	return fmt.Sprintf("%s/share/pages/%s/", baseURL(), code)
+}
+
+// shareExpiry returns when a page's share link is due to be revoked, or ""
+// if it never is.
+func shareExpiry(page *api.Page) string {
+	if page.Details == nil {
+		return ""
+	}
+	return page.Details.ShareExpiresAt
+}
+
+// revokeShareLink removes a page's access code and the expiry recorded for
+// it.
+func revokeShareLink(client *api.Client, page *api.Page) error {
+	if err := client.RemoveAccessCode(page.ExternalID); err != nil {
+		return err
+	}
+	if shareExpiry(page) != "" {
+		if _, err := client.SetPageDetails(page.ExternalID, map[string]any{"share_expires_at": nil}); err != nil {
+			printDebug("Revoked the share link, but failed to clear its expiry on %s: %v", page.ExternalID, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageShareCmd)
+
+	pageShareCmd.Flags().StringVar(&pageShareExpires, "expires", "", "stop the link working after this period (e.g. 24h, 7d)")
+	pageShareCmd.Flags().BoolVar(&pageShareRevoke, "revoke", false, "revoke the page's share link")
+}
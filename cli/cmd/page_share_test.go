@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageShare(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "ci log", "build ok\n")
+
+	url := strings.TrimSpace(env.mustRun("page", "share", page.ExternalID, "--quiet"))
+	code := env.server.AccessCode(page.ExternalID)
+	if code == "" || url != env.url+"/share/pages/"+code+"/" {
+		t.Fatalf("page share printed %q, server has code %q; want the link for the new code", url, code)
+	}
+
+	before := time.Now()
+	out := env.mustRun("page", "share", page.ExternalID, "--expires", "24h")
+	newCode := env.server.AccessCode(page.ExternalID)
+	if newCode == code || !strings.Contains(out, "/share/pages/"+newCode+"/") {
+		t.Errorf("output %q, want a replacing link (old code %q, new %q)", out, code, newCode)
+	}
+	shared, _ := env.server.Page(page.ExternalID)
+	at, err := time.Parse(time.RFC3339, shared.Details.ShareExpiresAt)
+	if err != nil || at.Sub(before) < 24*time.Hour-time.Second || at.Sub(before) > 24*time.Hour+time.Minute {
+		t.Errorf("share_expires_at = %q, want 24 hours from now", shared.Details.ShareExpiresAt)
+	}
+
+	env.mustRun("page", "share", page.ExternalID, "--revoke")
+	if code := env.server.AccessCode(page.ExternalID); code != "" {
+		t.Errorf("access code = %q after --revoke, want none", code)
+	}
+	if revoked, _ := env.server.Page(page.ExternalID); revoked.Details.ShareExpiresAt != "" {
+		t.Errorf("share_expires_at = %q after --revoke, want it cleared", revoked.Details.ShareExpiresAt)
+	}
+	if _, _, err := env.run("page", "share", page.ExternalID, "--revoke"); err == nil {
+		t.Error("revoking a page without a link succeeded")
+	}
+}
+
+func TestPageShare_GCRevokesExpiredLinks(t *testing.T) {
+	env := newCLIEnv(t)
+	expired := env.server.AddPage(apitest.DefaultProjectID, "vendor log", "")
+	fresh := env.server.AddPage(apitest.DefaultProjectID, "other log", "")
+	env.mustRun("page", "share", expired.ExternalID, "--expires", "1h")
+	env.mustRun("page", "share", fresh.ExternalID, "--expires", "1h")
+	env.server.SetPageDetails(expired.ExternalID, map[string]any{"share_expires_at": time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)})
+
+	out := env.mustRun("page", "gc", "--output", "json")
+	var report struct {
+		ExpiredShares []expiredPage `json:"expired_shares"`
+		Revoked       int           `json:"revoked"`
+		Deleted       int           `json:"deleted"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("output %q: %v", out, err)
+	}
+	if len(report.ExpiredShares) != 1 || report.ExpiredShares[0].ExternalID != expired.ExternalID || report.Revoked != 1 || report.Deleted != 0 {
+		t.Errorf("report = %+v, want only %s's link revoked", report, expired.ExternalID)
+	}
+	if code := env.server.AccessCode(expired.ExternalID); code != "" {
+		t.Errorf("expired link still works (code %q)", code)
+	}
+	if env.server.AccessCode(fresh.ExternalID) == "" {
+		t.Error("link that has not expired was revoked")
+	}
+	if _, ok := env.server.Page(expired.ExternalID); !ok {
+		t.Error("page with an expired link was deleted, want only its link revoked")
+	}
+}
+
+func TestPageShare_Refusals(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "ci log", "")
+	viewed := env.server.AddPage(apitest.DefaultProjectID, "team notes", "")
+	env.server.SetPageRole(viewed.ExternalID, "viewer")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{page.ExternalID, "--revoke", "--expires", "1h"}, "cannot be used together"},
+		{[]string{page.ExternalID, "--expires", "tomorrow"}, "--expires"},
+		{[]string{viewed.ExternalID}, "requires editor"},
+	}
+	for _, tt := range tests {
+		_, _, err := env.run(append([]string{"page", "share"}, tt.args...)...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("page share %s = %v, want %q", strings.Join(tt.args, " "), err, tt.want)
+		}
+	}
+	if env.server.AccessCode(viewed.ExternalID) != "" {
+		t.Error("page shared by a viewer")
+	}
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var pageSplitOn string
+
+// createConcurrency is how many pages are created at once when one command
+// creates several.
+const createConcurrency = 4
+
+// pageDraft is one of several pages to create.
+type pageDraft struct {
+	Title   string
+	Content string
+	// Filetype overrides --filetype when set.
+	Filetype string
+	// Meta is extra metadata lines for --meta.
+	Meta []string
+}
+
+// checkSplitOn compiles --split-on, refusing flags that only make sense for
+// a single page. It returns nil when --split-on is not set.
+func checkSplitOn() (*regexp.Regexp, error) {
+	if pageSplitOn == "" {
+		return nil, nil
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{pageFromGitShow != "", "--from-git-show"},
+		{pageFromURL != "", "--url"},
+		{pageInteractive, "--interactive"},
+		{pageLinkFrom != "", "--link-from"},
+		{pagePreview, "--preview"},
+		{pageNewOpen, "--open"},
+		{pageSkipDuplicate, "--skip-duplicate"},
+		{upsertMode() != "", "--append-if-exists or --overwrite-if-exists"},
+		{csvColumnOpsSet(), "--csv-select, --csv-drop, and --csv-rename"},
+	} {
+		if conflict.set {
+			return nil, fmt.Errorf("--split-on cannot be combined with %s", conflict.flag)
+		}
+	}
+	re, err := regexp.Compile(pageSplitOn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --split-on pattern: %w", err)
+	}
+	return re, nil
+}
+
+// splitSections cuts content at each line matching re. The delimiter line
+// starts a section and is left out of it; its title is the pattern's first
+// capture group, or the whole match without one. Text before the first
+// delimiter becomes a section titled preamble. Sections with nothing but
+// whitespace are dropped. It also returns how many lines matched.
+func splitSections(content string, re *regexp.Regexp, preamble string) (sections []pageDraft, matched int) {
+	current := &pageDraft{Title: preamble}
+	var body strings.Builder
+	flush := func() {
+		if strings.TrimSpace(body.String()) != "" {
+			current.Content = body.String()
+			sections = append(sections, *current)
+		}
+		body.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		m := re.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+		if m == nil {
+			body.WriteString(line)
+			continue
+		}
+		flush()
+		matched++
+		title := m[0]
+		if len(m) > 1 {
+			title = m[1]
+		}
+		current = &pageDraft{Title: strings.TrimSpace(title)}
+	}
+	flush()
+	return sections, matched
+}
+
+// runPageNewSplit creates one page per section of content, cut with
+// --split-on.
+func runPageNewSplit(projectID string, content string, re *regexp.Regexp, expires time.Time, guard *uploadGuard) error {
+	preamble := pageTitle
+	if preamble == "" {
+		preamble = generateDefaultTitle()
+	}
+	sections, matched := splitSections(content, re, preamble)
+	if matched == 0 {
+		return fmt.Errorf("--split-on %q matched no lines; use page new without it to create a single page", pageSplitOn)
+	}
+	if len(sections) == 0 {
+		return fmt.Errorf("no content provided")
+	}
+
+	for i, s := range sections {
+		if s.Title == "" {
+			sections[i].Title = fmt.Sprintf("%s (%d)", preamble, i+1)
+		}
+	}
+	return createPages(projectID, sections, content, expires, guard)
+}
+
+// createdPage is the outcome of creating one of several pages.
+type createdPage struct {
+	ExternalID string `json:"external_id,omitempty"`
+	Title      string `json:"title"`
+	Filetype   string `json:"filetype,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// createPages creates each draft's page concurrently, with the page new
+// flags that apply to each page, and reports every page's result. Each page
+// expires at expires unless it is zero. input is what is saved if an
+// interrupt aborts the uploads.
+func createPages(projectID string, drafts []pageDraft, input string, expires time.Time, guard *uploadGuard) error {
+	if err := guard.beginUpload(input); err != nil {
+		return err
+	}
+
+	client := newClient()
+	results := make([]createdPage, len(drafts))
+	errs := make([]error, len(drafts))
+	creating := startProgress("create", len(drafts), "pages")
+	failures := forEachConcurrent(len(drafts), min(len(drafts), createConcurrency), func(i int) error {
+		d := drafts[i]
+		filetype := d.Filetype
+		if filetype == "" {
+			filetype = pageFiletype
+		}
+		if filetype == autoFiletype {
+			filetype = detectFiletype(d.Content, "txt")
+		}
+		content := d.Content
+		if pageMeta {
+			meta := d.Meta
+			if !expires.IsZero() {
+				meta = append(slices.Clone(meta), expiresLine(expires))
+			}
+			content = appendMetadata(content, meta...)
+		}
+		details := &api.PageDetails{Content: content, Filetype: filetype, Labels: pageLabels}
+		if !expires.IsZero() {
+			details.ExpiresAt = expires.Format(time.RFC3339)
+		}
+		if filetype == "csv" && !pageNoSchema {
+			details.Schema = inferCSVSchema(content)
+		}
+		if w := pageWrite("create", content); w != nil {
+			details.Writes = []api.PageWrite{*w}
+		}
+
+		results[i] = createdPage{Title: d.Title, Filetype: filetype}
+		page, err := client.CreatePageWithDetails(projectID, d.Title, details)
+		status := "created"
+		if err != nil {
+			status = "failed"
+			errs[i] = err
+		} else {
+			results[i].ExternalID = page.ExternalID
+		}
+		creating.advance(d.Title, status, err)
+		return err
+	})
+	for i, msg := range failures {
+		results[i].Error = msg
+	}
+
+	if err := printCreatedPages(results); err != nil {
+		return err
+	}
+
+	for _, err := range errs {
+		if aborted := guard.aborted(err, input); aborted != nil {
+			return aborted
+		}
+	}
+	if len(failures) > 0 {
+		return handleContentError(fmt.Errorf("%d of %d pages could not be created", len(failures), len(drafts)))
+	}
+	cleanupStdinTemp()
+	return guard.interruptedAfterUpload()
+}
+
+// printCreatedPages prints a table of the pages created, with a warning for
+// each that failed; with --quiet, just the IDs.
+func printCreatedPages(results []createdPage) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(results)
+	}
+
+	created := 0
+	for _, r := range results {
+		if r.Error != "" {
+			printWarning("%s: %s", r.Title, r.Error)
+			continue
+		}
+		created++
+		if quiet {
+			fmt.Println(r.ExternalID)
+		}
+	}
+	if quiet || created == 0 {
+		return nil
+	}
+
+	printSuccess("Created %d pages", created)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTITLE\tTYPE\tURL")
+	for _, r := range results {
+		if r.Error == "" {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.ExternalID, r.Title, r.Filetype, pageURL(r.ExternalID))
+		}
+	}
+	return w.Flush()
+}
+
+func init() {
+	pageNewCmd.Flags().StringVar(&pageSplitOn, "split-on", "", "create one page per section of the input, starting at each line matching this regex (the first capture group is the title)")
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestSplitSections(t *testing.T) {
+	re := regexp.MustCompile(`^=== (.+) ===$`)
+	input := "harness v2\n=== unit ===\nok 12\n=== lint ===\n\n=== e2e ===\nFAIL login\n"
+
+	sections, matched := splitSections(input, re, "Report")
+	if matched != 3 {
+		t.Errorf("matched = %d, want 3", matched)
+	}
+	want := []pageDraft{
+		{Title: "Report", Content: "harness v2\n"},
+		{Title: "unit", Content: "ok 12\n"},
+		{Title: "e2e", Content: "FAIL login\n"},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("sections = %+v, want %+v (the empty lint section dropped)", sections, want)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(sections[i], want[i]) {
+			t.Errorf("section %d = %+v, want %+v", i, sections[i], want[i])
+		}
+	}
+
+	sections, _ = splitSections("--- a\nx\n", regexp.MustCompile(`^--- \w+`), "Report")
+	if len(sections) != 1 || sections[0].Title != "--- a" {
+		t.Errorf("without a capture group, sections = %+v, want the whole match as the title", sections)
+	}
+}
+
+func TestPageNew_SplitOn(t *testing.T) {
+	env := newCLIEnv(t)
+	file := filepath.Join(t.TempDir(), "report.txt")
+	report := "=== unit ===\nok 12\n=== users ===\nname,email,role\nann,a@example.com,admin\nbob,b@example.com,viewer\ncy,c@example.com,editor\n"
+	if err := os.WriteFile(file, []byte(report), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := env.mustRun("page", "new", "--project", apitest.DefaultProjectID, "--file", file,
+		"--split-on", "^=== (.+) ===$", "--label", "ci", "--output", "json")
+	var results []createdPage
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("output %q: %v", out, err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want 2 pages", results)
+	}
+
+	unit, _ := env.server.Page(results[0].ExternalID)
+	users, _ := env.server.Page(results[1].ExternalID)
+	if unit.Title != "unit" || unit.Details.Content != "ok 12\n" {
+		t.Errorf("first page = %q %q", unit.Title, unit.Details.Content)
+	}
+	if users.Title != "users" || users.Details.Filetype != "csv" {
+		t.Errorf("second page = %q (%s), want a csv page per its own content", users.Title, users.Details.Filetype)
+	}
+	if strings.Join(users.Details.Labels, ",") != "ci" {
+		t.Errorf("labels = %v, want --label applied to every page", users.Details.Labels)
+	}
+}
+
+func TestPageNew_SplitOnErrors(t *testing.T) {
+	env := newCLIEnv(t)
+	file := filepath.Join(t.TempDir(), "report.txt")
+	_ = os.WriteFile(file, []byte("no delimiters here\n"), 0644)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--split-on", "^=== (.+) ===$"}, "matched no lines"},
+		{[]string{"--split-on", "(unclosed"}, "invalid --split-on pattern"},
+		{[]string{"--split-on", "^=== (.+) ===$", "--link-from", "page_1"}, "cannot be combined with --link-from"},
+	}
+	for _, tt := range tests {
+		_, _, err := env.run(append([]string{"page", "new", "--project", apitest.DefaultProjectID, "--file", file}, tt.args...)...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("page new %s = %v, want %q", strings.Join(tt.args, " "), err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var pageStatusIcon string
+
+// pageStatuses are the accepted statuses and their indicators, in
+// workflow order.
+var pageStatuses = []struct {
+	Name      string
+	Indicator string
+}{
+	{"todo", "⬜"},
+	{"in-progress", "🔄"},
+	{"blocked", "⛔"},
+	{"done", "✅"},
+}
+
+var pageSetStatusCmd = &cobra.Command{
+	Use:   "set-status <page-id> <todo|in-progress|blocked|done|none>",
+	Short: "Set a page's status",
+	Long: `Set a task status on a page so capture pages can double as lightweight
+task tracking. The status is stored in the page details and shown in a
+STATUS column by 'page list'. Use "none" to clear it.
+
+Examples:
+  hyperclast page set-status page_xyz789 in-progress
+  hyperclast page set-status page_xyz789 done --icon 🚀
+  hyperclast page set-status page_xyz789 none`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		pageID, status := args[0], args[1]
+		fields := map[string]any{}
+		if status == "none" {
+			fields["status"] = nil
+		} else {
+			if statusIndicator(status) == "" {
+				return fmt.Errorf("invalid status %q (must be one of: %s, none)", status, strings.Join(pageStatusNames(), ", "))
+			}
+			fields["status"] = status
+		}
+		if cmd.Flags().Changed("icon") {
+			if pageStatusIcon == "" {
+				fields["icon"] = nil
+			} else {
+				fields["icon"] = pageStatusIcon
+			}
+		}
+
+		client := newClient()
+		page, err := client.SetPageDetails(pageID, fields)
+		if err != nil {
+			return fmt.Errorf("failed to set status: %w", err)
+		}
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(page)
+		}
+
+		if quiet {
+			fmt.Println(page.ExternalID)
+			return nil
+		}
+
+		if status == "none" {
+			printSuccess("Cleared status of page \"%s\" (%s)", page.Title, page.ExternalID)
+			return nil
+		}
+		printSuccess("Set status of page \"%s\" (%s) to %s", page.Title, page.ExternalID, formatPageStatus(page))
+		return nil
+	},
+}
+
+func pageStatusNames() []string {
+	names := make([]string, len(pageStatuses))
+	for i, s := range pageStatuses {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// statusIndicator returns the emoji for status, or "" if it is unknown.
+func statusIndicator(status string) string {
+	for _, s := range pageStatuses {
+		if s.Name == status {
+			return s.Indicator
+		}
+	}
+	return ""
+}
+
+// formatPageStatus renders a page's status for tables: its icon (or the
+// status indicator) followed by the status name.
+func formatPageStatus(page *api.Page) string {
+	if page.Details == nil || (page.Details.Status == "" && page.Details.Icon == "") {
+		return ""
+	}
+	icon := page.Details.Icon
+	if icon == "" {
+		icon = statusIndicator(page.Details.Status)
+	}
+	return strings.TrimSpace(icon + " " + page.Details.Status)
+}
+
+// fillPageStatuses sets the status and icon of pages from a project
+// listing, which omits page details. They are read from the listing of all
+// pages, which includes them, most recently updated first, stopping once
+// every page is found.
+func fillPageStatuses(client *api.Client, pages []api.Page) error {
+	index := make(map[string]int, len(pages))
+	for i := range pages {
+		index[pages[i].ExternalID] = i
+	}
+	for page, err := range client.AllPages("", api.DefaultPageBatch) {
+		if err != nil {
+			return fmt.Errorf("failed to list page statuses: %w", err)
+		}
+		i, ok := index[page.ExternalID]
+		if !ok {
+			continue
+		}
+		if page.Details != nil {
+			pages[i].Details = &api.PageDetails{Status: page.Details.Status, Icon: page.Details.Icon}
+		}
+		delete(index, page.ExternalID)
+		if len(index) == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// hasPageStatus reports whether any page carries a status or icon.
+func hasPageStatus(pages []api.Page) bool {
+	for i := range pages {
+		if formatPageStatus(&pages[i]) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	pageCmd.AddCommand(pageSetStatusCmd)
+
+	pageSetStatusCmd.Flags().StringVar(&pageStatusIcon, "icon", "", "page icon (an emoji) shown instead of the status indicator; empty clears it")
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestPageSetStatus_SendsDetailsOnly(t *testing.T) {
+	resetPageFlags()
+
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Title:      "Deploy",
+			Details:    &api.PageDetails{Status: "done"},
+		})
+	}))
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	quiet = true
+	defer func() { quiet = false }()
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	err := pageSetStatusCmd.RunE(pageSetStatusCmd, []string{"page_xyz", "done"})
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["title"] != "Deploy" {
+		t.Errorf("title = %v, want existing title", body["title"])
+	}
+	details, _ := body["details"].(map[string]any)
+	if details["status"] != "done" {
+		t.Errorf("details = %v, want status done", details)
+	}
+	if _, ok := details["content"]; ok {
+		t.Error("content must not be sent, or the server would replace it")
+	}
+	if _, ok := body["mode"]; ok {
+		t.Error("mode should not be sent for a details-only update")
+	}
+}
+
+func TestPageSetStatus_NoneClears(t *testing.T) {
+	resetPageFlags()
+
+	var raw string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			b, _ := io.ReadAll(r.Body)
+			raw = string(b)
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Title: "Deploy"})
+	}))
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	quiet = true
+	defer func() { quiet = false }()
+
+	if err := pageSetStatusCmd.RunE(pageSetStatusCmd, []string{"page_xyz", "none"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(raw, `"status":null`) {
+		t.Errorf("body = %s, want status null", raw)
+	}
+}
+
+func TestPageSetStatus_Invalid(t *testing.T) {
+	resetPageFlags()
+	cfg = &config.Config{Token: "test-token"}
+
+	err := pageSetStatusCmd.RunE(pageSetStatusCmd, []string{"page_xyz", "shipped"})
+	if err == nil || !strings.Contains(err.Error(), "todo, in-progress, blocked, done, none") {
+		t.Errorf("err = %v, want list of valid statuses", err)
+	}
+}
+
+func TestPageList_StatusColumn(t *testing.T) {
+	resetPageFlags()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []api.Page{
+				{ExternalID: "page_a", Title: "Deploy", Details: &api.PageDetails{Status: "in-progress"}},
+				{ExternalID: "page_b", Title: "Notes", Details: &api.PageDetails{Icon: "📌"}},
+				{ExternalID: "page_c", Title: "Log"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := pageListCmd.RunE(pageListCmd, nil)
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output, _ := io.ReadAll(r)
+	out := string(output)
+	for _, want := range []string{"STATUS", "🔄 in-progress", "📌"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPageList_ShowStatusListsOnce(t *testing.T) {
+	env := newCLIEnv(t)
+	deploy := env.server.AddPage(apitest.DefaultProjectID, "Deploy", "")
+	env.server.SetPageDetails(deploy.ExternalID, map[string]any{"status": "done"})
+	for _, title := range []string{"Notes", "Log", "Todo"} {
+		env.server.AddPage(apitest.DefaultProjectID, title, "")
+	}
+
+	var pageGets atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/pages/") && r.URL.Path != "/pages/" {
+			pageGets.Add(1)
+		}
+		env.server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	env.url = ts.URL
+
+	out := env.mustRun("page", "list", "--project", apitest.DefaultProjectID, "--show-status")
+	if !strings.Contains(out, "done") {
+		t.Errorf("output missing the status:\n%s", out)
+	}
+	if n := pageGets.Load(); n != 0 {
+		t.Errorf("%d page requests, want statuses from the listing", n)
+	}
+}
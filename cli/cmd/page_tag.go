@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var pageListTags []string
+
+var pageTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add, remove, and list a page's tags",
+	Long: `Tags are the page's labels: the same details.labels that 'capture --label'
+and 'page bulk-label' set, so tags added either way show up here and in
+'page list --tag'.`,
+}
+
+var pageTagAddCmd = &cobra.Command{
+	Use:   "add <page-id> <tag>...",
+	Short: "Tag a page",
+	Long: `Add tags to a page. Tags it already has are left alone.
+
+Examples:
+  hyperclast page tag add page_xyz789 nightly
+  hyperclast page tag add page_xyz789 ci env=staging`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPageTag(args[0], args[1:], nil)
+	},
+}
+
+var pageTagRmCmd = &cobra.Command{
+	Use:     "rm <page-id> <tag>...",
+	Aliases: []string{"remove"},
+	Short:   "Remove tags from a page",
+	Long: `Remove tags from a page. Tags it does not have are ignored.
+
+Examples:
+  hyperclast page tag rm page_xyz789 nightly`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPageTag(args[0], nil, args[1:])
+	},
+}
+
+var pageTagListCmd = &cobra.Command{
+	Use:   "list <page-id>",
+	Short: "List a page's tags",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		page, err := newClient().GetPageMetadata(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		tags := pageTags(page)
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"page_id": page.ExternalID,
+				"title":   page.Title,
+				"tags":    tags,
+			})
+		}
+		if len(tags) == 0 && !quiet {
+			printInfo("Page \"%s\" (%s) has no tags", page.Title, page.ExternalID)
+			return nil
+		}
+		for _, tag := range tags {
+			fmt.Println(tag)
+		}
+		return nil
+	},
+}
+
+// runPageTag adds and removes tags on a page in one details update,
+// skipping the update when the tags would not change.
+func runPageTag(pageID string, add, remove []string) error {
+	if err := requireAuth(); err != nil {
+		return err
+	}
+	for _, tag := range slices.Concat(add, remove) {
+		if err := validateTag(tag); err != nil {
+			return err
+		}
+	}
+
+	client := newClient()
+	page, err := client.GetPageMetadata(pageID)
+	if err != nil {
+		return fmt.Errorf("failed to get page: %w", err)
+	}
+	before := pageTags(page)
+	after := relabel(before, add, remove)
+	changed := !slices.Equal(before, after)
+
+	if changed {
+		var labels any = after
+		if len(after) == 0 {
+			labels = nil
+		}
+		if page, err = client.SetPageDetails(pageID, map[string]any{"labels": labels}); err != nil {
+			return fmt.Errorf("failed to update tags: %w", err)
+		}
+	}
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"page_id": page.ExternalID,
+			"title":   page.Title,
+			"tags":    after,
+			"changed": changed,
+		})
+	}
+	if quiet {
+		fmt.Println(page.ExternalID)
+		return nil
+	}
+	if !changed {
+		printInfo("Tags of page \"%s\" (%s) unchanged: %s", page.Title, page.ExternalID, formatTags(after))
+		return nil
+	}
+	printSuccess("Tags of page \"%s\" (%s): %s", page.Title, page.ExternalID, formatTags(after))
+	return nil
+}
+
+// validateTag rejects tags that could not be told apart in the
+// comma-separated lists tags are printed in.
+func validateTag(tag string) error {
+	if strings.TrimSpace(tag) == "" {
+		return fmt.Errorf("tags must not be empty")
+	}
+	if strings.ContainsAny(tag, ",\n") {
+		return fmt.Errorf("invalid tag %q: tags cannot contain commas or newlines", tag)
+	}
+	return nil
+}
+
+func pageTags(page *api.Page) []string {
+	if page.Details == nil {
+		return []string{}
+	}
+	return mergeLabels(nil, page.Details.Labels)
+}
+
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "(none)"
+	}
+	return strings.Join(tags, ", ")
+}
+
+// filterPagesByTags keeps the pages that have every tag. A project listing
+// omits page details, so, as for fillPageStatuses, tags are then read from
+// the listing of all pages, most recently updated first, stopping once
+// every page is found.
+func filterPagesByTags(client *api.Client, pages []api.Page, tags []string) ([]api.Page, error) {
+	hasTags := func(page *api.Page) bool {
+		have := pageTags(page)
+		return !slices.ContainsFunc(tags, func(t string) bool { return !slices.Contains(have, t) })
+	}
+
+	tagged := make(map[string]bool, len(pages))
+	missing := make(map[string]bool)
+	for i := range pages {
+		if pages[i].Details == nil {
+			missing[pages[i].ExternalID] = true
+		} else if hasTags(&pages[i]) {
+			tagged[pages[i].ExternalID] = true
+		}
+	}
+	if len(missing) > 0 {
+		for page, err := range client.AllPages("", api.DefaultPageBatch) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to list page tags: %w", err)
+			}
+			if !missing[page.ExternalID] {
+				continue
+			}
+			tagged[page.ExternalID] = hasTags(&page)
+			delete(missing, page.ExternalID)
+			if len(missing) == 0 {
+				break
+			}
+		}
+	}
+
+	kept := []api.Page{}
+	for _, page := range pages {
+		if tagged[page.ExternalID] {
+			kept = append(kept, page)
+		}
+	}
+	return kept, nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageTagCmd)
+	pageTagCmd.AddCommand(pageTagAddCmd)
+	pageTagCmd.AddCommand(pageTagRmCmd)
+	pageTagCmd.AddCommand(pageTagListCmd)
+
+	pageListCmd.Flags().StringArrayVar(&pageListTags, "tag", nil, "list only pages with this tag (repeatable; pages must have all)")
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageTag_AddRemoveList(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "ok\n")
+
+	env.mustRun("page", "tag", "add", page.ExternalID, "ci", "nightly")
+	env.mustRun("page", "tag", "add", page.ExternalID, "ci", "arm")
+	if out := env.mustRun("page", "tag", "list", page.ExternalID); out != "ci\nnightly\narm\n" {
+		t.Errorf("tags after add = %q, want ci, nightly, arm in order", out)
+	}
+
+	out := env.mustRun("page", "tag", "rm", page.ExternalID, "nightly", "missing", "--output", "json")
+	var result struct {
+		Tags    []string `json:"tags"`
+		Changed bool     `json:"changed"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if !result.Changed || strings.Join(result.Tags, ",") != "ci,arm" {
+		t.Errorf("rm result = %+v, want ci,arm changed", result)
+	}
+
+	got, _ := env.server.Page(page.ExternalID)
+	if strings.Join(got.Details.Labels, ",") != "ci,arm" || got.Details.Content != "ok\n" {
+		t.Errorf("stored details = %+v, want labels ci,arm and content untouched", got.Details)
+	}
+}
+
+func TestPageTag_RemoveLastClearsLabels(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "")
+
+	env.mustRun("page", "tag", "add", page.ExternalID, "ci")
+	env.mustRun("page", "tag", "rm", page.ExternalID, "ci")
+
+	got, _ := env.server.Page(page.ExternalID)
+	if got.Details.Labels != nil {
+		t.Errorf("labels = %v, want none", got.Details.Labels)
+	}
+	if out := env.mustRun("page", "tag", "list", page.ExternalID, "--quiet"); out != "" {
+		t.Errorf("tag list of untagged page = %q, want nothing", out)
+	}
+}
+
+func TestPageTag_RejectsComma(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "")
+
+	_, _, err := env.run("page", "tag", "add", page.ExternalID, "a,b")
+	if err == nil || !strings.Contains(err.Error(), "cannot contain commas") {
+		t.Fatalf("tag add a,b = %v, want comma error", err)
+	}
+}
+
+func TestPageList_FilterByTag(t *testing.T) {
+	env := newCLIEnv(t)
+	a := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "")
+	b := env.server.AddPage(apitest.DefaultProjectID, "Build 42", "")
+	c := env.server.AddPage(apitest.DefaultProjectID, "Notes", "")
+	env.mustRun("page", "tag", "add", a.ExternalID, "ci", "nightly")
+	env.mustRun("page", "tag", "add", b.ExternalID, "ci")
+
+	out := env.mustRun("page", "list", "--tag", "ci", "--format", "{{.ExternalID}}")
+	if lines := strings.Fields(out); len(lines) != 2 || strings.Contains(out, c.ExternalID) {
+		t.Errorf("page list --tag ci = %q, want the two builds", out)
+	}
+	out = env.mustRun("page", "list", "--project", apitest.DefaultProjectID, "--tag", "ci", "--tag", "nightly", "--format", "{{.ExternalID}}")
+	if strings.TrimSpace(out) != a.ExternalID {
+		t.Errorf("page list --tag ci --tag nightly = %q, want only %s", out, a.ExternalID)
+	}
+}
+
+func TestPageList_FilterByTagReadsListing(t *testing.T) {
+	env := newCLIEnv(t)
+	tagged := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "")
+	env.server.SetPageDetails(tagged.ExternalID, map[string]any{"labels": []any{"ci"}})
+	for _, title := range []string{"Notes", "Log", "Todo"} {
+		env.server.AddPage(apitest.DefaultProjectID, title, "")
+	}
+
+	var pageGets atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/pages/") && r.URL.Path != "/pages/" {
+			pageGets.Add(1)
+		}
+		env.server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	env.url = ts.URL
+
+	out := env.mustRun("page", "list", "--project", apitest.DefaultProjectID, "--tag", "ci", "--format", "{{.ExternalID}}")
+	if strings.TrimSpace(out) != tagged.ExternalID {
+		t.Errorf("page list --tag ci = %q, want only %s", out, tagged.ExternalID)
+	}
+	if n := pageGets.Load(); n != 0 {
+		t.Errorf("%d page requests, want tags from the listing", n)
+	}
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageTailLines    int
+	pageTailFollow   bool
+	pageTailInterval time.Duration
+)
+
+// tailWindow is how much of the end of a page 'page tail' fetches first.
+// It grows fourfold until it holds the lines asked for.
+const tailWindow = 64 << 10
+
+var pageTailCmd = &cobra.Command{
+	Use:   "tail <page-id>",
+	Short: "Print the last lines of a page",
+	Long: `Print the last lines of a page, like tail(1), fetching only the end of the
+page rather than all of it. With -f, keep printing what is appended until
+Ctrl-C.
+
+Examples:
+  hyperclast page tail page_xyz789
+  hyperclast page tail page_xyz789 -n 50
+  hyperclast page tail page_xyz789 -f`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageTailLines < 0 {
+			return fmt.Errorf("--lines must not be negative")
+		}
+		if pageTailFollow && pageTailInterval < minFollowInterval {
+			return fmt.Errorf("--interval must be at least %s", minFollowInterval)
+		}
+
+		client := newClient()
+		t := &pageTailer{client: client, pageID: args[0]}
+		lines, err := t.tail(pageTailLines)
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if outputFmt == "json" {
+			if err := json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"external_id": args[0],
+				"lines":       splitLines(lines),
+			}); err != nil {
+				return err
+			}
+		} else {
+			fmt.Print(lines)
+		}
+		if !pageTailFollow {
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return t.follow(ctx, pageTailInterval, os.Stdout)
+	},
+}
+
+// pageTailer reads a page from the end, remembering how far it has read so
+// that following it fetches only what was appended.
+type pageTailer struct {
+	client *api.Client
+	pageID string
+	// offset is how many bytes of the page have been read.
+	offset int64
+	// ranged is set once the server has honored a range request. Without
+	// ranges, the page is fetched whole, but only once it was updated, and
+	// its content read from details.content rather than its download.
+	ranged  bool
+	updated string
+}
+
+// tail returns the last n lines of the page, fetching a growing window from
+// its end until the window holds them or the whole page.
+func (t *pageTailer) tail(n int) (string, error) {
+	for window := int64(tailWindow); ; window *= 4 {
+		r, err := t.client.GetPageRange(t.pageID, -window)
+		if err != nil {
+			return "", err
+		}
+		t.ranged = r.Partial
+		t.offset = r.Start + int64(len(r.Content))
+		content := r.Content
+		if r.Start > 0 {
+			// The window starts mid-line, maybe mid-character.
+			_, content, _ = bytes.Cut(content, []byte("\n"))
+		}
+		lines := bytes.Count(content, []byte("\n"))
+		if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+			lines++
+		}
+		if r.Start == 0 || lines >= n {
+			return lastLines(string(content), n), nil
+		}
+	}
+}
+
+// appended returns what was added to the page since it was last read. A
+// page that shrank was rewritten, so its last n lines are returned instead,
+// like tail -F on a truncated file.
+func (t *pageTailer) appended(n int) (string, error) {
+	var r *api.PageRange
+	if t.ranged {
+		var err error
+		if r, err = t.client.GetPageRange(t.pageID, t.offset); err != nil {
+			return "", err
+		}
+	} else {
+		meta, err := t.client.GetPageMetadata(t.pageID)
+		if err != nil {
+			return "", err
+		}
+		if u := pageUpdated(meta); u != "" && u == t.updated {
+			return "", nil
+		}
+		t.updated = pageUpdated(meta)
+		page, err := t.client.GetPage(t.pageID)
+		if err != nil {
+			return "", err
+		}
+		content := pageContent(page)
+		r = &api.PageRange{Content: []byte(content), Size: int64(len(content))}
+	}
+	if r.Size < t.offset {
+		printWarning("page %s was rewritten; showing its last %d lines", t.pageID, n)
+		return t.tail(n)
+	}
+	content := r.Content
+	if !r.Partial {
+		content = content[t.offset:]
+	}
+	t.offset = r.Size
+	return string(content), nil
+}
+
+// follow prints what is appended to the page every interval until ctx is
+// cancelled. Fetch errors are reported and polling continues.
+func (t *pageTailer) follow(ctx context.Context, interval time.Duration, out io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		added, err := t.appended(pageTailLines)
+		if err != nil {
+			printWarning("%v (retrying)", err)
+			continue
+		}
+		if added == "" {
+			continue
+		}
+		if outputFmt == "json" {
+			err = json.NewEncoder(out).Encode(map[string]any{
+				"time":        time.Now().UTC().Format(time.RFC3339),
+				"added_lines": splitLines(added),
+			})
+		} else {
+			_, err = fmt.Fprint(out, added)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// lastLines returns the last n lines of s, a final line without a newline
+// counting as one.
+func lastLines(s string, n int) string {
+	if n == 0 {
+		return ""
+	}
+	end := len(s)
+	if strings.HasSuffix(s, "\n") {
+		end--
+	}
+	for i := end - 1; i >= 0; i-- {
+		if s[i] == '\n' {
+			n--
+			if n == 0 {
+				return s[i+1:]
+			}
+		}
+	}
+	return s
+}
+
+// splitLines splits s into lines without their newlines.
+func splitLines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func init() {
+	pageCmd.AddCommand(pageTailCmd)
+
+	pageTailCmd.Flags().IntVarP(&pageTailLines, "lines", "n", 10, "number of lines to print")
+	pageTailCmd.Flags().BoolVarP(&pageTailFollow, "follow", "f", false, "keep printing lines as they are appended, until Ctrl-C")
+	pageTailCmd.Flags().DurationVar(&pageTailInterval, "interval", 2*time.Second, "how often to check for new lines with --follow")
+}
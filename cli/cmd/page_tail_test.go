@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestLastLines(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"a\nb\nc\n", 2, "b\nc\n"},
+		{"a\nb\nc", 2, "b\nc"},
+		{"a\nb\n", 5, "a\nb\n"},
+		{"a\nb\n", 0, ""},
+		{"", 3, ""},
+	}
+	for _, tt := range tests {
+		if got := lastLines(tt.s, tt.n); got != tt.want {
+			t.Errorf("lastLines(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPageTail(t *testing.T) {
+	env := newCLIEnv(t)
+	var b strings.Builder
+	for i := 1; i <= 20000; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	big := env.server.AddPage(apitest.DefaultProjectID, "build log", b.String())
+	empty := env.server.AddPage(apitest.DefaultProjectID, "empty", "")
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		env.server.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	env.url = ts.URL
+
+	out := env.mustRun("page", "tail", big.ExternalID, "-n", "3")
+	if out != "line 19998\nline 19999\nline 20000\n" {
+		t.Errorf("page tail -n 3 = %q, want the last 3 lines", out)
+	}
+	if len(requests) == 0 {
+		t.Fatal("page tail made no requests")
+	}
+	for _, path := range requests {
+		if path == "/pages/"+big.ExternalID+"/" {
+			t.Errorf("page tail fetched the whole page: %s", path)
+		}
+	}
+
+	// More lines than the first window holds.
+	out = env.mustRun("page", "tail", big.ExternalID, "-n", "15000")
+	if lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n"); len(lines) != 15000 || lines[0] != "line 5001" {
+		t.Errorf("page tail -n 15000 printed %d lines from %q", len(lines), lines[0])
+	}
+
+	if out := env.mustRun("page", "tail", empty.ExternalID); out != "" {
+		t.Errorf("page tail of an empty page = %q", out)
+	}
+	if out := env.mustRun("page", "tail", big.ExternalID, "-n", "1", "--output", "json"); !strings.Contains(out, `"lines":["line 20000"]`) {
+		t.Errorf("page tail --output json = %q", out)
+	}
+}
+
+func TestPageTailer_Follow(t *testing.T) {
+	env := newCLIEnv(t)
+	created := env.server.AddPage(apitest.DefaultProjectID, "build log", "one\ntwo\n")
+	client := api.NewClient(env.url, "integration-token")
+	tailer := &pageTailer{client: client, pageID: created.ExternalID}
+	if got, err := tailer.tail(1); err != nil || got != "two\n" {
+		t.Fatalf("tail = %q, %v", got, err)
+	}
+
+	if added, err := tailer.appended(1); err != nil || added != "" {
+		t.Errorf("appended with no change = %q, %v", added, err)
+	}
+	if _, err := client.UpdatePageContent(created.ExternalID, "three\n", "append"); err != nil {
+		t.Fatal(err)
+	}
+	if added, err := tailer.appended(1); err != nil || added != "three\n" {
+		t.Errorf("appended = %q, %v, want the appended line", added, err)
+	}
+
+	if _, err := client.UpdatePageContent(created.ExternalID, "new\n", "overwrite"); err != nil {
+		t.Fatal(err)
+	}
+	if added, err := tailer.appended(1); err != nil || added != "new\n" {
+		t.Errorf("appended after a rewrite = %q, %v, want the last line again", added, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := tailer.follow(ctx, 10*time.Millisecond, &syncBuffer{}); err != nil {
+		t.Errorf("follow = %v, want nil once cancelled", err)
+	}
+}
+
+func TestPageTailer_WithoutRanges(t *testing.T) {
+	env := newCLIEnv(t)
+	created := env.server.AddPage(apitest.DefaultProjectID, "build log", "one\ntwo\n")
+	env.server.SetPageDetails(created.ExternalID, map[string]any{"filetype": "md"})
+
+	// Like the API: the download ignores Range and heads an md page with
+	// its title.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pages/"+created.ExternalID+"/download/" {
+			page, _ := env.server.Page(created.ExternalID)
+			_, _ = fmt.Fprintf(w, "# %s\n\n%s", page.Title, page.Details.Content)
+			return
+		}
+		env.server.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	client := api.NewClient(ts.URL, "integration-token")
+
+	tailer := &pageTailer{client: client, pageID: created.ExternalID}
+	if got, err := tailer.tail(5); err != nil || got != "one\ntwo\n" {
+		t.Fatalf("tail = %q, %v, want the content without the heading", got, err)
+	}
+	if _, err := client.UpdatePageContent(created.ExternalID, "three\n", "append"); err != nil {
+		t.Fatal(err)
+	}
+	if added, err := tailer.appended(5); err != nil || added != "three\n" {
+		t.Errorf("appended = %q, %v, want the appended line", added, err)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hyperclast/workspace/cli/internal/api"
 	"github.com/hyperclast/workspace/cli/internal/config"
@@ -692,11 +694,66 @@ func resetPageFlags() {
 	pageProjectID = ""
 	pageTitle = ""
 	pageFile = ""
-	pageFiletype = "txt"
+	pageFiletype = autoFiletype
 	pageMeta = false
 	pageSource = ""
+	pageSection = ""
+	pageCSVSelect = nil
+	pageCSVDrop = nil
+	pageCSVRename = nil
+	pageNoSchema = false
+	pageFromGitShow = ""
+	pageFromURL = ""
+	pageToMarkdown = false
+	pageSplitOn = ""
+	pageFiles = nil
+	pageExpires = ""
+	pageLinkFrom = ""
+	pageInteractive = false
+	pageOnBehalfOf = ""
+	pagePreview = false
+	pageUpdateForce = false
+	pageIfUnmodified = ""
+	pageSubstitute = false
+	pageAppendJournal = false
+	pageAppendMaxLines = 0
+	pageAppendMaxBytes = ""
+	pageAppendKeep = "tail"
+	pageGetSection = ""
+	pageGetFollow = false
+	pageGetDiff = false
+	pageGetInterval = 2 * time.Second
+	pageGetMetadataOnly = false
+	pageGetForce = false
+	pageGetHTML = false
+	pageGetOut = ""
+	pageGetDir = ""
+	pageGetProjectID = ""
+	pageGetPDF = false
+	pageGetRender = false
+	pageGetPlain = false
+	pageGetTable = false
+	pageAppendAlso = nil
+	pagePreset = ""
+	pageLabels = nil
+	pageRedact = false
+	pageGetMaxRows = 50
+	pageGetMaxCellWidth = 40
 	pageDeleteForce = false
 	pageListProjectID = ""
+	pageListJSONSchema = false
+	pageListShowStatus = false
+	pageListArchived = false
+	pageListUpdatedWithin = 0
+	pageListFollow = false
+	pageListInterval = 5 * time.Second
+	pageListFormat = ""
+	pageListLimit = 0
+	pageListPage = 0
+	pageListCursor = ""
+	pageListAll = false
+	listSort = ""
+	listReverse = false
 	outputFmt = "text"
 	quiet = false
 	stdinTempPath = ""
@@ -981,3 +1038,99 @@ func TestPageGet_JSONOutput(t *testing.T) {
 		t.Errorf("external_id = %q, want page_xyz", page.ExternalID)
 	}
 }
+
+func TestPageGet_Section(t *testing.T) {
+	resetPageFlags()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Title:      "Deploys",
+			Details: &api.PageDetails{
+				Content: "intro\n" + wrapSection("rolled out v2", "deploy 2024-06-01"),
+			},
+		})
+	}))
+	defer server.Close()
+
+	cfg = &config.Config{
+		APIURL: server.URL,
+		Token:  "test-token",
+	}
+	pageGetSection = "deploy 2024-06-01"
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, _ := io.ReadAll(r)
+	if string(output) != "rolled out v2\n" {
+		t.Errorf("output = %q, want %q", string(output), "rolled out v2\n")
+	}
+}
+
+func TestPageDelete_PreflightRejectsNonCreator(t *testing.T) {
+	resetPageFlags()
+
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Title:      "Shared Page",
+			Role:       api.RoleEditor,
+		})
+	}))
+	defer server.Close()
+
+	cfg = &config.Config{
+		APIURL: server.URL,
+		Token:  "test-token",
+	}
+	pageDeleteForce = true
+
+	err := pageDeleteCmd.RunE(pageDeleteCmd, []string{"page_xyz"})
+	var permErr *api.PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("err = %v, want PermissionError", err)
+	}
+	if deleted {
+		t.Error("DELETE should not be sent when the pre-flight check fails")
+	}
+}
+
+func TestPageNew_UnsupportedFiletype(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token", Defaults: config.Defaults{ProjectID: "proj_abc"}}
+
+	pageFiletype = "yaml"
+	err := pageNewCmd.RunE(pageNewCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), `unsupported filetype "yaml" (supported: auto, txt, md, csv, log)`) {
+		t.Errorf("err = %v, want the supported types", err)
+	}
+
+	if err := checkFiletype("log"); err != nil {
+		t.Errorf("log rejected: %v", err)
+	}
+}
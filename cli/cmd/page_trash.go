@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/trash"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageListTrashed    bool
+	pagePurgeAll       bool
+	pagePurgeForce     bool
+	pagePurgeProjectID string
+)
+
+var pageTrashCmd = &cobra.Command{
+	Use:   "trash <page-id>...",
+	Short: "Delete pages, keeping a local copy to restore",
+	Long: `Delete pages, first saving a copy of each, with its content and details,
+to the trash in the CLI state directory, so that a script that trashes the
+wrong page can be undone with 'page restore-trashed'. The server has no
+trash of its own, so a restored page is created again and gets a new ID.
+Copies are kept for 30 days; 'page purge' removes them sooner.
+
+Examples:
+  hyperclast page trash page_xyz789
+  hyperclast page list --trashed
+  hyperclast page restore-trashed page_xyz789`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		client := newClient()
+		pages, err := eachPage(args, func(id string) (*api.Page, error) {
+			return trashPage(client, id)
+		})
+		return printTrashResult(pages, err, "trashed", func(p *api.Page) {
+			printSuccess("Moved page \"%s\" (%s) to the trash", p.Title, p.ExternalID)
+			printInfo("  Undo with 'hyperclast page restore-trashed %s'", p.ExternalID)
+		})
+	},
+}
+
+var pageRestoreTrashedCmd = &cobra.Command{
+	Use:   "restore-trashed <page-id>...",
+	Short: "Create trashed pages again from their copies",
+	Long: `Create trashed pages again, with their title, content, and details, in
+the project and folder they were in. Each gets a new ID, which is printed. 'page list
+--trashed' shows what is in the trash.
+
+Examples:
+  hyperclast page restore-trashed page_xyz789`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		client := newClient()
+		bin := trash.Open(config.StateDir())
+		var restoredFrom []string
+		pages, err := eachPage(args, func(id string) (*api.Page, error) {
+			entry, err := bin.Get(id)
+			if err != nil {
+				return nil, err
+			}
+			details := entry.Page.Details
+			if details == nil {
+				details = &api.PageDetails{}
+			}
+			details.Lock = nil
+			page, err := client.CreatePageWithDetails(entry.Page.ProjectID, entry.Page.Title, details)
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore page %s: %w", id, err)
+			}
+			if folderID := entry.Page.FolderID; folderID != "" {
+				if err := client.MovePages(entry.Page.ProjectID, []string{page.ExternalID}, folderID); err != nil {
+					printWarning("Restored page %s to the project root, not its folder: %v", page.ExternalID, err)
+				}
+			}
+			if err := bin.Remove(id); err != nil {
+				printWarning("%v", err)
+			}
+			restoredFrom = append(restoredFrom, id)
+			return page, nil
+		})
+		return printTrashResult(pages, err, "restored", func(p *api.Page) {
+			from := restoredFrom[0]
+			restoredFrom = restoredFrom[1:]
+			printSuccess("Restored page \"%s\" as %s (was %s)", p.Title, p.ExternalID, from)
+		})
+	},
+}
+
+var pagePurgeCmd = &cobra.Command{
+	Use:   "purge (<page-id>... | --all)",
+	Short: "Remove trashed pages' copies for good",
+	Long: `Remove the copies of trashed pages from the trash, so they can no longer
+be restored. With --all, empty the trash of --project, or of every project.
+Prompts for confirmation unless --force is used.
+
+Examples:
+  hyperclast page purge page_xyz789
+  hyperclast page purge --all --project proj_abc123 --force`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if pagePurgeAll {
+			if len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with page IDs")
+			}
+			return nil
+		}
+		if cmd.Flags().Changed("project") {
+			return fmt.Errorf("--project requires --all")
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bin := trash.Open(config.StateDir())
+
+		var pages []*api.Page
+		if pagePurgeAll {
+			entries, err := bin.List(pagePurgeProjectID, time.Now())
+			if err != nil {
+				return err
+			}
+			for i := range entries {
+				pages = append(pages, &entries[i].Page)
+			}
+		} else {
+			for _, id := range args {
+				entry, err := bin.Get(id)
+				if err != nil {
+					return err
+				}
+				pages = append(pages, &entry.Page)
+			}
+		}
+
+		if len(pages) == 0 {
+			if outputFmt == "json" {
+				return json.NewEncoder(os.Stdout).Encode(map[string]any{"purged": []string{}})
+			}
+			printInfo("The trash is empty")
+			return nil
+		}
+
+		if !pagePurgeForce {
+			stat, _ := os.Stdin.Stat()
+			if (stat.Mode() & os.ModeCharDevice) == 0 {
+				return fmt.Errorf("--force is required in non-interactive mode (stdin is not a terminal)")
+			}
+			if len(pages) == 1 {
+				fmt.Fprintf(os.Stderr, "Permanently remove trashed page \"%s\" (%s)? [y/N] ", pages[0].Title, pages[0].ExternalID)
+			} else {
+				fmt.Fprintf(os.Stderr, "Permanently remove %d trashed pages? [y/N] ", len(pages))
+			}
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "y" && confirm != "Y" {
+				printInfo("Cancelled")
+				return nil
+			}
+		}
+
+		var purged []*api.Page
+		var err error
+		for _, p := range pages {
+			if err = bin.Remove(p.ExternalID); err != nil {
+				break
+			}
+			purged = append(purged, p)
+		}
+		return printTrashResult(purged, err, "purged", func(p *api.Page) {
+			printSuccess("Permanently removed page \"%s\" (%s) from the trash", p.Title, p.ExternalID)
+		})
+	},
+}
+
+// trashPage saves a copy of the page pageID to the trash and deletes it,
+// returning the page as it was. The copy is removed again if the delete
+// fails.
+func trashPage(client *api.Client, pageID string) (*api.Page, error) {
+	page, err := client.GetPage(pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page %s: %w", pageID, err)
+	}
+	bin := trash.Open(config.StateDir())
+	if _, err := bin.Put(*page, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to trash page %s: %w", pageID, err)
+	}
+	if err := client.DeletePage(pageID); err != nil {
+		_ = bin.Remove(pageID)
+		return nil, fmt.Errorf("failed to trash page %s: %w", pageID, err)
+	}
+	return page, nil
+}
+
+// listTrashedPages returns the trashed pages of projectID, or of every
+// project, most recently trashed first.
+func listTrashedPages(projectID string) ([]api.Page, error) {
+	entries, err := trash.Open(config.StateDir()).List(projectID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	pages := make([]api.Page, len(entries))
+	for i, e := range entries {
+		pages[i] = e.Page
+	}
+	return pages, nil
+}
+
+// eachPage calls fn for each page ID in turn, stopping at the first error.
+// It returns the pages handled before the error.
+func eachPage(ids []string, fn func(id string) (*api.Page, error)) ([]*api.Page, error) {
+	var done []*api.Page
+	for _, id := range ids {
+		page, err := fn(id)
+		if err != nil {
+			return done, err
+		}
+		done = append(done, page)
+	}
+	return done, nil
+}
+
+func pageIDs(pages []*api.Page) []string {
+	ids := make([]string, len(pages))
+	for i, p := range pages {
+		ids[i] = p.ExternalID
+	}
+	return ids
+}
+
+// printTrashResult reports the pages a trash command handled, under key in
+// JSON, then returns err, so pages handled before a failure are still
+// reported.
+func printTrashResult(pages []*api.Page, err error, key string, text func(*api.Page)) error {
+	switch {
+	case outputFmt == "json":
+		if encErr := json.NewEncoder(os.Stdout).Encode(map[string]any{key: pageIDs(pages)}); encErr != nil {
+			return encErr
+		}
+	case quiet:
+		for _, p := range pages {
+			fmt.Println(p.ExternalID)
+		}
+	default:
+		for _, p := range pages {
+			text(p)
+		}
+	}
+	return err
+}
+
+// checkPageListTrashed validates --trashed against the other 'page list'
+// flags. The trash is listed in one response and has no archive.
+func checkPageListTrashed() error {
+	if !pageListTrashed {
+		return nil
+	}
+	switch {
+	case pageListArchived:
+		return fmt.Errorf("--trashed cannot be combined with --archived")
+	case pageListFollow:
+		return fmt.Errorf("--trashed cannot be combined with --follow")
+	case pageListLimit > 0 || pageListPage > 0 || pageListCursor != "":
+		return fmt.Errorf("--trashed lists the whole trash; it cannot be combined with --limit, --page, or --cursor")
+	}
+	return nil
+}
+
+func init() {
+	pageCmd.AddCommand(pageTrashCmd)
+	pageCmd.AddCommand(pageRestoreTrashedCmd)
+	pageCmd.AddCommand(pagePurgeCmd)
+
+	pagePurgeCmd.Flags().BoolVar(&pagePurgeAll, "all", false, "purge every page in the trash")
+	pagePurgeCmd.Flags().StringVar(&pagePurgeProjectID, "project", "", "with --all, purge only this project's trash")
+	pagePurgeCmd.Flags().BoolVar(&pagePurgeForce, "force", false, "skip confirmation prompt")
+
+	pageListCmd.Flags().BoolVar(&pageListTrashed, "trashed", false, "list only pages in the trash")
+}
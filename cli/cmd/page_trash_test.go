@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageTrash_RestoreRoundTrip(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "log\n")
+	env.server.SetPageDetails(page.ExternalID, map[string]any{"labels": []string{"ci"}})
+
+	env.mustRun("page", "trash", page.ExternalID)
+	if _, ok := env.server.Page(page.ExternalID); ok {
+		t.Fatal("trashed page is still on the server")
+	}
+	if out := env.mustRun("page", "list", "--quiet"); out != "" {
+		t.Errorf("page list after trash = %q, want nothing", out)
+	}
+	if out := env.mustRun("page", "list", "--trashed", "--format", "{{.ExternalID}} {{.Title}}"); out != page.ExternalID+" Build 41\n" {
+		t.Errorf("page list --trashed = %q", out)
+	}
+
+	out := env.mustRun("page", "restore-trashed", page.ExternalID, "--output", "json")
+	var result struct {
+		Restored []string `json:"restored"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil || len(result.Restored) != 1 {
+		t.Fatalf("restore-trashed printed %q", out)
+	}
+	got, ok := env.server.Page(result.Restored[0])
+	if !ok || got.Title != "Build 41" || got.Details.Content != "log\n" || strings.Join(got.Details.Labels, ",") != "ci" {
+		t.Errorf("restored page = %+v", got)
+	}
+	if out := env.mustRun("page", "list", "--trashed", "--quiet"); out != "" {
+		t.Errorf("page list --trashed after restore = %q, want nothing", out)
+	}
+}
+
+func TestPagePurge_OnlyTrashedPages(t *testing.T) {
+	env := newCLIEnv(t)
+	page := env.server.AddPage(apitest.DefaultProjectID, "Build 41", "")
+
+	_, _, err := env.run("page", "purge", page.ExternalID, "--force")
+	if err == nil || !strings.Contains(err.Error(), "not in the trash") {
+		t.Fatalf("purge of an untrashed page = %v, want not-in-trash error", err)
+	}
+
+	env.mustRun("page", "trash", page.ExternalID)
+	_, _, err = env.run("page", "purge", page.ExternalID)
+	if err == nil || !strings.Contains(err.Error(), "--force is required") {
+		t.Fatalf("purge without --force = %v, want non-interactive error", err)
+	}
+
+	env.mustRun("page", "purge", page.ExternalID, "--force")
+	_, _, err = env.run("page", "restore-trashed", page.ExternalID)
+	if err == nil || !strings.Contains(err.Error(), "not in the trash") {
+		t.Errorf("restore after purge = %v, want not-in-trash error", err)
+	}
+}
+
+func TestPagePurge_All(t *testing.T) {
+	env := newCLIEnv(t)
+	other := env.server.AddProject("Other")
+	a := env.server.AddPage(apitest.DefaultProjectID, "a", "")
+	b := env.server.AddPage(other.ExternalID, "b", "")
+	env.mustRun("page", "trash", a.ExternalID, b.ExternalID)
+
+	out := env.mustRun("page", "purge", "--all", "--project", apitest.DefaultProjectID, "--force", "--output", "json")
+	var result struct {
+		Purged []string `json:"purged"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if strings.Join(result.Purged, ",") != a.ExternalID {
+		t.Errorf("purged = %v, want only %s", result.Purged, a.ExternalID)
+	}
+	if out := env.mustRun("page", "list", "--trashed", "--format", "{{.ExternalID}}"); out != b.ExternalID+"\n" {
+		t.Errorf("page list --trashed = %q, want the other project's page kept", out)
+	}
+}
+
+func TestPagePurge_Args(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, args := range [][]string{
+		{"page", "purge", "--all", "page_1"},
+		{"page", "purge", "--project", "proj_x", "page_1"},
+		{"page", "purge"},
+	} {
+		if _, _, err := env.run(args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestPageList_TrashedRejectsPaging(t *testing.T) {
+	env := newCLIEnv(t)
+	_, _, err := env.run("page", "list", "--trashed", "--limit", "10")
+	if err == nil || !strings.Contains(err.Error(), "whole trash") {
+		t.Fatalf("page list --trashed --limit = %v, want error", err)
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var (
+	pageAppendIfExists    bool
+	pageOverwriteIfExists bool
+)
+
+// upsertMode is how 'page new' writes to a page that already has its
+// title: "append" or "overwrite", or "" to create a page regardless.
+func upsertMode() string {
+	switch {
+	case pageAppendIfExists:
+		return "append"
+	case pageOverwriteIfExists:
+		return "overwrite"
+	}
+	return ""
+}
+
+// checkUpsert validates --append-if-exists and --overwrite-if-exists. The
+// title must be given, or come from the source, since a generated title
+// never matches.
+func checkUpsert() error {
+	if upsertMode() == "" {
+		return nil
+	}
+	if pageAppendIfExists && pageOverwriteIfExists {
+		return fmt.Errorf("--append-if-exists and --overwrite-if-exists cannot be used together")
+	}
+	flag := "--" + upsertMode() + "-if-exists"
+	if pageTitle == "" && pageFromGitShow == "" && pageFromURL == "" {
+		return fmt.Errorf("%s requires --title", flag)
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{pagePreview, "--preview"},
+		{pageSkipDuplicate, "--skip-duplicate"},
+	} {
+		if conflict.set {
+			return fmt.Errorf("%s cannot be combined with %s", flag, conflict.flag)
+		}
+	}
+	return nil
+}
+
+// findUpsertTarget returns the page in projectID titled title, matched as
+// by --title on other commands, or nil if there is none. Several matches
+// are an error, since it is unclear which to write.
+func findUpsertTarget(client *api.Client, projectID, title string) (*api.Page, error) {
+	lookup := titleLookup{scoped: true}
+	matches, err := findPagesTitled(client, title, projectID, lookup)
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	if err := titleMatchError(matches, title, projectID, lookup); err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// upsertPage writes content to target, the page 'page new' found with its
+// title, in mode instead of creating a page, and reports it as 'page
+// append' or 'page overwrite' would. The details 'page new' would have
+// created the page with are then applied as upsertFields describes, and
+// linkFrom, if set, links to the page unless it already does.
+func upsertPage(client *api.Client, target *api.Page, content, mode string, details *api.PageDetails, linkFrom *api.Page, guard *uploadGuard) error {
+	if err := guard.beginUpload(content); err != nil {
+		return err
+	}
+	existing, page, err := updatePage(target.ExternalID, content, mode)
+	if err != nil {
+		if aborted := guard.aborted(err, content); aborted != nil {
+			return aborted
+		}
+		return handleContentError(err)
+	}
+
+	cleanupStdinTemp()
+	if fields := upsertFields(existing, details, mode); len(fields) > 0 {
+		updated, err := client.SetPageDetails(page.ExternalID, fields)
+		if err != nil {
+			return fmt.Errorf("wrote to page %s, but failed to update its details: %w", page.ExternalID, err)
+		}
+		page = updated
+	}
+	if linkFrom != nil && (linkFrom.Details == nil || !slices.Contains(linkFrom.Details.Related, page.ExternalID)) {
+		linkFromPage(client, linkFrom, page)
+	}
+	if pageNewOpen {
+		openCreatedPage(page.ExternalID)
+	}
+	return printPageUpdate(existing, page, content, mode, guard)
+}
+
+// upsertFields returns the details to merge into existing, the page an
+// upsert wrote to, from those 'page new' would have created it with.
+// Labels and related pages are added to the page's own, and an expiry
+// replaces its one. The filetype and CSV schema describe the content, so
+// they replace the page's on an overwrite; an append keeps them unless
+// --filetype was given.
+func upsertFields(existing *api.Page, details *api.PageDetails, mode string) map[string]any {
+	var have api.PageDetails
+	if existing.Details != nil {
+		have = *existing.Details
+	}
+	fields := map[string]any{}
+	if labels := addMissing(have.Labels, details.Labels); len(labels) > len(have.Labels) {
+		fields["labels"] = labels
+	}
+	if related := addMissing(have.Related, details.Related); len(related) > len(have.Related) {
+		fields["related"] = related
+	}
+	if details.ExpiresAt != "" {
+		fields["expires_at"] = details.ExpiresAt
+	}
+	if mode == "overwrite" || pageFiletype != autoFiletype {
+		if details.Filetype != have.Filetype {
+			fields["filetype"] = details.Filetype
+		}
+	}
+	if mode == "overwrite" {
+		switch {
+		case details.Schema != nil:
+			fields["schema"] = details.Schema
+		case have.Schema != nil:
+			fields["schema"] = nil
+		}
+	}
+	return fields
+}
+
+// addMissing returns have with the values of add it lacks appended.
+func addMissing(have, add []string) []string {
+	out := slices.Clone(have)
+	for _, v := range add {
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func init() {
+	pageNewCmd.Flags().BoolVar(&pageAppendIfExists, "append-if-exists", false, "append to the project's page with this title, if there is one, instead of creating another")
+	pageNewCmd.Flags().BoolVar(&pageOverwriteIfExists, "overwrite-if-exists", false, "overwrite the project's page with this title, if there is one, instead of creating another")
+}
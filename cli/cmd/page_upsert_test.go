@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageNew_AppendIfExists(t *testing.T) {
+	env := newCLIEnv(t)
+	other := env.server.AddProject("Other")
+	env.server.AddPage(other.ExternalID, "Rollup 2026-10-18", "elsewhere\n")
+	file := filepath.Join(t.TempDir(), "rollup.txt")
+	client := api.NewClient(env.url, "integration-token")
+
+	_ = os.WriteFile(file, []byte("first run\n"), 0600)
+	id := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--title", "Rollup 2026-10-18", "--file", file, "--append-if-exists"))
+
+	_ = os.WriteFile(file, []byte("second run\n"), 0600)
+	out := env.mustRun("page", "new", "--project", apitest.DefaultProjectID, "--title", "Rollup 2026-10-18", "--file", file, "--append-if-exists")
+	if !strings.Contains(out, `Appended to page "Rollup 2026-10-18" (`+id+`)`) {
+		t.Errorf("second run printed %q, want an append to %s", out, id)
+	}
+	if page, _ := env.server.Page(id); page.Details.Content != "first run\nsecond run\n" {
+		t.Errorf("content = %q", page.Details.Content)
+	}
+	if pages, _ := client.ListPages(apitest.DefaultProjectID); len(pages) != 1 {
+		t.Errorf("%d pages in the project, want 1", len(pages))
+	}
+}
+
+func TestPageNew_OverwriteIfExists(t *testing.T) {
+	env := newCLIEnv(t)
+	existing := env.server.AddPage(apitest.DefaultProjectID, "Status", "old\n")
+	file := filepath.Join(t.TempDir(), "status.txt")
+	_ = os.WriteFile(file, []byte("new\n"), 0600)
+
+	out := env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--title", "Status", "--file", file, "--overwrite-if-exists")
+	if strings.TrimSpace(out) != existing.ExternalID {
+		t.Errorf("printed %q, want %s", out, existing.ExternalID)
+	}
+	if page, _ := env.server.Page(existing.ExternalID); page.Details.Content != "new\n" {
+		t.Errorf("content = %q", page.Details.Content)
+	}
+}
+
+func TestPageNew_UpsertAppliesDetails(t *testing.T) {
+	env := newCLIEnv(t)
+	existing := env.server.AddPage(apitest.DefaultProjectID, "Rollup", "old\n")
+	env.server.SetPageDetails(existing.ExternalID, map[string]any{"labels": []string{"team=ops"}})
+	index := env.server.AddPage(apitest.DefaultProjectID, "Index", "")
+	file := filepath.Join(t.TempDir(), "rollup.txt")
+	_ = os.WriteFile(file, []byte("new\n"), 0600)
+
+	args := []string{"page", "new", "--project", apitest.DefaultProjectID, "--title", "Rollup", "--file", file, "--append-if-exists",
+		"--label", "env=prod", "--label", "team=ops", "--expires", "7d", "--link-from", index.ExternalID}
+	env.mustRun(args...)
+	env.mustRun(args...)
+
+	page, _ := env.server.Page(existing.ExternalID)
+	if got := strings.Join(page.Details.Labels, ","); got != "team=ops,env=prod" {
+		t.Errorf("labels = %q, want the page's own with --label added", got)
+	}
+	if page.Details.ExpiresAt == "" {
+		t.Error("--expires was not recorded on the existing page")
+	}
+	if len(page.Details.Related) != 1 || page.Details.Related[0] != index.ExternalID {
+		t.Errorf("related = %v, want %s", page.Details.Related, index.ExternalID)
+	}
+	if page.Details.Filetype != "txt" {
+		t.Errorf("filetype = %q, want the page's own kept on append", page.Details.Filetype)
+	}
+	from, _ := env.server.Page(index.ExternalID)
+	if n := strings.Count(from.Details.Content, existing.ExternalID); n != 1 {
+		t.Errorf("--link-from page links to the page %d times, want once:\n%s", n, from.Details.Content)
+	}
+}
+
+func TestPageNew_UpsertErrors(t *testing.T) {
+	env := newCLIEnv(t)
+	env.server.AddPage(apitest.DefaultProjectID, "Twice", "a\n")
+	env.server.AddPage(apitest.DefaultProjectID, "Twice", "b\n")
+	file := filepath.Join(t.TempDir(), "in.txt")
+	_ = os.WriteFile(file, []byte("x\n"), 0600)
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--append-if-exists"}, "requires --title"},
+		{[]string{"--title", "T", "--append-if-exists", "--overwrite-if-exists"}, "cannot be used together"},
+		{[]string{"--title", "T", "--append-if-exists", "--skip-duplicate"}, "cannot be combined with --skip-duplicate"},
+		{[]string{"--title", "Twice", "--append-if-exists"}, "2 pages are titled"},
+	} {
+		args := append([]string{"page", "new", "--project", apitest.DefaultProjectID, "--file", file}, tt.args...)
+		if _, _, err := env.run(args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pageWatchInterval time.Duration
+	pageWatchPoll     bool
+)
+
+var pageWatchCmd = &cobra.Command{
+	Use:   "watch <page-id>",
+	Short: "Print a page's changes as others make them",
+	Long: `Follow a page as other users and processes update it, printing only what
+changes, until Ctrl-C. Appends are printed as is and other edits as their
+added lines, or as a diff with --diff, so a page works as a shared live log.
+
+Changes are pushed by the server when it streams page events. Otherwise the
+page's metadata is polled every --interval, and its content fetched only
+when it was updated.
+
+Examples:
+  hyperclast page watch page_xyz789
+  hyperclast page watch page_xyz789 --diff
+  hyperclast page watch page_xyz789 --poll --interval 10s`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if pageWatchInterval < minFollowInterval {
+			return fmt.Errorf("--interval must be at least %s", minFollowInterval)
+		}
+
+		client := newClient()
+		page, err := client.GetPage(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get page: %w", err)
+		}
+		if !quiet && outputFmt != "json" {
+			fmt.Fprintf(os.Stderr, "Watching \"%s\" (%s); press Ctrl-C to stop\n", page.Title, page.ExternalID)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fetch := conditionalFetch(client, page)
+		if !pageWatchPoll {
+			err := watchPageEvents(ctx, client, page.ExternalID, fetch, pageContent(page), os.Stdout)
+			if !errors.Is(err, api.ErrEventsUnsupported) {
+				return err
+			}
+			printDebug("Server does not stream page events; polling every %s", pageWatchInterval)
+		}
+		return followPage(ctx, fetch, pageContent(page), pageWatchInterval, os.Stdout)
+	},
+}
+
+// conditionalFetch returns a fetch for followPage that gets the page's
+// metadata, and its content only when the metadata shows an update since
+// the last fetch. Servers that send no update time get a full fetch.
+func conditionalFetch(client *api.Client, page *api.Page) func() (string, error) {
+	updated, content := pageUpdated(page), pageContent(page)
+	return func() (string, error) {
+		meta, err := client.GetPageMetadata(page.ExternalID)
+		if err != nil {
+			return "", err
+		}
+		if u := pageUpdated(meta); u != "" && u == updated {
+			return content, nil
+		}
+		full, err := client.GetPage(page.ExternalID)
+		if err != nil {
+			return "", err
+		}
+		updated, content = pageUpdated(full), pageContent(full)
+		return content, nil
+	}
+}
+
+// watchPageEvents writes each change to a page as the server announces it,
+// like followPage, until ctx is cancelled. A dropped stream is reopened
+// after --interval, catching up on changes made meanwhile. It returns
+// api.ErrEventsUnsupported straight away if the server has no stream.
+func watchPageEvents(ctx context.Context, client *api.Client, pageID string, fetch func() (string, error), initial string, out io.Writer) error {
+	prev := initial
+	// update returns only errors writing out, which end the watch; fetch
+	// errors are reported and the next event tries again.
+	update := func() error {
+		current, err := fetch()
+		if err != nil {
+			printWarning("%v (retrying)", err)
+			return nil
+		}
+		if current == prev {
+			return nil
+		}
+		if err := writeFollowDelta(out, prev, current); err != nil {
+			return err
+		}
+		prev = current
+		return nil
+	}
+
+	for connected := false; ; connected = true {
+		if connected {
+			if err := update(); err != nil {
+				return err
+			}
+		}
+		var writeErr error
+		err := client.WatchPageEvents(ctx, pageID, func(api.PageEvent) error {
+			writeErr = update()
+			return writeErr
+		})
+		switch {
+		case writeErr != nil:
+			return writeErr
+		case errors.Is(err, api.ErrEventsUnsupported) && !connected:
+			return err
+		case err != nil:
+			printWarning("%v (reconnecting)", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pageWatchInterval):
+		}
+	}
+}
+
+func init() {
+	pageCmd.AddCommand(pageWatchCmd)
+
+	pageWatchCmd.Flags().DurationVar(&pageWatchInterval, "interval", 2*time.Second, "how often to poll, or to reconnect a dropped event stream")
+	pageWatchCmd.Flags().BoolVar(&pageWatchPoll, "poll", false, "poll even if the server streams page events")
+	pageWatchCmd.Flags().BoolVar(&pageGetDiff, "diff", false, "print changes other than appends as a unified diff")
+}
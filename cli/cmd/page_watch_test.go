@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+// syncBuffer is a bytes.Buffer safe to write from one goroutine while the
+// test reads it from another.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchPageEvents(t *testing.T) {
+	env := newCLIEnv(t)
+	created := env.server.AddPage(apitest.DefaultProjectID, "shared log", "line 1\n")
+
+	// The API has no event stream, so neither has the fake; this server
+	// stands in for one that announces each write to the page.
+	writes := make(chan struct{}, 16)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pages/"+created.ExternalID+"/events/" {
+			env.server.ServeHTTP(w, r)
+			if r.Method == http.MethodPut {
+				writes <- struct{}{}
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-writes:
+				_, _ = fmt.Fprint(w, "event: update\ndata: {}\n\n")
+				w.(http.Flusher).Flush()
+			}
+		}
+	}))
+	defer ts.Close()
+	client := api.NewClient(ts.URL, "integration-token")
+	page, err := client.GetPage(created.ExternalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() {
+		done <- watchPageEvents(ctx, client, page.ExternalID, conditionalFetch(client, page), pageContent(page), &out)
+	}()
+
+	if _, err := client.UpdatePageContent(page.ExternalID, "line 2\n", "append"); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), "line 2\n") && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watchPageEvents: %v", err)
+	}
+	if got := out.String(); got != "line 2\n" {
+		t.Errorf("printed %q, want only the appended line", got)
+	}
+}
+
+func TestWatchPageEvents_Unsupported(t *testing.T) {
+	env := newCLIEnv(t)
+	created := env.server.AddPage(apitest.DefaultProjectID, "shared log", "")
+	// Like the API, the fake has no event stream, so page watch polls.
+	client := api.NewClient(env.url, "integration-token")
+	err := watchPageEvents(context.Background(), client, created.ExternalID, nil, "", &bytes.Buffer{})
+	if err != api.ErrEventsUnsupported {
+		t.Errorf("watchPageEvents = %v, want ErrEventsUnsupported so page watch polls", err)
+	}
+}
+
+func TestConditionalFetch(t *testing.T) {
+	env := newCLIEnv(t)
+	created := env.server.AddPage(apitest.DefaultProjectID, "shared log", "line 1\n")
+	client := api.NewClient(env.url, "integration-token")
+	page, _ := client.GetPage(created.ExternalID)
+
+	var requests []string
+	api.SetRequestObserver(func(r api.RequestRecord) { requests = append(requests, r.Path) })
+	fetch := conditionalFetch(client, page)
+
+	if content, err := fetch(); err != nil || content != "line 1\n" {
+		t.Fatalf("fetch = %q, %v", content, err)
+	}
+	if len(requests) != 1 || !strings.Contains(requests[0], "omit=content") {
+		t.Errorf("unchanged page fetched with %v, want only its metadata", requests)
+	}
+
+	if _, err := client.UpdatePageContent(page.ExternalID, "line 2\n", "append"); err != nil {
+		t.Fatal(err)
+	}
+	requests = nil
+	if content, err := fetch(); err != nil || content != "line 1\nline 2\n" {
+		t.Fatalf("fetch after update = %q, %v", content, err)
+	}
+	if len(requests) != 2 {
+		t.Errorf("updated page fetched with %v, want its metadata then its content", requests)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+var pageGetNoPager bool
+
+// pagerCommand is the pager for output to a terminal: $HYPERCLAST_PAGER,
+// else $PAGER, else less. It returns nil when paging is turned off by
+// setting either to "" or cat.
+func pagerCommand() []string {
+	pager, ok := os.LookupEnv("HYPERCLAST_PAGER")
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		pager = "less"
+	}
+	parts := strings.Fields(pager)
+	if len(parts) == 0 || parts[0] == "cat" {
+		return nil
+	}
+	return parts
+}
+
+// terminalSize is the size of the terminal on stdout; tests replace it.
+var terminalSize = func() (width, height int, err error) {
+	return term.GetSize(int(os.Stdout.Fd()))
+}
+
+// fitsScreen reports whether text, with lines wrapped at width, leaves a
+// row free for the shell prompt on a screen height rows tall. Escape
+// sequences are counted as if printed, so styled text may be paged a
+// little early.
+func fitsScreen(text string, width, height int) bool {
+	if width <= 0 || height <= 0 {
+		return true
+	}
+	rows := 0
+	for rest := text; rest != ""; {
+		line, next, _ := strings.Cut(rest, "\n")
+		rows += max(1, (utf8.RuneCountInString(line)+width-1)/width)
+		if rows >= height {
+			return false
+		}
+		rest = next
+	}
+	return true
+}
+
+// runPager shows text in the pager command, returning once the pager has
+// exited, or an error if it could not be started. It is a variable so
+// tests can substitute a fake pager.
+var runPager = func(command []string, text string) error {
+	c := exec.Command(command[0], command[1:]...)
+	c.Stdin = strings.NewReader(text)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		// As git does: pass colors through and leave the text on screen
+		// when less quits.
+		c.Env = append(c.Env, "LESS=FRX")
+	}
+	if err := c.Start(); err != nil {
+		return err
+	}
+	// Ctrl-C belongs to the pager, which may use it to stop a search.
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	_ = c.Wait()
+	return nil
+}
+
+// pageOutput writes output to stdout, through the pager when stdout is a
+// terminal and the output is longer than the screen, unless --no-pager is
+// set. Output is printed directly if the pager cannot be started.
+func pageOutput(write func(io.Writer) error) error {
+	command := pagerCommand()
+	if pageGetNoPager || command == nil || !stdoutIsTerminal() {
+		return write(os.Stdout)
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+	if width, height, err := terminalSize(); err != nil || fitsScreen(buf.String(), width, height) {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := runPager(command, buf.String()); err != nil {
+		printDebug("Pager %q did not start (%v); printing directly", strings.Join(command, " "), err)
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return nil
+}
+
+func init() {
+	pageGetCmd.Flags().BoolVar(&pageGetNoPager, "no-pager", false, "print to a terminal directly instead of through $PAGER (less) when the page is longer than the screen")
+}
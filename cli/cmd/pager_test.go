@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestFitsScreen(t *testing.T) {
+	tests := []struct {
+		text          string
+		width, height int
+		want          bool
+	}{
+		{"a\nb\n", 80, 3, true},
+		{"a\nb\nc\n", 80, 3, false},
+		{strings.Repeat("x", 100) + "\n", 80, 3, true},
+		{strings.Repeat("x", 100) + "\nb\n", 80, 3, false},
+		{"é\n", 1, 2, true},
+		{"a\nb\nc\n", 0, 0, true},
+	}
+	for _, tt := range tests {
+		if got := fitsScreen(tt.text, tt.width, tt.height); got != tt.want {
+			t.Errorf("fitsScreen(%q, %d, %d) = %v, want %v", tt.text, tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "more -s")
+	t.Setenv("HYPERCLAST_PAGER", "less -S")
+	if got := pagerCommand(); !reflect.DeepEqual(got, []string{"less", "-S"}) {
+		t.Errorf("with HYPERCLAST_PAGER: %q", got)
+	}
+	t.Setenv("HYPERCLAST_PAGER", "cat")
+	if got := pagerCommand(); got != nil {
+		t.Errorf("HYPERCLAST_PAGER=cat: %q, want no pager", got)
+	}
+	t.Setenv("HYPERCLAST_PAGER", "")
+	if got := pagerCommand(); got != nil {
+		t.Errorf("empty HYPERCLAST_PAGER: %q, want no pager", got)
+	}
+}
+
+// fakeTerminal makes stdout a terminal of height rows whose pager records
+// what it is given, failing to start with err if it is set.
+func fakeTerminal(t *testing.T, height int, err error) *[]string {
+	t.Helper()
+	t.Setenv("HYPERCLAST_PAGER", "less")
+	var paged []string
+	origTerminal, origSize, origPager := stdoutIsTerminal, terminalSize, runPager
+	stdoutIsTerminal = func() bool { return true }
+	terminalSize = func() (int, int, error) { return 80, height, nil }
+	runPager = func(command []string, text string) error {
+		if err != nil {
+			return err
+		}
+		paged = append(paged, text)
+		return nil
+	}
+	t.Cleanup(func() { stdoutIsTerminal, terminalSize, runPager = origTerminal, origSize, origPager })
+	return &paged
+}
+
+func TestPageGet_Pager(t *testing.T) {
+	env := newCLIEnv(t)
+	long := env.server.AddPage(apitest.DefaultProjectID, "build log", "1\n2\n3\n4\n5\n")
+	short := env.server.AddPage(apitest.DefaultProjectID, "note", "1\n")
+	paged := fakeTerminal(t, 4, nil)
+
+	if out := env.mustRun("page", "get", long.ExternalID); out != "" {
+		t.Errorf("stdout = %q, want the page sent to the pager", out)
+	}
+	if want := []string{"1\n2\n3\n4\n5\n"}; !reflect.DeepEqual(*paged, want) {
+		t.Errorf("paged %q, want %q", *paged, want)
+	}
+
+	*paged = nil
+	if out := env.mustRun("page", "get", short.ExternalID); out != "1\n" || len(*paged) != 0 {
+		t.Errorf("a page that fits the screen: stdout %q, paged %q", out, *paged)
+	}
+	if out := env.mustRun("page", "get", long.ExternalID, "--no-pager"); out != "1\n2\n3\n4\n5\n" || len(*paged) != 0 {
+		t.Errorf("--no-pager: stdout %q, paged %q", out, *paged)
+	}
+	if out := env.mustRun("page", "get", long.ExternalID, "--output", "json"); !strings.Contains(out, long.ExternalID) || len(*paged) != 0 {
+		t.Errorf("--output json: stdout %q, paged %q", out, *paged)
+	}
+}
+
+func TestPageGet_PagerFailsToStart(t *testing.T) {
+	env := newCLIEnv(t)
+	long := env.server.AddPage(apitest.DefaultProjectID, "build log", "1\n2\n3\n4\n5\n")
+	fakeTerminal(t, 4, errors.New("executable file not found"))
+
+	if out := env.mustRun("page", "get", long.ExternalID); out != "1\n2\n3\n4\n5\n" {
+		t.Errorf("stdout = %q, want the page printed directly", out)
+	}
+}
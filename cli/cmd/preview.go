@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var pagePreview bool
+
+// filetypeSource explains where page new's filetype came from.
+func filetypeSource() string {
+	switch {
+	case pageFiletype != autoFiletype:
+		return "--filetype"
+	case csvColumnOpsSet():
+		return "CSV column operations"
+	default:
+		return "detected"
+	}
+}
+
+// printPagePreview shows what page new would send. The request summary goes
+// to stderr and the content, byte for byte, to stdout, so redirecting stdout
+// captures exactly what would be uploaded. With --output json the full
+// request is printed instead.
+func printPagePreview(req api.CreatePageRequest, filetypeFrom string, metadata bool) error {
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"method": "POST",
+			"url":    cfg.APIURL + "/pages/",
+			"body":   req,
+		})
+	}
+
+	writePreviewSummary(os.Stderr, req, filetypeFrom, metadata)
+	_, err := io.WriteString(os.Stdout, req.Details.Content)
+	return err
+}
+
+func writePreviewSummary(w io.Writer, req api.CreatePageRequest, filetypeFrom string, metadata bool) {
+	project := req.ProjectID
+	if project == "" {
+		project = "(none: use --project or set a default)"
+	}
+	content := req.Details.Content
+
+	fmt.Fprintln(w, "Preview: nothing will be sent")
+	fmt.Fprintf(w, "  Request:  POST %s/pages/\n", cfg.APIURL)
+	fmt.Fprintf(w, "  Project:  %s\n", project)
+	fmt.Fprintf(w, "  Title:    %s\n", req.Title)
+	fmt.Fprintf(w, "  Filetype: %s (%s)\n", req.Details.Filetype, filetypeFrom)
+	if req.Details.Schema != nil {
+		cols := make([]string, len(req.Details.Schema.Columns))
+		for i, c := range req.Details.Schema.Columns {
+			cols[i] = c.Name + ":" + c.Type
+		}
+		fmt.Fprintf(w, "  Schema:   %s\n", strings.Join(cols, ", "))
+	}
+	if len(req.Details.Labels) > 0 {
+		fmt.Fprintf(w, "  Labels:   %s\n", strings.Join(req.Details.Labels, ", "))
+	}
+	fmt.Fprintf(w, "  Content:  %s, %d lines\n", formatBytes(int64(len(content))), countLines(content))
+	if metadata {
+		fmt.Fprintln(w, "  Metadata: appended")
+	}
+	fmt.Fprintln(w, "---")
+}
+
+func countLines(s string) int {
+	n := strings.Count(s, "\n")
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
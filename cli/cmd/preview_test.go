@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// runPreview runs page new with --preview against a server that fails the
+// test if it receives any request, returning stdout and stderr.
+func runPreview(t *testing.T, content string) (string, string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg = &config.Config{APIURL: server.URL}
+	pageFile = path
+	pagePreview = true
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+
+	err := pageNewCmd.RunE(pageNewCmd, nil)
+
+	_ = outW.Close()
+	_ = errW.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stdout, _ := io.ReadAll(outR)
+	stderr, _ := io.ReadAll(errR)
+	return string(stdout), string(stderr)
+}
+
+func TestPageNew_Preview(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	pageTitle = "Users"
+	pageCSVDrop = []string{"password"}
+
+	stdout, stderr := runPreview(t, "name,password\nalice,hunter2\n")
+
+	if stdout != "name\nalice\n" {
+		t.Errorf("content = %q, want transformed CSV", stdout)
+	}
+	for _, want := range []string{
+		"nothing will be sent",
+		"POST " + cfg.APIURL + "/pages/",
+		"(none: use --project",
+		"Title:    Users",
+		"Filetype: csv (CSV column operations)",
+		"Schema:   name:string",
+		"2 lines",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("summary missing %q:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestPageNew_PreviewJSON(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	pageProjectID = "proj_abc"
+	pageTitle = "Notes"
+	pageMeta = true
+	outputFmt = "json"
+
+	stdout, _ := runPreview(t, "hello\n")
+
+	var got struct {
+		Method string `json:"method"`
+		Body   struct {
+			ProjectID string `json:"project_id"`
+			Title     string `json:"title"`
+			Details   struct {
+				Content       string `json:"content"`
+				Filetype      string `json:"filetype"`
+				SchemaVersion int    `json:"schema_version"`
+			} `json:"details"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout, err)
+	}
+	if got.Method != "POST" || got.Body.ProjectID != "proj_abc" || got.Body.Title != "Notes" {
+		t.Errorf("unexpected request: %+v", got)
+	}
+	if got.Body.Details.SchemaVersion != 1 || got.Body.Details.Filetype != "txt" {
+		t.Errorf("unexpected details: %+v", got.Body.Details)
+	}
+	if !strings.HasPrefix(got.Body.Details.Content, "hello\n") || !strings.Contains(got.Body.Details.Content, "Captured by Hyperclast CLI") {
+		t.Errorf("content = %q, want metadata appended", got.Body.Details.Content)
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	tests := map[string]int{"": 0, "a": 1, "a\n": 1, "a\nb": 2, "a\nb\n": 2}
+	for in, want := range tests {
+		if got := countLines(in); got != want {
+			t.Errorf("countLines(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// progressFormat is --progress: "none", or "json" for one event per line on
+// stderr that wrappers and GUIs can render themselves.
+var progressFormat string
+
+// progressOut receives progress events. A variable so tests can capture it.
+var progressOut io.Writer = os.Stderr
+
+// uploadProgressThreshold is the smallest request body reported as an
+// upload; smaller requests finish too quickly to be worth tracking.
+const uploadProgressThreshold = 1 << 20
+
+// progressEvent is one line of --progress json.
+type progressEvent struct {
+	Time    string  `json:"time"`
+	Phase   string  `json:"phase"`
+	Item    string  `json:"item,omitempty"`
+	Status  string  `json:"status,omitempty"`
+	Error   string  `json:"error,omitempty"`
+	Done    int64   `json:"done"`
+	Total   int64   `json:"total"`
+	Unit    string  `json:"unit"`
+	Percent float64 `json:"percent"`
+}
+
+// progress tracks one phase of a long operation. All methods are safe to
+// call from several goroutines, and on a nil progress, which is what
+// startProgress returns unless --progress json is set.
+type progress struct {
+	mu          sync.Mutex
+	phase       string
+	unit        string
+	done, total int64
+	lastPercent int
+}
+
+// startProgress begins a phase of total units ("pages" or "bytes") and
+// reports it at 0%.
+func startProgress(phase string, total int, unit string) *progress {
+	if progressFormat != "json" {
+		return nil
+	}
+	p := &progress{phase: phase, unit: unit, total: int64(total), lastPercent: -1}
+	p.emit("", "", "")
+	return p
+}
+
+// advance records one finished item. status is e.g. "written", "skipped",
+// or "failed"; err, if set, explains a failure.
+func (p *progress) advance(item, status string, err error) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	p.emit(item, status, msg)
+}
+
+// set records done units of total, reporting only when the whole percent
+// changes so byte counts do not flood the stream.
+func (p *progress) set(item string, done, total int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done, p.total = done, total
+	if percent := int(p.percent()); percent != p.lastPercent {
+		p.emit(item, "", "")
+	}
+}
+
+func (p *progress) percent() float64 {
+	if p.total <= 0 {
+		return 100
+	}
+	return float64(p.done) * 100 / float64(p.total)
+}
+
+// emit writes the current state. Callers hold p.mu, except startProgress.
+func (p *progress) emit(item, status, errMsg string) {
+	percent := p.percent()
+	p.lastPercent = int(percent)
+	data, err := json.Marshal(progressEvent{
+		Time:    time.Now().UTC().Format(time.RFC3339Nano),
+		Phase:   p.phase,
+		Item:    item,
+		Status:  status,
+		Error:   errMsg,
+		Done:    p.done,
+		Total:   p.total,
+		Unit:    p.unit,
+		Percent: float64(int(percent*10)) / 10,
+	})
+	if err != nil {
+		return
+	}
+	_, _ = progressOut.Write(append(data, '\n'))
+}
+
+func checkProgressFormat() error {
+	switch progressFormat {
+	case "none", "json":
+		return nil
+	default:
+		return fmt.Errorf("invalid progress format %q (must be none or json)", progressFormat)
+	}
+}
+
+// uploadProgress reports request bodies of at least uploadProgressThreshold
+// as an "upload" phase. It is the client's OnUpload hook.
+func uploadProgress() func(method, path string, sent, total int64) {
+	if progressFormat != "json" {
+		return nil
+	}
+	var mu sync.Mutex
+	uploads := map[string]*progress{}
+	return func(method, path string, sent, total int64) {
+		if total < uploadProgressThreshold {
+			return
+		}
+		item := method + " " + path
+		mu.Lock()
+		p := uploads[item]
+		if p == nil || sent == 0 {
+			p = &progress{phase: "upload", unit: "bytes", total: total, lastPercent: -1}
+			uploads[item] = p
+		}
+		mu.Unlock()
+		p.set(item, sent, total)
+	}
+}
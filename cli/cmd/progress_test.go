@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func captureProgress(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	progressFormat, progressOut = "json", &buf
+	t.Cleanup(func() { progressFormat, progressOut = "none", os.Stderr })
+	return &buf
+}
+
+func progressEvents(t *testing.T, buf *bytes.Buffer) []progressEvent {
+	t.Helper()
+	var events []progressEvent
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var e progressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("bad event %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestProgress_OrgExport(t *testing.T) {
+	buf := captureProgress(t)
+	_, client := newExportServer(t)
+
+	if _, err := runOrgExport(client, "org_1", t.TempDir(), 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var export []progressEvent
+	for _, e := range progressEvents(t, buf) {
+		if e.Phase == "export" {
+			export = append(export, e)
+		}
+	}
+	if len(export) != 4 || export[0].Done != 0 || export[0].Total != 3 || export[0].Unit != "pages" {
+		t.Fatalf("export events = %+v, want a start event and one per page", export)
+	}
+	if last := export[3]; last.Done != 3 || last.Percent != 100 || last.Status != "written" || last.Item == "" {
+		t.Errorf("last event = %+v, want page 3 of 3 written", last)
+	}
+}
+
+func TestProgress_UploadReportsWholePercents(t *testing.T) {
+	buf := captureProgress(t)
+	report := uploadProgress()
+
+	report("PUT", "/pages/small/", 0, 100)
+	total := int64(uploadProgressThreshold)
+	for sent := int64(0); sent < total; sent += total / 400 {
+		report("PUT", "/pages/big/", sent, total)
+	}
+	report("PUT", "/pages/big/", total, total)
+
+	events := progressEvents(t, buf)
+	if len(events) != 101 {
+		t.Fatalf("got %d events, want one per whole percent (0-100) and none for a small body", len(events))
+	}
+	if e := events[100]; e.Phase != "upload" || e.Item != "PUT /pages/big/" || e.Percent != 100 || e.Unit != "bytes" {
+		t.Errorf("last event = %+v", e)
+	}
+}
+
+func TestProgress_Disabled(t *testing.T) {
+	progressFormat = "none"
+	var p *progress = startProgress("export", 3, "pages")
+	p.advance("page_a", "written", nil)
+	if p != nil || uploadProgress() != nil {
+		t.Error("progress reported without --progress json")
+	}
+
+	progressFormat = "xml"
+	defer func() { progressFormat = "none" }()
+	if err := checkProgressFormat(); err == nil {
+		t.Error("invalid --progress accepted")
+	}
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -12,10 +16,11 @@ import (
 )
 
 var (
-	projectOrgID     string
-	projectNewOrgID  string
-	projectNewDesc   string
-	projectNewSetUse bool
+	projectOrgID          string
+	projectNewOrgID       string
+	projectNewDesc        string
+	projectNewSetUse      bool
+	projectUseInteractive bool
 )
 
 var projectCmd = &cobra.Command{
@@ -32,16 +37,21 @@ var projectListCmd = &cobra.Command{
 			return err
 		}
 
+		if err := checkListSort(); err != nil {
+			return err
+		}
+
 		orgID := projectOrgID
 		if orgID == "" {
 			orgID = cfg.GetDefaultOrg()
 		}
 
-		client := api.NewClient(cfg.APIURL, cfg.Token)
+		client := newClient()
 		projects, err := client.ListProjects(orgID)
 		if err != nil {
 			return err
 		}
+		sortListing(projects, projectSortFields)
 
 		if outputFmt == "json" {
 			return json.NewEncoder(os.Stdout).Encode(projects)
@@ -112,7 +122,7 @@ Examples:
 			return fmt.Errorf("no organization specified")
 		}
 
-		client := api.NewClient(cfg.APIURL, cfg.Token)
+		client := newClient()
 		project, err := client.CreateProject(orgID, name, projectNewDesc)
 		if err != nil {
 			return fmt.Errorf("failed to create project: %w", err)
@@ -157,7 +167,7 @@ var projectCurrentCmd = &cobra.Command{
 		}
 
 		if cfg.IsAuthenticated() {
-			client := api.NewClient(cfg.APIURL, cfg.Token)
+			client := newClient()
 			project, err := client.GetProject(defaultProject)
 			if err == nil {
 				printInfo("Default project: %s (%s)", project.Name, project.ExternalID)
@@ -171,14 +181,30 @@ var projectCurrentCmd = &cobra.Command{
 }
 
 var projectUseCmd = &cobra.Command{
-	Use:   "use <id>",
+	Use:   "use [id]",
 	Short: "Set default project",
-	Args:  cobra.ExactArgs(1),
+	Long: `Set the default project used by page commands.
+
+When run without an ID (or with --interactive), fetches your projects and
+presents a numbered picker grouped by organization. Type a number to select,
+or type part of a name to narrow the list.
+
+Examples:
+  hyperclast project use proj_abc123
+  hyperclast project use`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && projectUseInteractive {
+			return fmt.Errorf("--interactive cannot be combined with a project ID")
+		}
+		if len(args) == 0 {
+			return runProjectUseInteractive()
+		}
+
 		projectID := args[0]
 
 		if cfg.IsAuthenticated() {
-			client := api.NewClient(cfg.APIURL, cfg.Token)
+			client := newClient()
 			project, err := client.GetProject(projectID)
 			if err != nil {
 				return fmt.Errorf("project '%s' not found. Run 'hyperclast project list' to see available projects", projectID)
@@ -203,6 +229,133 @@ var projectUseCmd = &cobra.Command{
 	},
 }
 
+func runProjectUseInteractive() error {
+	if err := requireAuth(); err != nil {
+		return err
+	}
+
+	stat, _ := os.Stdin.Stat()
+	if (stat.Mode() & os.ModeCharDevice) == 0 {
+		return fmt.Errorf("interactive selection requires a terminal. Pass a project ID: hyperclast project use <id>")
+	}
+
+	client := newClient()
+	projects, err := client.ListProjects("")
+	if err != nil {
+		return err
+	}
+
+	if len(projects) == 0 {
+		printInfo("No projects found")
+		printInfo("Run 'hyperclast project new <name>' to create one")
+		return nil
+	}
+
+	project, err := pickProject(projects, os.Stdin, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		printInfo("Cancelled")
+		return nil
+	}
+
+	cfg.SetDefaultProject(project.ExternalID)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	printSuccess("Default project set to \"%s\" (%s)", project.Name, project.ExternalID)
+	return nil
+}
+
+// pickProject shows projects grouped by org and reads a selection from in.
+// Numeric input selects by position; any other input filters by name
+// (case-insensitive substring) and re-prompts until one project remains.
+// Returns nil without error when the user enters nothing or hits EOF.
+func pickProject(projects []api.Project, in io.Reader, out io.Writer) (*api.Project, error) {
+	sorted := make([]api.Project, len(projects))
+	copy(sorted, projects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Org.Name) < strings.ToLower(sorted[j].Org.Name)
+	})
+
+	reader := bufio.NewReader(in)
+	candidates := sorted
+
+	for {
+		printProjectChoices(out, candidates)
+		_, _ = fmt.Fprintf(out, "Select a project [1-%d], or type to filter: ", len(candidates))
+
+		line, err := reader.ReadString('\n')
+		input := strings.TrimSpace(line)
+		if input == "" {
+			if err != nil && err != io.EOF {
+				return nil, fmt.Errorf("failed to read selection: %w", err)
+			}
+			return nil, nil
+		}
+
+		if n, convErr := strconv.Atoi(input); convErr == nil {
+			if n < 1 || n > len(candidates) {
+				_, _ = fmt.Fprintf(out, "Invalid selection: %d\n\n", n)
+				if err != nil {
+					return nil, nil
+				}
+				continue
+			}
+			return &candidates[n-1], nil
+		}
+
+		filtered := filterProjectsByName(sorted, input)
+		switch len(filtered) {
+		case 0:
+			_, _ = fmt.Fprintf(out, "No projects match %q\n\n", input)
+			candidates = sorted
+		case 1:
+			return &filtered[0], nil
+		default:
+			_, _ = fmt.Fprintln(out)
+			candidates = filtered
+		}
+
+		if err != nil {
+			return nil, nil
+		}
+	}
+}
+
+func printProjectChoices(out io.Writer, projects []api.Project) {
+	currentOrg := ""
+	for i, project := range projects {
+		if i == 0 || project.Org.Name != currentOrg {
+			currentOrg = project.Org.Name
+			orgName := currentOrg
+			if orgName == "" {
+				orgName = "(no organization)"
+			}
+			_, _ = fmt.Fprintf(out, "%s\n", orgName)
+		}
+		defaultMark := ""
+		if project.ExternalID == cfg.GetDefaultProject() {
+			defaultMark = " (default)"
+		}
+		_, _ = fmt.Fprintf(out, "  %2d) %s%s  %s\n", i+1, project.Name, defaultMark, project.ExternalID)
+	}
+}
+
+func filterProjectsByName(projects []api.Project, query string) []api.Project {
+	query = strings.ToLower(query)
+	var matches []api.Project
+	for _, project := range projects {
+		if strings.Contains(strings.ToLower(project.Name), query) ||
+			strings.Contains(strings.ToLower(project.ExternalID), query) {
+			matches = append(matches, project)
+		}
+	}
+	return matches
+}
+
 func init() {
 	rootCmd.AddCommand(projectCmd)
 	projectCmd.AddCommand(projectNewCmd)
@@ -215,4 +368,6 @@ func init() {
 	projectNewCmd.Flags().BoolVar(&projectNewSetUse, "use", false, "set as default project after creation")
 
 	projectListCmd.Flags().StringVar(&projectOrgID, "org", "", "filter by organization ID")
+
+	projectUseCmd.Flags().BoolVar(&projectUseInteractive, "interactive", false, "choose from a list of your projects")
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectDefaultPageProjectID string
+	projectDefaultPageUnset     bool
+)
+
+var projectDefaultPageCmd = &cobra.Command{
+	Use:   "default-page [page-id]",
+	Short: "Show or set a project's default page",
+	Long: `Show or set the default page of a project: the inbox that
+'hyperclast push' appends to. Without a page ID, shows the current one.
+
+Without a default page, push uses the project's "Inbox" page, creating it
+if needed. The setting is kept in your config, per project.
+
+Examples:
+  hyperclast project default-page page_xyz789
+  hyperclast project default-page --project proj_abc123
+  hyperclast project default-page --unset`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID := projectDefaultPageProjectID
+		if projectID == "" {
+			projectID = cfg.GetDefaultProject()
+		}
+		if projectID == "" {
+			printError("No project specified.")
+			printInfo("  Use --project <id> or set a default: hyperclast project use <id>")
+			cmd.SilenceErrors = true
+			return fmt.Errorf("no project specified")
+		}
+		if projectDefaultPageUnset && len(args) > 0 {
+			return fmt.Errorf("--unset cannot be combined with a page ID")
+		}
+
+		switch {
+		case projectDefaultPageUnset:
+			cfg.SetDefaultPage(projectID, "")
+			if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+		case len(args) == 1:
+			if err := requireAuth(); err != nil {
+				return err
+			}
+			page, err := newClient().GetPageMetadata(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get page: %w", err)
+			}
+			if page.ProjectID != "" && page.ProjectID != projectID {
+				return fmt.Errorf("page %s is in project %s, not %s", page.ExternalID, page.ProjectID, projectID)
+			}
+			cfg.SetDefaultPage(projectID, page.ExternalID)
+			if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			if outputFmt != "json" {
+				printSuccess("Default page of project %s set to \"%s\" (%s)", projectID, page.Title, page.ExternalID)
+				return nil
+			}
+		}
+
+		pageID := cfg.GetDefaultPage(projectID)
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]string{
+				"project_id": projectID,
+				"page_id":    pageID,
+			})
+		}
+		if projectDefaultPageUnset {
+			printSuccess("Cleared the default page of project %s", projectID)
+			return nil
+		}
+		if pageID == "" {
+			printInfo("No default page set for project %s; push uses its \"%s\" page", projectID, inboxTitle)
+			return nil
+		}
+		if quiet {
+			fmt.Println(pageID)
+			return nil
+		}
+		printInfo("Default page of project %s: %s", projectID, pageID)
+		return nil
+	},
+}
+
+func init() {
+	projectCmd.AddCommand(projectDefaultPageCmd)
+
+	projectDefaultPageCmd.Flags().StringVar(&projectDefaultPageProjectID, "project", "", "project ID (default: from config)")
+	projectDefaultPageCmd.Flags().BoolVar(&projectDefaultPageUnset, "unset", false, "clear the project's default page")
+}
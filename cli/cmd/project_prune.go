@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectPruneEmpty     bool
+	projectPruneOlderThan string
+	projectPruneDryRun    bool
+	projectPruneForce     bool
+)
+
+var projectPruneCmd = &cobra.Command{
+	Use:   "prune <project-id>",
+	Short: "Delete empty or stale pages in a project",
+	Long: `Find pages in a project that are empty or have not been updated within a
+period, and delete them in bulk. A page matching either criterion is pruned.
+
+Use --dry-run first to see what would be deleted. Prompts for confirmation
+unless --force is used.
+
+Examples:
+  hyperclast project prune proj_abc123 --empty --dry-run
+  hyperclast project prune proj_abc123 --older-than 90d
+  hyperclast project prune proj_abc123 --empty --older-than 90d --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if !projectPruneEmpty && projectPruneOlderThan == "" {
+			return fmt.Errorf("specify --empty and/or --older-than")
+		}
+
+		var cutoff time.Time
+		if projectPruneOlderThan != "" {
+			age, err := parseLongDuration(projectPruneOlderThan)
+			if err != nil {
+				return err
+			}
+			cutoff = time.Now().Add(-age)
+		}
+
+		client := newClient()
+		// Archived pages are kept on purpose, so they are never pruned.
+		pages, _, err := listPagesByArchive(client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list pages: %w", err)
+		}
+
+		candidates, err := findPrunablePages(client, pages, projectPruneEmpty, cutoff)
+		if err != nil {
+			return err
+		}
+
+		if projectPruneDryRun || len(candidates) == 0 {
+			return printPruneReport(candidates, nil, true)
+		}
+
+		if !projectPruneForce {
+			stat, _ := os.Stdin.Stat()
+			if (stat.Mode() & os.ModeCharDevice) == 0 {
+				return fmt.Errorf("--force is required in non-interactive mode (stdin is not a terminal)")
+			}
+			if err := printPruneTable(candidates); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Delete %d pages? [y/N] ", len(candidates))
+			var confirm string
+			_, _ = fmt.Scanln(&confirm)
+			if confirm != "y" && confirm != "Y" {
+				printInfo("Cancelled")
+				return nil
+			}
+		}
+
+		failures := make(map[string]string)
+		deleting := startProgress("delete", len(candidates), "pages")
+		for _, c := range candidates {
+			err := client.DeletePage(c.ExternalID)
+			status := "deleted"
+			if err != nil {
+				status = "failed"
+				failures[c.ExternalID] = err.Error()
+				printDebug("Failed to delete %s: %v", c.ExternalID, err)
+			}
+			deleting.advance(c.ExternalID, status, err)
+		}
+
+		return printPruneReport(candidates, failures, false)
+	},
+}
+
+// pruneCandidate is a page selected for pruning and why.
+type pruneCandidate struct {
+	ExternalID string   `json:"external_id"`
+	Title      string   `json:"title"`
+	Updated    string   `json:"updated"`
+	Reasons    []string `json:"reasons"`
+}
+
+// findPrunablePages returns pages that are empty (when checkEmpty) or were
+// last updated before cutoff (when cutoff is non-zero). Checking for empty
+// pages fetches each page, since project listings omit content.
+func findPrunablePages(client *api.Client, pages []api.Page, checkEmpty bool, cutoff time.Time) ([]pruneCandidate, error) {
+	var candidates []pruneCandidate
+	for _, page := range pages {
+		updated := page.Updated
+		if updated == "" {
+			updated = page.Modified
+		}
+
+		var reasons []string
+		if !cutoff.IsZero() {
+			if t, err := time.Parse(time.RFC3339, updated); err == nil && t.Before(cutoff) {
+				reasons = append(reasons, "stale")
+			}
+		}
+		if checkEmpty {
+			full, err := client.GetPage(page.ExternalID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get page %s: %w", page.ExternalID, err)
+			}
+			if strings.TrimSpace(pageContent(full)) == "" {
+				reasons = append(reasons, "empty")
+			}
+		}
+
+		if len(reasons) > 0 {
+			candidates = append(candidates, pruneCandidate{
+				ExternalID: page.ExternalID,
+				Title:      page.Title,
+				Updated:    updated,
+				Reasons:    reasons,
+			})
+		}
+	}
+	return candidates, nil
+}
+
+func printPruneTable(candidates []pruneCandidate) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTITLE\tUPDATED\tREASON")
+	for _, c := range candidates {
+		updated := c.Updated
+		if t, err := time.Parse(time.RFC3339, updated); err == nil {
+			updated = t.Format("Jan 2, 2006 3:04 PM")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.ExternalID, c.Title, updated, strings.Join(c.Reasons, ", "))
+	}
+	return w.Flush()
+}
+
+func printPruneReport(candidates []pruneCandidate, failures map[string]string, dryRun bool) error {
+	deleted := 0
+	if !dryRun {
+		deleted = len(candidates) - len(failures)
+	}
+
+	if outputFmt == "json" {
+		if candidates == nil {
+			candidates = []pruneCandidate{}
+		}
+		result := map[string]any{
+			"dry_run": dryRun,
+			"matched": candidates,
+			"deleted": deleted,
+		}
+		if len(failures) > 0 {
+			result["failed"] = failures
+		}
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+
+	if len(candidates) == 0 {
+		printInfo("No pages to prune")
+		return nil
+	}
+
+	if dryRun {
+		if err := printPruneTable(candidates); err != nil {
+			return err
+		}
+		printInfo("\nDry run: would delete %d pages", len(candidates))
+		return nil
+	}
+
+	for id, msg := range failures {
+		printError("failed to delete %s: %s", id, msg)
+	}
+	printSuccess("Deleted %d of %d pages", deleted, len(candidates))
+	if len(failures) > 0 {
+		return fmt.Errorf("%d pages could not be deleted", len(failures))
+	}
+	return nil
+}
+
+func init() {
+	projectCmd.AddCommand(projectPruneCmd)
+
+	projectPruneCmd.Flags().BoolVar(&projectPruneEmpty, "empty", false, "prune pages with no content")
+	projectPruneCmd.Flags().StringVar(&projectPruneOlderThan, "older-than", "", "prune pages not updated within this period (e.g. 90d, 12w)")
+	projectPruneCmd.Flags().BoolVar(&projectPruneDryRun, "dry-run", false, "show what would be deleted without deleting")
+	projectPruneCmd.Flags().BoolVar(&projectPruneForce, "force", false, "skip confirmation prompt")
+}
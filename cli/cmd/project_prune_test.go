@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func resetProjectPruneFlags() {
+	projectPruneEmpty = false
+	projectPruneOlderThan = ""
+	projectPruneDryRun = false
+	projectPruneForce = false
+	outputFmt = "text"
+	quiet = false
+}
+
+// pruneServer serves a project with a fresh page, an empty page, and a page
+// last updated a year ago, and records deletions.
+func pruneServer(t *testing.T, deleted *[]string) *httptest.Server {
+	t.Helper()
+	now := time.Now().UTC()
+	pages := map[string]api.Page{
+		"page_fresh": {ExternalID: "page_fresh", Title: "Fresh", Updated: now.Format(time.RFC3339),
+			Details: &api.PageDetails{Content: "notes"}},
+		"page_empty": {ExternalID: "page_empty", Title: "Empty", Updated: now.Format(time.RFC3339),
+			Details: &api.PageDetails{Content: "  \n"}},
+		"page_old": {ExternalID: "page_old", Title: "Old", Updated: now.AddDate(-1, 0, 0).Format(time.RFC3339),
+			Details: &api.PageDetails{Content: "old notes"}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/projects/proj_abc/" {
+			var summaries []api.Page
+			for _, id := range []string{"page_fresh", "page_empty", "page_old"} {
+				p := pages[id]
+				p.Details = nil
+				summaries = append(summaries, p)
+			}
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: "proj_abc", Pages: summaries})
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+		if r.Method == http.MethodDelete {
+			*deleted = append(*deleted, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pages[id])
+	}))
+}
+
+func TestProjectPrune_DryRun(t *testing.T) {
+	resetProjectPruneFlags()
+	var deleted []string
+	server := pruneServer(t, &deleted)
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	projectPruneEmpty = true
+	projectPruneOlderThan = "90d"
+	projectPruneDryRun = true
+	outputFmt = "json"
+	defer resetProjectPruneFlags()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := projectPruneCmd.RunE(projectPruneCmd, []string{"proj_abc"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("dry run deleted %v", deleted)
+	}
+
+	output, _ := io.ReadAll(r)
+	var result struct {
+		DryRun  bool             `json:"dry_run"`
+		Matched []pruneCandidate `json:"matched"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("output is not valid JSON: %v\nraw: %s", err, output)
+	}
+	if !result.DryRun || len(result.Matched) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Matched[0].ExternalID != "page_empty" || result.Matched[0].Reasons[0] != "empty" {
+		t.Errorf("first match = %+v, want page_empty (empty)", result.Matched[0])
+	}
+	if result.Matched[1].ExternalID != "page_old" || result.Matched[1].Reasons[0] != "stale" {
+		t.Errorf("second match = %+v, want page_old (stale)", result.Matched[1])
+	}
+}
+
+func TestProjectPrune_OlderThanDeletes(t *testing.T) {
+	resetProjectPruneFlags()
+	var deleted []string
+	server := pruneServer(t, &deleted)
+	defer server.Close()
+
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	projectPruneOlderThan = "90d"
+	projectPruneForce = true
+	quiet = true
+	defer resetProjectPruneFlags()
+
+	if err := projectPruneCmd.RunE(projectPruneCmd, []string{"proj_abc"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "page_old" {
+		t.Errorf("deleted = %v, want [page_old]", deleted)
+	}
+}
+
+func TestProjectPrune_RequiresCriterion(t *testing.T) {
+	resetProjectPruneFlags()
+	cfg = &config.Config{Token: "test-token"}
+
+	err := projectPruneCmd.RunE(projectPruneCmd, []string{"proj_abc"})
+	if err == nil || !strings.Contains(err.Error(), "--empty and/or --older-than") {
+		t.Errorf("err = %v, want missing criterion error", err)
+	}
+}
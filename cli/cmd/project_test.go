@@ -33,6 +33,7 @@ func resetProjectFlags() {
 	projectNewOrgID = ""
 	projectNewDesc = ""
 	projectNewSetUse = false
+	projectUseInteractive = false
 	outputFmt = "text"
 	quiet = false
 }
@@ -453,3 +454,105 @@ func TestProjectNew_SendsAuthHeader(t *testing.T) {
 		t.Errorf("expected 'Bearer my-secret-token', got %q", receivedAuth)
 	}
 }
+
+// --- project use picker tests ---
+
+func samplePickerProjects() []api.Project {
+	return []api.Project{
+		{ExternalID: "proj_1", Name: "Build Logs", Org: api.Org{Name: "Zeta"}},
+		{ExternalID: "proj_2", Name: "Notes", Org: api.Org{Name: "Acme"}},
+		{ExternalID: "proj_3", Name: "Deploy Logs", Org: api.Org{Name: "Acme"}},
+	}
+}
+
+func TestPickProject_SelectByNumberGroupedByOrg(t *testing.T) {
+	resetProjectFlags()
+	cfg = &config.Config{}
+
+	var out strings.Builder
+	// Sorted by org: Acme (Notes, Deploy Logs), then Zeta (Build Logs)
+	project, err := pickProject(samplePickerProjects(), strings.NewReader("3\n"), &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project == nil || project.ExternalID != "proj_1" {
+		t.Fatalf("selected = %+v, want proj_1", project)
+	}
+	if strings.Index(out.String(), "Acme") > strings.Index(out.String(), "Zeta") {
+		t.Errorf("expected orgs to be listed alphabetically, got %q", out.String())
+	}
+}
+
+func TestPickProject_FilterToSingleMatch(t *testing.T) {
+	resetProjectFlags()
+	cfg = &config.Config{}
+
+	var out strings.Builder
+	project, err := pickProject(samplePickerProjects(), strings.NewReader("notes\n"), &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project == nil || project.ExternalID != "proj_2" {
+		t.Fatalf("selected = %+v, want proj_2", project)
+	}
+}
+
+func TestPickProject_FilterThenNumber(t *testing.T) {
+	resetProjectFlags()
+	cfg = &config.Config{}
+
+	var out strings.Builder
+	project, err := pickProject(samplePickerProjects(), strings.NewReader("logs\n2\n"), &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Filtered list: Deploy Logs (Acme), Build Logs (Zeta)
+	if project == nil || project.ExternalID != "proj_1" {
+		t.Fatalf("selected = %+v, want proj_1", project)
+	}
+}
+
+func TestPickProject_EmptyInputCancels(t *testing.T) {
+	resetProjectFlags()
+	cfg = &config.Config{}
+
+	var out strings.Builder
+	project, err := pickProject(samplePickerProjects(), strings.NewReader("\n"), &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project != nil {
+		t.Errorf("expected no selection, got %+v", project)
+	}
+}
+
+func TestPickProject_OutOfRangeReprompts(t *testing.T) {
+	resetProjectFlags()
+	cfg = &config.Config{}
+
+	var out strings.Builder
+	project, err := pickProject(samplePickerProjects(), strings.NewReader("9\n1\n"), &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project == nil || project.ExternalID != "proj_2" {
+		t.Fatalf("selected = %+v, want proj_2", project)
+	}
+	if !strings.Contains(out.String(), "Invalid selection: 9") {
+		t.Errorf("expected invalid selection notice, got %q", out.String())
+	}
+}
+
+func TestProjectUse_InteractiveWithIDRejected(t *testing.T) {
+	resetProjectFlags()
+	cfg = &config.Config{}
+	projectUseInteractive = true
+
+	err := projectUseCmd.RunE(projectUseCmd, []string{"proj_abc123"})
+	if err == nil || err.Error() != "--interactive cannot be combined with a project ID" {
+		t.Fatalf("err = %v, want a conflict error", err)
+	}
+	if cfg.GetDefaultProject() != "" {
+		t.Errorf("default project = %q, want it unchanged", cfg.GetDefaultProject())
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// confirmProtectedWrite guards writes to a project listed in
+// protected_projects. Naming the project with --project (explicit) is
+// enough; a project inherited from the defaults needs a confirmation, and
+// without a terminal to ask on the write is refused. what describes the
+// write for the messages ("create a page").
+func confirmProtectedWrite(projectID string, explicit bool, what string) error {
+	if explicit || !cfg.IsProtectedProject(projectID) {
+		return nil
+	}
+
+	stat, _ := os.Stdin.Stat()
+	if (stat.Mode() & os.ModeCharDevice) == 0 {
+		return fmt.Errorf("project %s is protected: pass --project %s to %s in it", projectID, projectID, what)
+	}
+	fmt.Fprintf(os.Stderr, "Project %s is protected (it comes from your defaults, not --project). Really %s in it? [y/N] ", projectID, what)
+	var confirm string
+	_, _ = fmt.Scanln(&confirm)
+	if confirm != "y" && confirm != "Y" {
+		return fmt.Errorf("cancelled: project %s is protected", projectID)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+func TestPush_ProtectedDefaultProject(t *testing.T) {
+	s := newPushServer(t, []api.Page{{ExternalID: "page_inbox", Title: "Inbox"}})
+	cfg.ProtectedProjects = []string{"proj_a"}
+
+	// Piped stdin cannot confirm, so the inherited project is refused.
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = oldStdin }()
+
+	err = runPush(t, "oops\n")
+	if err == nil || !strings.Contains(err.Error(), "pass --project proj_a") {
+		t.Fatalf("err = %v, want the protected project refused", err)
+	}
+	if len(s.appends) != 0 || len(s.created) != 0 {
+		t.Fatalf("appends = %v, created = %v; want nothing written", s.appends, s.created)
+	}
+
+	// Naming it explicitly is enough.
+	pushProjectID = "proj_a"
+	defer func() { pushProjectID = "" }()
+	if err := runPush(t, "meant it\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.appends["page_inbox"] != "meant it\n" {
+		t.Errorf("appends = %v, want the explicit push written", s.appends)
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// inboxTitle is the title of the page 'hyperclast push' appends to when a
+// project has no default page set.
+const inboxTitle = "Inbox"
+
+var pushProjectID string
+
+var pushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Append stdin to the default project's inbox page",
+	Long: `Append stdin to the default page of the default project: the shortest
+path from a terminal to the workspace.
+
+The default page is the one set with 'hyperclast project default-page'.
+Without one, the project's "Inbox" page is used, and created on first use;
+either way it is remembered in the config for later pushes.
+
+Examples:
+  echo "call the vendor back" | hyperclast push
+  make test 2>&1 | hyperclast push
+  hyperclast push --file notes.txt --project proj_abc123`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		projectID := pushProjectID
+		if projectID == "" {
+			projectID = cfg.GetDefaultProject()
+		}
+		if projectID == "" {
+			printError("No project specified.")
+			printInfo("  Use --project <id> or set a default: hyperclast project use <id>")
+			cmd.SilenceErrors = true
+			return fmt.Errorf("no project specified")
+		}
+		if err := confirmProtectedWrite(projectID, pushProjectID != "", "push"); err != nil {
+			return err
+		}
+
+		// Fail before an inbox page is created for nothing.
+		if pageFile == "" {
+			stat, _ := os.Stdin.Stat()
+			if (stat.Mode() & os.ModeCharDevice) != 0 {
+				printError("No content provided. Pipe content or use --file <path>")
+				return fmt.Errorf("no content provided")
+			}
+		}
+
+		pageID, err := resolveDefaultPage(newClient(), projectID)
+		if err != nil {
+			return err
+		}
+		return runPageUpdate([]string{pageID}, "append")
+	},
+}
+
+// resolveDefaultPage returns projectID's default page: the one set in the
+// config, else the most recently updated page titled inboxTitle, which is
+// created if there is none. A page found or created is saved as the
+// project's default so later calls skip the lookup.
+func resolveDefaultPage(client *api.Client, projectID string) (string, error) {
+	if pageID := cfg.GetDefaultPage(projectID); pageID != "" {
+		return pageID, nil
+	}
+
+	project, err := client.GetProject(projectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+	var inbox *api.Page
+	for i, p := range project.Pages {
+		if p.Title == inboxTitle && (inbox == nil || p.Updated > inbox.Updated) {
+			inbox = &project.Pages[i]
+		}
+	}
+	if inbox == nil {
+		if inbox, err = client.CreatePage(projectID, inboxTitle, "", "txt"); err != nil {
+			return "", fmt.Errorf("failed to create inbox page: %w", err)
+		}
+		printInfo("Created page \"%s\" (%s) as the default page of project %s", inbox.Title, inbox.ExternalID, projectID)
+	}
+
+	cfg.SetDefaultPage(projectID, inbox.ExternalID)
+	if err := cfg.Save(); err != nil {
+		printWarning("could not remember the default page: %v", err)
+	}
+	return inbox.ExternalID, nil
+}
+
+func init() {
+	rootCmd.AddCommand(pushCmd)
+
+	pushCmd.Flags().StringVar(&pushProjectID, "project", "", "project whose default page to append to (default: from config)")
+	pushCmd.Flags().StringVar(&pageFile, "file", "", "read content from file instead of stdin")
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+// pushServer serves project proj_a with the given pages, creates pages on
+// POST, and records the page and content of each append.
+type pushServer struct {
+	mu      sync.Mutex
+	created []string
+	appends map[string]string
+}
+
+func newPushServer(t *testing.T, pages []api.Page) *pushServer {
+	t.Helper()
+	s := &pushServer{appends: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.URL.Path == "/projects/proj_a/":
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: "proj_a", Pages: pages})
+		case r.Method == http.MethodPost:
+			var req api.CreatePageRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			s.created = append(s.created, req.Title)
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_new", Title: req.Title})
+		case r.Method == http.MethodPut:
+			var req api.UpdatePageContentRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+			s.appends[id] = req.Details.Content
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: id, Title: "Inbox"})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/pages/"):
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pages/"), "/")
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: id, Title: "Inbox", ProjectID: "proj_a", Role: api.RoleAdmin})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	cfg.SetDefaultProject("proj_a")
+	return s
+}
+
+func runPush(t *testing.T, content string) error {
+	t.Helper()
+	resetPageFlags()
+	t.Cleanup(resetPageFlags)
+	pageFile = filepath.Join(t.TempDir(), "note.txt")
+	if err := os.WriteFile(pageFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	quiet = true
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+	return pushCmd.RunE(pushCmd, nil)
+}
+
+func TestPush_AppendsToNewestInboxAndRemembersIt(t *testing.T) {
+	s := newPushServer(t, []api.Page{
+		{ExternalID: "page_old", Title: "Inbox", Updated: "2026-01-01T00:00:00Z"},
+		{ExternalID: "page_inbox", Title: "Inbox", Updated: "2026-10-01T00:00:00Z"},
+		{ExternalID: "page_notes", Title: "Notes", Updated: "2026-10-17T00:00:00Z"},
+	})
+
+	if err := runPush(t, "call the vendor back\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.appends["page_inbox"] != "call the vendor back\n" || len(s.created) != 0 {
+		t.Errorf("appends = %v, created = %v; want the newest Inbox page appended to", s.appends, s.created)
+	}
+	if got := cfg.GetDefaultPage("proj_a"); got != "page_inbox" {
+		t.Errorf("default page = %q, want it remembered", got)
+	}
+}
+
+func TestPush_CreatesInbox(t *testing.T) {
+	s := newPushServer(t, []api.Page{{ExternalID: "page_notes", Title: "Notes"}})
+
+	if err := runPush(t, "first\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(s.created) != 1 || s.created[0] != "Inbox" || s.appends["page_new"] != "first\n" {
+		t.Errorf("created = %v, appends = %v; want an Inbox page created and appended to", s.created, s.appends)
+	}
+}
+
+func TestProjectDefaultPage(t *testing.T) {
+	newPushServer(t, nil)
+	defer func() { projectDefaultPageUnset = false }()
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	if err := projectDefaultPageCmd.RunE(projectDefaultPageCmd, []string{"page_todo"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.GetDefaultPage("proj_a"); got != "page_todo" {
+		t.Errorf("default page = %q, want page_todo", got)
+	}
+
+	projectDefaultPageProjectID = "proj_b"
+	err := projectDefaultPageCmd.RunE(projectDefaultPageCmd, []string{"page_todo"})
+	projectDefaultPageProjectID = ""
+	if err == nil || !strings.Contains(err.Error(), "not proj_b") {
+		t.Errorf("err = %v, want a page from another project refused", err)
+	}
+
+	projectDefaultPageUnset = true
+	if err := projectDefaultPageCmd.RunE(projectDefaultPageCmd, nil); err != nil || cfg.GetDefaultPage("proj_a") != "" {
+		t.Errorf("--unset: err = %v, default page = %q", err, cfg.GetDefaultPage("proj_a"))
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// quotaWarnRatio is how full a page may get before writes warn about it.
+const quotaWarnRatio = 0.8
+
+// pageQuota estimates the cost of writing to a page against the server's
+// per-page limit. The API does not expose per-org plan limits, so the fixed
+// server limit is the only one checked.
+type pageQuota struct {
+	PageID  string
+	Current int64 // bytes on the page now (0 for overwrite)
+	Adding  int64 // bytes about to be written
+}
+
+func newPageQuota(page *api.Page, content, mode string) pageQuota {
+	q := pageQuota{PageID: page.ExternalID, Adding: int64(len(content))}
+	if mode != "overwrite" {
+		q.Current = int64(len(pageContent(page)))
+	}
+	return q
+}
+
+func (q pageQuota) projected() int64 {
+	return q.Current + q.Adding
+}
+
+func (q pageQuota) exceeded() bool {
+	return q.projected() > api.MaxPageBytes
+}
+
+func (q pageQuota) nearLimit() bool {
+	return float64(q.projected()) >= quotaWarnRatio*api.MaxPageBytes
+}
+
+func (q pageQuota) String() string {
+	return fmt.Sprintf("writing %s would bring page %s to %s, over the %s per-page limit",
+		formatBytes(q.Adding), q.PageID, formatBytes(q.projected()), formatBytes(api.MaxPageBytes))
+}
+
+// enforcePageQuota refuses a write that would push a page over the limit,
+// before anything is sent, unless force is set. A write that leaves the page
+// near the limit only warns.
+func enforcePageQuota(q pageQuota, force bool) error {
+	switch {
+	case q.exceeded() && !force:
+		return fmt.Errorf("%s (use --force to send it anyway)", q)
+	case q.exceeded():
+		printWarning("%s; sending anyway (--force)", q)
+	case q.nearLimit():
+		printWarning("page %s will be at %s of its %s limit", q.PageID, formatBytes(q.projected()), formatBytes(api.MaxPageBytes))
+	}
+	return nil
+}
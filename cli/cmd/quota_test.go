@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestPageQuota(t *testing.T) {
+	page := &api.Page{ExternalID: "page_xyz", Details: &api.PageDetails{Content: strings.Repeat("x", 9*1024*1024)}}
+
+	tests := []struct {
+		name     string
+		adding   int
+		mode     string
+		exceeded bool
+		near     bool
+	}{
+		{"small append", 10, "append", false, true},
+		{"append over limit", 2 * 1024 * 1024, "append", true, true},
+		{"prepend over limit", 2 * 1024 * 1024, "prepend", true, true},
+		{"overwrite ignores current content", 2 * 1024 * 1024, "overwrite", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newPageQuota(page, strings.Repeat("y", tt.adding), tt.mode)
+			if q.exceeded() != tt.exceeded || q.nearLimit() != tt.near {
+				t.Errorf("exceeded=%v near=%v, want %v %v", q.exceeded(), q.nearLimit(), tt.exceeded, tt.near)
+			}
+		})
+	}
+}
+
+func TestEnforcePageQuota(t *testing.T) {
+	over := pageQuota{PageID: "page_xyz", Current: api.MaxPageBytes, Adding: 1}
+
+	err := enforcePageQuota(over, false)
+	if err == nil || !strings.Contains(err.Error(), "over the 10.0 MB per-page limit") || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("err = %v, want limit error mentioning --force", err)
+	}
+	if err := enforcePageQuota(over, true); err != nil {
+		t.Errorf("forced write should be allowed, got %v", err)
+	}
+	if err := enforcePageQuota(pageQuota{PageID: "page_xyz", Adding: 10}, false); err != nil {
+		t.Errorf("small write should be allowed, got %v", err)
+	}
+}
+
+func TestPageAppend_RefusesOverLimit(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts++
+		}
+		_ = json.NewEncoder(w).Encode(api.Page{
+			ExternalID: "page_xyz",
+			Title:      "Big Log",
+			Details:    &api.PageDetails{Content: strings.Repeat("x", api.MaxPageBytes-5)},
+		})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	pageFile = filepath.Join(t.TempDir(), "more.log")
+	if err := os.WriteFile(pageFile, []byte("one more line\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+	if err == nil || !strings.Contains(err.Error(), "per-page limit") {
+		t.Fatalf("err = %v, want per-page limit error", err)
+	}
+	if puts != 0 {
+		t.Errorf("sent %d PUT requests, want none", puts)
+	}
+
+	pageUpdateForce = true
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	err = pageAppendCmd.RunE(pageAppendCmd, []string{"page_xyz"})
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("unexpected error with --force: %v", err)
+	}
+	if puts != 1 {
+		t.Errorf("sent %d PUT requests with --force, want 1", puts)
+	}
+}
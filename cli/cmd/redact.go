@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// secretPatterns find secrets in captured content. A private key keeps
+// only its BEGIN and END lines; for the others the last group is the
+// secret and what comes before it is kept, so a redacted line still shows
+// which setting or header held it.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(-----BEGIN [A-Z ]*PRIVATE KEY-----)[\s\S]*?(-----END [A-Z ]*PRIVATE KEY-----)`),
+	regexp.MustCompile(`(?i)(\b(?:bearer|basic)\s+)([A-Za-z0-9._~+/=-]{8,})`),
+	regexp.MustCompile(`(?i)(\b[\w.-]*(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key)[\w.-]*["']?\s*[:=]\s*["']?)([^\s"',;]+)`),
+	regexp.MustCompile(`(://[^/\s:@]+:)([^/\s@]+)@`),
+	regexp.MustCompile(`()\b((?:AKIA|ASIA)[0-9A-Z]{16}|gh[pousr]_[A-Za-z0-9]{36,}|xox[abprs]-[A-Za-z0-9-]{10,})\b`),
+}
+
+// redactSecrets replaces passwords, tokens, API keys, private keys, and
+// credentials in URLs with [REDACTED], and returns how many it replaced.
+func redactSecrets(content string) (string, int) {
+	count := 0
+	for i, re := range secretPatterns {
+		content = re.ReplaceAllStringFunc(content, func(m string) string {
+			sub := re.FindStringSubmatch(m)
+			if i == 0 {
+				count++
+				return sub[1] + "\n" + redacted + "\n" + sub[2]
+			}
+			secret := sub[len(sub)-1]
+			if secret == redacted {
+				return m
+			}
+			count++
+			return sub[1] + redacted + strings.TrimPrefix(m, sub[1]+secret)
+		})
+	}
+	return content, count
+}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"time"
 
+	"github.com/hyperclast/workspace/cli/internal/api"
 	"github.com/hyperclast/workspace/cli/internal/config"
 	"github.com/spf13/cobra"
 )
@@ -18,10 +20,16 @@ var (
 var (
 	cfgFile   string
 	apiURL    string
+	webURL    string
 	outputFmt string
 	quiet     bool
 	verbose   bool
 	cfg       *config.Config
+
+	requestTimeout  time.Duration
+	requestRetries  int
+	requestThrottle string
+	requestLimiter  *api.RateLimiter
 )
 
 var rootCmd = &cobra.Command{
@@ -42,22 +50,125 @@ var rootCmd = &cobra.Command{
 		if apiURL != "" {
 			cfg.APIURL = apiURL
 		}
-		return nil
+		if webURL != "" {
+			cfg.WebURL = webURL
+		}
+		if cfg.WebURL != "" {
+			if err := config.ValidateWebURL(cfg.WebURL); err != nil {
+				return err
+			}
+		}
+
+		currentAudit = newAuditTrail()
+		api.SetRequestObserver(observeRequest)
+
+		if err := resolveClientOptions(cmd); err != nil {
+			return err
+		}
+
+		if err := checkProgressFormat(); err != nil {
+			return err
+		}
+
+		return resolveOutputFormat(cmd)
 	},
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	started := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordAudit(cmd, err)
+	recordTelemetry(cmd, started)
+	if err != nil {
+		var interrupted *interruptedError
+		if errors.As(err, &interrupted) {
+			os.Exit(exitInterrupted)
+		}
+		var status *exitStatusError
+		if errors.As(err, &status) {
+			os.Exit(status.code)
+		}
 		os.Exit(1)
 	}
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ~/.config/hyperclast/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: $HYPERCLAST_CONFIG, $HYPERCLAST_CONFIG_DIR/config.yaml, or ~/.config/hyperclast/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "API URL (default: https://hyperclast.com/api)")
+	rootCmd.PersistentFlags().StringVar(&webURL, "workspace-url", "", "web app URL for printed links (default: config web_url, or derived from the API URL)")
 	rootCmd.PersistentFlags().StringVar(&outputFmt, "output", "text", "output format: text, json")
 	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "suppress info messages")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "show debug output")
+	rootCmd.PersistentFlags().StringVar(&progressFormat, "progress", "none", "progress events for long operations: none, json (JSON lines on stderr)")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", 0, "per-request timeout, e.g. 5s or 5m (default: config timeout or 30s)")
+	rootCmd.PersistentFlags().IntVar(&requestRetries, "retries", 0, "retry failed requests this many times (default: config retries or 0)")
+	rootCmd.PersistentFlags().StringVar(&requestThrottle, "throttle", "", "limit API requests to this rate, e.g. 5rps or 120/m (default: config throttle or unlimited)")
+}
+
+// resolveClientOptions layers --timeout, --retries, and --throttle over the
+// config defaults. Flags passed explicitly always win.
+func resolveClientOptions(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("timeout") {
+		timeout, err := cfg.GetTimeout()
+		if err != nil {
+			return err
+		}
+		requestTimeout = timeout
+	} else if requestTimeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+
+	if !cmd.Flags().Changed("retries") {
+		requestRetries = cfg.GetRetries()
+	}
+	if requestRetries < 0 {
+		return fmt.Errorf("retries must not be negative")
+	}
+
+	rps, err := cfg.GetThrottle()
+	if cmd.Flags().Changed("throttle") {
+		rps, err = config.ParseRate(requestThrottle)
+	}
+	if err != nil {
+		return err
+	}
+	requestLimiter = nil
+	if rps > 0 {
+		requestLimiter = api.NewRateLimiter(rps)
+	}
+	return nil
+}
+
+func clientOptions() api.ClientOptions {
+	return api.ClientOptions{
+		Timeout:          requestTimeout,
+		Retries:          requestRetries,
+		VerifyConnection: pinVerifier(),
+		RateLimiter:      requestLimiter,
+		Context:          requestContext,
+		OnUpload:         uploadProgress(),
+	}
+}
+
+// newClient returns an API client for the configured URL and token, using
+// the resolved timeout and retry settings.
+func newClient() *api.Client {
+	return api.NewClientWithOptions(cfg.APIURL, cfg.Token, clientOptions())
+}
+
+// resolveOutputFormat applies the config's default output format unless
+// --output was passed explicitly, then validates the result.
+func resolveOutputFormat(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("output") && cfg.GetOutput() != "" {
+		outputFmt = cfg.GetOutput()
+	}
+
+	switch outputFmt {
+	case "text", "json":
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (must be text or json)", outputFmt)
+	}
 }
 
 func printSuccess(format string, a ...any) {
@@ -76,6 +187,10 @@ func printError(format string, a ...any) {
 	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", a...)
 }
 
+func printWarning(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", a...)
+}
+
 func printDebug(format string, a ...any) {
 	if verbose {
 		fmt.Printf("[DEBUG] "+format+"\n", a...)
@@ -89,11 +204,7 @@ func requireAuth() error {
 	return nil
 }
 
-// baseURL returns the web app URL by stripping the API path suffix
-// (e.g. "/api/v1" or "/api") from the configured API URL.
+// baseURL returns the web app URL, without a trailing slash.
 func baseURL() string {
-	u := strings.TrimSuffix(cfg.APIURL, "/")
-	u = strings.TrimSuffix(u, "/v1")
-	u = strings.TrimSuffix(u, "/api")
-	return u
+	return cfg.GetWebURL()
 }
@@ -0,0 +1,548 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runPageID    string
+	runProjectID string
+	runTitle     string
+
+	runSplitStreams bool
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [flags] -- <command> [args...]",
+	Short: "Run a command and save its output, exit status, and duration to a page",
+	Long: `Run a command, passing its output through to the terminal, and save its
+stdout and stderr, in the order they were written, to a log page along with
+the exit status and how long it took. Use --page to append the record to an
+existing page instead of creating one.
+
+The record is sent when the command exits. With --stream, output is
+appended in batches while the command runs, so the page fills in as it
+goes. With --split-streams, stdout and stderr are saved in separate
+sections, each line stamped with the time since the command started so
+their order can still be followed. --timestamps stamps each line of the
+saved output with the time it was written. --max-bytes and --tail-lines
+save only the end of the output, so a runaway command cannot produce a
+page too large to send.
+
+With --only-on-failure, nothing is saved when the command succeeds. With
+--group-by-command, the output is not saved; instead each run adds a CSV
+row with its start time, exit status, and duration to one page for the
+command, titled "Runs: <command>" unless --title or --page is given. The
+page keeps the last 1000 runs. Together they keep a history of a flaky
+command's failures.
+
+Terminal escape sequences, such as colors, are removed from the saved
+output unless --keep-ansi is given; --ansi-markdown instead saves a
+markdown page with colored and bold text emphasized.
+
+hyperclast exits with the command's exit status, so it can replace the
+command in scripts and CI steps. Ctrl-C reaches the command as usual, and
+what it wrote before stopping is still saved.
+
+Flags for hyperclast go before the command; everything from the command
+name on is passed to it unchanged.
+
+Examples:
+  hyperclast run -- make test
+  hyperclast run --title "Nightly backup" -- ./backup.sh --full
+  hyperclast run --stream -- ./build.sh
+  hyperclast run --split-streams -- ./migrate.sh
+  hyperclast run --only-on-failure --group-by-command -- make test
+  hyperclast run --page page_xyz789 -- kubectl rollout status deploy/api`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if runPageID != "" && (runProjectID != "" || runTitle != "") {
+			return fmt.Errorf("--page cannot be combined with --project or --title")
+		}
+		projectID := runProjectID
+		if projectID == "" {
+			projectID = cfg.GetDefaultProject()
+		}
+		if runPageID == "" && projectID == "" {
+			return fmt.Errorf("no project specified (use --project, --page, or set a default project)")
+		}
+		if runSplitStreams && streamOutput {
+			return fmt.Errorf("--split-streams cannot be combined with --stream")
+		}
+		if err := checkTimestampsFormat(); err != nil {
+			return err
+		}
+		if err := checkRunGroupFlags(); err != nil {
+			return err
+		}
+		budget, err := newTailBudget()
+		if err != nil {
+			return err
+		}
+		if budget != nil && (streamOutput || runSplitStreams) {
+			return fmt.Errorf("--max-bytes and --tail-lines cannot be combined with --stream or --split-streams")
+		}
+		if err := resolveANSI(true); err != nil {
+			return err
+		}
+		if ansiMode == ansiToMarkdown && (runPageID != "" || streamOutput || runSplitStreams) {
+			return fmt.Errorf("--ansi-markdown cannot be combined with --page, --stream, or --split-streams")
+		}
+		var maxBytes int64
+		if streamOutput {
+			if maxBytes, err = checkStreamFlags(); err != nil {
+				return err
+			}
+		}
+
+		// Check the target before running, so a long command is not run
+		// only to find its output has nowhere to go.
+		client := newClient()
+		var existing *api.Page
+		if runPageID != "" {
+			var err error
+			if existing, err = client.GetPage(runPageID); err != nil {
+				return fmt.Errorf("failed to get page: %w", err)
+			}
+			if err := checkPageLock(client, existing); err != nil {
+				return err
+			}
+		} else if err := confirmProtectedWrite(projectID, runProjectID != "", "run"); err != nil {
+			return err
+		}
+
+		c, err := captureCommand(context.Background(), args[0], args[1:]...)
+		if err != nil {
+			return err
+		}
+		// With JSON output, stdout carries only the result.
+		var stdout io.Writer = os.Stdout
+		if outputFmt == "json" {
+			stdout = nil
+		}
+		result := newRunResult(args)
+		title := runTitle
+		if title == "" {
+			title = truncateRunes(result.commandLine(), maxTitleLength)
+		}
+		if runGroupByCommand && existing == nil {
+			title = runGroupTitle(result)
+			if existing, err = findRunGroupPage(client, projectID, title); err != nil {
+				return err
+			}
+		}
+
+		var page *api.Page
+		if runGroupByCommand {
+			page, err = runGrouped(client, existing, projectID, title, c, result, stdout)
+		} else if streamOutput {
+			page, err = runStreamed(client, existing, projectID, title, c, result, stdout, maxBytes)
+		} else {
+			page, err = runBuffered(client, existing, projectID, title, c, result, stdout, budget)
+		}
+		// The command has run by the time its record fails to upload, so
+		// the failure is reported and its exit status kept.
+		var upload *uploadError
+		if errors.As(err, &upload) {
+			printError("%v", upload.err)
+		} else if err != nil {
+			return err
+		}
+
+		if err := printRunResult(page, result, upload); err != nil {
+			return err
+		}
+		if result.ExitCode != 0 {
+			return &exitStatusError{
+				code: result.ExitCode,
+				msg:  fmt.Sprintf("%s exited with status %d", args[0], result.ExitCode),
+			}
+		}
+		return nil
+	},
+}
+
+// runBuffered runs c and then sends its record in one request, to existing
+// or to a new page. With a budget, only the end of the output is kept.
+func runBuffered(client *api.Client, existing *api.Page, projectID, title string, c *exec.Cmd, result *runResult, stdout io.Writer, budget *appendBudget) (*api.Page, error) {
+	if runSplitStreams {
+		outLines, errLines := &lineStamper{}, &lineStamper{}
+		if err := result.execute(c, stdout, os.Stderr, outLines, errLines); err != nil {
+			return nil, err
+		}
+		result.Output = filterANSI(splitStreamsRecord(result.start, outLines.done(), errLines.done()))
+		result.Lines = len(outLines.lines) + len(errLines.lines)
+	} else {
+		var buf bytes.Buffer
+		var record io.Writer = &buf
+		finish := func() (budgetResult, error) { return budgetResult{Content: buf.String()}, nil }
+		if budget != nil {
+			record, finish = budget.writer()
+		}
+		if lineTimestamps != "" {
+			record = newTimestampWriter(record)
+		}
+		runErr := result.execute(c, stdout, os.Stderr, record, record)
+		kept, err := finish()
+		if runErr != nil {
+			return nil, runErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Output = filterANSI(strings.ToValidUTF8(normalizeNewlines(kept.Content), "�"))
+		result.Lines = countLines(result.Output)
+		if dropped := kept.dropped(); dropped != "" {
+			printWarning("Output over budget; dropped %s", dropped)
+			result.Output = budget.mark(result.Output, dropped)
+		}
+	}
+
+	if runOnlyOnFailure && result.ExitCode == 0 {
+		return nil, nil
+	}
+
+	content := result.record()
+	if existing != nil {
+		if err := enforcePageQuota(newPageQuota(existing, content, "append"), false); err != nil {
+			saveUnsent(content)
+			return nil, &uploadError{err}
+		}
+		page, err := client.UpdateFetchedPageContent(existing, content, "append")
+		if err != nil {
+			saveUnsent(content)
+			return nil, &uploadError{fmt.Errorf("failed to append to page: %w", err)}
+		}
+		return page, nil
+	}
+
+	if int64(len(content)) > api.MaxPageBytes {
+		saveUnsent(content)
+		return nil, &uploadError{fmt.Errorf("output is %s, over the %s per-page limit",
+			formatBytes(int64(len(content))), formatBytes(api.MaxPageBytes))}
+	}
+	filetype := "log"
+	if ansiMode == ansiToMarkdown {
+		filetype = "md"
+	}
+	page, err := client.CreatePageWithDetails(projectID, title, &api.PageDetails{Content: content, Filetype: filetype})
+	if err != nil {
+		saveUnsent(content)
+		return nil, &uploadError{fmt.Errorf("failed to create page: %w", err)}
+	}
+	return page, nil
+}
+
+// runStreamed writes the record's header to existing or a new page, appends
+// c's output in batches while it runs, and finishes with its exit status
+// and duration. If appending fails, the command runs on and its output is
+// still passed through.
+func runStreamed(client *api.Client, existing *api.Page, projectID, title string, c *exec.Cmd, result *runResult, stdout io.Writer, maxBytes int64) (*api.Page, error) {
+	page := existing
+	var err error
+	if page != nil {
+		page, err = client.UpdateFetchedPageContent(existing, result.streamHeader(), "append")
+		if err != nil {
+			return nil, fmt.Errorf("failed to append to page: %w", err)
+		}
+	} else {
+		page, err = client.CreatePageWithDetails(projectID, title, &api.PageDetails{Content: result.streamHeader(), Filetype: "log"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create page: %w", err)
+		}
+	}
+	if !quiet && outputFmt != "json" {
+		fmt.Fprintf(os.Stderr, "Streaming to page \"%s\" (%s)\n", page.Title, page.ExternalID)
+	}
+
+	r, w := io.Pipe()
+	streamed := make(chan error, 1)
+	go func() {
+		lines, err := streamToPage(context.Background(), client, page, result.Args[0], r, streamInterval, maxBytes)
+		result.Lines = lines
+		if err != nil {
+			printWarning("%v; the command keeps running, but the rest of its output will not be saved", err)
+			_, _ = io.Copy(io.Discard, r)
+		}
+		streamed <- err
+	}()
+	runErr := result.execute(c, stdout, os.Stderr, w, w)
+	_ = w.Close()
+	streamErr := <-streamed
+	if runErr != nil {
+		return nil, runErr
+	}
+	if streamErr != nil {
+		return nil, &uploadError{streamErr}
+	}
+
+	if _, err := client.UpdateFetchedPageContent(page, result.streamTrailer(), "append"); err != nil {
+		return nil, &uploadError{fmt.Errorf("failed to append the exit status to page: %w", err)}
+	}
+	return page, nil
+}
+
+// exitStatusError ends a command that wrapped another, which failed.
+// Execute exits with the wrapped command's status.
+type exitStatusError struct {
+	code int
+	msg  string
+}
+
+func (e *exitStatusError) Error() string {
+	return e.msg
+}
+
+// uploadError is a failure to save a command's record after the command
+// ran. It is reported, but the command's exit status is kept.
+type uploadError struct {
+	err error
+}
+
+func (e *uploadError) Error() string {
+	return e.err.Error()
+}
+
+func (e *uploadError) Unwrap() error {
+	return e.err
+}
+
+// runResult is a command run by 'hyperclast run' and, once it has finished,
+// how it went.
+type runResult struct {
+	Args     []string
+	Dir      string
+	Started  time.Time
+	Duration time.Duration
+	ExitCode int
+	// Signal names the signal that killed the command, if one did.
+	Signal string
+	// Output is the recorded output, when it is sent at the end.
+	Output string
+	Lines  int
+	// start is when the command was started.
+	start time.Time
+}
+
+func newRunResult(args []string) *runResult {
+	r := &runResult{Args: args, Started: time.Now()}
+	r.Dir, _ = os.Getwd()
+	return r
+}
+
+// execute runs c with our stdin, copying its stdout and stderr to the given
+// writers (nil to discard) and recording them to recordOut and recordErr,
+// which may be the same writer, one write at a time in the order they
+// arrive. Interrupts while it runs are left to the command: the terminal
+// delivers Ctrl-C to it directly, and SIGTERM is passed on.
+func (r *runResult) execute(c *exec.Cmd, stdout, stderr, recordOut, recordErr io.Writer) error {
+	var mu sync.Mutex
+	c.Stdin = os.Stdin
+	c.Stdout = &recordedStream{mu: &mu, record: recordOut, pass: stdout}
+	c.Stderr = &recordedStream{mu: &mu, record: recordErr, pass: stderr}
+	// Background processes it leaves behind may hold its output open; stop
+	// waiting for them soon after it exits.
+	c.WaitDelay = time.Second
+
+	r.start = time.Now()
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", r.Args[0], err)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for sig := range signals {
+			if sig != os.Interrupt {
+				_ = c.Process.Signal(sig)
+			}
+		}
+	}()
+	err := c.Wait()
+	signal.Stop(signals)
+	close(signals)
+
+	r.Duration = time.Since(r.start)
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		r.ExitCode = exitErr.ExitCode()
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			// As shells report it.
+			r.ExitCode = 128 + int(ws.Signal())
+			r.Signal = ws.Signal().String()
+		}
+	case err != nil && !errors.Is(err, exec.ErrWaitDelay):
+		return fmt.Errorf("%s failed: %w", r.Args[0], err)
+	}
+	return nil
+}
+
+// commandLine is the command as it would be typed, quoting arguments that
+// need it.
+func (r *runResult) commandLine() string {
+	parts := make([]string, len(r.Args))
+	for i, a := range r.Args {
+		parts[i] = shellQuote(a)
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote single-quotes s for a POSIX shell if it needs quoting.
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`*?[]{}()<>|&;#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// record is the page text for r: front-matter describing the run, then the
+// output.
+func (r *runResult) record() string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	r.writeCommand(&b)
+	r.writeOutcome(&b)
+	b.WriteString("---\n\n")
+	b.WriteString(r.Output)
+	if r.Output != "" && !strings.HasSuffix(r.Output, "\n") {
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// streamHeader starts the record of a streamed run, whose outcome is not
+// known until streamTrailer.
+func (r *runResult) streamHeader() string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	r.writeCommand(&b)
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+func (r *runResult) streamTrailer() string {
+	var b strings.Builder
+	b.WriteString("\n---\n")
+	r.writeOutcome(&b)
+	b.WriteString("---\n")
+	return b.String()
+}
+
+func (r *runResult) writeCommand(b *strings.Builder) {
+	fmt.Fprintf(b, "Command: %s\n", r.commandLine())
+	if r.Dir != "" {
+		fmt.Fprintf(b, "Directory: %s\n", r.Dir)
+	}
+	if host, err := os.Hostname(); err == nil {
+		fmt.Fprintf(b, "Host: %s\n", host)
+	}
+	fmt.Fprintf(b, "Started: %s UTC\n", r.Started.UTC().Format("2006-01-02 15:04:05"))
+}
+
+func (r *runResult) writeOutcome(b *strings.Builder) {
+	fmt.Fprintf(b, "Duration: %s\n", r.roundedDuration())
+	if r.Signal != "" {
+		fmt.Fprintf(b, "Exit status: %d (%s)\n", r.ExitCode, r.Signal)
+	} else {
+		fmt.Fprintf(b, "Exit status: %d\n", r.ExitCode)
+	}
+}
+
+func (r *runResult) roundedDuration() time.Duration {
+	if r.Duration < time.Second {
+		return r.Duration.Round(time.Millisecond)
+	}
+	return r.Duration.Round(100 * time.Millisecond)
+}
+
+// recordedStream records what a command writes to one of its outputs and
+// copies it to pass. Failing to copy, say to a closed pipe, never stops the
+// recording. The streams of a command share mu, so their writes are
+// recorded one at a time.
+type recordedStream struct {
+	mu     *sync.Mutex
+	record io.Writer
+	pass   io.Writer
+}
+
+func (s *recordedStream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	_, _ = s.record.Write(p)
+	s.mu.Unlock()
+	if s.pass != nil {
+		_, _ = s.pass.Write(p)
+	}
+	return len(p), nil
+}
+
+// printRunResult reports where the record went, if anywhere: page is nil
+// when --only-on-failure saved nothing, or when the upload failed. It goes
+// to stderr, after the command's own output, except as JSON.
+func printRunResult(page *api.Page, r *runResult, upload *uploadError) error {
+	if outputFmt == "json" {
+		result := map[string]any{
+			"exit_code":   r.ExitCode,
+			"duration_ms": r.Duration.Milliseconds(),
+			"lines":       r.Lines,
+		}
+		if page != nil {
+			result["external_id"] = page.ExternalID
+			result["title"] = page.Title
+		}
+		if upload != nil {
+			result["error"] = upload.Error()
+		}
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	if quiet || upload != nil {
+		return nil
+	}
+	switch {
+	case page == nil:
+		fmt.Fprintf(os.Stderr, "✓ %s succeeded in %s; nothing saved\n", r.Args[0], r.roundedDuration())
+		return nil
+	case runGroupByCommand:
+		fmt.Fprintf(os.Stderr, "✓ Recorded exit status %d (%s) on page \"%s\" (%s)\n",
+			r.ExitCode, r.roundedDuration(), page.Title, page.ExternalID)
+	default:
+		fmt.Fprintf(os.Stderr, "✓ Saved %d lines (exit status %d, %s) to page \"%s\" (%s)\n",
+			r.Lines, r.ExitCode, r.roundedDuration(), page.Title, page.ExternalID)
+	}
+	fmt.Fprintf(os.Stderr, "  %s\n", pageURL(page.ExternalID))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	// Flags after the command name belong to the command.
+	runCmd.Flags().SetInterspersed(false)
+	runCmd.Flags().StringVar(&runPageID, "page", "", "append the record to this page instead of creating one")
+	runCmd.Flags().StringVar(&runProjectID, "project", "", "project for the new page (default: the default project)")
+	runCmd.Flags().StringVar(&runTitle, "title", "", "title for the new page (default: the command line)")
+	addStreamFlags(runCmd)
+	addTimestampsFlag(runCmd)
+	addTailFlags(runCmd)
+	addKeepANSIFlag(runCmd)
+	addANSIMarkdownFlag(runCmd)
+	runCmd.Flags().BoolVar(&runSplitStreams, "split-streams", false, "save stdout and stderr in separate sections, each line stamped with the time since the command started")
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+var (
+	runOnlyOnFailure  bool
+	runGroupByCommand bool
+)
+
+// runGroupHeader starts a --group-by-command page, a CSV table with one row
+// per run.
+const runGroupHeader = "started,exit_code,duration_ms,host\n"
+
+// maxRunGroupRows is how many runs a --group-by-command page keeps. Once it
+// is full, the oldest rows are dropped as new ones are added.
+const maxRunGroupRows = 1000
+
+// checkRunGroupFlags validates --only-on-failure and --group-by-command.
+// A grouped run saves only its exit status and duration, so the flags that
+// shape the saved output do not apply.
+func checkRunGroupFlags() error {
+	if runOnlyOnFailure && streamOutput {
+		return fmt.Errorf("--only-on-failure cannot be combined with --stream, which saves output before the command exits")
+	}
+	if !runGroupByCommand {
+		return nil
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{streamOutput, "--stream"},
+		{runSplitStreams, "--split-streams"},
+		{lineTimestamps != "", "--timestamps"},
+		{tailMaxBytes != "" || tailLines != 0, "--max-bytes and --tail-lines"},
+		{keepANSI, "--keep-ansi"},
+		{ansiMarkdown, "--ansi-markdown"},
+	} {
+		if conflict.set {
+			return fmt.Errorf("--group-by-command saves only exit statuses and durations, so it cannot be combined with %s", conflict.flag)
+		}
+	}
+	return nil
+}
+
+// runGroupTitle is the title of the --group-by-command page for a command.
+func runGroupTitle(r *runResult) string {
+	if runTitle != "" {
+		return runTitle
+	}
+	return truncateRunes("Runs: "+r.commandLine(), maxTitleLength)
+}
+
+// findRunGroupPage returns the project's page titled title, with its
+// content, or nil if there is none yet.
+func findRunGroupPage(client *api.Client, projectID, title string) (*api.Page, error) {
+	target, err := findUpsertTarget(client, projectID, title)
+	if err != nil || target == nil {
+		return nil, err
+	}
+	page, err := client.GetPage(target.ExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	if err := checkPageLock(client, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// runGrouped runs c, passing its output through without saving it, and
+// adds a row for the run to existing, or to a new CSV page titled title.
+func runGrouped(client *api.Client, existing *api.Page, projectID, title string, c *exec.Cmd, result *runResult, stdout io.Writer) (*api.Page, error) {
+	if err := result.execute(c, stdout, os.Stderr, io.Discard, io.Discard); err != nil {
+		return nil, err
+	}
+	if runOnlyOnFailure && result.ExitCode == 0 {
+		return nil, nil
+	}
+
+	row := result.csvRow()
+	if existing == nil {
+		page, err := client.CreatePageWithDetails(projectID, title, &api.PageDetails{Content: runGroupHeader + row, Filetype: "csv"})
+		if err != nil {
+			return nil, &uploadError{fmt.Errorf("failed to create page: %w", err)}
+		}
+		return page, nil
+	}
+
+	content, mode := row, "append"
+	var current string
+	if existing.Details != nil {
+		current = existing.Details.Content
+	}
+	switch rows := strings.Split(strings.TrimSuffix(current, "\n"), "\n"); {
+	case current == "":
+		content, mode = runGroupHeader+row, "overwrite"
+	case len(rows) > maxRunGroupRows:
+		// Keep the header, and make room for the new row.
+		kept := append([]string{rows[0]}, rows[len(rows)-maxRunGroupRows+1:]...)
+		content, mode = strings.Join(kept, "\n")+"\n"+row, "overwrite"
+	case !strings.HasSuffix(current, "\n"):
+		content = "\n" + row
+	}
+	if err := enforcePageQuota(newPageQuota(existing, content, mode), false); err != nil {
+		return nil, &uploadError{err}
+	}
+	page, err := client.UpdateFetchedPageContent(existing, content, mode)
+	if err != nil {
+		return nil, &uploadError{fmt.Errorf("failed to update page: %w", err)}
+	}
+	return page, nil
+}
+
+// csvRow is r as a row of a --group-by-command page.
+func (r *runResult) csvRow() string {
+	host, _ := os.Hostname()
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	_ = w.Write([]string{
+		r.Started.UTC().Format("2006-01-02 15:04:05"),
+		strconv.Itoa(r.ExitCode),
+		strconv.FormatInt(r.Duration.Milliseconds(), 10),
+		host,
+	})
+	w.Flush()
+	return b.String()
+}
+
+func init() {
+	runCmd.Flags().BoolVar(&runOnlyOnFailure, "only-on-failure", false, "save nothing if the command exits with status 0")
+	runCmd.Flags().BoolVar(&runGroupByCommand, "group-by-command", false, "add a CSV row with the exit status and duration to one page per command, instead of saving the output")
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestRun_OnlyOnFailure(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+
+	stdout, stderr, err := env.run("run", "--project", apitest.DefaultProjectID, "--only-on-failure", "--", "sh", "-c", "echo fine")
+	if err != nil {
+		t.Fatalf("run: %v\nstderr: %s", err, stderr)
+	}
+	if stdout != "fine\n" || !strings.Contains(stderr, "nothing saved") {
+		t.Errorf("stdout = %q, stderr = %q", stdout, stderr)
+	}
+	client := api.NewClient(env.url, "integration-token")
+	if pages, _ := client.ListPages(apitest.DefaultProjectID); len(pages) != 0 {
+		t.Fatalf("%d pages after a success, want none", len(pages))
+	}
+
+	_, _, err = env.run("run", "--project", apitest.DefaultProjectID, "--only-on-failure", "--", "sh", "-c", "echo broke; exit 4")
+	var status *exitStatusError
+	if !errors.As(err, &status) || status.code != 4 {
+		t.Fatalf("err = %v, want exit status 4", err)
+	}
+	pages, _ := client.ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages after a failure, want 1", len(pages))
+	}
+	if page, _ := env.server.Page(pages[0].ExternalID); !strings.Contains(page.Details.Content, "Exit status: 4\n") || !strings.HasSuffix(page.Details.Content, "broke\n") {
+		t.Errorf("content = %q", page.Details.Content)
+	}
+}
+
+func TestRun_GroupByCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+	args := []string{"run", "--project", apitest.DefaultProjectID, "--only-on-failure", "--group-by-command", "--", "sh", "-c", "exit ${CODE:-0}"}
+
+	for _, code := range []string{"2", "0", "3"} {
+		t.Setenv("CODE", code)
+		_, stderr, err := env.run(args...)
+		if code == "0" && err != nil {
+			t.Fatalf("run: %v\nstderr: %s", err, stderr)
+		}
+		var status *exitStatusError
+		if code != "0" && (!errors.As(err, &status) || fmt.Sprint(status.code) != code) {
+			t.Fatalf("err = %v, want exit status %s", err, code)
+		}
+	}
+
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want one for the command", len(pages))
+	}
+	page, _ := env.server.Page(pages[0].ExternalID)
+	if want := "Runs: sh -c 'exit ${CODE:-0}'"; page.Title != want {
+		t.Errorf("title = %q, want %q", page.Title, want)
+	}
+	if page.Details.Filetype != "csv" {
+		t.Errorf("filetype = %q, want csv", page.Details.Filetype)
+	}
+	rows := strings.Split(strings.TrimSuffix(page.Details.Content, "\n"), "\n")
+	if len(rows) != 3 || rows[0]+"\n" != runGroupHeader {
+		t.Fatalf("content = %q, want a header and two rows", page.Details.Content)
+	}
+	for i, code := range []string{"2", "3"} {
+		if fields := strings.Split(rows[i+1], ","); len(fields) != 4 || fields[1] != code {
+			t.Errorf("row %d = %q, want exit status %s", i+1, rows[i+1], code)
+		}
+	}
+}
+
+func TestRun_GroupByCommandRolls(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+	var content strings.Builder
+	content.WriteString(runGroupHeader)
+	for i := range maxRunGroupRows {
+		fmt.Fprintf(&content, "2026-01-01 00:00:00,%d,10,ci\n", i+1)
+	}
+	existing := env.server.AddPage(apitest.DefaultProjectID, "Flaky", content.String())
+
+	_, _, err := env.run("--quiet", "run", "--page", existing.ExternalID, "--group-by-command", "--", "sh", "-c", "exit 7")
+	var status *exitStatusError
+	if !errors.As(err, &status) || status.code != 7 {
+		t.Fatalf("err = %v, want exit status 7", err)
+	}
+	page, _ := env.server.Page(existing.ExternalID)
+	rows := strings.Split(strings.TrimSuffix(page.Details.Content, "\n"), "\n")
+	if len(rows) != maxRunGroupRows+1 || rows[0]+"\n" != runGroupHeader {
+		t.Fatalf("%d lines, want the header and %d rows", len(rows), maxRunGroupRows)
+	}
+	if !strings.HasPrefix(rows[1], "2026-01-01 00:00:00,2,") || !strings.Contains(rows[len(rows)-1], ",7,") {
+		t.Errorf("rows run from %q to %q, want the oldest dropped and the new one last", rows[1], rows[len(rows)-1])
+	}
+}
+
+func TestRun_GroupByCommandErrors(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--only-on-failure", "--stream", "--", "true"}, "--only-on-failure cannot be combined with --stream"},
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--group-by-command", "--split-streams", "--", "true"}, "cannot be combined with --split-streams"},
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--group-by-command", "--tail-lines", "5", "--", "true"}, "cannot be combined with --max-bytes and --tail-lines"},
+	} {
+		if _, _, err := env.run(tt.args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
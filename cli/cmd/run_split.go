@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// lineStamper splits what is written to it into lines, noting when each
+// line started to arrive.
+type lineStamper struct {
+	lines []muxLine
+	// partial is the line being written, begun at partialAt.
+	partial   []byte
+	partialAt time.Time
+	open      bool
+}
+
+func (s *lineStamper) Write(p []byte) (int, error) {
+	now := time.Now()
+	n := len(p)
+	for len(p) > 0 {
+		if !s.open {
+			s.partialAt, s.open = now, true
+		}
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			s.partial = append(s.partial, p...)
+			break
+		}
+		s.partial = append(s.partial, p[:i]...)
+		s.endLine()
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+func (s *lineStamper) endLine() {
+	text := strings.ToValidUTF8(strings.TrimSuffix(string(s.partial), "\r"), "�")
+	s.lines = append(s.lines, muxLine{Time: s.partialAt, Text: text})
+	s.partial = s.partial[:0]
+	s.open = false
+}
+
+// done ends a last line without a newline and returns the lines.
+func (s *lineStamper) done() []muxLine {
+	if s.open {
+		s.endLine()
+	}
+	return s.lines
+}
+
+// splitStreamsRecord renders a command's stdout and stderr as two sections,
+// each line stamped with the time since start, or the time it was read
+// with --timestamps=rfc3339.
+func splitStreamsRecord(start time.Time, stdout, stderr []muxLine) string {
+	stamp := func(t time.Time) string { return "[" + formatElapsed(t.Sub(start)) + "] " }
+	if lineTimestamps == timestampsRFC3339 {
+		stamp = func(t time.Time) string { return lineStamp(start, t) }
+	}
+
+	var b strings.Builder
+	for i, section := range []struct {
+		name  string
+		lines []muxLine
+	}{
+		{"stdout", stdout},
+		{"stderr", stderr},
+	} {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "==> %s <==\n", section.name)
+		if len(section.lines) == 0 {
+			b.WriteString("(no output)\n")
+		}
+		for _, l := range section.lines {
+			fmt.Fprintf(&b, "%s%s\n", stamp(l.Time), l.Text)
+		}
+	}
+	return b.String()
+}
+
+// formatElapsed renders d as +hh:mm:ss.mmm.
+func formatElapsed(d time.Duration) string {
+	d = max(d, 0).Round(time.Millisecond)
+	h := d / time.Hour
+	m := d % time.Hour / time.Minute
+	sec := d % time.Minute / time.Second
+	ms := d % time.Second / time.Millisecond
+	return fmt.Sprintf("+%02d:%02d:%02d.%03d", h, m, sec, ms)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestLineStamper(t *testing.T) {
+	s := &lineStamper{}
+	for _, chunk := range []string{"one\r\ntw", "o\n", "\nthree"} {
+		_, _ = s.Write([]byte(chunk))
+	}
+	var got []string
+	for _, l := range s.done() {
+		got = append(got, l.Text)
+	}
+	if want := []string{"one", "two", "", "three"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestSplitStreamsRecord(t *testing.T) {
+	start := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+	out := []muxLine{{Time: start.Add(1500 * time.Millisecond), Text: "built"}}
+	got := splitStreamsRecord(start, out, nil)
+	want := "==> stdout <==\n[+00:00:01.500] built\n\n==> stderr <==\n(no output)\n"
+	if got != want {
+		t.Errorf("record = %q, want %q", got, want)
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "+00:00:00.000"},
+		{61*time.Minute + 2*time.Second + 3*time.Millisecond, "+01:01:02.003"},
+		{-time.Second, "+00:00:00.000"},
+	} {
+		if got := formatElapsed(tt.d); got != tt.want {
+			t.Errorf("formatElapsed(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestRun_SplitStreams(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+
+	_, _, err := env.run("run", "--project", apitest.DefaultProjectID, "--split-streams", "--", "sh", "-c", "echo built; echo warned >&2; echo done; exit 1")
+	var status *exitStatusError
+	if !errors.As(err, &status) || status.code != 1 {
+		t.Fatalf("err = %v, want exit status 1", err)
+	}
+
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	page, _ := env.server.Page(pages[0].ExternalID)
+	stamp := `\[\+\d\d:\d\d:\d\d\.\d{3}\] `
+	want := regexp.MustCompile(`---\n\n==> stdout <==\n` + stamp + `built\n` + stamp + `done\n\n==> stderr <==\n` + stamp + `warned\n$`)
+	if !want.MatchString(page.Details.Content) {
+		t.Errorf("content = %q", page.Details.Content)
+	}
+
+	if _, _, err := env.run("run", "--project", apitest.DefaultProjectID, "--split-streams", "--stream", "--", "true"); err == nil || !strings.Contains(err.Error(), "cannot be combined with --stream") {
+		t.Errorf("--split-streams --stream = %v", err)
+	}
+}
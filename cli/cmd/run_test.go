@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestRun_CreatesPageAndKeepsExitStatus(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+
+	stdout, stderr, err := env.run("run", "--project", apitest.DefaultProjectID, "--", "sh", "-c", "echo built; echo warned >&2; exit 3")
+	var status *exitStatusError
+	if !errors.As(err, &status) || status.code != 3 {
+		t.Fatalf("err = %v, want exit status 3", err)
+	}
+	if stdout != "built\n" || !strings.Contains(stderr, "warned\n") {
+		t.Errorf("stdout = %q, stderr = %q; want the output passed through", stdout, stderr)
+	}
+	if !strings.Contains(stderr, "exit status 3") {
+		t.Errorf("stderr = %q, want a summary", stderr)
+	}
+
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	page, _ := env.server.Page(pages[0].ExternalID)
+	if want := `sh -c 'echo built; echo warned >&2; exit 3'`; page.Title != want {
+		t.Errorf("title = %q, want %q", page.Title, want)
+	}
+	content := page.Details.Content
+	for _, want := range []string{"---\nCommand: sh -c ", "\nExit status: 3\n", "\nDuration: ", "built\n", "warned\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content %q is missing %q", content, want)
+		}
+	}
+	if page.Details.Filetype != "log" {
+		t.Errorf("filetype = %q, want log", page.Details.Filetype)
+	}
+}
+
+func TestRun_AppendsWithJSON(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+	existing := env.server.AddPage(apitest.DefaultProjectID, "Deploys", "earlier\n")
+
+	// Without --, flags after the command name are the command's.
+	stdout := env.mustRun("--output", "json", "run", "--page", existing.ExternalID, "sh", "-c", "echo deployed")
+	var got struct {
+		ExternalID string `json:"external_id"`
+		ExitCode   int    `json:"exit_code"`
+		Lines      int    `json:"lines"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("stdout %q is not only the result: %v", stdout, err)
+	}
+	if got.ExternalID != existing.ExternalID || got.ExitCode != 0 || got.Lines != 1 {
+		t.Errorf("result = %+v", got)
+	}
+	page, _ := env.server.Page(existing.ExternalID)
+	if content := page.Details.Content; !strings.HasPrefix(content, "earlier\n---\nCommand: sh -c 'echo deployed'\n") || !strings.HasSuffix(content, "---\n\ndeployed\n") {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestRun_UploadFailureKeepsExitStatus(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+
+	stdout, stderr, err := env.run("run", "--project", "proj_missing", "--", "sh", "-c", "echo built; exit 3")
+	var status *exitStatusError
+	if !errors.As(err, &status) || status.code != 3 {
+		t.Fatalf("err = %v, want the command's exit status 3", err)
+	}
+	if !strings.Contains(stderr, "Error: failed to create page") {
+		t.Errorf("stderr = %q, want the upload failure", stderr)
+	}
+	if !strings.Contains(stdout, "Your data is saved at: ") {
+		t.Errorf("stdout = %q, want where the output was saved", stdout)
+	}
+
+	if _, _, err := env.run("run", "--project", "proj_missing", "--", "true"); err != nil {
+		t.Errorf("err = %v, want success when the command succeeded", err)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"run", "--page", "page_x", "--title", "T", "--", "true"}, "cannot be combined"},
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--", "hyperclast-no-such-command"}, "not found on PATH"},
+	} {
+		if _, _, err := env.run(tt.args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+func TestRunResult_CommandLine(t *testing.T) {
+	r := &runResult{Args: []string{"grep", "-r", "it's here", "", "./src"}}
+	if got, want := r.commandLine(), `grep -r 'it'\''s here' '' ./src`; got != want {
+		t.Errorf("commandLine() = %q, want %q", got, want)
+	}
+}
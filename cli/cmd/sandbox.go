@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sandboxAddr  string
+	sandboxToken string
+)
+
+// sandboxShutdownTimeout bounds how long in-flight requests may finish
+// after Ctrl-C.
+const sandboxShutdownTimeout = 5 * time.Second
+
+var sandboxCmd = &cobra.Command{
+	Use:   "sandbox",
+	Short: "Run a local fake of the API to develop scripts against",
+}
+
+var sandboxServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve an in-memory fake of the API",
+	Long: `Serve an in-memory fake of the Hyperclast API on a local address, for
+developing and testing scripts without touching real pages.
+
+The sandbox implements the users, orgs, projects, folders, pages, and
+files endpoints, including append/prepend/overwrite writes, title search,
+and page history. Like the API it has no page events, filetype list, or
+telemetry endpoint, so commands fall back as they would there. It starts with one org (` + apitest.DefaultOrgID + `) and one
+project (` + apitest.DefaultProjectID + `), accepts only the token given with --token,
+and forgets everything when it stops. Point any command at it with
+--api-url, or by setting api_url in a separate config.
+
+Examples:
+  hyperclast sandbox serve
+  HYPERCLAST_TOKEN=` + apitest.DefaultToken + ` hyperclast --api-url http://127.0.0.1:8765/api page list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ln, err := net.Listen("tcp", sandboxAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", sandboxAddr, err)
+		}
+
+		mux := http.NewServeMux()
+		fake := apitest.New(sandboxToken)
+		mux.Handle("/api/", http.StripPrefix("/api", fake))
+		// File links are served from the site root, as on a real server.
+		mux.Handle("/files/", fake)
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		apiURL := "http://" + ln.Addr().String() + "/api"
+		printSuccess("Sandbox API listening at %s (Ctrl-C to stop; nothing is kept)", apiURL)
+		printInfo("  export HYPERCLAST_TOKEN=%s", sandboxToken)
+		printInfo("  hyperclast --api-url %s page new --project %s --title test < file.txt", apiURL, apitest.DefaultProjectID)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		errs := make(chan error, 1)
+		go func() { errs <- server.Serve(ln) }()
+
+		select {
+		case err := <-errs:
+			return fmt.Errorf("sandbox stopped: %w", err)
+		case <-ctx.Done():
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), sandboxShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		printInfo("Sandbox stopped")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sandboxCmd)
+	sandboxCmd.AddCommand(sandboxServeCmd)
+
+	sandboxServeCmd.Flags().StringVar(&sandboxAddr, "addr", "127.0.0.1:8765", "address to listen on")
+	sandboxServeCmd.Flags().StringVar(&sandboxToken, "token", apitest.DefaultToken, "bearer token the sandbox accepts")
+}
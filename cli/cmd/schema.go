@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+// outputSchemas maps each schema name to the Go type the CLI encodes for
+// it with --output json.
+var outputSchemas = map[string]any{
+	"page":    api.Page{},
+	"project": api.Project{},
+	"org":     api.Org{},
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema <page|project|org>",
+	Short: "Print the JSON Schema of CLI output",
+	Long: `Print the JSON Schema for an object the CLI emits with --output json.
+
+The schemas are generated from the same types used to produce the output,
+so downstream tooling can validate integrations against them. List commands
+emit arrays of these objects; 'page list --json-schema' prints that shape.
+
+Examples:
+  hyperclast schema page
+  hyperclast schema project > project.schema.json`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: schemaNames(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		v, ok := outputSchemas[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown schema %q (available: %s)", args[0], strings.Join(schemaNames(), ", "))
+		}
+		return printSchema(jsonschema.For(v, args[0]))
+	},
+}
+
+func schemaNames() []string {
+	names := make([]string, 0, len(outputSchemas))
+	for name := range outputSchemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func printSchema(s *jsonschema.Schema) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
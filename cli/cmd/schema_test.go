@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSchema_Org(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := schemaCmd.RunE(schemaCmd, []string{"org"})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var schema struct {
+		Title      string                     `json:"title"`
+		Type       string                     `json:"type"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if schema.Title != "org" || schema.Type != "object" {
+		t.Errorf("unexpected schema header: %+v", schema)
+	}
+	for _, field := range []string{"external_id", "name", "domain", "is_pro"} {
+		if _, ok := schema.Properties[field]; !ok {
+			t.Errorf("missing property %q", field)
+		}
+	}
+}
+
+func TestSchema_Unknown(t *testing.T) {
+	err := schemaCmd.RunE(schemaCmd, []string{"widget"})
+	if err == nil {
+		t.Fatal("expected error for unknown schema")
+	}
+	if !strings.Contains(err.Error(), "org, page, project") {
+		t.Errorf("error should list available schemas: %s", err)
+	}
+}
+
+func TestPageList_JSONSchemaSkipsAuth(t *testing.T) {
+	resetPageFlags()
+	cfg = nil
+	pageListJSONSchema = true
+	defer resetPageFlags()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := pageListCmd.RunE(pageListCmd, nil)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"type": "array"`) {
+		t.Errorf("expected array schema, got:\n%s", buf.String())
+	}
+}
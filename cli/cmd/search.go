@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/index"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchLocal     bool
+	searchOrgID     string
+	searchProjectID string
+	searchLimit     int
+	orgExportIndex  bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>...",
+	Short: "Search pages",
+	Long: `Search pages by title on the server, or by title and content in a local
+index with --local.
+
+The server only matches titles and returns at most 10 pages. The local index
+covers the full content of every page exported with 'org export --index', so
+--local works offline and answers instantly. Every word must match; the last
+word also matches as a prefix. Results are only as fresh as the last export.
+
+Examples:
+  hyperclast search "release notes"
+  hyperclast search --local timeout retry
+  hyperclast search --local deploy --project proj_abc123 --output json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := strings.Join(args, " ")
+		if searchLimit < 1 {
+			return fmt.Errorf("--limit must be at least 1")
+		}
+		if searchLocal {
+			return runLocalSearch(query)
+		}
+		if searchProjectID != "" {
+			return fmt.Errorf("--project requires --local")
+		}
+		return runServerSearch(query)
+	},
+}
+
+func runServerSearch(query string) error {
+	if err := requireAuth(); err != nil {
+		return err
+	}
+	orgID := searchOrgID
+	if orgID == "" {
+		orgID = cfg.GetDefaultOrg()
+	}
+
+	pages, err := newClient().SearchPages(query, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to search pages: %w", err)
+	}
+	if len(pages) > searchLimit {
+		pages = pages[:searchLimit]
+	}
+	return renderPageList(os.Stdout, pages)
+}
+
+func runLocalSearch(query string) error {
+	ix, err := index.Open(config.StateDir())
+	if err != nil {
+		return err
+	}
+	if !ix.Exists() {
+		return fmt.Errorf("no local search index; build one with 'hyperclast org export <org-id> --dir <dir> --index'")
+	}
+
+	results := ix.Search(query, 0)
+	if searchProjectID != "" {
+		kept := results[:0]
+		for _, r := range results {
+			if r.ProjectID == searchProjectID {
+				kept = append(kept, r)
+			}
+		}
+		results = kept
+	}
+	if len(results) > searchLimit {
+		results = results[:searchLimit]
+	}
+
+	if outputFmt == "json" {
+		return json.NewEncoder(os.Stdout).Encode(results)
+	}
+	if quiet {
+		for _, r := range results {
+			fmt.Println(r.ID)
+		}
+		return nil
+	}
+	if len(results) == 0 {
+		fmt.Println("No pages found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTITLE\tMATCH")
+	for _, r := range results {
+		match := ""
+		if r.Line > 0 {
+			match = fmt.Sprintf("%d: %s", r.Line, r.Snippet)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Title, match)
+	}
+	return w.Flush()
+}
+
+// indexResult summarizes a refresh of the local search index.
+type indexResult struct {
+	Indexed int `json:"indexed"`
+	Updated int `json:"updated"`
+	Removed int `json:"removed"`
+}
+
+// refreshSearchIndex brings the local index in line with the export in dir.
+// Only pages whose SHA-256, title, or location changed are re-read, and pages that were indexed
+// from dir but are gone from its manifest are dropped. Pages indexed from
+// other exports are left alone.
+func refreshSearchIndex(dir string) (*indexResult, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := readExportManifest(abs)
+	if err != nil {
+		return nil, err
+	}
+	ix, err := index.Open(config.StateDir())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &indexResult{}
+	indexing := startProgress("index", len(manifest.Pages), "pages")
+	present := make(map[string]bool, len(manifest.Pages))
+	for _, p := range manifest.Pages {
+		present[p.ExternalID] = true
+		doc := index.Doc{
+			ID:        p.ExternalID,
+			Title:     p.Title,
+			ProjectID: p.ProjectID,
+			Updated:   p.Updated,
+			Path:      filepath.Join(abs, filepath.FromSlash(p.Path)),
+			SHA256:    p.SHA256,
+		}
+		if prev, ok := ix.Docs[doc.ID]; ok && *prev == doc {
+			result.Indexed++
+			indexing.advance(doc.ID, "unchanged", nil)
+			continue
+		}
+		content, err := os.ReadFile(doc.Path)
+		if err != nil {
+			printWarning("not indexing %s: %v", doc.ID, err)
+			indexing.advance(doc.ID, "failed", err)
+			continue
+		}
+		ix.Add(doc, string(content))
+		result.Indexed++
+		result.Updated++
+		indexing.advance(doc.ID, "indexed", nil)
+	}
+
+	prefix := abs + string(filepath.Separator)
+	for id, doc := range ix.Docs {
+		if !present[id] && strings.HasPrefix(doc.Path, prefix) {
+			ix.Remove(id)
+			result.Removed++
+		}
+	}
+
+	start := time.Now()
+	if err := ix.Save(); err != nil {
+		return nil, err
+	}
+	printDebug("Saved search index %s (%d pages) in %s", ix.Path(), ix.Len(), time.Since(start).Round(time.Millisecond))
+	return result, nil
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().BoolVar(&searchLocal, "local", false, "search page content in the local index instead of titles on the server")
+	searchCmd.Flags().StringVar(&searchOrgID, "org", "", "organization to search (uses default if not specified)")
+	searchCmd.Flags().StringVar(&searchProjectID, "project", "", "only show pages from this project (with --local)")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "maximum number of results")
+
+	orgExportCmd.Flags().BoolVar(&orgExportIndex, "index", false, "update the local search index used by 'search --local'")
+}
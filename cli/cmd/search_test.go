@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/index"
+)
+
+func resetSearchFlags() {
+	searchLocal = false
+	searchOrgID = ""
+	searchProjectID = ""
+	searchLimit = 20
+	outputFmt = "text"
+	quiet = false
+}
+
+func TestRefreshSearchIndex(t *testing.T) {
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	s, client := newExportServer(t)
+	dir := t.TempDir()
+
+	if _, err := runOrgExport(client, "org_1", dir, 2); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	result, err := refreshSearchIndex(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *result != (indexResult{Indexed: 3, Updated: 3}) {
+		t.Errorf("first refresh = %+v, want all 3 pages indexed", *result)
+	}
+
+	s.mu.Lock()
+	s.content["page_a"] = "gamma"
+	s.updated["page_a"] = "2025-02-01T00:00:00Z"
+	s.mu.Unlock()
+	if _, err := runOrgExport(client, "org_1", dir, 2); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	result, _ = refreshSearchIndex(dir)
+	if *result != (indexResult{Indexed: 3, Updated: 1}) {
+		t.Errorf("second refresh = %+v, want only the changed page re-indexed", *result)
+	}
+
+	ix, _ := index.Open(config.StateDir())
+	if got := ix.Search("alpha", 0); len(got) != 0 {
+		t.Errorf("stale content still indexed: %+v", got)
+	}
+	if got := ix.Search("gamma", 0); len(got) != 1 || got[0].Path != filepath.Join(dir, "proj_1", "page_a.txt") {
+		t.Errorf("search = %+v, want page_a at its export path", got)
+	}
+}
+
+func TestSearchLocal(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+
+	searchLocal = true
+	if err := searchCmd.RunE(searchCmd, []string{"deploy"}); err == nil {
+		t.Fatal("expected an error without a local index")
+	}
+
+	ix, _ := index.Open(config.StateDir())
+	ix.Add(index.Doc{ID: "page_1", Title: "Deploys", ProjectID: "proj_1"}, "deploy log")
+	ix.Add(index.Doc{ID: "page_2", Title: "Notes", ProjectID: "proj_2"}, "deploy notes")
+	if err := ix.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	searchProjectID = "proj_2"
+	outputFmt = "json"
+	out := captureArchiveOutput(t, func() error {
+		return searchCmd.RunE(searchCmd, []string{"deploy"})
+	})
+	var results []index.Result
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("bad JSON %q: %v", out, err)
+	}
+	if len(results) != 1 || results[0].ID != "page_2" {
+		t.Errorf("results = %+v, want only the page in proj_2", results)
+	}
+}
+
+func TestSearchServer(t *testing.T) {
+	resetSearchFlags()
+	defer resetSearchFlags()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pages/autocomplete/" || r.URL.Query().Get("q") != "release notes" || r.URL.Query().Get("org_id") != "org_1" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]api.Page{"pages": {{ExternalID: "page_1", Title: "Release notes"}}})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+	cfg.SetDefaultOrg("org_1")
+
+	outputFmt = "json"
+	out := captureArchiveOutput(t, func() error {
+		return searchCmd.RunE(searchCmd, []string{"release", "notes"})
+	})
+	var pages []api.Page
+	if err := json.Unmarshal([]byte(out), &pages); err != nil || len(pages) != 1 || pages[0].ExternalID != "page_1" {
+		t.Errorf("output = %q, want the matching page", out)
+	}
+
+	searchProjectID = "proj_1"
+	if err := searchCmd.RunE(searchCmd, []string{"x"}); err == nil {
+		t.Error("--project accepted without --local")
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	sectionBeginPrefix = "### BEGIN hyperclast section: "
+	sectionEndPrefix   = "### END hyperclast section: "
+)
+
+// wrapSection surrounds content with begin/end anchor lines so the block can
+// later be extracted with `page get --section`.
+func wrapSection(content, name string) string {
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return sectionBeginPrefix + name + "\n" + content + sectionEndPrefix + name + "\n"
+}
+
+// extractSection returns the body of the named section. When the same name
+// appears more than once, the last occurrence wins, since append-only pages
+// usually care about the most recent entry. A section with no end marker runs
+// to the end of the content.
+func extractSection(content, name string) (string, error) {
+	begin := sectionBeginPrefix + name
+	end := sectionEndPrefix + name
+
+	lines := strings.SplitAfter(content, "\n")
+	var body strings.Builder
+	found := false
+	inside := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, "\r\n")
+		switch {
+		case trimmed == begin:
+			body.Reset()
+			found = true
+			inside = true
+		case trimmed == end && inside:
+			inside = false
+		case inside:
+			body.WriteString(line)
+		}
+	}
+
+	if !found {
+		return "", fmt.Errorf("section %q not found", name)
+	}
+	return body.String(), nil
+}
+
+// listSections returns section names in the order they first appear.
+func listSections(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if name, ok := strings.CutPrefix(line, sectionBeginPrefix); ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapSection(t *testing.T) {
+	got := wrapSection("line one\nline two", "deploy 2024-06-01")
+	want := "### BEGIN hyperclast section: deploy 2024-06-01\nline one\nline two\n### END hyperclast section: deploy 2024-06-01\n"
+	if got != want {
+		t.Errorf("wrapSection() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractSection(t *testing.T) {
+	content := "preamble\n" +
+		wrapSection("first deploy\n", "deploy") +
+		"unrelated\n" +
+		wrapSection("build output\n", "build") +
+		wrapSection("second deploy\n", "deploy")
+
+	tests := []struct {
+		name    string
+		section string
+		want    string
+		wantErr string
+	}{
+		{name: "single section", section: "build", want: "build output\n"},
+		{name: "last occurrence wins", section: "deploy", want: "second deploy\n"},
+		{name: "missing section", section: "nope", wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractSection(content, tt.section)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("extractSection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSection_Unterminated(t *testing.T) {
+	content := "### BEGIN hyperclast section: live\r\nstill running\r\n"
+	got, err := extractSection(content, "live")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "still running\r\n" {
+		t.Errorf("extractSection() = %q, want %q", got, "still running\r\n")
+	}
+}
+
+func TestListSections(t *testing.T) {
+	content := wrapSection("a", "one") + wrapSection("b", "two") + wrapSection("c", "one")
+	got := listSections(content)
+	if strings.Join(got, ",") != "one,two" {
+		t.Errorf("listSections() = %v, want [one two]", got)
+	}
+}
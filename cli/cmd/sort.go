@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// --sort and --reverse, shared by 'page list' and 'project list'.
+var (
+	listSort    string
+	listReverse bool
+)
+
+// sortFields are what a listed item is sorted by.
+type sortFields struct {
+	updated, created, title string
+}
+
+func pageSortFields(p api.Page) sortFields {
+	return sortFields{updated: pageUpdated(&p), created: p.Created, title: p.Title}
+}
+
+func projectSortFields(p api.Project) sortFields {
+	return sortFields{updated: p.Modified, created: p.Created, title: p.Name}
+}
+
+// checkListSort validates --sort.
+func checkListSort() error {
+	switch listSort {
+	case "", "updated", "created", "title":
+		return nil
+	default:
+		return fmt.Errorf("invalid --sort %q (must be updated, created, or title)", listSort)
+	}
+}
+
+// sortListing orders items by --sort: most recent first for updated and
+// created, A to Z for title, keeping the server's order among ties. Items
+// without a parsable time go last. --reverse flips the result, or the
+// server's order when --sort is not set.
+func sortListing[T any](items []T, fields func(T) sortFields) {
+	switch listSort {
+	case "updated", "created":
+		at := func(item T) time.Time {
+			f := fields(item)
+			s := f.updated
+			if listSort == "created" {
+				s = f.created
+			}
+			t, _ := time.Parse(time.RFC3339, s)
+			return t
+		}
+		slices.SortStableFunc(items, func(a, b T) int { return at(b).Compare(at(a)) })
+	case "title":
+		slices.SortStableFunc(items, func(a, b T) int {
+			return strings.Compare(strings.ToLower(fields(a).title), strings.ToLower(fields(b).title))
+		})
+	}
+	if listReverse {
+		slices.Reverse(items)
+	}
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{pageListCmd, projectListCmd} {
+		cmd.Flags().StringVar(&listSort, "sort", "", "sort by updated or created (most recent first), or title")
+		cmd.Flags().BoolVar(&listReverse, "reverse", false, "reverse the order")
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+func TestSortListing(t *testing.T) {
+	defer resetPageFlags()
+	pages := []api.Page{
+		{ExternalID: "b", Title: "beta", Updated: "2025-03-01T00:00:00Z", Created: "2025-01-01T00:00:00Z"},
+		{ExternalID: "n", Title: "No dates"},
+		{ExternalID: "a", Title: "Alpha", Updated: "2025-01-01T00:00:00Z", Created: "2025-02-01T00:00:00+01:00"},
+		{ExternalID: "c", Title: "gamma", Modified: "2025-02-01T00:00:00Z", Created: "2024-12-01T00:00:00Z"},
+	}
+
+	for _, tc := range []struct {
+		sort    string
+		reverse bool
+		want    string
+	}{
+		{"", false, "b,n,a,c"},
+		{"", true, "c,a,n,b"},
+		{"updated", false, "b,c,a,n"},
+		{"created", false, "a,b,c,n"},
+		{"title", false, "a,b,c,n"},
+		{"title", true, "n,c,b,a"},
+	} {
+		resetPageFlags()
+		listSort, listReverse = tc.sort, tc.reverse
+		sorted := append([]api.Page(nil), pages...)
+		sortListing(sorted, pageSortFields)
+		var ids []string
+		for _, p := range sorted {
+			ids = append(ids, p.ExternalID)
+		}
+		if got := strings.Join(ids, ","); got != tc.want {
+			t.Errorf("--sort %q --reverse=%v: got %s, want %s", tc.sort, tc.reverse, got, tc.want)
+		}
+	}
+
+	listSort = "size"
+	if err := checkListSort(); err == nil {
+		t.Error("checkListSort accepted --sort size")
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var statsUsageSince string
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show CLI statistics",
+	Long:  `Commands for inspecting how the CLI is being used.`,
+}
+
+var statsUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show your API usage from this machine",
+	Long: `Show API call counts, bytes uploaded, and pages created over a period.
+
+Usage is recorded in a local ledger (in the CLI state directory) as requests
+are made, so it covers only requests made by the CLI on this machine. The
+ledger keeps at most 90 days of requests.
+
+Examples:
+  hyperclast stats usage
+  hyperclast stats usage --since 24h
+  hyperclast stats usage --since 7d --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		window, err := parseLongDuration(statsUsageSince)
+		if err != nil {
+			return err
+		}
+		if window > usage.Retention {
+			return fmt.Errorf("--since cannot be longer than %dd, the time the usage ledger keeps entries", int(usage.Retention.Hours()/24))
+		}
+		since := time.Now().Add(-window)
+
+		entries, err := usage.Open(config.StateDir()).Entries(since)
+		if err != nil {
+			return err
+		}
+		summary := usage.Summarize(entries, since)
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(summary)
+		}
+
+		fmt.Printf("Usage since %s (last %s), recorded on this machine\n\n", since.Format("Jan 2, 2006 3:04 PM"), statsUsageSince)
+		fmt.Printf("API calls:       %d (%d failed)\n", summary.Calls, summary.Failed)
+		fmt.Printf("Bytes uploaded:  %s\n", formatBytes(summary.BytesUploaded))
+		fmt.Printf("Pages created:   %d\n", summary.PagesCreated)
+
+		if len(summary.Endpoints) == 0 {
+			return nil
+		}
+
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ENDPOINT\tCALLS\tUPLOADED")
+		for _, e := range summary.Endpoints {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", e.Endpoint, e.Calls, formatBytes(e.BytesUploaded))
+		}
+		_ = w.Flush()
+
+		return nil
+	},
+}
+
+// recordUsage appends an API request to the local usage ledger. Failures are
+// only reported in verbose mode; accounting must never break a command.
+func recordUsage(r api.RequestRecord) {
+	err := usage.Open(config.StateDir()).Record(usage.Entry{
+		Time:      time.Now().UTC(),
+		Method:    r.Method,
+		Path:      r.Path,
+		Status:    r.Status,
+		BytesSent: r.BytesSent,
+	})
+	if err != nil {
+		printDebug("failed to record usage: %v", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.AddCommand(statsUsageCmd)
+
+	statsUsageCmd.Flags().StringVar(&statsUsageSince, "since", "30d", "reporting window, at most 90d (e.g. 24h, 7d, 2w)")
+}
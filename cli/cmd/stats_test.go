@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/usage"
+)
+
+func TestStatsUsage_JSONFromLedger(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	outputFmt = "json"
+	quiet = false
+	statsUsageSince = "7d"
+	defer func() { outputFmt = "text" }()
+
+	recordUsage(api.RequestRecord{Method: "POST", Path: "/pages/", Status: 201, BytesSent: 42})
+	recordUsage(api.RequestRecord{Method: "GET", Path: "/pages/page_1/", Status: 200})
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := statsUsageCmd.RunE(statsUsageCmd, []string{})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output, _ := io.ReadAll(r)
+	var summary usage.Summary
+	if err := json.Unmarshal(output, &summary); err != nil {
+		t.Fatalf("output is not valid JSON: %v\nraw: %s", err, string(output))
+	}
+	if summary.Calls != 2 || summary.PagesCreated != 1 || summary.BytesUploaded != 42 {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestStatsUsage_InvalidSince(t *testing.T) {
+	statsUsageSince = "soon"
+	defer func() { statsUsageSince = "30d" }()
+
+	if err := statsUsageCmd.RunE(statsUsageCmd, []string{}); err == nil {
+		t.Fatal("expected error for invalid --since")
+	}
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// Flags for 'page new --stream' and 'run --stream'.
+var (
+	streamOutput   bool
+	streamInterval time.Duration
+	streamBytes    string
+)
+
+// checkStreamFlags validates --stream-interval and --stream-bytes,
+// returning the batch size that is sent early.
+func checkStreamFlags() (int64, error) {
+	if streamInterval < minFollowInterval {
+		return 0, fmt.Errorf("--stream-interval must be at least %s", minFollowInterval)
+	}
+	n, err := parseByteSize(streamBytes)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --stream-bytes: %w", err)
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("--stream-bytes must be positive")
+	}
+	return n, nil
+}
+
+// streamToPage appends the lines read from r to page in batches, every
+// interval and whenever maxBytes of text are waiting (0 for no limit),
+// until r ends or ctx is cancelled. It stops before the page would exceed
+// the size limit. Lines are stamped with --timestamps, stripped of escape
+// sequences as ansiMode says, and redacted with --redact, and batches held
+// back by the config's quiet_hours and upload_rate. It returns the lines
+// appended.
+func streamToPage(ctx context.Context, client *api.Client, page *api.Page, name string, r io.Reader, interval time.Duration, maxBytes int64) (int, error) {
+	quota := newPageQuota(page, "", "append")
+	if err := enforcePageQuota(quota, false); err != nil {
+		return 0, err
+	}
+	gate, err := newUploadGate(interval)
+	if err != nil {
+		return 0, err
+	}
+
+	format := formatCapturedLines
+	if lineTimestamps != "" {
+		start := time.Now()
+		format = func(batch []muxLine) string {
+			var b strings.Builder
+			for _, l := range batch {
+				b.WriteString(lineStamp(start, l.Time))
+				b.WriteString(strings.ToValidUTF8(l.Text, "�"))
+				b.WriteByte('\n')
+			}
+			return b.String()
+		}
+	}
+
+	var lines int
+	ready := func(batch []muxLine) int {
+		return gate.ready(batch, quota.Current, format)
+	}
+	err = runMuxLimited(ctx, []muxInput{{Name: name, Reader: r}}, interval, maxBytes, ready, func(batch []muxLine) error {
+		text := redactContent(filterANSI(format(batch)))
+		quota.Adding = int64(len(text))
+		if quota.exceeded() {
+			return fmt.Errorf("stopping after %d lines: %s", lines, quota)
+		}
+		if _, err := client.UpdateFetchedPageContent(page, text, "append"); err != nil {
+			return fmt.Errorf("failed to append to page: %w", err)
+		}
+		quota.Current += quota.Adding
+		gate.spent(len(text))
+		lines += len(batch)
+		printDebug("Appended %d lines", len(batch))
+		return nil
+	})
+	return lines, err
+}
+
+// checkPageNewStream refuses 'page new --stream' with flags that need the
+// whole input at once.
+func checkPageNewStream() error {
+	if !streamOutput {
+		return nil
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{pageFile != "", "--file"},
+		{len(pageFiles) > 0, "--files"},
+		{pageClipboard, "--clipboard"},
+		{pageFromGitShow != "", "--from-git-show"},
+		{pageFromURL != "", "--url"},
+		{pageInteractive, "--interactive"},
+		{pageLinkFrom != "", "--link-from"},
+		{pagePreview, "--preview"},
+		{pageMeta, "--meta"},
+		{pageSubstitute, "--substitute"},
+		{pageSkipDuplicate, "--skip-duplicate"},
+		{upsertMode() != "", "--append-if-exists or --overwrite-if-exists"},
+		{pageSplitOn != "", "--split-on"},
+		{csvColumnOpsSet(), "--csv-select, --csv-drop, and --csv-rename"},
+	} {
+		if conflict.set {
+			return fmt.Errorf("--stream cannot be combined with %s", conflict.flag)
+		}
+	}
+	return nil
+}
+
+// runPageNewStream creates an empty page and appends stdin to it in batches
+// until end of input or Ctrl-C.
+func runPageNewStream(projectID string, expires time.Time) error {
+	maxBytes, err := checkStreamFlags()
+	if err != nil {
+		return err
+	}
+	title := pageTitle
+	if title == "" {
+		title = generateDefaultTitle()
+	}
+	filetype := pageFiletype
+	if filetype == autoFiletype {
+		// There is no content to detect from yet.
+		filetype = "log"
+	}
+	details := &api.PageDetails{Filetype: filetype, Labels: pageLabels}
+	if !expires.IsZero() {
+		details.ExpiresAt = expires.Format(time.RFC3339)
+	}
+
+	client := newClient()
+	page, err := client.CreatePageWithDetails(projectID, title, details)
+	if err != nil {
+		return fmt.Errorf("failed to create page: %w", err)
+	}
+	printInfo("Streaming to page \"%s\" (%s)", page.Title, page.ExternalID)
+	if pageNewOpen {
+		openCreatedPage(page.ExternalID)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	lines, err := streamToPage(ctx, client, page, "stdin", os.Stdin, streamInterval, maxBytes)
+	if err != nil {
+		return fmt.Errorf("%w (page %s has the lines appended before this)", err, page.ExternalID)
+	}
+
+	switch {
+	case outputFmt == "json":
+		if err := json.NewEncoder(os.Stdout).Encode(map[string]any{
+			"external_id": page.ExternalID,
+			"title":       page.Title,
+			"lines":       lines,
+		}); err != nil {
+			return err
+		}
+	case quiet:
+		fmt.Println(page.ExternalID)
+	default:
+		printSuccess("Streamed %d lines to page \"%s\" (%s)", lines, page.Title, page.ExternalID)
+		printInfo("  %s", pageURL(page.ExternalID))
+	}
+	if ctx.Err() != nil {
+		return &interruptedError{msg: "interrupted; the input read before it was appended"}
+	}
+	return nil
+}
+
+// addStreamFlags adds --stream and its tuning flags to c.
+func addStreamFlags(c *cobra.Command) {
+	c.Flags().BoolVar(&streamOutput, "stream", false, "append to the page in batches as output arrives, instead of all at once at the end")
+	c.Flags().DurationVar(&streamInterval, "stream-interval", 2*time.Second, "with --stream, how often to append buffered lines")
+	c.Flags().StringVar(&streamBytes, "stream-bytes", "64KB", "with --stream, append early once this much output is buffered")
+}
+
+func init() {
+	addStreamFlags(pageNewCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+// countAppends routes env's requests through a server that counts the
+// page updates sent.
+func countAppends(t *testing.T, env *cliEnv) *atomic.Int32 {
+	var puts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts.Add(1)
+		}
+		env.server.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	env.url = ts.URL
+	return &puts
+}
+
+func TestPageNew_Stream(t *testing.T) {
+	env := newCLIEnv(t)
+	puts := countAppends(t, env)
+	env.stdin = "one\ntwo\nthree\n"
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--title", "Build", "--stream", "--stream-bytes", "4"))
+	page, ok := env.server.Page(id)
+	if !ok {
+		t.Fatalf("printed %q, not a page ID", id)
+	}
+	if page.Details.Content != env.stdin || page.Details.Filetype != "log" {
+		t.Errorf("page has %q (%s)", page.Details.Content, page.Details.Filetype)
+	}
+	// Every line fills a 4-byte batch, so each is sent on its own.
+	if n := puts.Load(); n != 3 {
+		t.Errorf("%d appends, want 3", n)
+	}
+}
+
+func TestPageNew_StreamErrors(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--file", "in.txt"}, "--stream cannot be combined with --file"},
+		{[]string{"--preview"}, "--stream cannot be combined with --preview"},
+		{[]string{"--stream-interval", "10ms"}, "--stream-interval must be at least"},
+		{[]string{"--stream-bytes", "lots"}, "invalid --stream-bytes"},
+	} {
+		args := append([]string{"page", "new", "--project", apitest.DefaultProjectID, "--stream"}, tt.args...)
+		if _, _, err := env.run(args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+	if pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID); len(pages) != 0 {
+		t.Errorf("%d pages created, want none", len(pages))
+	}
+}
+
+func TestRun_Stream(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+	puts := countAppends(t, env)
+
+	stdout, _, err := env.run("run", "--project", apitest.DefaultProjectID, "--stream", "--stream-bytes", "1", "--", "sh", "-c", "echo one; echo two; exit 4")
+	var status *exitStatusError
+	if !errors.As(err, &status) || status.code != 4 {
+		t.Fatalf("err = %v, want exit status 4", err)
+	}
+	if stdout != "one\ntwo\n" {
+		t.Errorf("stdout = %q", stdout)
+	}
+
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	page, _ := env.server.Page(pages[0].ExternalID)
+	content := page.Details.Content
+	if !strings.HasPrefix(content, "---\nCommand: sh -c ") || !strings.Contains(content, "---\n\none\ntwo\n\n---\nDuration: ") || !strings.HasSuffix(content, "Exit status: 4\n---\n") {
+		t.Errorf("content = %q", content)
+	}
+	// Two lines, then the exit status.
+	if n := puts.Load(); n != 3 {
+		t.Errorf("%d appends, want 3", n)
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var pageSubstitute bool
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+
+// substitutionNow is the clock used by {{date}}; tests replace it.
+var substitutionNow = time.Now
+
+// expandPlaceholders replaces {{...}} placeholders in content:
+//
+//	{{env "NAME"}}          value of an environment variable (error if unset)
+//	{{date}}                today's date, 2006-01-02
+//	{{date "<layout>"}}     the current time in a Go time layout
+//	{{hostname}}            this machine's hostname
+//	{{git.sha}}             HEAD commit of the git repo in the working directory
+//	{{git.short_sha}}       abbreviated HEAD commit
+//	{{git.branch}}          current branch
+//
+// Unknown placeholders are an error rather than being uploaded verbatim.
+func expandPlaceholders(content string) (string, error) {
+	return expandPlaceholdersWith(content, nil)
+}
+
+// expandPlaceholdersWith is expandPlaceholders with extra argument-less
+// placeholders, which take precedence over the built-in ones.
+func expandPlaceholdersWith(content string, vars map[string]string) (string, error) {
+	git := map[string]string{}
+	var firstErr error
+
+	out := placeholderPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		expr := placeholderPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[expr]; ok {
+			return value
+		}
+		value, err := evalPlaceholder(expr, git)
+		if err != nil {
+			firstErr = fmt.Errorf("failed to expand %s: %w", match, err)
+			return match
+		}
+		return value
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
+
+func evalPlaceholder(expr string, git map[string]string) (string, error) {
+	args, err := splitPlaceholderArgs(expr)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("empty placeholder")
+	}
+
+	name, args := args[0], args[1:]
+	switch name {
+	case "env":
+		if len(args) != 1 {
+			return "", fmt.Errorf(`usage: {{env "NAME"}}`)
+		}
+		value, ok := os.LookupEnv(args[0])
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", args[0])
+		}
+		return value, nil
+	case "date":
+		layout := "2006-01-02"
+		if len(args) > 1 {
+			return "", fmt.Errorf(`usage: {{date}} or {{date "<layout>"}}`)
+		}
+		if len(args) == 1 {
+			layout = args[0]
+		}
+		return substitutionNow().Format(layout), nil
+	case "hostname":
+		return os.Hostname()
+	case "git.sha", "git.short_sha", "git.branch":
+		if len(args) != 0 {
+			return "", fmt.Errorf("%s takes no arguments", name)
+		}
+		if v, ok := git[name]; ok {
+			return v, nil
+		}
+		v, err := gitPlaceholder(name)
+		if err != nil {
+			return "", err
+		}
+		git[name] = v
+		return v, nil
+	default:
+		return "", fmt.Errorf("unknown placeholder %q", name)
+	}
+}
+
+func gitPlaceholder(name string) (string, error) {
+	var args []string
+	switch name {
+	case "git.sha":
+		args = []string{"rev-parse", "HEAD"}
+	case "git.short_sha":
+		args = []string{"rev-parse", "--short", "HEAD"}
+	case "git.branch":
+		args = []string{"rev-parse", "--abbrev-ref", "HEAD"}
+	}
+	out, err := runGit(args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// splitPlaceholderArgs splits a placeholder on whitespace, treating
+// double-quoted Go string literals as single arguments.
+func splitPlaceholderArgs(expr string) ([]string, error) {
+	var args []string
+	for expr = strings.TrimSpace(expr); expr != ""; expr = strings.TrimLeftFunc(expr, unicode.IsSpace) {
+		if expr[0] != '"' {
+			end := strings.IndexFunc(expr, unicode.IsSpace)
+			if end < 0 {
+				end = len(expr)
+			}
+			args = append(args, expr[:end])
+			expr = expr[end:]
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(expr)
+		if err != nil {
+			return nil, fmt.Errorf("unterminated string in %q", expr)
+		}
+		arg, _ := strconv.Unquote(quoted)
+		args = append(args, arg)
+		expr = expr[len(quoted):]
+	}
+	return args, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpandPlaceholders(t *testing.T) {
+	t.Setenv("DEPLOY_ENV", "staging")
+	substitutionNow = func() time.Time { return time.Date(2025, 12, 30, 14, 45, 0, 0, time.UTC) }
+	defer func() { substitutionNow = time.Now }()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`env: {{env "DEPLOY_ENV"}}`, "env: staging"},
+		{`{{ env "DEPLOY_ENV" }}`, "staging"},
+		{"on {{date}}", "on 2025-12-30"},
+		{`at {{date "15:04 MST"}}`, "at 14:45 UTC"},
+		{"no placeholders", "no placeholders"},
+		{"{ {not one} }", "{ {not one} }"},
+	}
+	for _, tt := range tests {
+		got, err := expandPlaceholders(tt.in)
+		if err != nil {
+			t.Errorf("expandPlaceholders(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("expandPlaceholders(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPlaceholders_Errors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{{env "HYPERCLAST_TEST_UNSET_VAR"}}`, "is not set"},
+		{"{{env}}", "usage"},
+		{"{{nope}}", `unknown placeholder "nope"`},
+		{"{{}}", "empty placeholder"},
+		{`{{env "unterminated}}`, "unterminated string"},
+	}
+	for _, tt := range tests {
+		_, err := expandPlaceholders(tt.in)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("expandPlaceholders(%q) error = %v, want %q", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestExpandPlaceholders_Git(t *testing.T) {
+	initGitRepo(t)
+	sha, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := expandPlaceholders("commit {{git.sha}} ({{git.short_sha}}) on {{git.branch}}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	full := strings.TrimSpace(string(sha))
+	if !strings.HasPrefix(got, "commit "+full+" ("+full[:7]) {
+		t.Errorf("got %q, want HEAD sha %s", got, full)
+	}
+	if strings.HasSuffix(got, "on ") || strings.Contains(got, "{{") {
+		t.Errorf("got %q, want branch expanded", got)
+	}
+}
+
+func TestSplitPlaceholderArgs(t *testing.T) {
+	args, err := splitPlaceholderArgs(`date  "Jan 2, 2006"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 2 || args[0] != "date" || args[1] != "Jan 2, 2006" {
+		t.Errorf("args = %q", args)
+	}
+}
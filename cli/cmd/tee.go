@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	teePageID    string
+	teeProjectID string
+	teeTitle     string
+)
+
+var teeCmd = &cobra.Command{
+	Use:   "tee",
+	Short: "Copy stdin to stdout and save it to a page",
+	Long: `Copy stdin to stdout unchanged, like tee(1), and save it to a page once
+input ends, so you can watch a build and capture it at the same time. Use
+--page to append to an existing page instead of creating one.
+
+Ctrl-C stops the command feeding the pipe, and what it wrote is still
+saved. A second Ctrl-C stops hyperclast too, leaving the input read so far
+in a temp file.
+
+--max-bytes and --tail-lines save only the end of the input, so a runaway
+command cannot produce a page too large to send; all of it is still copied
+to stdout.
+
+Terminal escape sequences, such as colors, are removed from the saved
+input unless --keep-ansi is given; --ansi-markdown instead saves a markdown
+page with colored and bold text emphasized.
+
+Examples:
+  make 2>&1 | hyperclast tee --title "Build $(date +%F)"
+  ./deploy.sh | hyperclast tee --page page_xyz789 | grep -i error`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+		if outputFmt == "json" {
+			return fmt.Errorf("tee writes its input to stdout, so --output json is not supported")
+		}
+		if teePageID != "" && (teeProjectID != "" || teeTitle != "") {
+			return fmt.Errorf("--page cannot be combined with --project or --title")
+		}
+		projectID := teeProjectID
+		if projectID == "" {
+			projectID = cfg.GetDefaultProject()
+		}
+		if teePageID == "" && projectID == "" {
+			return fmt.Errorf("no project specified (use --project, --page, or set a default project)")
+		}
+		budget, err := newTailBudget()
+		if err != nil {
+			return err
+		}
+		if err := resolveANSI(true); err != nil {
+			return err
+		}
+		if ansiMode == ansiToMarkdown && teePageID != "" {
+			return fmt.Errorf("--ansi-markdown cannot be combined with --page")
+		}
+		title := teeTitle
+		if title == "" {
+			title = generateDefaultTitle()
+		}
+
+		// Check the target before reading, so input is not held back from
+		// the page only to find it has nowhere to go.
+		client := newClient()
+		var existing *api.Page
+		if teePageID != "" {
+			var err error
+			if existing, err = client.GetPage(teePageID); err != nil {
+				return fmt.Errorf("failed to get page: %w", err)
+			}
+			if err := checkPageLock(client, existing); err != nil {
+				return err
+			}
+		} else if err := confirmProtectedWrite(projectID, teeProjectID != "", "tee"); err != nil {
+			return err
+		}
+
+		// A reader that stops early, like head, should not stop the capture.
+		signal.Ignore(syscall.SIGPIPE)
+		stopTrap := trapTeeInterrupts()
+		tempPath, err := bufferReaderToTemp(io.TeeReader(os.Stdin, &passthroughWriter{w: os.Stdout}))
+		stopTrap()
+		if err != nil {
+			return err
+		}
+
+		retry := fmt.Sprintf("hyperclast page new --project %s --title %s", projectID, shellQuote(title))
+		if existing != nil {
+			retry = "hyperclast page append " + existing.ExternalID
+		}
+		if tailMaxBytes != "" {
+			retry += " --max-bytes " + shellQuote(tailMaxBytes)
+		}
+		if tailLines != 0 {
+			retry += fmt.Sprintf(" --tail-lines %d", tailLines)
+		}
+		switch ansiMode {
+		case ansiStrip:
+			retry += " --strip-ansi"
+		case ansiToMarkdown:
+			retry += " --ansi-markdown"
+		}
+		if info, err := os.Stat(tempPath); err == nil && info.Size() == 0 {
+			_ = os.Remove(tempPath)
+			printTeeNote("No input; nothing saved")
+			return nil
+		}
+		var content string
+		if budget != nil {
+			content, err = readBudgetedFile(tempPath, budget)
+		} else {
+			content, err = readAndValidateFile(tempPath)
+		}
+		if err != nil {
+			printTeeRecovery(tempPath, retry)
+			return err
+		}
+		content = filterANSI(content)
+
+		var page *api.Page
+		if existing != nil {
+			if err = enforcePageQuota(newPageQuota(existing, content, "append"), false); err == nil {
+				page, err = client.UpdateFetchedPageContent(existing, content, "append")
+				if err != nil {
+					err = fmt.Errorf("failed to append to page: %w", err)
+				}
+			}
+		} else {
+			filetype := detectFiletype(content, "txt")
+			if ansiMode == ansiToMarkdown {
+				filetype = "md"
+			}
+			page, err = client.CreatePageWithDetails(projectID, title, &api.PageDetails{Content: content, Filetype: filetype})
+			if err != nil {
+				err = fmt.Errorf("failed to create page: %w", err)
+			}
+		}
+		if err != nil {
+			printTeeRecovery(tempPath, retry)
+			return err
+		}
+		_ = os.Remove(tempPath)
+
+		printTeeNote("✓ Saved %d lines to page \"%s\" (%s)", countLines(content), page.Title, page.ExternalID)
+		printTeeNote("  %s", pageURL(page.ExternalID))
+		return nil
+	},
+}
+
+// passthroughWriter copies to w until a write fails, say because the
+// reader downstream exited, and then discards the rest without error.
+type passthroughWriter struct {
+	w      io.Writer
+	failed bool
+}
+
+func (p *passthroughWriter) Write(b []byte) (int, error) {
+	if !p.failed {
+		if _, err := p.w.Write(b); err != nil {
+			p.failed = true
+			printDebug("Stopped copying to stdout: %v", err)
+		}
+	}
+	return len(b), nil
+}
+
+// trapTeeInterrupts keeps the first Ctrl-C or SIGTERM from stopping tee,
+// since it also reaches the command feeding the pipe, whose output should
+// still be saved. A second one exits, saying where the input read so far
+// is. The returned func removes the trap.
+func trapTeeInterrupts() func() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		first := true
+		for range signals {
+			if first {
+				first = false
+				printWarning("Interrupted; saving what was read once input ends (interrupt again to stop)")
+				continue
+			}
+			if path := stdinBufferPath; path != "" {
+				fmt.Fprintf(os.Stderr, "Input read so far is saved at: %s\n", path)
+			}
+			os.Exit(exitInterrupted)
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(signals)
+	}
+}
+
+// printTeeNote is printInfo on stderr, which tee uses since its stdout is
+// the input.
+func printTeeNote(format string, a ...any) {
+	if !quiet {
+		fmt.Fprintf(os.Stderr, format+"\n", a...)
+	}
+}
+
+func printTeeRecovery(tempPath, retry string) {
+	fmt.Fprintf(os.Stderr, "Your data is saved at: %s\n", tempPath)
+	fmt.Fprintf(os.Stderr, "Retry with: %s --file %s\n", retry, tempPath)
+}
+
+func init() {
+	rootCmd.AddCommand(teeCmd)
+
+	teeCmd.Flags().StringVar(&teePageID, "page", "", "append to this page instead of creating one")
+	teeCmd.Flags().StringVar(&teeProjectID, "project", "", "project for the new page (default: the default project)")
+	teeCmd.Flags().StringVar(&teeTitle, "title", "", "title for the new page (default: the current date and time)")
+	addTailFlags(teeCmd)
+	addKeepANSIFlag(teeCmd)
+	addANSIMarkdownFlag(teeCmd)
+}
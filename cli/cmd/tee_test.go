@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestTee_CopiesInputAndCreatesPage(t *testing.T) {
+	env := newCLIEnv(t)
+	env.stdin = "compiling\nlinking\n"
+
+	stdout, stderr, err := env.run("tee", "--project", apitest.DefaultProjectID, "--title", "Build")
+	if err != nil {
+		t.Fatalf("tee: %v\nstderr: %s", err, stderr)
+	}
+	if stdout != env.stdin {
+		t.Errorf("stdout = %q, want the input unchanged", stdout)
+	}
+	if !strings.Contains(stderr, `Saved 2 lines to page "Build"`) {
+		t.Errorf("stderr = %q, want a summary", stderr)
+	}
+
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	if page, _ := env.server.Page(pages[0].ExternalID); page.Title != "Build" || page.Details.Content != env.stdin {
+		t.Errorf("page = %q with %q", page.Title, page.Details.Content)
+	}
+}
+
+func TestTee_Appends(t *testing.T) {
+	env := newCLIEnv(t)
+	existing := env.server.AddPage(apitest.DefaultProjectID, "Deploys", "earlier\n")
+	env.stdin = "deployed\n"
+
+	stdout, stderr, err := env.run("--quiet", "tee", "--page", existing.ExternalID)
+	if err != nil {
+		t.Fatalf("tee: %v\nstderr: %s", err, stderr)
+	}
+	if stdout != "deployed\n" || stderr != "" {
+		t.Errorf("stdout = %q, stderr = %q; want only the input", stdout, stderr)
+	}
+	if page, _ := env.server.Page(existing.ExternalID); page.Details.Content != "earlier\ndeployed\n" {
+		t.Errorf("content = %q", page.Details.Content)
+	}
+}
+
+func TestTee_Errors(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"tee", "--page", "page_x", "--project", "proj_x"}, "cannot be combined"},
+		{[]string{"--output", "json", "tee", "--project", apitest.DefaultProjectID}, "--output json is not supported"},
+	} {
+		if _, _, err := env.run(tt.args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+type failingWriter struct{ writes int }
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	f.writes++
+	return 0, errors.New("broken pipe")
+}
+
+func TestPassthroughWriter_StopsAfterFailure(t *testing.T) {
+	f := &failingWriter{}
+	p := &passthroughWriter{w: f}
+	for range 3 {
+		if n, err := p.Write([]byte("line\n")); n != 5 || err != nil {
+			t.Fatalf("Write = %d, %v; want the write to succeed", n, err)
+		}
+	}
+	if f.writes != 1 {
+		t.Errorf("%d writes reached the failed writer, want 1", f.writes)
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+// telemetryTimeout bounds sending a batch, so a slow server never holds up
+// the command that triggered it.
+const telemetryTimeout = 3 * time.Second
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Manage anonymous usage metrics (off unless enabled)",
+	Long: `Anonymous usage metrics tell the maintainers which commands matter.
+They are off unless you run 'hyperclast telemetry enable'.
+
+When enabled, the CLI records the name of each command you run (such as
+"page new") and how long it took. Nothing else is recorded: no arguments,
+flag values, page content, IDs, or errors. Events are queued in the CLI
+state directory and sent in batches, without your token, so they are not
+tied to your account. 'hyperclast telemetry show' prints exactly what the
+next batch would send. Setting DO_NOT_TRACK=1 turns telemetry off.`,
+}
+
+var telemetryEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Opt in to anonymous usage metrics",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := telemetry.Open(config.StateDir()).SetEnabled(true); err != nil {
+			return err
+		}
+		printSuccess("Telemetry enabled: command names and durations will be sent in batches of %d", telemetry.BatchSize)
+		printInfo("  See what would be sent with 'hyperclast telemetry show'; stop with 'hyperclast telemetry disable'")
+		return nil
+	},
+}
+
+var telemetryDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Opt out of usage metrics and discard unsent events",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := telemetry.Open(config.StateDir()).SetEnabled(false); err != nil {
+			return err
+		}
+		printSuccess("Telemetry disabled; unsent events were discarded")
+		return nil
+	},
+}
+
+var telemetryShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show whether telemetry is on and what would be sent",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := telemetry.Open(config.StateDir())
+		events, err := store.Pending()
+		if err != nil {
+			return err
+		}
+		body := map[string]any{"events": telemetryBatch(events)}
+		endpoint := "POST " + cfg.APIURL + "/cli/telemetry/"
+
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"enabled":    store.Enabled(),
+				"refused":    store.Refused(cfg.APIURL),
+				"endpoint":   endpoint,
+				"batch_size": telemetry.BatchSize,
+				"body":       body,
+			})
+		}
+
+		if store.Enabled() {
+			printInfo("Telemetry is enabled (stop with 'hyperclast telemetry disable')")
+			if store.Refused(cfg.APIURL) {
+				printInfo("The server has no telemetry endpoint, so nothing is sent to it")
+			}
+		} else {
+			printInfo("Telemetry is disabled (opt in with 'hyperclast telemetry enable')")
+		}
+		printInfo("%d events queued; every %d are sent, without your token, as:", len(events), telemetry.BatchSize)
+		printInfo("  %s", endpoint)
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(body)
+	},
+}
+
+// telemetryBatch converts queued events to the request body's form.
+func telemetryBatch(events []telemetry.Event) []api.TelemetryEvent {
+	batch := make([]api.TelemetryEvent, len(events))
+	for i, e := range events {
+		batch[i] = api.TelemetryEvent{Command: e.Command, DurationMS: e.DurationMS}
+	}
+	return batch
+}
+
+// recordTelemetry queues the finished command if telemetry is enabled and
+// sends the queue once it holds a batch. Like usage accounting, failures
+// are only reported in verbose mode; unsent events wait for the next
+// batch. A server without the telemetry endpoint is warned about once and
+// not sent batches again until telemetry is re-enabled.
+func recordTelemetry(cmd *cobra.Command, started time.Time) {
+	if cmd == nil || cmd == rootCmd {
+		return
+	}
+	store := telemetry.Open(config.StateDir())
+	if !store.Enabled() {
+		return
+	}
+	err := store.Record(telemetry.Event{
+		Command:    strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" "),
+		DurationMS: time.Since(started).Milliseconds(),
+	})
+	if err != nil {
+		printDebug("failed to record telemetry: %v", err)
+		return
+	}
+
+	events, err := store.Pending()
+	if err != nil || len(events) < telemetry.BatchSize || cfg == nil || store.Refused(cfg.APIURL) {
+		return
+	}
+	// The batch is not the user's request: keep it out of the usage ledger.
+	api.SetRequestObserver(nil)
+	client := api.NewClientWithOptions(cfg.APIURL, "", api.ClientOptions{
+		Timeout:          telemetryTimeout,
+		VerifyConnection: pinVerifier(),
+	})
+	err = client.SendTelemetry(telemetryBatch(events))
+	if errors.Is(err, api.ErrTelemetryUnsupported) {
+		if err := store.SetRefused(cfg.APIURL); err != nil {
+			printDebug("%v", err)
+		}
+		printWarning("%s does not accept telemetry, so no events are sent to it; they stay queued locally ('hyperclast telemetry show', or 'hyperclast telemetry disable' to stop)", cfg.APIURL)
+		return
+	}
+	if err != nil {
+		printDebug("failed to send telemetry: %v", err)
+		return
+	}
+	if err := store.Clear(); err != nil {
+		printDebug("%v", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(telemetryCmd)
+	telemetryCmd.AddCommand(telemetryEnableCmd)
+	telemetryCmd.AddCommand(telemetryDisableCmd)
+	telemetryCmd.AddCommand(telemetryShowCmd)
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/telemetry"
+)
+
+func TestRecordTelemetry_SendsBatchWithoutToken(t *testing.T) {
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	t.Setenv("DO_NOT_TRACK", "")
+
+	var got struct {
+		Events []api.TelemetryEvent `json:"events"`
+	}
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/cli/telemetry/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "secret-token"}
+
+	store := telemetry.Open(config.StateDir())
+	if err := store.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	for range telemetry.BatchSize - 2 {
+		recordTelemetry(pageListCmd, time.Now())
+	}
+	if got.Events != nil {
+		t.Fatalf("sent %d events before a batch was queued", len(got.Events))
+	}
+
+	recordTelemetry(pageHistoryDiffCmd, time.Now())
+	recordTelemetry(pageNewCmd, time.Now().Add(-1500*time.Millisecond))
+
+	if len(got.Events) != telemetry.BatchSize {
+		t.Fatalf("sent %d events, want %d", len(got.Events), telemetry.BatchSize)
+	}
+	if last := got.Events[len(got.Events)-1]; last.Command != "page new" || last.DurationMS < 1500 {
+		t.Errorf("last event = %+v, want page new taking 1.5s", last)
+	}
+	if got.Events[len(got.Events)-2].Command != "page history diff" {
+		t.Errorf("events = %+v, want subcommand paths", got.Events)
+	}
+	if auth != "" {
+		t.Errorf("Authorization = %q, want no token", auth)
+	}
+	if events, _ := store.Pending(); len(events) != 0 {
+		t.Errorf("%d events still queued after sending", len(events))
+	}
+}
+
+func TestRecordTelemetry_OffByDefault(t *testing.T) {
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	cfg = &config.Config{}
+
+	recordTelemetry(pageNewCmd, time.Now())
+
+	if events, _ := telemetry.Open(config.StateDir()).Pending(); len(events) != 0 {
+		t.Errorf("recorded %+v without opting in", events)
+	}
+}
+
+func TestRecordTelemetry_StopsWhenServerHasNoEndpoint(t *testing.T) {
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	t.Setenv("DO_NOT_TRACK", "")
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL}
+
+	store := telemetry.Open(config.StateDir())
+	if err := store.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 * telemetry.BatchSize {
+		recordTelemetry(pageListCmd, time.Now())
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want one before giving up on the server", requests)
+	}
+	if !store.Refused(server.URL) {
+		t.Error("server without the endpoint was not recorded")
+	}
+	if events, _ := store.Pending(); len(events) != 2*telemetry.BatchSize {
+		t.Errorf("%d events queued, want them all kept for 'telemetry show'", len(events))
+	}
+
+	if err := store.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	if store.Refused(server.URL) {
+		t.Error("re-enabling telemetry did not retry the server")
+	}
+}
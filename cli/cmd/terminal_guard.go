@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+var pageGetForce bool
+
+// maxTerminalLineBytes is the longest line 'page get' prints to a terminal
+// without --force. Longer lines are almost always minified or encoded data.
+const maxTerminalLineBytes = 64 * 1024
+
+// stdoutIsTerminal reports whether stdout is a terminal; tests replace it.
+var stdoutIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// terminalHazard describes why content should not be printed to a terminal
+// as is, or returns "" if it is safe. Like git, it treats NUL bytes and
+// invalid UTF-8 as binary; it also flags a share of control characters
+// (other than tab, newline, carriage return, and the ANSI escape) above 1%,
+// and any line too long to be read.
+func terminalHazard(content string) string {
+	if strings.IndexByte(content, 0) >= 0 || !utf8.ValidString(content) {
+		return "binary data"
+	}
+
+	controls := 0
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if (c < 0x20 && c != '\t' && c != '\n' && c != '\r' && c != 0x1b) || c == 0x7f {
+			controls++
+		}
+	}
+	if controls > 0 && controls*100 > len(content) {
+		return fmt.Sprintf("binary-like data (%d control characters)", controls)
+	}
+
+	for rest := content; rest != ""; {
+		line, next, _ := strings.Cut(rest, "\n")
+		if len(line) > maxTerminalLineBytes {
+			return fmt.Sprintf("a %s line", formatBytes(int64(len(line))))
+		}
+		rest = next
+	}
+	return ""
+}
+
+// guardTerminalOutput refuses to print hazardous content to a terminal
+// unless forced. Redirected or piped output is never refused.
+func guardTerminalOutput(content string, force bool) error {
+	if force || !stdoutIsTerminal() {
+		return nil
+	}
+	if hazard := terminalHazard(content); hazard != "" {
+		return fmt.Errorf("page contains %s and stdout is a terminal (use --force, or redirect or pipe the output, e.g. | cat)", hazard)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestTerminalHazard(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"plain text", "build ok\n\tdone\r\n", ""},
+		{"ansi colors", "\x1b[32mok\x1b[0m\n", ""},
+		{"nul byte", "abc\x00def", "binary data"},
+		{"invalid utf-8", "abc\xffdef", "binary data"},
+		{"control characters", "a\x07b\x08c\n", "binary-like data (2 control characters)"},
+		{"one stray control character", strings.Repeat("x", 200) + "\x07", ""},
+		{"long line", "short\n" + strings.Repeat("x", maxTerminalLineBytes+1) + "\n", "a 64.0 KB line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminalHazard(tt.content); got != tt.want {
+				t.Errorf("terminalHazard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageGet_GuardsTerminal(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	oldIsTerminal := stdoutIsTerminal
+	defer func() { stdoutIsTerminal = oldIsTerminal }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz", Details: &api.PageDetails{Content: "\x01\x02\x03\x04"}})
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, Token: "test-token"}
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout = stdout }()
+
+	stdoutIsTerminal = func() bool { return true }
+	err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"})
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("err = %v, want refusal mentioning --force", err)
+	}
+
+	pageGetForce = true
+	if err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"}); err != nil {
+		t.Errorf("--force: unexpected error: %v", err)
+	}
+
+	pageGetForce = false
+	stdoutIsTerminal = func() bool { return false }
+	if err := pageGetCmd.RunE(pageGetCmd, []string{"page_xyz"}); err != nil {
+		t.Errorf("piped: unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// lineTimestamps is the --timestamps format, or "" for none.
+var lineTimestamps string
+
+const (
+	timestampsRFC3339 = "rfc3339"
+	timestampsElapsed = "elapsed"
+)
+
+// checkTimestamps validates --timestamps for page new and page append,
+// which stamp lines only as they are read from stdin.
+func checkTimestamps() error {
+	if err := checkTimestampsFormat(); err != nil || lineTimestamps == "" {
+		return err
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{pageFile != "", "--file"},
+		{len(pageFiles) > 0, "--files"},
+		{pageClipboard, "--clipboard"},
+		{pageFromGitShow != "", "--from-git-show"},
+		{pageFromURL != "", "--url"},
+		{pageInteractive, "--interactive"},
+	} {
+		if conflict.set {
+			return fmt.Errorf("--timestamps stamps lines as they are read from stdin, so it cannot be combined with %s", conflict.flag)
+		}
+	}
+	return nil
+}
+
+func checkTimestampsFormat() error {
+	switch lineTimestamps {
+	case "", timestampsRFC3339, timestampsElapsed:
+		return nil
+	}
+	return fmt.Errorf("invalid --timestamps %q (use rfc3339 or elapsed)", lineTimestamps)
+}
+
+// lineStamp is the --timestamps prefix for a line read at t, in a capture
+// that began at start.
+func lineStamp(start, t time.Time) string {
+	if lineTimestamps == timestampsElapsed {
+		return "[" + formatElapsed(t.Sub(start)) + "] "
+	}
+	return "[" + t.UTC().Format("2006-01-02T15:04:05.000Z07:00") + "] "
+}
+
+// timestampWriter writes to w with each line prefixed by lineStamp, taken
+// when the line's first byte is written.
+type timestampWriter struct {
+	w       io.Writer
+	start   time.Time
+	midLine bool
+}
+
+func newTimestampWriter(w io.Writer) *timestampWriter {
+	return &timestampWriter{w: w, start: time.Now()}
+}
+
+func (t *timestampWriter) Write(p []byte) (int, error) {
+	now := time.Now()
+	n := len(p)
+	for len(p) > 0 {
+		if !t.midLine {
+			if _, err := io.WriteString(t.w, lineStamp(t.start, now)); err != nil {
+				return 0, err
+			}
+			t.midLine = true
+		}
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line = p[:i+1]
+			t.midLine = false
+		}
+		if _, err := t.w.Write(line); err != nil {
+			return 0, err
+		}
+		p = p[len(line):]
+	}
+	return n, nil
+}
+
+// timestampReader returns the lines of r stamped as they are read from it.
+func timestampReader(r io.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := io.Copy(newTimestampWriter(pw), r)
+		_ = pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// addTimestampsFlag adds --timestamps to c. Given without a value, it means
+// rfc3339.
+func addTimestampsFlag(c *cobra.Command) {
+	c.Flags().StringVar(&lineTimestamps, "timestamps", "", "prefix each line with the time it was read: rfc3339 (the default with no value), or --timestamps=elapsed for the time since the start")
+	c.Flags().Lookup("timestamps").NoOptDefVal = timestampsRFC3339
+}
+
+func init() {
+	addTimestampsFlag(pageNewCmd)
+	addTimestampsFlag(pageAppendCmd)
+}
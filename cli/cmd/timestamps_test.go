@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+const (
+	elapsedStamp = `\[\+\d\d:\d\d:\d\d\.\d{3}\] `
+	rfc3339Stamp = `\[\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{3}Z\] `
+)
+
+func TestTimestampWriter(t *testing.T) {
+	old := lineTimestamps
+	t.Cleanup(func() { lineTimestamps = old })
+	lineTimestamps = timestampsElapsed
+
+	var buf bytes.Buffer
+	w := newTimestampWriter(&buf)
+	for _, chunk := range []string{"compiling", " main.go\nlink", "ing\n\ndone"} {
+		if n, err := w.Write([]byte(chunk)); n != len(chunk) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	want := regexp.MustCompile(`^` + elapsedStamp + `compiling main.go\n` + elapsedStamp + `linking\n` + elapsedStamp + `\n` + elapsedStamp + `done$`)
+	if !want.MatchString(buf.String()) {
+		t.Errorf("wrote %q", buf.String())
+	}
+}
+
+func TestPageNewAndAppend_Timestamps(t *testing.T) {
+	env := newCLIEnv(t)
+	env.stdin = "one\ntwo\n"
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--timestamps=elapsed"))
+	page, _ := env.server.Page(id)
+	if want := regexp.MustCompile(`^` + elapsedStamp + `one\n` + elapsedStamp + `two\n$`); !want.MatchString(page.Details.Content) {
+		t.Errorf("new page has %q", page.Details.Content)
+	}
+
+	env.stdin = "three\n"
+	env.mustRun("page", "append", id, "--timestamps")
+	page, _ = env.server.Page(id)
+	if want := regexp.MustCompile(`two\n` + rfc3339Stamp + `three\n$`); !want.MatchString(page.Details.Content) {
+		t.Errorf("appended page has %q", page.Details.Content)
+	}
+}
+
+func TestTimestamps_Errors(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--timestamps", "--file", "in.txt"}, "cannot be combined with --file"},
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--timestamps=iso"}, `invalid --timestamps "iso"`},
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--timestamps=iso", "--", "true"}, `invalid --timestamps "iso"`},
+	} {
+		if _, _, err := env.run(tt.args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+func TestRun_Timestamps(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+
+	stdout := env.mustRun("run", "--project", apitest.DefaultProjectID, "--timestamps", "--", "sh", "-c", "echo built")
+	if stdout != "built\n" {
+		t.Errorf("stdout = %q, want the output unstamped", stdout)
+	}
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	page, _ := env.server.Page(pages[0].ExternalID)
+	if want := regexp.MustCompile(`---\n\n` + rfc3339Stamp + `built\n$`); !want.MatchString(page.Details.Content) {
+		t.Errorf("content = %q", page.Details.Content)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/tlspin"
+)
+
+// pinVerifier returns a TLS verification hook that pins the certificate of
+// a self-hosted API server on first use. It returns nil for the default API
+// URL, which is verified by the system CAs alone, and for plain http.
+func pinVerifier() func(tls.ConnectionState) error {
+	if cfg == nil || cfg.UsesDefaultAPIURL() {
+		return nil
+	}
+	host := tlspin.Host(cfg.APIURL)
+	if host == "" {
+		return nil
+	}
+
+	store, err := tlspin.Open(config.StateDir())
+	if err != nil {
+		// Fail closed: an unreadable pin store must not disable pinning.
+		return func(tls.ConnectionState) error { return err }
+	}
+
+	var warnOnce sync.Once
+	verify := store.Verifier(host, func(host, fingerprint string) {
+		fmt.Fprintf(os.Stderr, "Trusting TLS certificate for %s on first use (%s)\n", host, fingerprint)
+	})
+	return func(cs tls.ConnectionState) error {
+		err := verify(cs)
+		var mismatch *tlspin.MismatchError
+		if errors.As(err, &mismatch) {
+			warnOnce.Do(func() { printCertMismatch(mismatch) })
+		}
+		return err
+	}
+}
+
+func printCertMismatch(e *tlspin.MismatchError) {
+	banner := "@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@"
+	fmt.Fprintln(os.Stderr, colorize(ansiRed, banner))
+	fmt.Fprintln(os.Stderr, colorize(ansiRed+ansiBold, "@  WARNING: API SERVER TLS CERTIFICATE HAS CHANGED!       @"))
+	fmt.Fprintln(os.Stderr, colorize(ansiRed, banner))
+	fmt.Fprintf(os.Stderr, "The certificate presented by %s does not match the one\n", e.Host)
+	fmt.Fprintln(os.Stderr, "recorded the first time this CLI connected to it. Someone may be")
+	fmt.Fprintln(os.Stderr, "intercepting your connection (man-in-the-middle attack).")
+	fmt.Fprintf(os.Stderr, "  Pinned:    %s\n", e.Pinned)
+	fmt.Fprintf(os.Stderr, "  Presented: %s\n", e.Got)
+	fmt.Fprintln(os.Stderr, "Nothing was sent. If the certificate was legitimately replaced,")
+	fmt.Fprintln(os.Stderr, "run 'hyperclast auth trust' to pin the new one.")
+}
+
+// defaultTrustTimeout bounds the handshake in auth trust when --timeout is unset.
+const defaultTrustTimeout = 30 * time.Second
+
+// fetchServerFingerprint connects to host and returns the fingerprint of the
+// certificate it presents, after normal verification against system CAs.
+func fetchServerFingerprint(host string) (string, error) {
+	timeout := requestTimeout
+	if timeout <= 0 {
+		timeout = defaultTrustTimeout
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", host, &tls.Config{})
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", host, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", fmt.Errorf("no TLS certificate presented by %s", host)
+	}
+	return tlspin.Fingerprint(certs[0]), nil
+}
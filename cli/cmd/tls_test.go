@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/config"
+	"github.com/hyperclast/workspace/cli/internal/tlspin"
+)
+
+func TestPinVerifier_DefaultURLNotPinned(t *testing.T) {
+	cfg = &config.Config{APIURL: "https://hyperclast.com/api"}
+	if pinVerifier() != nil {
+		t.Error("default API URL should not be pinned")
+	}
+}
+
+func TestPinVerifier_SelfHosted(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	cfg = &config.Config{APIURL: "https://notes.example.com/api"}
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{server.Certificate()}}
+
+	verify := pinVerifier()
+	if verify == nil {
+		t.Fatal("self-hosted https URL should be pinned")
+	}
+	if err := verify(state); err != nil {
+		t.Fatalf("first use should be trusted: %v", err)
+	}
+
+	store, _ := tlspin.Open(config.StateDir())
+	if store.Lookup("notes.example.com:443") != tlspin.Fingerprint(server.Certificate()) {
+		t.Error("certificate was not pinned")
+	}
+
+	// Only the raw bytes feed the fingerprint, so any other DER will do.
+	other := &x509.Certificate{Raw: []byte("a different certificate")}
+	err := pinVerifier()(tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}})
+	var mismatch *tlspin.MismatchError
+	if !errors.As(err, &mismatch) {
+		t.Errorf("err = %v, want MismatchError for a changed certificate", err)
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for 'page new', 'run', and 'tee' that keep only the end of
+// oversized input. 'page append' takes --tail-lines too, alongside its own
+// --max-lines, --max-bytes, and --keep.
+var (
+	tailMaxBytes string
+	tailLines    int
+)
+
+// newTailBudget checks --max-bytes and --tail-lines, returning a budget
+// that keeps the end of the content. It returns nil without them.
+func newTailBudget() (*appendBudget, error) {
+	if tailLines < 0 {
+		return nil, fmt.Errorf("--tail-lines must not be negative")
+	}
+	b := &appendBudget{MaxLines: tailLines, Verb: "saved"}
+	if tailMaxBytes != "" {
+		n, err := parseByteSize(tailMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("--max-bytes: %w", err)
+		}
+		b.MaxBytes = n
+	}
+	if b.MaxLines == 0 && b.MaxBytes == 0 {
+		return nil, nil
+	}
+	return b, nil
+}
+
+// checkPageNewTail returns the budget for 'page new', refusing it with
+// sources that are not read through one.
+func checkPageNewTail() (*appendBudget, error) {
+	budget, err := newTailBudget()
+	if err != nil || budget == nil {
+		return nil, err
+	}
+	for _, conflict := range []struct {
+		set  bool
+		flag string
+	}{
+		{streamOutput, "--stream"},
+		{len(pageFiles) > 0, "--files"},
+		{pageFromGitShow != "", "--from-git-show"},
+		{pageFromURL != "", "--url"},
+		{pageInteractive, "--interactive"},
+	} {
+		if conflict.set {
+			return nil, fmt.Errorf("--max-bytes and --tail-lines cannot be combined with %s", conflict.flag)
+		}
+	}
+	return budget, nil
+}
+
+// writer returns a writer whose input is cut to b as it arrives, so that
+// only what is kept is held in memory, and a func that ends the input and
+// returns what was kept.
+func (b *appendBudget) writer() (io.Writer, func() (budgetResult, error)) {
+	pr, pw := io.Pipe()
+	type applied struct {
+		res budgetResult
+		err error
+	}
+	done := make(chan applied, 1)
+	go func() {
+		res, err := b.apply(pr)
+		_ = pr.CloseWithError(err)
+		done <- applied{res, err}
+	}()
+	return pw, func() (budgetResult, error) {
+		_ = pw.Close()
+		a := <-done
+		return a.res, a.err
+	}
+}
+
+// addTailFlags adds --max-bytes and --tail-lines to c.
+func addTailFlags(c *cobra.Command) {
+	c.Flags().StringVar(&tailMaxBytes, "max-bytes", "", "save at most this much of the input, keeping its end, e.g. 1MB")
+	c.Flags().IntVar(&tailLines, "tail-lines", 0, "save at most the last n lines of the input")
+}
+
+func init() {
+	addTailFlags(pageNewCmd)
+	pageAppendCmd.Flags().IntVar(&tailLines, "tail-lines", 0, "send at most the last n lines of the input (--max-lines with --keep tail)")
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+func TestPageNew_TailLines(t *testing.T) {
+	env := newCLIEnv(t)
+	env.stdin = "one\ntwo\nthree\nfour\n"
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--quiet", "--project", apitest.DefaultProjectID, "--tail-lines", "2"))
+	page, _ := env.server.Page(id)
+	if want := "[truncated by hyperclast: 2 lines, 8 B before this not saved]\nthree\nfour\n"; page.Details.Content != want {
+		t.Errorf("content = %q, want %q", page.Details.Content, want)
+	}
+}
+
+func TestPageAppend_TailLines(t *testing.T) {
+	env := newCLIEnv(t)
+	existing := env.server.AddPage(apitest.DefaultProjectID, "Nightly", "earlier\n")
+	env.stdin = "one\ntwo\nthree\n"
+
+	env.mustRun("page", "append", existing.ExternalID, "--tail-lines", "1")
+	page, _ := env.server.Page(existing.ExternalID)
+	if want := "earlier\n[truncated by hyperclast: 2 lines, 8 B before this not appended]\nthree\n"; page.Details.Content != want {
+		t.Errorf("content = %q, want %q", page.Details.Content, want)
+	}
+}
+
+func TestTee_MaxBytes(t *testing.T) {
+	env := newCLIEnv(t)
+	env.stdin = "aaaa\nbbbb\ncccc\n"
+
+	stdout, stderr, err := env.run("tee", "--project", apitest.DefaultProjectID, "--max-bytes", "10")
+	if err != nil {
+		t.Fatalf("tee: %v\nstderr: %s", err, stderr)
+	}
+	if stdout != env.stdin {
+		t.Errorf("stdout = %q, want all of the input", stdout)
+	}
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	page, _ := env.server.Page(pages[0].ExternalID)
+	if want := "[truncated by hyperclast: 1 lines, 5 B before this not saved]\nbbbb\ncccc\n"; page.Details.Content != want {
+		t.Errorf("content = %q, want %q", page.Details.Content, want)
+	}
+}
+
+func TestRun_TailLines(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	env := newCLIEnv(t)
+
+	stdout, stderr, err := env.run("run", "--project", apitest.DefaultProjectID, "--tail-lines", "2", "--", "sh", "-c", "for i in 1 2 3 4 5; do echo line $i; done")
+	if err != nil {
+		t.Fatalf("run: %v\nstderr: %s", err, stderr)
+	}
+	if strings.Count(stdout, "\n") != 5 {
+		t.Errorf("stdout = %q, want all of the output", stdout)
+	}
+	if !strings.Contains(stderr, "Output over budget; dropped 3 lines") || !strings.Contains(stderr, "Saved 2 lines") {
+		t.Errorf("stderr = %q", stderr)
+	}
+	pages, _ := api.NewClient(env.url, "integration-token").ListPages(apitest.DefaultProjectID)
+	if len(pages) != 1 {
+		t.Fatalf("%d pages, want 1", len(pages))
+	}
+	page, _ := env.server.Page(pages[0].ExternalID)
+	if want := "---\n\n[truncated by hyperclast: 3 lines, 21 B before this not saved]\nline 4\nline 5\n"; !strings.HasSuffix(page.Details.Content, want) {
+		t.Errorf("content = %q, want it to end %q", page.Details.Content, want)
+	}
+}
+
+func TestTailBudget_Errors(t *testing.T) {
+	env := newCLIEnv(t)
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--tail-lines", "-1"}, "--tail-lines must not be negative"},
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--max-bytes", "lots"}, "--max-bytes"},
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--tail-lines", "5", "--stream"}, "cannot be combined with --stream"},
+		{[]string{"page", "new", "--project", apitest.DefaultProjectID, "--max-bytes", "1MB", "--url", "https://example.com"}, "cannot be combined with --url"},
+		{[]string{"page", "append", "page_x", "--tail-lines", "5", "--max-lines", "2"}, "--tail-lines cannot be combined with --max-lines"},
+		{[]string{"page", "append", "page_x", "--tail-lines", "5", "--keep", "head"}, "--tail-lines cannot be combined with --keep head"},
+		{[]string{"run", "--project", apitest.DefaultProjectID, "--tail-lines", "5", "--split-streams", "--", "true"}, "cannot be combined with --stream or --split-streams"},
+	} {
+		if _, _, err := env.run(tt.args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/schedule"
+)
+
+// uploadGate paces the appends of long-running captures (mux, capture
+// --follow) by the config's quiet_hours and upload_rate, so background
+// capture on a laptop stays out of the way of video calls and metered
+// connections.
+type uploadGate struct {
+	quiet  []schedule.Window
+	budget *schedule.Budget
+	now    func() time.Time
+}
+
+// newUploadGate reads the schedule from the config. interval is how often
+// the caller appends; the rate budget saves up at most that long.
+func newUploadGate(interval time.Duration) (*uploadGate, error) {
+	quiet, err := cfg.GetQuietHours()
+	if err != nil {
+		return nil, err
+	}
+	rate, err := cfg.GetUploadRate()
+	if err != nil {
+		return nil, err
+	}
+	return &uploadGate{quiet: quiet, budget: schedule.NewBudget(rate, interval), now: time.Now}, nil
+}
+
+// ready returns how many leading lines of batch may be appended now, with
+// format rendering lines as they will be sent: none during quiet hours, and
+// as many as fit the rate budget, but at least one once it has any room. A
+// batch held back so long that it no longer fits on a page holding current
+// bytes is released whole, so the caller's quota check stops the run
+// instead of the batch growing without bound.
+func (g *uploadGate) ready(batch []muxLine, current int64, format func([]muxLine) string) int {
+	if current+int64(len(format(batch))) > api.MaxPageBytes {
+		return len(batch)
+	}
+	now := g.now()
+	if schedule.Quiet(g.quiet, now) {
+		return 0
+	}
+	avail := g.budget.Available(now)
+	if avail == 0 {
+		return 0
+	}
+	n, size := 0, 0
+	for n < len(batch) {
+		size += len(format(batch[n : n+1]))
+		if size > avail && n > 0 {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// spent records n bytes appended.
+func (g *uploadGate) spent(n int) {
+	g.budget.Spend(n)
+}
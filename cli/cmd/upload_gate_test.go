@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestUploadGate_Ready(t *testing.T) {
+	cfg = &config.Config{QuietHours: "09:00-17:30", UploadRate: "10/s"}
+	gate, err := newUploadGate(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.Local)
+	gate.now = func() time.Time { return now }
+	batch := []muxLine{{Text: "aaaa"}, {Text: "bbbb"}, {Text: "cccccccccccc"}}
+
+	if n := gate.ready(batch, 0, formatCapturedLines); n != 0 {
+		t.Errorf("ready() = %d during quiet hours, want 0", n)
+	}
+	if n := gate.ready(batch, api.MaxPageBytes-10, formatCapturedLines); n != 3 {
+		t.Errorf("ready() = %d for a batch that no longer fits the page, want all of it", n)
+	}
+
+	now = now.Add(8 * time.Hour)
+	if n := gate.ready(batch, 0, formatCapturedLines); n != 2 {
+		t.Fatalf("ready() = %d, want the 2 lines that fit 10 bytes", n)
+	}
+	gate.spent(10)
+	if n := gate.ready(batch[2:], 0, formatCapturedLines); n != 0 {
+		t.Errorf("ready() = %d with the budget spent, want 0", n)
+	}
+	now = now.Add(100 * time.Millisecond)
+	if n := gate.ready(batch[2:], 0, formatCapturedLines); n != 1 {
+		t.Errorf("ready() = %d, want the long line once the budget has room", n)
+	}
+
+	cfg = &config.Config{UploadRate: "lots"}
+	if _, err := newUploadGate(time.Second); err == nil {
+		t.Error("invalid upload_rate accepted")
+	}
+}
+
+func TestRunMux_GateHoldsLinesUntilTheEnd(t *testing.T) {
+	r, w := io.Pipe()
+	inputs := []muxInput{{Name: "app", Reader: r}}
+	var gated atomic.Int32
+	hold := func([]muxLine) int {
+		gated.Add(1)
+		return 0
+	}
+	var sent [][]muxLine
+	done := make(chan error)
+	go func() {
+		done <- runMux(context.Background(), inputs, 5*time.Millisecond, hold, func(batch []muxLine) error {
+			sent = append(sent, batch)
+			return nil
+		})
+	}()
+
+	for i := range 3 {
+		fmt.Fprintf(w, "line %d\n", i)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for gated.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	_ = w.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gated.Load() < 3 || len(sent) != 1 || len(sent[0]) != 3 {
+		t.Errorf("gated %d times, sent %v; want every line held, then sent once at the end", gated.Load(), sent)
+	}
+}
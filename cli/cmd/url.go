@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var urlCmd = &cobra.Command{
+	Use:   "url <page-or-project-id>",
+	Short: "Print the web URL of a page or project",
+	Long: `Print the canonical web URL of a page or project, and nothing else, for
+scripts and chat messages.
+
+The web app opens a project through its pages, so a project's URL is that
+of its most recently updated page.
+
+Links use the web_url config setting or --workspace-url when set, and are
+otherwise derived from the API URL.
+
+Examples:
+  hyperclast url page_xyz789
+  open "$(hyperclast url proj_abc123)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuth(); err != nil {
+			return err
+		}
+
+		id := args[0]
+		client := newClient()
+		kind, target := "page", id
+		if _, pageErr := client.GetPageMetadata(id); pageErr != nil {
+			project, err := client.GetProject(id)
+			if err != nil {
+				return fmt.Errorf("no page or project %s: %w", id, pageErr)
+			}
+			var latest *api.Page
+			for i, p := range project.Pages {
+				if latest == nil || pageUpdated(&p) > pageUpdated(latest) {
+					latest = &project.Pages[i]
+				}
+			}
+			if latest == nil {
+				return fmt.Errorf("project %s has no pages to link to", id)
+			}
+			kind, target = "project", latest.ExternalID
+		}
+
+		link := pageURL(target)
+		if outputFmt == "json" {
+			return json.NewEncoder(os.Stdout).Encode(map[string]string{
+				"id":   id,
+				"type": kind,
+				"url":  link,
+			})
+		}
+		fmt.Println(link)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(urlCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/config"
+)
+
+func TestURL_PageAndProject(t *testing.T) {
+	resetPageFlags()
+	defer resetPageFlags()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pages/page_xyz/":
+			_ = json.NewEncoder(w).Encode(api.Page{ExternalID: "page_xyz"})
+		case "/projects/proj_abc/":
+			_ = json.NewEncoder(w).Encode(api.Project{ExternalID: "proj_abc", Pages: []api.Page{
+				{ExternalID: "page_old", Updated: "2025-01-01T00:00:00Z"},
+				{ExternalID: "page_new", Updated: "2025-06-01T00:00:00Z"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	cfg = &config.Config{APIURL: server.URL, WebURL: "https://notes.example.com/", Token: "test-token"}
+
+	for id, want := range map[string]string{
+		"page_xyz": "https://notes.example.com/pages/page_xyz/\n",
+		"proj_abc": "https://notes.example.com/pages/page_new/\n",
+	} {
+		got := captureArchiveOutput(t, func() error { return urlCmd.RunE(urlCmd, []string{id}) })
+		if got != want {
+			t.Errorf("url %s = %q, want %q", id, got, want)
+		}
+	}
+
+	err := urlCmd.RunE(urlCmd, []string{"nope"})
+	if err == nil || !strings.Contains(err.Error(), "no page or project") {
+		t.Errorf("unknown ID: err = %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/htmlmd"
+)
+
+var (
+	pageFromURL    string
+	pageToMarkdown bool
+)
+
+// urlSource describes content fetched from a URL.
+type urlSource struct {
+	// URL is where the content was fetched from, after any redirects.
+	URL         string
+	ContentType string
+	// Converted is set when HTML was converted to Markdown.
+	Converted bool
+	// Title is the document's <title>, else the last element of its path.
+	Title string
+	// Filetype is implied by the content type or extension, "" if neither
+	// says.
+	Filetype string
+}
+
+func (s urlSource) metadataLine() string {
+	line := fmt.Sprintf("URL: %s", s.URL)
+	switch {
+	case s.Converted:
+		line += " (HTML converted to Markdown)"
+	case s.ContentType != "":
+		line += fmt.Sprintf(" (%s)", s.ContentType)
+	}
+	return line
+}
+
+// readURL fetches raw, an http or https URL, and returns its content. With
+// toMarkdown, an HTML response is converted to Markdown.
+func readURL(raw string, toMarkdown bool) (string, urlSource, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", urlSource{}, fmt.Errorf("invalid --url %q (expected an http or https URL)", raw)
+	}
+
+	timeout := requestTimeout
+	if timeout <= 0 {
+		timeout = api.DefaultTimeout
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", urlSource{}, fmt.Errorf("invalid --url %q: %w", raw, err)
+	}
+	req.Header.Set("User-Agent", "hyperclast-cli/"+api.ClientVersion)
+
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return "", urlSource{}, fmt.Errorf("failed to fetch %s: %w", raw, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", urlSource{}, fmt.Errorf("failed to fetch %s: %s", raw, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxContentSize+1))
+	if err != nil {
+		return "", urlSource{}, fmt.Errorf("failed to fetch %s: %w", raw, err)
+	}
+	if int64(len(body)) > maxContentSize {
+		return "", urlSource{}, fmt.Errorf("content too large (more than %d bytes)", maxContentSize)
+	}
+	if err := validateTextContent(body); err != nil {
+		return "", urlSource{}, err
+	}
+	content := normalizeNewlines(string(body))
+
+	final := resp.Request.URL
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	src := urlSource{
+		URL:         final.String(),
+		ContentType: mediaType,
+		Title:       path.Base(strings.TrimSuffix(final.Path, "/")),
+	}
+	if src.Title == "." || src.Title == "/" {
+		src.Title = final.Host
+	}
+
+	switch ext := strings.ToLower(path.Ext(final.Path)); {
+	case mediaType == "text/markdown" || ext == ".md" || ext == ".markdown":
+		src.Filetype = "md"
+	case mediaType == "text/csv" || ext == ".csv":
+		src.Filetype = "csv"
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		title, markdown := htmlmd.Convert(content, final)
+		if title != "" {
+			src.Title = title
+		}
+		if toMarkdown {
+			content = markdown
+			src.Converted = true
+			src.Filetype = "md"
+		}
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return "", urlSource{}, fmt.Errorf("no content at %s", raw)
+	}
+	return content, src, nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/apitest"
+)
+
+// newDocServer serves a Markdown runbook, an HTML doc, and a 404.
+func newDocServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/runbook.md", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# Runbook\n\nRestart the api.\n"))
+	})
+	mux.HandleFunc("/docs/deploy", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><title>Deploying</title></head>
+<body><h1>Deploy</h1><p>See <a href="/runbook.md">the runbook</a>.</p></body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPageNew_FromURL(t *testing.T) {
+	env := newCLIEnv(t)
+	docs := newDocServer(t)
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--url", docs.URL+"/runbook.md", "--quiet", "--project", apitest.DefaultProjectID))
+	page, ok := env.server.Page(id)
+	if !ok {
+		t.Fatalf("page %q not created", id)
+	}
+	if page.Title != "runbook.md" || page.Details.Filetype != "md" {
+		t.Errorf("title, filetype = %q, %q; want the file name and md", page.Title, page.Details.Filetype)
+	}
+	content := page.Details.Content
+	if !strings.HasPrefix(content, "# Runbook\n\nRestart the api.\n") {
+		t.Errorf("content = %q, want the fetched file", content)
+	}
+	if !strings.Contains(content, "URL: "+docs.URL+"/runbook.md") {
+		t.Errorf("content = %q, want the URL in the metadata", content)
+	}
+}
+
+func TestPageNew_FromURLToMarkdown(t *testing.T) {
+	env := newCLIEnv(t)
+	docs := newDocServer(t)
+
+	id := strings.TrimSpace(env.mustRun("page", "new", "--url", docs.URL+"/docs/deploy", "--to-markdown", "--quiet", "--project", apitest.DefaultProjectID))
+	page, _ := env.server.Page(id)
+	if page.Title != "Deploying" || page.Details.Filetype != "md" {
+		t.Errorf("title, filetype = %q, %q; want the HTML title and md", page.Title, page.Details.Filetype)
+	}
+	want := "# Deploy\n\nSee [the runbook](" + docs.URL + "/runbook.md).\n"
+	if !strings.HasPrefix(page.Details.Content, want) {
+		t.Errorf("content = %q, want it to start with %q", page.Details.Content, want)
+	}
+	if !strings.Contains(page.Details.Content, "(HTML converted to Markdown)") {
+		t.Errorf("content = %q, want the conversion noted in the metadata", page.Details.Content)
+	}
+}
+
+func TestPageNew_FromURLErrors(t *testing.T) {
+	env := newCLIEnv(t)
+	docs := newDocServer(t)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--url", "ftp://example.com/a.md"}, "expected an http or https URL"},
+		{[]string{"--url", docs.URL + "/missing"}, "404 Not Found"},
+		{[]string{"--url", docs.URL + "/runbook.md", "--file", "notes.txt"}, "--url and --file cannot be used together"},
+		{[]string{"--to-markdown"}, "--to-markdown requires --url"},
+	}
+	for _, tt := range tests {
+		_, _, err := env.run(append([]string{"page", "new", "--project", apitest.DefaultProjectID}, tt.args...)...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("page new %s = %v, want %q", strings.Join(tt.args, " "), err, tt.want)
+		}
+	}
+}
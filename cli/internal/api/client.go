@@ -2,12 +2,18 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
+	"mime"
 	"net/http"
 	"net/url"
 	"runtime"
+	"slices"
+	"strconv"
 	"time"
 )
 
@@ -19,48 +25,231 @@ func SetVersion(v string) {
 	ClientVersion = v
 }
 
+// RequestRecord describes a completed API request for usage accounting.
+type RequestRecord struct {
+	Method    string
+	Path      string
+	Status    int
+	BytesSent int64
+}
+
+var requestObserver func(RequestRecord)
+
+// SetRequestObserver registers fn to be called after every API request.
+// Status is 0 when the request failed before a response was received.
+func SetRequestObserver(fn func(RequestRecord)) {
+	requestObserver = fn
+}
+
 func buildClientHeader() string {
 	return fmt.Sprintf("client=%s; version=%s; os=%s; arch=%s",
 		ClientName, ClientVersion, runtime.GOOS, runtime.GOARCH)
 }
 
+const DefaultTimeout = 30 * time.Second
+
+// ClientOptions tunes request behavior. Zero values use the defaults.
+type ClientOptions struct {
+	// Timeout bounds each request attempt. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Retries is how many times a failed request is retried. GET and DELETE
+	// are retried on network errors, 429, and 5xx responses; other methods
+	// only on 429, since the server rejected them without acting.
+	Retries int
+	// VerifyConnection, if set, runs after normal TLS certificate
+	// verification and can reject the connection (e.g. certificate pinning).
+	VerifyConnection func(tls.ConnectionState) error
+	// RateLimiter, if set, paces every request attempt. Share one limiter
+	// between clients to pace them together.
+	RateLimiter *RateLimiter
+	// OnRetry, if set, is called before each retry of a request.
+	OnRetry func(method, path string)
+	// Context, if set, is attached to every request. Cancelling it aborts
+	// the request in flight and any wait before a retry.
+	Context context.Context
+	// OnUpload, if set, is called as each request body is sent, with the
+	// bytes sent so far (starting at 0) and the body's size.
+	OnUpload func(method, path string, sent, total int64)
+}
+
 type Client struct {
 	baseURL    string
 	token      string
 	httpClient *http.Client
+	retries    int
+	limiter    *RateLimiter
+	onRetry    func(method, path string)
+	ctx        context.Context
+	onUpload   func(method, path string, sent, total int64)
 }
 
 func NewClient(baseURL, token string) *Client {
+	return NewClientWithOptions(baseURL, token, ClientOptions{})
+}
+
+func NewClientWithOptions(baseURL, token string, opts ClientOptions) *Client {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	httpClient := &http.Client{
+		Timeout: timeout,
+	}
+	if opts.VerifyConnection != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{VerifyConnection: opts.VerifyConnection}
+		httpClient.Transport = transport
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	return &Client{
-		baseURL: baseURL,
-		token:   token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: httpClient,
+		retries:    max(opts.Retries, 0),
+		limiter:    opts.RateLimiter,
+		onRetry:    opts.OnRetry,
+		ctx:        ctx,
+		onUpload:   opts.OnUpload,
 	}
 }
 
+// retryBaseDelay is the backoff before the first retry; it doubles for each
+// subsequent attempt. A variable so tests can shorten it.
+var retryBaseDelay = 500 * time.Millisecond
+
+// maxRetryAfter caps how long a Retry-After header can make us wait.
+const maxRetryAfter = 30 * time.Second
+
 func (c *Client) doRequest(method, path string, body any) (*http.Response, error) {
-	var reqBody io.Reader
+	return c.doRequestAccept(method, path, body, "application/json")
+}
+
+// doRequestAccept is doRequest asking for a response of type accept.
+func (c *Client) doRequestAccept(method, path string, body any, accept string) (*http.Response, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewReader(jsonBody)
+	}
+	return c.doRequestRaw(method, path, jsonBody, "application/json", accept, nil)
+}
+
+// doRequestRaw sends body, of type contentType, with any extra header,
+// retrying as configured.
+func (c *Client) doRequestRaw(method, path string, body []byte, contentType, accept string, header http.Header) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			c.limiter.Wait()
+		}
+		resp, err := c.send(method, path, body, contentType, accept, header)
+		if attempt >= c.retries || !shouldRetry(method, resp, err) {
+			return resp, err
+		}
+
+		delay := retryBaseDelay << attempt
+		if resp != nil {
+			if secs, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && secs >= 0 {
+				delay = min(time.Duration(secs)*time.Second, maxRetryAfter)
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+			_ = resp.Body.Close()
+		}
+		if c.onRetry != nil {
+			c.onRetry(method, path)
+		}
+		select {
+		case <-time.After(delay):
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		}
+	}
+}
+
+func (c *Client) send(method, path string, body []byte, contentType, accept string, header http.Header) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+		if c.onUpload != nil {
+			reqBody = newUploadReader(body, func(sent int64) {
+				c.onUpload(method, path, sent, int64(len(body)))
+			})
+		}
 	}
 
-	req, err := http.NewRequest(method, c.baseURL+path, reqBody)
+	req, err := http.NewRequestWithContext(c.ctx, method, c.baseURL+path, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if body != nil {
+		// The upload reader hides the length, which would otherwise make
+		// the request chunked.
+		req.ContentLength = int64(len(body))
+	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", accept)
 	req.Header.Set("X-Hyperclast-Client", buildClientHeader())
+	for name, values := range header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if requestObserver != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		requestObserver(RequestRecord{
+			Method:    method,
+			Path:      path,
+			Status:    status,
+			BytesSent: int64(len(body)),
+		})
+	}
+	return resp, err
+}
+
+// uploadReader reports how much of a request body has been read by the
+// transport.
+type uploadReader struct {
+	r      *bytes.Reader
+	sent   int64
+	report func(sent int64)
+}
 
-	return c.httpClient.Do(req)
+func newUploadReader(body []byte, report func(sent int64)) *uploadReader {
+	report(0)
+	return &uploadReader{r: bytes.NewReader(body), report: report}
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	if n > 0 {
+		u.sent += int64(n)
+		u.report(u.sent)
+	}
+	return n, err
+}
+
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if method != http.MethodGet && method != http.MethodDelete {
+		return false
+	}
+	return err != nil || (resp != nil && resp.StatusCode >= 500)
 }
 
 // maxErrorBodySize limits how much of an error response body we read into memory.
@@ -71,6 +260,12 @@ func (c *Client) do(method, path string, body any, result any) error {
 	if err != nil {
 		return err
 	}
+	return decodeResponse(resp, result)
+}
+
+// decodeResponse closes resp after decoding its JSON body into result, if
+// set, or turning an error status into an error.
+func decodeResponse(resp *http.Response, result any) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == http.StatusUnauthorized {
@@ -107,6 +302,60 @@ func (c *Client) Delete(path string) error {
 	return c.do(http.MethodDelete, path, nil, nil)
 }
 
+// SetPageDetails merges fields into the page's details without touching its
+// content. A nil value removes the field.
+func (c *Client) SetPageDetails(pageID string, fields map[string]any) (*Page, error) {
+	existingPage, err := c.GetPage(pageID)
+	if err != nil {
+		return nil, err
+	}
+	if !existingPage.CanEdit() {
+		return nil, &PermissionError{Action: "update page details", Role: existingPage.Role, Needs: "editor"}
+	}
+
+	req := struct {
+		Title   string         `json:"title"`
+		Details map[string]any `json:"details"`
+	}{
+		Title:   existingPage.Title,
+		Details: fields,
+	}
+
+	var page Page
+	if err := c.Put(fmt.Sprintf("/pages/%s/", pageID), req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// RenamePage changes a page's title, leaving its details alone. The server
+// allows only the page's creator to do this.
+func (c *Client) RenamePage(pageID, title string) (*Page, error) {
+	req := struct {
+		Title string `json:"title"`
+	}{Title: title}
+
+	var page Page
+	if err := c.Put(fmt.Sprintf("/pages/%s/", pageID), req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// MovePage moves a page to another project in its organization, keeping
+// its title. Only the page's creator may. Check the returned page's
+// ProjectID: a server from before project moves ignores the project and
+// returns the page where it was.
+func (c *Client) MovePage(pageID, title, projectID string) (*Page, error) {
+	req := UpdatePageContentRequest{Title: title, ProjectID: projectID}
+
+	var page Page
+	if err := c.Put(fmt.Sprintf("/pages/%s/", pageID), req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
 func (c *Client) DeletePage(pageID string) error {
 	return c.Delete(fmt.Sprintf("/pages/%s/", pageID))
 }
@@ -117,11 +366,40 @@ type User struct {
 	AccessToken string `json:"access_token"`
 }
 
+// AccessToken is a user-managed API token. Tokens created by device login
+// are system-managed and not listed.
+type AccessToken struct {
+	ExternalID string `json:"external_id"`
+	Value      string `json:"value"`
+	Label      string `json:"label"`
+	IsDefault  bool   `json:"is_default"`
+	IsActive   bool   `json:"is_active"`
+	Created    string `json:"created"`
+}
+
 type Org struct {
 	ExternalID string `json:"external_id"`
 	Name       string `json:"name"`
 	Domain     string `json:"domain"`
 	IsPro      bool   `json:"is_pro"`
+	// Role is the current user's role in the org. The server does not
+	// report it yet; see OrgRole.
+	Role string `json:"role,omitempty"`
+}
+
+// Org membership roles, as in OrgMember.Role.
+const (
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+// OrgMember is a user's membership in an org.
+type OrgMember struct {
+	ExternalID string `json:"external_id"`
+	Email      string `json:"email"`
+	Username   string `json:"username"`
+	Role       string `json:"role"`
+	Created    string `json:"created"`
 }
 
 type Creator struct {
@@ -130,33 +408,183 @@ type Creator struct {
 }
 
 type Project struct {
-	ExternalID  string  `json:"external_id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Version     string  `json:"version"`
-	Modified    string  `json:"modified"`
-	Created     string  `json:"created"`
-	Creator     Creator `json:"creator"`
-	Org         Org     `json:"org"`
-	Pages       []Page  `json:"pages,omitempty"`
+	ExternalID  string   `json:"external_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Version     string   `json:"version"`
+	Modified    string   `json:"modified"`
+	Created     string   `json:"created"`
+	Creator     Creator  `json:"creator"`
+	Org         Org      `json:"org"`
+	Pages       []Page   `json:"pages,omitempty"`
+	Folders     []Folder `json:"folders,omitempty"`
+}
+
+// Folder groups pages within a project. ParentID is empty for top-level
+// folders.
+type Folder struct {
+	ExternalID string `json:"external_id"`
+	ParentID   string `json:"parent_id,omitempty"`
+	Name       string `json:"name"`
+}
+
+// ColumnSchema is a typed column hint for CSV pages. Type is one of
+// "number", "date", "url", or "string".
+type ColumnSchema struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type TableSchema struct {
+	Columns []ColumnSchema `json:"columns"`
 }
 
 type PageDetails struct {
-	Content       string `json:"content,omitempty"`
-	Filetype      string `json:"filetype,omitempty"`
-	SchemaVersion int    `json:"schema_version,omitempty"`
+	Content       string       `json:"content,omitempty"`
+	Filetype      string       `json:"filetype,omitempty"`
+	SchemaVersion int          `json:"schema_version,omitempty"`
+	Schema        *TableSchema `json:"schema,omitempty"`
+	Status        string       `json:"status,omitempty"`
+	Icon          string       `json:"icon,omitempty"`
+	Labels        []string     `json:"labels,omitempty"`
+	// Related holds the IDs of pages linked to this one.
+	Related []string `json:"related,omitempty"`
+	// ContentSize is the content length in bytes, reported in place of
+	// Content by servers that honor GetPageMetadata's query parameter.
+	ContentSize int64 `json:"content_size,omitempty"`
+	// Writes attributes recent CLI writes, oldest first. Each write sends
+	// the whole list and the server replaces it, so concurrent writers can
+	// lose each other's entries: attribution is best-effort.
+	Writes []PageWrite `json:"writes,omitempty"`
+	// Lock is set while a user holds the page against writes by others.
+	Lock *PageLock `json:"lock,omitempty"`
+	// ExpiresAt is when the page is due for deletion, in RFC 3339. Servers
+	// that do not expire pages keep it for 'hyperclast page gc'.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// ShareExpiresAt is when the page's access code is due to be removed,
+	// in RFC 3339. The server does not expire access codes, so
+	// 'hyperclast page gc' removes them.
+	ShareExpiresAt string `json:"share_expires_at,omitempty"`
+	// ContentHash is "sha256:" and the hex SHA-256 of the content the page
+	// was created with, before any metadata backmatter, so duplicates can
+	// be found from a listing without fetching each page.
+	ContentHash string `json:"content_hash,omitempty"`
+}
+
+// PageLock records who locked a page, so other users' writes can be
+// refused instead of overwriting a page someone is working on.
+type PageLock struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	At     string `json:"at"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// PageWrite records who a write was made for, so pages shared by several
+// pipelines behind one token stay attributable.
+type PageWrite struct {
+	OnBehalfOf string `json:"on_behalf_of"`
+	Mode       string `json:"mode"`
+	At         string `json:"at"`
+	Bytes      int    `json:"bytes"`
+}
+
+// MaxPageWrites bounds details.writes; older entries are dropped.
+const MaxPageWrites = 100
+
+// AddPageWrite appends w to writes, dropping the oldest entries beyond
+// MaxPageWrites.
+func AddPageWrite(writes []PageWrite, w PageWrite) []PageWrite {
+	out := append(slices.Clone(writes), w)
+	if len(out) > MaxPageWrites {
+		out = out[len(out)-MaxPageWrites:]
+	}
+	return out
 }
 
 type Page struct {
-	ExternalID string       `json:"external_id"`
-	Title      string       `json:"title"`
-	Filetype   string       `json:"filetype,omitempty"`
-	Updated    string       `json:"updated,omitempty"`
-	Modified   string       `json:"modified,omitempty"`
-	Created    string       `json:"created,omitempty"`
+	ExternalID string `json:"external_id"`
+	Title      string `json:"title"`
+	Filetype   string `json:"filetype,omitempty"`
+	Updated    string `json:"updated,omitempty"`
+	Modified   string `json:"modified,omitempty"`
+	Created    string `json:"created,omitempty"`
+	Role       string `json:"role,omitempty"`
+	FolderID   string `json:"folder_id,omitempty"`
+	ProjectID  string `json:"project_external_id,omitempty"`
+	OrgID      string `json:"org_external_id,omitempty"`
+	// AccessCode is the page's read-only access code, if it is shared.
+	AccessCode string       `json:"access_code,omitempty"`
 	Details    *PageDetails `json:"details,omitempty"`
 }
 
+// Access levels reported in Page.Role for the current user.
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// Version identifies the page as it was read, from its modified and updated
+// times, which every write to it changes. It is empty for a page read
+// without them.
+func (p *Page) Version() string {
+	if p.Modified == "" && p.Updated == "" {
+		return ""
+	}
+	return p.Modified + "," + p.Updated
+}
+
+// CanEdit reports whether the current user may change the page's content.
+// An unknown role is assumed to allow it and left to the server to decide.
+func (p *Page) CanEdit() bool {
+	return p.Role == "" || p.Role == RoleEditor || p.Role == RoleAdmin
+}
+
+// CanDelete reports whether the current user may delete the page. Only the
+// page's creator can, and creators always have the admin role.
+func (p *Page) CanDelete() bool {
+	return p.Role == "" || p.Role == RoleAdmin
+}
+
+// PermissionError is returned when a pre-flight check shows an operation
+// would be rejected by the server for lack of access.
+type PermissionError struct {
+	Action string
+	Role   string
+	Needs  string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("cannot %s: you have %s access to this page (requires %s)", e.Action, e.Role, e.Needs)
+}
+
+// LockedError is returned when a write is refused because another user
+// holds the page's lock.
+type LockedError struct {
+	PageID string
+	Lock   PageLock
+}
+
+func (e *LockedError) Error() string {
+	msg := fmt.Sprintf("page %s is locked by %s", e.PageID, e.Lock.Email)
+	if e.Lock.Reason != "" {
+		msg += fmt.Sprintf(" (%s)", e.Lock.Reason)
+	}
+	return msg
+}
+
+// ConflictError is returned when a write is refused because the page's
+// Version is no longer the one the caller read, so the write would have
+// discarded someone else's change.
+type ConflictError struct {
+	PageID string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("page %s was changed by someone else since it was read", e.PageID)
+}
+
 type CreatePageRequest struct {
 	ProjectID string       `json:"project_id"`
 	Title     string       `json:"title"`
@@ -164,9 +592,10 @@ type CreatePageRequest struct {
 }
 
 type UpdatePageContentRequest struct {
-	Title   string       `json:"title"`
-	Details *PageDetails `json:"details,omitempty"`
-	Mode    string       `json:"mode"`
+	Title     string       `json:"title"`
+	ProjectID string       `json:"project_id,omitempty"`
+	Details   *PageDetails `json:"details,omitempty"`
+	Mode      string       `json:"mode,omitempty"`
 }
 
 func (c *Client) GetCurrentUser() (*User, error) {
@@ -177,6 +606,14 @@ func (c *Client) GetCurrentUser() (*User, error) {
 	return &user, nil
 }
 
+func (c *Client) ListAccessTokens() ([]AccessToken, error) {
+	var tokens []AccessToken
+	if err := c.Get("/users/me/tokens/", &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
 func (c *Client) ListOrgs() ([]Org, error) {
 	var orgs []Org
 	if err := c.Get("/orgs/", &orgs); err != nil {
@@ -185,6 +622,38 @@ func (c *Client) ListOrgs() ([]Org, error) {
 	return orgs, nil
 }
 
+func (c *Client) ListOrgMembers(orgID string) ([]OrgMember, error) {
+	var members []OrgMember
+	if err := c.Get(fmt.Sprintf("/orgs/%s/members/", orgID), &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// OrgRole returns the role of the user with external ID userID in org,
+// looking it up in the member list when the org does not carry it.
+func (c *Client) OrgRole(org Org, userID string) (string, error) {
+	if org.Role != "" {
+		return org.Role, nil
+	}
+	members, err := c.ListOrgMembers(org.ExternalID)
+	if err != nil {
+		return "", err
+	}
+	for _, m := range members {
+		if m.ExternalID == userID {
+			return m.Role, nil
+		}
+	}
+	return "", fmt.Errorf("not a member of organization %s", org.ExternalID)
+}
+
+// RemoveOrgMember removes a user from an org; removing yourself leaves it.
+// The server refuses to remove an org's only admin.
+func (c *Client) RemoveOrgMember(orgID, userID string) error {
+	return c.Delete(fmt.Sprintf("/orgs/%s/members/%s/", orgID, userID))
+}
+
 func (c *Client) ListProjects(orgID string) ([]Project, error) {
 	path := "/projects/"
 	if orgID != "" {
@@ -225,42 +694,200 @@ func (c *Client) CreateProject(orgID, name, description string) (*Project, error
 	return &project, nil
 }
 
+type CreateFolderRequest struct {
+	Name     string  `json:"name"`
+	ParentID *string `json:"parent_id"`
+}
+
+// CreateFolder creates a top-level folder in a project.
+func (c *Client) CreateFolder(projectID, name string) (*Folder, error) {
+	var folder Folder
+	if err := c.Post(fmt.Sprintf("/projects/%s/folders/", projectID), CreateFolderRequest{Name: name}, &folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+type MovePagesRequest struct {
+	PageIDs  []string `json:"page_ids"`
+	FolderID *string  `json:"folder_id"`
+}
+
+// MovePages moves pages of a project into a folder, or to the project root
+// when folderID is empty. Unlike changing folder_id through a page update,
+// this only requires editor access to the project.
+func (c *Client) MovePages(projectID string, pageIDs []string, folderID string) error {
+	req := MovePagesRequest{PageIDs: pageIDs}
+	if folderID != "" {
+		req.FolderID = &folderID
+	}
+	return c.Post(fmt.Sprintf("/projects/%s/folders/move-pages/", projectID), req, nil)
+}
+
+// DefaultPageBatch is how many pages AllPages requests at a time by
+// default, the server's own page size.
+const DefaultPageBatch = 100
+
+// PageList is one window of a page listing: Items, out of Count pages in
+// the whole listing.
+type PageList struct {
+	Items []Page `json:"items"`
+	Count int    `json:"count"`
+}
+
+// ListPages returns every page of a project, or every page the user can
+// access, most recently updated first, when projectID is empty.
 func (c *Client) ListPages(projectID string) ([]Page, error) {
+	pages := []Page{}
+	for page, err := range c.AllPages(projectID, DefaultPageBatch) {
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// ListPagesRange returns up to limit pages of a listing like ListPages,
+// skipping the first offset. A project's pages come in a single response,
+// so its listings are windowed client-side.
+func (c *Client) ListPagesRange(projectID string, limit, offset int) (*PageList, error) {
 	if projectID != "" {
 		project, err := c.GetProject(projectID)
 		if err != nil {
 			return nil, err
 		}
-		return project.Pages, nil
+		pages := project.Pages
+		start, end := min(offset, len(pages)), min(offset+limit, len(pages))
+		return &PageList{Items: pages[start:end], Count: len(pages)}, nil
 	}
 
+	params := url.Values{"limit": {strconv.Itoa(limit)}, "offset": {strconv.Itoa(offset)}}
+	var result PageList
+	if err := c.Get("/pages/?"+params.Encode(), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AllPages iterates over a listing like ListPages, requesting batch pages
+// at a time. Pages that move between requests, because they were updated
+// meanwhile, are yielded once. Iteration stops at the first error, which
+// is yielded with a zero Page.
+func (c *Client) AllPages(projectID string, batch int) iter.Seq2[Page, error] {
+	if batch <= 0 {
+		batch = DefaultPageBatch
+	}
+	return func(yield func(Page, error) bool) {
+		// A project's pages come in one response, however large the batch.
+		if projectID != "" {
+			project, err := c.GetProject(projectID)
+			if err != nil {
+				yield(Page{}, err)
+				return
+			}
+			for _, page := range project.Pages {
+				if !yield(page, nil) {
+					return
+				}
+			}
+			return
+		}
+
+		seen := make(map[string]bool)
+		for offset := 0; ; offset += batch {
+			list, err := c.ListPagesRange("", batch, offset)
+			if err != nil {
+				yield(Page{}, err)
+				return
+			}
+			for _, page := range list.Items {
+				if seen[page.ExternalID] {
+					continue
+				}
+				seen[page.ExternalID] = true
+				if !yield(page, nil) {
+					return
+				}
+			}
+			if len(list.Items) < batch || offset+batch >= list.Count {
+				return
+			}
+		}
+	}
+}
+
+// SearchPages returns up to 10 pages whose titles contain query, most
+// recently updated first. The server does not search page content.
+func (c *Client) SearchPages(query, orgID string) ([]Page, error) {
+	params := url.Values{"q": {query}}
+	if orgID != "" {
+		params.Set("org_id", orgID)
+	}
 	var result struct {
-		Items []Page `json:"items"`
+		Pages []Page `json:"pages"`
 	}
-	if err := c.Get("/pages/", &result); err != nil {
+	if err := c.Get("/pages/autocomplete/?"+params.Encode(), &result); err != nil {
 		return nil, err
 	}
-	return result.Items, nil
+	return result.Pages, nil
 }
 
 func (c *Client) GetPage(pageID string) (*Page, error) {
+	return c.getPage(fmt.Sprintf("/pages/%s/", pageID))
+}
+
+// GetPageMetadata fetches a page with ?omit=content, so the server reports
+// the content's size in Details.ContentSize instead of sending it. Servers
+// that predate the parameter send the whole page, so callers should not rely
+// on Details.Content being empty.
+func (c *Client) GetPageMetadata(pageID string) (*Page, error) {
+	return c.getPage(fmt.Sprintf("/pages/%s/?omit=content", pageID))
+}
+
+func (c *Client) getPage(path string) (*Page, error) {
 	var page Page
-	if err := c.Get(fmt.Sprintf("/pages/%s/", pageID), &page); err != nil {
+	if err := c.Get(path, &page); err != nil {
 		return nil, err
 	}
 	return &page, nil
 }
 
+// CountRevisions returns how many revisions (rewinds) a page has. It
+// fails if the server has revision history disabled.
+func (c *Client) CountRevisions(pageID string) (int, error) {
+	var result struct {
+		Count int `json:"count"`
+	}
+	if err := c.Get(fmt.Sprintf("/pages/%s/rewind/?limit=1", pageID), &result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
 func (c *Client) CreatePage(projectID, title, content, filetype string) (*Page, error) {
-	req := CreatePageRequest{
+	return c.CreatePageWithDetails(projectID, title, &PageDetails{
+		Content:  content,
+		Filetype: filetype,
+	})
+}
+
+// NewCreatePageRequest builds the body CreatePageWithDetails sends.
+func NewCreatePageRequest(projectID, title string, details *PageDetails) CreatePageRequest {
+	if details.SchemaVersion == 0 {
+		details.SchemaVersion = 1
+	}
+	return CreatePageRequest{
 		ProjectID: projectID,
 		Title:     title,
-		Details: &PageDetails{
-			Content:       content,
-			Filetype:      filetype,
-			SchemaVersion: 1,
-		},
+		Details:   details,
 	}
+}
+
+// CreatePageWithDetails creates a page with caller-supplied details, such as
+// a table schema. SchemaVersion defaults to 1 when unset.
+func (c *Client) CreatePageWithDetails(projectID, title string, details *PageDetails) (*Page, error) {
+	req := NewCreatePageRequest(projectID, title, details)
 
 	var page Page
 	if err := c.Post("/pages/", req, &page); err != nil {
@@ -269,11 +896,40 @@ func (c *Client) CreatePage(projectID, title, content, filetype string) (*Page,
 	return &page, nil
 }
 
+// PageFiletypes are the filetypes pages are rendered as, by the server's
+// downloads and by the web app. Other values would be stored but shown as
+// plain text.
+var PageFiletypes = []string{"txt", "md", "csv", "log"}
+
+// MaxPageBytes is the server's limit on a page's content, checked after
+// append and prepend are merged.
+const MaxPageBytes = 10 * 1024 * 1024
+
 func (c *Client) UpdatePageContent(pageID, content, mode string) (*Page, error) {
 	existingPage, err := c.GetPage(pageID)
 	if err != nil {
 		return nil, err
 	}
+	return c.UpdateFetchedPageContent(existingPage, content, mode)
+}
+
+// UpdateFetchedPageContent is UpdatePageContent for a page the caller has
+// already fetched, so it can inspect the page first without a second GET.
+func (c *Client) UpdateFetchedPageContent(existingPage *Page, content, mode string) (*Page, error) {
+	return c.UpdateFetchedPageContentAs(existingPage, content, mode, nil)
+}
+
+// UpdateFetchedPageContentAs is UpdateFetchedPageContent that also records
+// write in details.writes, in the same request. A nil write records nothing.
+//
+// The write is unconditional: the server has no conditional write, so
+// callers that must not discard a change compare existingPage.Version with
+// the version they expect first.
+func (c *Client) UpdateFetchedPageContentAs(existingPage *Page, content, mode string, write *PageWrite) (*Page, error) {
+	pageID := existingPage.ExternalID
+	if !existingPage.CanEdit() {
+		return nil, &PermissionError{Action: mode + " content", Role: existingPage.Role, Needs: "editor"}
+	}
 
 	filetype := "txt"
 	if existingPage.Details != nil && existingPage.Details.Filetype != "" {
@@ -289,6 +945,13 @@ func (c *Client) UpdatePageContent(pageID, content, mode string) (*Page, error)
 		},
 		Mode: mode,
 	}
+	if write != nil {
+		var writes []PageWrite
+		if existingPage.Details != nil {
+			writes = existingPage.Details.Writes
+		}
+		req.Details.Writes = AddPageWrite(writes, *write)
+	}
 
 	var page Page
 	if err := c.Put(fmt.Sprintf("/pages/%s/", pageID), req, &page); err != nil {
@@ -296,3 +959,68 @@ func (c *Client) UpdatePageContent(pageID, content, mode string) (*Page, error)
 	}
 	return &page, nil
 }
+
+// AppendRelated appends line to a fetched page and adds relatedID to its
+// related pages, in one request.
+func (c *Client) AppendRelated(existingPage *Page, line, relatedID string) (*Page, error) {
+	if !existingPage.CanEdit() {
+		return nil, &PermissionError{Action: "link from page", Role: existingPage.Role, Needs: "editor"}
+	}
+
+	details := &PageDetails{Content: line, Filetype: "txt", SchemaVersion: 1}
+	if d := existingPage.Details; d != nil {
+		if d.Filetype != "" {
+			details.Filetype = d.Filetype
+		}
+		details.Related = append(details.Related, d.Related...)
+	}
+	if !slices.Contains(details.Related, relatedID) {
+		details.Related = append(details.Related, relatedID)
+	}
+
+	req := UpdatePageContentRequest{Title: existingPage.Title, Details: details, Mode: "append"}
+	var page Page
+	if err := c.Put(fmt.Sprintf("/pages/%s/", existingPage.ExternalID), req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// RenderedPage is a page rendered by the server.
+type RenderedPage struct {
+	// ContentType is the media type the server sent, without parameters.
+	ContentType string
+	Body        []byte
+}
+
+// RenderPage downloads a page rendered as format ("html" or "pdf"). Servers
+// that cannot render send the raw file instead, so callers must check
+// ContentType.
+func (c *Client) RenderPage(pageID, format string) (*RenderedPage, error) {
+	accept := map[string]string{"html": "text/html", "pdf": "application/pdf"}[format]
+	if accept == "" {
+		return nil, fmt.Errorf("unsupported render format %q", format)
+	}
+
+	path := fmt.Sprintf("/pages/%s/download/?format=%s", pageID, format)
+	resp, err := c.doRequestAccept(http.MethodGet, path, nil, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("authentication failed: invalid or expired token")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return &RenderedPage{ContentType: contentType, Body: body}, nil
+}
@@ -1,12 +1,17 @@
 package api
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 // --- Request header tests ---
@@ -563,6 +568,65 @@ func TestCreatePage_RequestBody(t *testing.T) {
 
 // --- UpdatePageContent ---
 
+func TestCreatePageWithDetails_SendsSchema(t *testing.T) {
+	var raw map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &raw)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Page{ExternalID: "page_new"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	_, err := client.CreatePageWithDetails("proj_abc", "Data", &PageDetails{
+		Content:  "a,b\n1,x\n",
+		Filetype: "csv",
+		Schema: &TableSchema{Columns: []ColumnSchema{
+			{Name: "a", Type: "number"},
+			{Name: "b", Type: "string"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	details, _ := raw["details"].(map[string]any)
+	if details["schema_version"] != float64(1) {
+		t.Errorf("schema_version = %v, want 1", details["schema_version"])
+	}
+	schema, _ := details["schema"].(map[string]any)
+	columns, _ := schema["columns"].([]any)
+	if len(columns) != 2 {
+		t.Fatalf("expected 2 schema columns, got %v", details["schema"])
+	}
+	first, _ := columns[0].(map[string]any)
+	if first["name"] != "a" || first["type"] != "number" {
+		t.Errorf("first column = %v, want a/number", first)
+	}
+}
+
+func TestCreatePage_OmitsSchema(t *testing.T) {
+	var raw map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &raw)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Page{ExternalID: "page_new"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	if _, err := client.CreatePage("proj_abc", "Notes", "hello", "txt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	details, _ := raw["details"].(map[string]any)
+	if _, ok := details["schema"]; ok {
+		t.Errorf("schema should be omitted when not set, got %v", details["schema"])
+	}
+}
+
 func TestUpdatePageContent_GETThenPUT(t *testing.T) {
 	var requestLog []string
 	var putBody UpdatePageContentRequest
@@ -754,3 +818,317 @@ func TestGet_InvalidJSON(t *testing.T) {
 		t.Errorf("error = %q, expected 'failed to decode response'", err)
 	}
 }
+
+// --- Request observer tests ---
+
+func TestRequestObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var records []RequestRecord
+	SetRequestObserver(func(r RequestRecord) { records = append(records, r) })
+	defer SetRequestObserver(nil)
+
+	client := NewClient(server.URL, "token")
+	_ = client.Post("/pages/", map[string]string{"title": "x"}, &struct{}{})
+
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.Method != "POST" || r.Path != "/pages/" || r.Status != http.StatusCreated {
+		t.Errorf("record = %+v", r)
+	}
+	if r.BytesSent != int64(len(`{"title":"x"}`)) {
+		t.Errorf("BytesSent = %d, want %d", r.BytesSent, len(`{"title":"x"}`))
+	}
+}
+
+// --- Timeout and retry tests ---
+
+func TestNewClientWithOptions_Timeout(t *testing.T) {
+	if got := NewClient("http://x", "t").httpClient.Timeout; got != DefaultTimeout {
+		t.Errorf("default timeout = %s, want %s", got, DefaultTimeout)
+	}
+	c := NewClientWithOptions("http://x", "t", ClientOptions{Timeout: 5 * time.Second})
+	if c.httpClient.Timeout != 5*time.Second {
+		t.Errorf("timeout = %s, want 5s", c.httpClient.Timeout)
+	}
+}
+
+func TestRetries_GetRetriedOnServerError(t *testing.T) {
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = 500 * time.Millisecond }()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "t", ClientOptions{Retries: 2})
+	if err := client.Get("/test/", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestRetries_GivesUpAfterLimit(t *testing.T) {
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = 500 * time.Millisecond }()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "t", ClientOptions{Retries: 1})
+	err := client.Get("/test/", nil)
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("err = %v, want 503 error", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestRetries_PostNotRetriedOnServerError(t *testing.T) {
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = 500 * time.Millisecond }()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "t", ClientOptions{Retries: 3})
+	_ = client.Post("/test/", map[string]string{"a": "b"}, nil)
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (POST is not idempotent)", calls)
+	}
+}
+
+func TestRetries_PostRetriedOnRateLimitWithBody(t *testing.T) {
+	retryBaseDelay = time.Millisecond
+	defer func() { retryBaseDelay = 500 * time.Millisecond }()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "t", ClientOptions{Retries: 1})
+	if err := client.Post("/test/", map[string]string{"a": "b"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("bodies = %q, want the same body sent twice", bodies)
+	}
+}
+
+func TestPageVersion(t *testing.T) {
+	page := Page{Modified: "2026-03-02T14:00:00Z", Updated: "2026-03-01T09:00:00Z"}
+	if got := page.Version(); got != "2026-03-02T14:00:00Z,2026-03-01T09:00:00Z" {
+		t.Errorf("Version() = %q", got)
+	}
+	changed := page
+	changed.Modified = "2026-03-02T14:05:00Z"
+	if changed.Version() == page.Version() {
+		t.Error("a new modified time did not change the version")
+	}
+	if got := (&Page{}).Version(); got != "" {
+		t.Errorf("Version() without timestamps = %q, want empty", got)
+	}
+}
+
+func TestUpdatePageContent_OverwriteSendsOnlyTheWrite(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		_ = json.NewEncoder(w).Encode(Page{ExternalID: "page_1", Title: "T"})
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "t")
+
+	page := &Page{ExternalID: "page_1", Title: "T", Modified: "2026-03-02T14:00:00Z"}
+	if _, err := client.UpdateFetchedPageContent(page, "new", "overwrite"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"PUT /pages/page_1/"}; !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want the write alone", requests)
+	}
+}
+
+// --- Permission pre-flight tests ---
+
+func TestUpdatePageContent_ViewerRejectedBeforePut(t *testing.T) {
+	putSent := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			putSent = true
+		}
+		_ = json.NewEncoder(w).Encode(Page{ExternalID: "page_1", Title: "T", Role: RoleViewer})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "t")
+	_, err := client.UpdatePageContent("page_1", "more", "append")
+
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("err = %v, want PermissionError", err)
+	}
+	if !strings.Contains(err.Error(), "viewer access") {
+		t.Errorf("error should mention the role: %s", err)
+	}
+	if putSent {
+		t.Error("PUT should not be sent for a viewer")
+	}
+}
+
+func TestPage_Permissions(t *testing.T) {
+	tests := []struct {
+		role      string
+		canEdit   bool
+		canDelete bool
+	}{
+		{"", true, true},
+		{RoleViewer, false, false},
+		{RoleEditor, true, false},
+		{RoleAdmin, true, true},
+	}
+	for _, tt := range tests {
+		p := &Page{Role: tt.role}
+		if p.CanEdit() != tt.canEdit || p.CanDelete() != tt.canDelete {
+			t.Errorf("role %q: CanEdit=%v CanDelete=%v, want %v %v", tt.role, p.CanEdit(), p.CanDelete(), tt.canEdit, tt.canDelete)
+		}
+	}
+}
+
+// --- TLS verification hook tests ---
+
+func TestNewClientWithOptions_VerifyConnection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	rejected := errors.New("pin mismatch")
+	calls := 0
+	client := NewClientWithOptions(server.URL, "t", ClientOptions{
+		VerifyConnection: func(tls.ConnectionState) error {
+			calls++
+			return rejected
+		},
+	})
+	// Trust the test server's self-signed certificate so only the hook decides.
+	transport := client.httpClient.Transport.(*http.Transport)
+	transport.TLSClientConfig.RootCAs = server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	err := client.Get("/", nil)
+	if !errors.Is(err, rejected) {
+		t.Errorf("err = %v, want hook error", err)
+	}
+	if calls != 1 {
+		t.Errorf("hook called %d times, want 1", calls)
+	}
+}
+
+func TestClient_OnUpload(t *testing.T) {
+	var contentLength int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		_, _ = io.Copy(io.Discard, r.Body)
+		_ = json.NewEncoder(w).Encode(Page{ExternalID: "page_1"})
+	}))
+	defer server.Close()
+
+	var calls [][2]int64
+	client := NewClientWithOptions(server.URL, "test-token", ClientOptions{
+		OnUpload: func(method, path string, sent, total int64) {
+			calls = append(calls, [2]int64{sent, total})
+		},
+	})
+	body := map[string]string{"content": strings.Repeat("x", 100000)}
+	if err := client.Put("/pages/page_1/", body, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(calls) < 2 || calls[0][0] != 0 {
+		t.Fatalf("calls = %v, want a start at 0 then progress", calls)
+	}
+	last := calls[len(calls)-1]
+	if last[0] != last[1] || last[1] != contentLength || contentLength <= 100000 {
+		t.Errorf("last call = %v, content length = %d; want the whole body sent with its length set", last, contentLength)
+	}
+}
+
+func TestAllPages_FollowsOffsets(t *testing.T) {
+	all := []Page{{ExternalID: "page_1"}, {ExternalID: "page_2"}, {ExternalID: "page_3"}, {ExternalID: "page_4"}, {ExternalID: "page_5"}}
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		// A page created after the first request pushes page_2 back
+		// into the second window.
+		pages := all
+		if offset > 0 {
+			pages = append([]Page{{ExternalID: "page_new"}}, all...)
+		}
+		_ = json.NewEncoder(w).Encode(PageList{Items: pages[offset:min(offset+limit, len(pages))], Count: len(pages)})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	var ids []string
+	for page, err := range client.AllPages("", 2) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, page.ExternalID)
+	}
+	if got := strings.Join(ids, ","); got != "page_1,page_2,page_3,page_4,page_5" {
+		t.Errorf("pages = %s, want each page once", got)
+	}
+	if got := strings.Join(offsets, ","); got != "0,2,4" {
+		t.Errorf("offsets requested = %s, want 0,2,4", got)
+	}
+}
+
+func TestListPagesRange_Project(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Project{Pages: []Page{{ExternalID: "page_1"}, {ExternalID: "page_2"}, {ExternalID: "page_3"}}})
+	}))
+	defer server.Close()
+
+	list, err := NewClient(server.URL, "token").ListPagesRange("proj_abc", 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.Count != 3 || len(list.Items) != 1 || list.Items[0].ExternalID != "page_3" {
+		t.Errorf("ListPagesRange = %+v, want page_3 of 3", list)
+	}
+}
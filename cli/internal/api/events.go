@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// PageEvent is a change to a page, sent as a server-sent event by
+// GET /pages/{id}/events/. It says that the page changed, not how; the
+// page is fetched to see the change.
+type PageEvent struct {
+	// Type is the event's name, "update" unless the server says otherwise.
+	Type     string `json:"-"`
+	Modified string `json:"modified,omitempty"`
+}
+
+// ErrEventsUnsupported is returned by WatchPageEvents when the server has
+// no event stream for pages, so the caller can poll instead.
+var ErrEventsUnsupported = errors.New("server does not stream page events")
+
+// WatchPageEvents subscribes to a page's changes, calling handle for each
+// event until ctx is cancelled, the server ends the stream, or handle
+// returns an error, which is returned. The stream is not bound by the
+// client's request timeout, since it stays open while the page is idle.
+func (c *Client) WatchPageEvents(ctx context.Context, pageID string, handle func(PageEvent) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/pages/%s/events/", c.baseURL, pageID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-Hyperclast-Client", buildClientHeader())
+
+	stream := *c.httpClient
+	stream.Timeout = 0
+	resp, err := stream.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("authentication failed: invalid or expired token")
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusMethodNotAllowed,
+		resp.StatusCode == http.StatusNotAcceptable, resp.StatusCode == http.StatusNotImplemented:
+		return ErrEventsUnsupported
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+	case !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream"):
+		return ErrEventsUnsupported
+	}
+
+	err = readEvents(resp.Body, handle)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// readEvents parses a server-sent event stream, calling handle for each
+// event. Comments, used as keepalives, are skipped, and data that is not
+// a JSON PageEvent still counts as a change.
+func readEvents(r io.Reader, handle func(PageEvent) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var name string
+	var data []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			if name == "" && data == nil {
+				continue
+			}
+			event := PageEvent{}
+			_ = json.Unmarshal([]byte(strings.Join(data, "\n")), &event)
+			event.Type = name
+			if event.Type == "" {
+				event.Type = "update"
+			}
+			if err := handle(event); err != nil {
+				return err
+			}
+			name, data = "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWatchPageEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pages/page_a/events/" || r.Header.Get("Accept") != "text/event-stream" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keepalive\n\n")
+		fmt.Fprint(w, "event: update\ndata: {\"modified\": \"2026-10-18T10:00:00Z\"}\n\n")
+		fmt.Fprint(w, "data: not json\r\n\r\n")
+		fmt.Fprint(w, "event: delete\n\n")
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "tok")
+
+	var events []PageEvent
+	err := client.WatchPageEvents(context.Background(), "page_a", func(e PageEvent) error {
+		events = append(events, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WatchPageEvents: %v", err)
+	}
+	want := []PageEvent{{Type: "update", Modified: "2026-10-18T10:00:00Z"}, {Type: "update"}, {Type: "delete"}}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+
+	stop := errors.New("stop")
+	if err := client.WatchPageEvents(context.Background(), "page_a", func(PageEvent) error { return stop }); err != stop {
+		t.Errorf("WatchPageEvents = %v, want the handler's error", err)
+	}
+	if err := client.WatchPageEvents(context.Background(), "page_b", nil); !errors.Is(err, ErrEventsUnsupported) {
+		t.Errorf("WatchPageEvents on a server without events = %v, want ErrEventsUnsupported", err)
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// File is an upload stored in a project, such as a screenshot or a core
+// dump. Pages refer to it by linking to its Link. Unlike page content it
+// may be binary.
+type File struct {
+	ExternalID  string `json:"external_id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	Status      string `json:"status"`
+	// Link is the file's permanent download URL, which works without
+	// authentication. It may be relative to the server's root.
+	Link    string `json:"link"`
+	Created string `json:"created"`
+}
+
+// FileUpload is the response of POST /files/: the pending file and where
+// to send its bytes.
+type FileUpload struct {
+	File          File              `json:"file"`
+	UploadURL     string            `json:"upload_url"`
+	UploadHeaders map[string]string `json:"upload_headers"`
+	// WebhookEnabled is set when storage finalizes the upload itself, so
+	// the client must not.
+	WebhookEnabled bool `json:"webhook_enabled"`
+}
+
+// CreateFileUploadRequest is the request body of POST /files/.
+type CreateFileUploadRequest struct {
+	ProjectID   string `json:"project_id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+}
+
+// CreateFileUpload registers a file of size bytes in a project and returns
+// the signed URL to upload it to.
+func (c *Client) CreateFileUpload(projectID, filename, contentType string, size int64) (*FileUpload, error) {
+	req := CreateFileUploadRequest{ProjectID: projectID, Filename: filename, ContentType: contentType, SizeBytes: size}
+	var upload FileUpload
+	if err := c.Post("/files/", req, &upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// UploadFileData sends a file's size bytes from r to the URL CreateFileUpload
+// returned. The URL is signed, so the request carries no token.
+func (c *Client) UploadFileData(upload *FileUpload, r io.Reader, size int64) error {
+	target, err := c.resolveURL(upload.UploadURL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodPut, target, r)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	for name, value := range upload.UploadHeaders {
+		req.Header.Set(name, value)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("storage error (%d): %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// FinalizeFileUpload marks an uploaded file as available once storage has
+// it.
+func (c *Client) FinalizeFileUpload(fileID string) (*File, error) {
+	var file File
+	if err := c.Post(fmt.Sprintf("/files/%s/finalize/", fileID), map[string]any{}, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// FailFileUpload marks an upload whose bytes could not be sent as failed,
+// so the server can clean it up.
+func (c *Client) FailFileUpload(fileID string) error {
+	return c.Post(fmt.Sprintf("/files/%s/finalize/?mark_failed=true", fileID), map[string]any{}, nil)
+}
+
+// ErrFileNotFound is returned by GetFile for a file that does not exist
+// or was deleted.
+var ErrFileNotFound = errors.New("file not found")
+
+// GetFile returns a file's metadata.
+func (c *Client) GetFile(fileID string) (*File, error) {
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("/files/%s/", fileID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("file %s: %w", fileID, ErrFileNotFound)
+	}
+	var file File
+	if err := decodeResponse(resp, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DownloadOut is the response of GET /files/{id}/download/.
+type DownloadOut struct {
+	DownloadURL string `json:"download_url"`
+}
+
+// DownloadFile writes a file's bytes to w as they arrive and returns how
+// many were written. The bytes are fetched from the file's download URL,
+// which needs no token.
+func (c *Client) DownloadFile(fileID string, w io.Writer) (int64, error) {
+	var out DownloadOut
+	if err := c.Get(fmt.Sprintf("/files/%s/download/", fileID), &out); err != nil {
+		return 0, err
+	}
+	target, err := c.resolveURL(out.DownloadURL)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return 0, fmt.Errorf("download error (%d): %s", resp.StatusCode, string(respBody))
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download file after %d bytes: %w", n, err)
+	}
+	return n, nil
+}
+
+// resolveURL resolves a URL the server returned, which may be relative to
+// its root, against the API URL.
+func (c *Client) resolveURL(ref string) (string, error) {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid API URL: %w", err)
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL from server %q: %w", ref, err)
+	}
+	return u.String(), nil
+}
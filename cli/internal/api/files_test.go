@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFileEndpoints(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00")
+	var requests []string
+	var uploaded []byte
+	var created CreateFileUploadRequest
+	var uploadAuth, uploadType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/files/":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(FileUpload{
+				File:          File{ExternalID: "f1", Filename: created.Filename},
+				UploadURL:     "/storage/f1?sig=x",
+				UploadHeaders: map[string]string{"Content-Type": created.ContentType},
+			})
+		case r.Method == http.MethodPut:
+			uploadAuth, uploadType = r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+			uploaded, _ = io.ReadAll(r.Body)
+		case strings.HasSuffix(r.URL.Path, "/finalize/"):
+			_ = json.NewEncoder(w).Encode(File{ExternalID: "f1", Status: "available", Link: "/files/p1/f1/tok/"})
+		case strings.HasSuffix(r.URL.Path, "/download/"):
+			_ = json.NewEncoder(w).Encode(DownloadOut{DownloadURL: "/files/p1/f1/tok/"})
+		case r.URL.Path == "/files/p1/f1/tok/":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("download link was sent the API token")
+			}
+			_, _ = w.Write(png)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL+"/api", "tok")
+
+	upload, err := client.CreateFileUpload("p1", "shot.png", "image/png", int64(len(png)))
+	if err != nil {
+		t.Fatalf("CreateFileUpload: %v", err)
+	}
+	if created.ProjectID != "p1" || created.SizeBytes != int64(len(png)) {
+		t.Errorf("create request = %+v", created)
+	}
+	if err := client.UploadFileData(upload, bytes.NewReader(png), int64(len(png))); err != nil {
+		t.Fatalf("UploadFileData: %v", err)
+	}
+	if !bytes.Equal(uploaded, png) || uploadType != "image/png" || uploadAuth != "" {
+		t.Errorf("uploaded %q as %q with Authorization %q, want the file byte for byte and no token", uploaded, uploadType, uploadAuth)
+	}
+	file, err := client.FinalizeFileUpload("f1")
+	if err != nil || file.Status != "available" {
+		t.Fatalf("FinalizeFileUpload = %+v, %v", file, err)
+	}
+
+	var out bytes.Buffer
+	if n, err := client.DownloadFile("f1", &out); err != nil || n != int64(len(png)) || !bytes.Equal(out.Bytes(), png) {
+		t.Fatalf("DownloadFile = %d, %v; got %q", n, err, out.Bytes())
+	}
+
+	want := []string{
+		"POST /api/files/",
+		"PUT /storage/f1?sig=x",
+		"POST /api/files/f1/finalize/",
+		"GET /api/files/f1/download/",
+		"GET /files/p1/f1/tok/",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
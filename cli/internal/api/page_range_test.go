@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetPageRange(t *testing.T) {
+	const content = "0123456789"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pages/page_noranges/download/":
+			// As the API sends an md page: headed by its title.
+			_, _ = w.Write([]byte("# Build\n\n" + content))
+		case "/pages/page_noranges/":
+			_ = json.NewEncoder(w).Encode(Page{ExternalID: "page_noranges", Details: &PageDetails{Content: content, Filetype: "md"}})
+		default:
+			http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "tok")
+
+	tests := []struct {
+		page  string
+		start int64
+		want  PageRange
+	}{
+		{"page_a", -4, PageRange{Content: []byte("6789"), Start: 6, Size: 10, Partial: true}},
+		{"page_a", -40, PageRange{Content: []byte(content), Start: 0, Size: 10, Partial: true}},
+		{"page_a", 7, PageRange{Content: []byte("789"), Start: 7, Size: 10, Partial: true}},
+		{"page_a", 10, PageRange{Start: 10, Size: 10, Partial: true}},
+		{"page_noranges", -4, PageRange{Content: []byte(content), Size: 10}},
+	}
+	for _, tt := range tests {
+		got, err := client.GetPageRange(tt.page, tt.start)
+		if err != nil {
+			t.Errorf("GetPageRange(%s, %d): %v", tt.page, tt.start, err)
+			continue
+		}
+		if string(got.Content) != string(tt.want.Content) || got.Start != tt.want.Start || got.Size != tt.want.Size || got.Partial != tt.want.Partial {
+			t.Errorf("GetPageRange(%s, %d) = %+v, want %+v", tt.page, tt.start, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// RevisionSummary is one revision of a page (a "rewind" on the server),
+// without its content.
+type RevisionSummary struct {
+	ExternalID    string   `json:"external_id"`
+	Number        int      `json:"rewind_number"`
+	Title         string   `json:"title"`
+	Bytes         int64    `json:"content_size_bytes"`
+	Editors       []string `json:"editors"`
+	Label         string   `json:"label"`
+	LinesAdded    int      `json:"lines_added"`
+	LinesDeleted  int      `json:"lines_deleted"`
+	Compacted     bool     `json:"is_compacted"`
+	CompactedFrom int      `json:"compacted_from_count"`
+	Created       string   `json:"created"`
+}
+
+// Revision is a revision with its content.
+type Revision struct {
+	RevisionSummary
+	Content string `json:"content"`
+}
+
+// RevisionList is one window of a page's revisions, newest first, with
+// the total count.
+type RevisionList struct {
+	Items []RevisionSummary `json:"items"`
+	Count int               `json:"count"`
+}
+
+// revisionBatch is how many revisions FindRevision requests at a time.
+const revisionBatch = 100
+
+// ListPageRevisions returns up to limit of a page's revisions, newest
+// first, skipping the first offset. It fails if the server has revision
+// history disabled.
+func (c *Client) ListPageRevisions(pageID string, limit, offset int) (*RevisionList, error) {
+	params := url.Values{"limit": {strconv.Itoa(limit)}, "offset": {strconv.Itoa(offset)}}
+	var list RevisionList
+	if err := c.Get(fmt.Sprintf("/pages/%s/rewind/?%s", pageID, params.Encode()), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetRevision returns a revision of a page with its content.
+func (c *Client) GetRevision(pageID, revisionID string) (*Revision, error) {
+	var revision Revision
+	if err := c.Get(fmt.Sprintf("/pages/%s/rewind/%s/", pageID, revisionID), &revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// FindRevision returns the page's revision with the given number. The
+// server lists revisions newest first, so the search stops once it passes
+// the number. Compaction merges old revisions, so a number may be gone.
+func (c *Client) FindRevision(pageID string, number int) (*RevisionSummary, error) {
+	for offset := 0; ; offset += revisionBatch {
+		list, err := c.ListPageRevisions(pageID, revisionBatch, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range list.Items {
+			if r.Number == number {
+				return &r, nil
+			}
+			if r.Number < number {
+				return nil, fmt.Errorf("page %s has no revision v%d", pageID, number)
+			}
+		}
+		if len(list.Items) < revisionBatch || offset+revisionBatch >= list.Count {
+			return nil, fmt.Errorf("page %s has no revision v%d", pageID, number)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// revisionServer lists revisions numbered total down to 1, newest first.
+func revisionServer(t *testing.T, total int, requests *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path != "/pages/page_a/rewind/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		list := RevisionList{Items: []RevisionSummary{}, Count: total}
+		for n := total - offset; n > 0 && n > total-offset-limit; n-- {
+			list.Items = append(list.Items, RevisionSummary{ExternalID: fmt.Sprintf("rw_%d", n), Number: n})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+}
+
+func TestFindRevision(t *testing.T) {
+	var requests int
+	server := revisionServer(t, 250, &requests)
+	defer server.Close()
+	client := NewClient(server.URL, "tok")
+
+	r, err := client.FindRevision("page_a", 120)
+	if err != nil || r.ExternalID != "rw_120" {
+		t.Fatalf("FindRevision = %+v, %v; want rw_120", r, err)
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests, want 2 pages of %d", requests, revisionBatch)
+	}
+
+	requests = 0
+	if _, err := client.FindRevision("page_a", 251); err == nil || !strings.Contains(err.Error(), "no revision v251") {
+		t.Errorf("err = %v, want no revision v251", err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, want the search to stop at the newest revision", requests)
+	}
+}
+
+func TestGetRevision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pages/page_a/rewind/rw_7/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"external_id":"rw_7","rewind_number":7,"label":"nightly","content":"a\n"}`))
+	}))
+	defer server.Close()
+
+	r, err := NewClient(server.URL, "tok").GetRevision("page_a", "rw_7")
+	if err != nil || r.Number != 7 || r.Label != "nightly" || r.Content != "a\n" {
+		t.Errorf("GetRevision = %+v, %v", r, err)
+	}
+}
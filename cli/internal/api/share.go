@@ -0,0 +1,25 @@
+package api
+
+import "fmt"
+
+// AccessCodeOut is the response of POST /pages/{id}/access-code/.
+type AccessCodeOut struct {
+	AccessCode string `json:"access_code"`
+}
+
+// CreateAccessCode returns the page's read-only access code, creating one
+// if it has none. Anyone with the code can read the page without an
+// account.
+func (c *Client) CreateAccessCode(pageID string) (string, error) {
+	var out AccessCodeOut
+	if err := c.Post(fmt.Sprintf("/pages/%s/access-code/", pageID), nil, &out); err != nil {
+		return "", err
+	}
+	return out.AccessCode, nil
+}
+
+// RemoveAccessCode stops the page's access code from working. It succeeds
+// if the page has none.
+func (c *Client) RemoveAccessCode(pageID string) error {
+	return c.Delete(fmt.Sprintf("/pages/%s/access-code/", pageID))
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAccessCodeEndpoints(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(AccessCodeOut{AccessCode: "abc"})
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "tok")
+
+	code, err := client.CreateAccessCode("page_a")
+	if err != nil || code != "abc" {
+		t.Fatalf("CreateAccessCode = %q, %v", code, err)
+	}
+	if err := client.RemoveAccessCode("page_a"); err != nil {
+		t.Fatalf("RemoveAccessCode: %v", err)
+	}
+
+	want := []string{"POST /pages/page_a/access-code/", "DELETE /pages/page_a/access-code/"}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
@@ -0,0 +1,312 @@
+package api
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// StreamPageContent writes a page's content to w as the response arrives,
+// without holding the page in memory: details.content is decoded straight
+// out of the JSON body. Output is flushed whenever the body stalls, so a
+// reader downstream sees content as soon as it is received. It returns the
+// bytes written; a page without content writes nothing.
+func (c *Client) StreamPageContent(pageID string, w io.Writer) (int64, error) {
+	resp, err := c.doRequest(http.MethodGet, "/pages/"+pageID+"/", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return 0, fmt.Errorf("authentication failed: invalid or expired token")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return 0, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+	}
+
+	s := &jsonStream{r: bufio.NewReaderSize(resp.Body, 64*1024)}
+	out := &flushWriter{w: bufio.NewWriterSize(w, 64*1024)}
+	s.stall = out.flush
+	err = s.copyField(out, "details", "content")
+	if flushErr := out.flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return out.n, fmt.Errorf("failed to read page content after %d bytes: %w", out.n, err)
+	}
+	return out.n, nil
+}
+
+// flushWriter counts what is written through a bufio.Writer.
+type flushWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (f *flushWriter) WriteRune(r rune) error {
+	if f.err == nil {
+		var n int
+		n, f.err = f.w.WriteRune(r)
+		f.n += int64(n)
+	}
+	return f.err
+}
+
+func (f *flushWriter) flush() error {
+	if f.err == nil {
+		f.err = f.w.Flush()
+	}
+	return f.err
+}
+
+// maxStreamKeyLength bounds the object keys jsonStream reads into memory.
+const maxStreamKeyLength = 1024
+
+var errStreamSyntax = errors.New("malformed JSON in response")
+
+// jsonStream reads a JSON document a byte at a time, so one string value
+// can be decoded to a writer without holding it. stall, if set, is called
+// before a read that would block.
+type jsonStream struct {
+	r     *bufio.Reader
+	stall func() error
+}
+
+func (s *jsonStream) next() (byte, error) {
+	if s.stall != nil && s.r.Buffered() == 0 {
+		if err := s.stall(); err != nil {
+			return 0, err
+		}
+	}
+	b, err := s.r.ReadByte()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return b, err
+}
+
+// nextToken returns the next byte that is not whitespace.
+func (s *jsonStream) nextToken() (byte, error) {
+	for {
+		b, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return b, nil
+		}
+	}
+}
+
+// copyField finds the value at path in the top-level object and, if it is
+// a string, decodes it to out. A missing or null value writes nothing.
+func (s *jsonStream) copyField(out *flushWriter, path ...string) error {
+	b, err := s.nextToken()
+	if err != nil {
+		return err
+	}
+	for depth := 0; ; depth++ {
+		if b != '{' {
+			return s.skipValue(b)
+		}
+		found := false
+		for !found {
+			b, err = s.nextToken()
+			if err != nil {
+				return err
+			}
+			if b == '}' {
+				return nil
+			}
+			if b == ',' {
+				if b, err = s.nextToken(); err != nil {
+					return err
+				}
+			}
+			if b != '"' {
+				return errStreamSyntax
+			}
+			var key []byte
+			if err := s.readString(func(r rune) error {
+				if len(key) >= maxStreamKeyLength {
+					return errStreamSyntax
+				}
+				key = utf8.AppendRune(key, r)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if b, err = s.nextToken(); err != nil || b != ':' {
+				return errors.Join(err, errStreamSyntax)
+			}
+			if b, err = s.nextToken(); err != nil {
+				return err
+			}
+			if string(key) != path[depth] {
+				if err := s.skipValue(b); err != nil {
+					return err
+				}
+				continue
+			}
+			found = true
+		}
+		if depth == len(path)-1 {
+			if b != '"' {
+				return s.skipValue(b)
+			}
+			return s.readString(out.WriteRune)
+		}
+	}
+}
+
+// readString decodes a string whose opening quote has been read, passing
+// each rune to emit.
+func (s *jsonStream) readString(emit func(rune) error) error {
+	for {
+		b, err := s.next()
+		if err != nil {
+			return err
+		}
+		switch {
+		case b == '"':
+			return nil
+		case b == '\\':
+			r, err := s.readEscape()
+			if err != nil {
+				return err
+			}
+			if err := emit(r); err != nil {
+				return err
+			}
+		case b < utf8.RuneSelf:
+			if err := emit(rune(b)); err != nil {
+				return err
+			}
+		default:
+			// Gather the rest of a multi-byte UTF-8 sequence.
+			buf := []byte{b}
+			for !utf8.FullRune(buf) && len(buf) < utf8.UTFMax {
+				c, err := s.next()
+				if err != nil {
+					return err
+				}
+				buf = append(buf, c)
+			}
+			r, _ := utf8.DecodeRune(buf)
+			if err := emit(r); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readEscape decodes an escape after its backslash, joining surrogate
+// pairs.
+func (s *jsonStream) readEscape() (rune, error) {
+	b, err := s.next()
+	if err != nil {
+		return 0, err
+	}
+	switch b {
+	case '"', '\\', '/':
+		return rune(b), nil
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case 'u':
+		r, err := s.readHex()
+		if err != nil || !utf16.IsSurrogate(r) {
+			return r, err
+		}
+		// A high surrogate must be followed by \u and its low half.
+		if b, err := s.next(); err != nil || b != '\\' {
+			return utf8.RuneError, errors.Join(err, errStreamSyntax)
+		}
+		if b, err := s.next(); err != nil || b != 'u' {
+			return utf8.RuneError, errors.Join(err, errStreamSyntax)
+		}
+		low, err := s.readHex()
+		if err != nil {
+			return 0, err
+		}
+		return utf16.DecodeRune(r, low), nil
+	default:
+		return 0, errStreamSyntax
+	}
+}
+
+func (s *jsonStream) readHex() (rune, error) {
+	var hex [4]byte
+	for i := range hex {
+		b, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		hex[i] = b
+	}
+	n, err := strconv.ParseUint(string(hex[:]), 16, 16)
+	if err != nil {
+		return 0, errStreamSyntax
+	}
+	return rune(n), nil
+}
+
+// skipValue skips the value starting with b.
+func (s *jsonStream) skipValue(b byte) error {
+	switch b {
+	case '"':
+		return s.readString(func(rune) error { return nil })
+	case '{', '[':
+		depth := 1
+		for depth > 0 {
+			c, err := s.next()
+			if err != nil {
+				return err
+			}
+			switch c {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			case '"':
+				if err := s.readString(func(rune) error { return nil }); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		// A number or literal ends at the delimiter after it, which the
+		// caller reads next.
+		for {
+			c, err := s.r.ReadByte()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			switch c {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return s.r.UnreadByte()
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStreamPageContent(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{"content", `{"external_id":"page_a","details":{"content":"one\ntwo\n","filetype":"log"}}`, "one\ntwo\n"},
+		{"escapes", `{"details":{"content":"tab\t \"q\" \\ \/ é 😀 \u00fc \ud83d\ude00"}}`, "tab\t \"q\" \\ / é 😀 ü 😀"},
+		{"skips", `{"links":[{"content":"no"}],"size":12,"ok":true,"details":{"schema":{"content":"no"},"n":null,"content":"yes"}}`, "yes"},
+		{"whitespace", "{ \"details\" : { \"content\" : \"x\" } }", "x"},
+		{"null details", `{"external_id":"page_a","details":null}`, ""},
+		{"no content", `{"details":{"filetype":"md"}}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			var out strings.Builder
+			n, err := NewClient(server.URL, "tok").StreamPageContent("page_a", &out)
+			if err != nil || out.String() != tt.want || n != int64(len(tt.want)) {
+				t.Errorf("got %q (%d bytes), %v; want %q", out.String(), n, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamPageContent_MatchesDecoder(t *testing.T) {
+	content := strings.Repeat("línea <b>\"7\"</b>\x01\n", 5000)
+	body, _ := json.Marshal(Page{ExternalID: "page_a", Details: &PageDetails{Content: content}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	var out strings.Builder
+	if _, err := NewClient(server.URL, "tok").StreamPageContent("page_a", &out); err != nil || out.String() != content {
+		t.Errorf("streamed %d bytes, %v; want the %d bytes encoding/json decodes", out.Len(), err, len(content))
+	}
+}
+
+func TestStreamPageContent_WritesBeforeBodyEnds(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"details":{"content":"first\n`)
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = io.WriteString(w, `second\n"}}`)
+	}))
+	defer server.Close()
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewClient(server.URL, "tok").StreamPageContent("page_a", pw)
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	buf := make([]byte, 6)
+	if _, err := io.ReadFull(pr, buf); err != nil || string(buf) != "first\n" {
+		t.Fatalf("read %q, %v; want the first line while the body is still open", buf, err)
+	}
+	close(release)
+	rest, _ := io.ReadAll(pr)
+	if err := <-done; err != nil || string(rest) != "second\n" {
+		t.Errorf("rest = %q, %v", rest, err)
+	}
+}
+
+func TestStreamPageContent_Truncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"details":{"content":"cut off`)
+	}))
+	defer server.Close()
+
+	var out strings.Builder
+	_, err := NewClient(server.URL, "tok").StreamPageContent("page_a", &out)
+	if err == nil || !strings.Contains(err.Error(), "after 7 bytes") {
+		t.Errorf("err = %v, want the truncation reported", err)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+)
+
+// TelemetryEvent is one command run reported by SendTelemetry.
+type TelemetryEvent struct {
+	Command    string `json:"command"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// ErrTelemetryUnsupported is returned by SendTelemetry when the server has
+// no telemetry endpoint, as the Hyperclast API does not today.
+var ErrTelemetryUnsupported = errors.New("server does not accept telemetry")
+
+// SendTelemetry reports a batch of command runs. Callers send it from a
+// client without a token, so the batch is not tied to an account.
+func (c *Client) SendTelemetry(events []TelemetryEvent) error {
+	resp, err := c.doRequest(http.MethodPost, "/cli/telemetry/", map[string]any{"events": events})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		_ = resp.Body.Close()
+		return ErrTelemetryUnsupported
+	}
+	return decodeResponse(resp, nil)
+}
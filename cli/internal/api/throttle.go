@@ -0,0 +1,56 @@
+package api
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request that uses it,
+// including retries. It holds up to one second's worth of tokens, so short
+// bursts go out immediately and sustained traffic settles at the rate.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewRateLimiter returns a limiter allowing rps requests per second.
+func NewRateLimiter(rps float64) *RateLimiter {
+	burst := math.Max(1, math.Floor(rps))
+	return &RateLimiter{
+		rate:   rps,
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}
+}
+
+// Wait blocks until a request may be sent.
+func (l *RateLimiter) Wait() {
+	l.mu.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	// Take the token now, possibly going negative, so concurrent callers
+	// queue up behind each other instead of all waking at once.
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay > 0 {
+		l.sleep(delay)
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when the limiter sleeps.
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) install(l *RateLimiter) {
+	l.now = func() time.Time { return c.now }
+	l.sleep = func(d time.Duration) {
+		c.slept = append(c.slept, d)
+		c.now = c.now.Add(d)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := NewRateLimiter(2)
+	clock.install(l)
+
+	// A burst of two goes out immediately, then requests are spaced 500ms apart.
+	for i := 0; i < 4; i++ {
+		l.Wait()
+	}
+	want := []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}
+	if len(clock.slept) != len(want) || clock.slept[0] != want[0] || clock.slept[1] != want[1] {
+		t.Errorf("slept %v, want %v", clock.slept, want)
+	}
+
+	// After idling the bucket refills, but never beyond the burst size.
+	clock.slept = nil
+	clock.now = clock.now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		l.Wait()
+	}
+	if len(clock.slept) != 1 || clock.slept[0] != 500*time.Millisecond {
+		t.Errorf("after idle slept %v, want one 500ms wait", clock.slept)
+	}
+}
+
+func TestRateLimiter_FractionalRate(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := NewRateLimiter(0.5)
+	clock.install(l)
+
+	l.Wait()
+	l.Wait()
+	if len(clock.slept) != 1 || clock.slept[0] != 2*time.Second {
+		t.Errorf("slept %v, want one 2s wait", clock.slept)
+	}
+}
+
+func TestClient_UsesRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := NewRateLimiter(1)
+	clock.install(limiter)
+
+	// Two clients sharing a limiter are paced together.
+	a := NewClientWithOptions(server.URL, "t", ClientOptions{RateLimiter: limiter})
+	b := NewClientWithOptions(server.URL, "t", ClientOptions{RateLimiter: limiter})
+	var out map[string]any
+	for _, c := range []*Client{a, b, a} {
+		if err := c.Get("/users/me/", &out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(clock.slept) != 2 {
+		t.Errorf("slept %v, want 2 waits for 3 requests at 1rps", clock.slept)
+	}
+}
@@ -0,0 +1,151 @@
+package apitest
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// maxFileBytes is the largest upload the server accepts by default.
+const maxFileBytes = 10 << 20
+
+type file struct {
+	api.File
+	projectID string
+	// uploadToken signs the upload URL; token is the access token in the
+	// permanent download link.
+	uploadToken, token string
+	data               []byte
+	uploaded           bool
+}
+
+// serveFiles serves /files/: creating an upload, finalizing it, and the
+// file's metadata and download URL.
+func (s *Server) serveFiles(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.postFile(w, r)
+		return
+	case len(parts) == 0:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	f := s.files[parts[0]]
+	if f == nil || s.project(f.projectID) == nil {
+		writeError(w, http.StatusNotFound, "File not found")
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, f.File)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(s.files, f.ExternalID)
+		w.WriteHeader(http.StatusNoContent)
+	case route(parts[1:], "finalize") && r.Method == http.MethodPost:
+		switch {
+		case r.URL.Query().Get("mark_failed") == "true":
+			if f.Status != "available" {
+				f.Status = "failed"
+			}
+		case !f.uploaded:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "finalize_failed", "message": "No pending blob found for this upload"})
+			return
+		default:
+			f.Status = "available"
+		}
+		writeJSON(w, http.StatusOK, f.File)
+	case route(parts[1:], "download") && r.Method == http.MethodGet:
+		if f.Status != "available" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "download_failed", "message": "File is not available (status: " + f.Status + ")"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"download_url": f.Link, "provider": "hyper", "expires_at": nil})
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) postFile(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateFileUploadRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	switch {
+	case req.Filename == "" || req.ContentType == "" || req.SizeBytes <= 0:
+		writeError(w, http.StatusUnprocessableEntity, "filename, content_type, and a positive size_bytes are required")
+		return
+	case req.SizeBytes > maxFileBytes:
+		writeError(w, http.StatusUnprocessableEntity, "File size exceeds maximum allowed size")
+		return
+	}
+	if s.project(req.ProjectID) == nil {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	f := &file{
+		File: api.File{
+			ExternalID:  s.newID("file"),
+			Filename:    req.Filename,
+			ContentType: req.ContentType,
+			SizeBytes:   req.SizeBytes,
+			Status:      "pending_url",
+			Created:     s.now(),
+		},
+		projectID:   req.ProjectID,
+		uploadToken: s.newID("sig"),
+		token:       s.newID("tok"),
+	}
+	// Like a server without WS_ROOT_URL set, the link is relative to its
+	// root.
+	f.Link = "/files/" + f.projectID + "/" + f.ExternalID + "/" + f.token + "/"
+	s.files[f.ExternalID] = f
+
+	// The upload URL is absolute, as storage's would be, and names this
+	// server's API root, as local storage does.
+	apiRoot := strings.TrimSuffix(strings.SplitN(r.RequestURI, "?", 2)[0], r.URL.Path)
+	query := url.Values{"key": {f.ExternalID}, "token": {f.uploadToken}}
+	writeJSON(w, http.StatusCreated, api.FileUpload{
+		File:          f.File,
+		UploadURL:     "http://" + r.Host + apiRoot + "/internal/upload-local/?" + query.Encode(),
+		UploadHeaders: map[string]string{"Content-Type": f.ContentType},
+	})
+}
+
+// serveUpload stores the bytes of a pending upload, authorized by the
+// signed URL instead of a token.
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.files[r.URL.Query().Get("key")]
+	if f == nil || r.URL.Query().Get("token") != f.uploadToken {
+		writeError(w, http.StatusForbidden, "Invalid upload signature")
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxFileBytes+1))
+	if err != nil || int64(len(data)) != f.SizeBytes {
+		writeError(w, http.StatusBadRequest, "Upload does not match the size given")
+		return
+	}
+	f.data = data
+	f.uploaded = true
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveDownload serves a file at its permanent link,
+// /files/{project_id}/{file_id}/{access_token}/, which needs no token.
+func (s *Server) serveDownload(w http.ResponseWriter, parts []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.files[parts[1]]
+	if f == nil || f.projectID != parts[0] || f.token != parts[2] || f.Status != "available" {
+		writeError(w, http.StatusNotFound, "File not found")
+		return
+	}
+	w.Header().Set("Content-Type", f.ContentType)
+	_, _ = w.Write(f.data)
+}
@@ -0,0 +1,340 @@
+package apitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+	"github.com/hyperclast/workspace/cli/internal/diff"
+)
+
+// autocompleteLimit is how many pages a title search returns, as on a real
+// server.
+const autocompleteLimit = 10
+
+func (s *Server) servePages(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.listPages(w, r)
+		return
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.postPage(w, r)
+		return
+	case route(parts, "autocomplete") && r.Method == http.MethodGet:
+		s.autocomplete(w, r)
+		return
+	case len(parts) == 0:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	p := s.pages[parts[0]]
+	if p == nil || s.project(p.projectID) == nil {
+		writeError(w, http.StatusNotFound, "Page not found")
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.pageJSON(p, r.URL.Query().Get("omit") == "content"))
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		s.putPage(w, r, p)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(s.pages, p.id)
+		w.WriteHeader(http.StatusNoContent)
+	case route(parts[1:], "download") && r.Method == http.MethodGet:
+		// Like a server that cannot render, send the raw file whatever
+		// format was asked for. Range requests are honored.
+		content, _ := p.details["content"].(string)
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+	case route(parts[1:], "access-code") && r.Method == http.MethodPost:
+		if p.role == api.RoleViewer {
+			writeError(w, http.StatusForbidden, "You don't have permission to generate access codes for this page")
+			return
+		}
+		if p.accessCode == "" {
+			p.accessCode = s.newID("code")
+		}
+		writeJSON(w, http.StatusOK, api.AccessCodeOut{AccessCode: p.accessCode})
+	case route(parts[1:], "access-code") && r.Method == http.MethodDelete:
+		if p.role == api.RoleViewer {
+			writeError(w, http.StatusForbidden, "You don't have permission to remove access codes from this page")
+			return
+		}
+		p.accessCode = ""
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 2 && parts[1] == "rewind" && r.Method == http.MethodGet:
+		s.listRevisions(w, r, p)
+	case len(parts) == 3 && parts[1] == "rewind" && r.Method == http.MethodGet:
+		for _, rev := range p.revisions {
+			if rev.ExternalID == parts[2] {
+				writeJSON(w, http.StatusOK, api.Revision{RevisionSummary: rev.RevisionSummary, Content: rev.content})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Revision not found")
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) listPages(w http.ResponseWriter, r *http.Request) {
+	pages := []wirePage{}
+	for _, p := range s.sortedPages() {
+		if s.project(p.projectID) != nil {
+			pages = append(pages, s.pageJSON(p, true))
+		}
+	}
+	limit := queryInt(r, "limit", api.DefaultPageBatch)
+	offset := queryInt(r, "offset", 0)
+	start, end := min(offset, len(pages)), min(offset+limit, len(pages))
+	writeJSON(w, http.StatusOK, map[string]any{"items": pages[start:end], "count": len(pages)})
+}
+
+func (s *Server) autocomplete(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("q"))
+	orgID := r.URL.Query().Get("org_id")
+	pages := []wirePage{}
+	for _, p := range s.sortedPages() {
+		proj := s.project(p.projectID)
+		if proj == nil || (orgID != "" && proj.orgID != orgID) || !strings.Contains(strings.ToLower(p.title), query) {
+			continue
+		}
+		pages = append(pages, s.pageJSON(p, true))
+		if len(pages) == autocompleteLimit {
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"pages": pages})
+}
+
+func (s *Server) postPage(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ProjectID string         `json:"project_id"`
+		Title     string         `json:"title"`
+		Details   map[string]any `json:"details"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if s.project(req.ProjectID) == nil {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	if req.Title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+	if req.Details == nil {
+		req.Details = map[string]any{}
+	}
+	if ft, _ := req.Details["filetype"].(string); ft != "" && !slices.Contains(api.PageFiletypes, ft) {
+		writeError(w, http.StatusBadRequest, "Unsupported filetype: "+ft)
+		return
+	}
+	if content, _ := req.Details["content"].(string); len(content) > api.MaxPageBytes {
+		writeError(w, http.StatusRequestEntityTooLarge, "Content exceeds the maximum page size")
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.pageJSON(s.createPage(req.ProjectID, req.Title, req.Details), false))
+}
+
+func (s *Server) createPage(projectID, title string, details map[string]any) *page {
+	now := s.now()
+	p := &page{
+		id:        s.newID("page"),
+		title:     title,
+		projectID: projectID,
+		created:   now,
+		modified:  now,
+		details:   details,
+	}
+	s.pages[p.id] = p
+	s.recordRevision(p, "")
+	return p
+}
+
+// putPage updates a page as PUT /pages/{id}/ does: content is merged by
+// mode (append unless given), other detail fields replace those stored or,
+// when null, remove them, and project_id moves the page.
+func (s *Server) putPage(w http.ResponseWriter, r *http.Request, p *page) {
+	var req struct {
+		Title     string         `json:"title"`
+		ProjectID string         `json:"project_id"`
+		Details   map[string]any `json:"details"`
+		Mode      string         `json:"mode"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.ProjectID != "" && req.ProjectID != p.projectID {
+		target := s.project(req.ProjectID)
+		switch {
+		case target == nil:
+			writeError(w, http.StatusNotFound, "Project not found")
+			return
+		case target.orgID != s.project(p.projectID).orgID:
+			writeError(w, http.StatusBadRequest, "Pages cannot move to a project in another organization")
+			return
+		case p.role != "" && p.role != api.RoleAdmin:
+			writeError(w, http.StatusForbidden, "Only the creator can change a page's project")
+			return
+		}
+	}
+
+	old := pageContent(p)
+	content := old
+	if v, ok := req.Details["content"]; ok {
+		incoming, _ := v.(string)
+		switch req.Mode {
+		case "", "append":
+			content = old + incoming
+		case "prepend":
+			content = incoming + old
+		case "overwrite":
+			content = incoming
+		default:
+			writeError(w, http.StatusBadRequest, "Invalid mode: "+req.Mode)
+			return
+		}
+	}
+	if len(content) > api.MaxPageBytes {
+		writeError(w, http.StatusRequestEntityTooLarge, "Content exceeds the maximum page size")
+		return
+	}
+
+	for k, v := range req.Details {
+		switch {
+		case k == "content":
+		case v == nil:
+			delete(p.details, k)
+		default:
+			p.details[k] = v
+		}
+	}
+	p.details["content"] = content
+	if req.Title != "" {
+		p.title = req.Title
+	}
+	if req.ProjectID != "" && req.ProjectID != p.projectID {
+		p.projectID = req.ProjectID
+		p.folderID = ""
+	}
+	p.modified = s.now()
+	if content != old {
+		s.recordRevision(p, old)
+	}
+	writeJSON(w, http.StatusOK, s.pageJSON(p, false))
+}
+
+// recordRevision adds the page's current content to its history, counting
+// lines against old, the content it replaced.
+func (s *Server) recordRevision(p *page, old string) {
+	content := pageContent(p)
+	added, deleted := diff.Stat(diff.Lines(old, content))
+	p.revisions = append(p.revisions, revision{
+		RevisionSummary: api.RevisionSummary{
+			ExternalID:   s.newID("rw"),
+			Number:       len(p.revisions) + 1,
+			Title:        p.title,
+			Bytes:        int64(len(content)),
+			Editors:      []string{UserEmail},
+			LinesAdded:   added,
+			LinesDeleted: deleted,
+			Created:      p.modified,
+		},
+		content: content,
+	})
+}
+
+func (s *Server) listRevisions(w http.ResponseWriter, r *http.Request, p *page) {
+	label := r.URL.Query().Get("label")
+	items := []api.RevisionSummary{}
+	for i := len(p.revisions) - 1; i >= 0; i-- {
+		if label == "" || p.revisions[i].Label == label {
+			items = append(items, p.revisions[i].RevisionSummary)
+		}
+	}
+	limit := queryInt(r, "limit", len(items))
+	offset := queryInt(r, "offset", 0)
+	start, end := min(offset, len(items)), min(offset+limit, len(items))
+	writeJSON(w, http.StatusOK, api.RevisionList{Items: items[start:end], Count: len(items)})
+}
+
+// sortedPages returns every page, most recently updated first.
+func (s *Server) sortedPages() []*page {
+	pages := make([]*page, 0, len(s.pages))
+	for _, p := range s.pages {
+		pages = append(pages, p)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].modified != pages[j].modified {
+			return pages[i].modified > pages[j].modified
+		}
+		return pages[i].id > pages[j].id
+	})
+	return pages
+}
+
+func pageContent(p *page) string {
+	content, _ := p.details["content"].(string)
+	return content
+}
+
+// wirePage is a page as the API sends it. Details stay a map so fields the
+// client does not model yet survive a round trip.
+type wirePage struct {
+	api.Page
+	Details map[string]any `json:"details"`
+}
+
+// pageJSON returns p as the API sends it. Without content, as in listings and
+// for ?omit=content, details carry content_size instead.
+func (s *Server) pageJSON(p *page, omitContent bool) wirePage {
+	details := make(map[string]any, len(p.details)+1)
+	for k, v := range p.details {
+		details[k] = v
+	}
+	if omitContent {
+		delete(details, "content")
+		details["content_size"] = len(pageContent(p))
+	}
+	filetype, _ := details["filetype"].(string)
+	role := p.role
+	if role == "" {
+		role = api.RoleAdmin
+	}
+
+	out := wirePage{
+		Page: api.Page{
+			ExternalID: p.id,
+			Title:      p.title,
+			Filetype:   filetype,
+			Updated:    p.modified,
+			Modified:   p.modified,
+			Created:    p.created,
+			Role:       role,
+			FolderID:   p.folderID,
+			ProjectID:  p.projectID,
+			AccessCode: p.accessCode,
+		},
+		Details: details,
+	}
+	if proj := s.project(p.projectID); proj != nil {
+		out.OrgID = proj.orgID
+	}
+	return out
+}
+
+// decode converts v, as the API sends it, to the client's type T.
+func decode[T any](v any) T {
+	var out T
+	if data, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(data, &out)
+	}
+	return out
+}
@@ -0,0 +1,458 @@
+// Package apitest is an in-memory fake of the Hyperclast API: the user,
+// orgs, projects, folders, pages, page revisions, files and access codes.
+// Only routes the real API has are served, so a CLI feature the server
+// lacks fails against the fake as it would against the server. It backs the command-level tests and
+// 'hyperclast sandbox serve', so it is an http.Handler rather than a test
+// helper, and state lives only as long as the Server.
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+// The sandbox's seeded user, org, and project.
+const (
+	DefaultToken     = "sandbox-token"
+	UserID           = "user_sandbox"
+	UserEmail        = "dev@sandbox.local"
+	DefaultOrgID     = "org_sandbox"
+	DefaultProjectID = "proj_sandbox"
+)
+
+type project struct {
+	id, name, description string
+	orgID                 string
+	created, modified     string
+	folders               []api.Folder
+}
+
+type page struct {
+	id, title         string
+	projectID         string
+	folderID          string
+	created, modified string
+	role              string
+	details           map[string]any
+	revisions         []revision
+	accessCode        string
+}
+
+type revision struct {
+	api.RevisionSummary
+	content string
+}
+
+// Server is the fake API. Paths are relative to the API root, so mount it
+// under /api with http.StripPrefix to match a real server's URLs.
+type Server struct {
+	mu       sync.Mutex
+	token    string
+	clock    time.Time
+	nextID   int
+	orgs     []api.Org
+	members  map[string][]api.OrgMember
+	projects []*project
+	pages    map[string]*page
+	files    map[string]*file
+}
+
+// New returns a server accepting token as its only bearer token, or
+// DefaultToken when token is empty, seeded with one org and project.
+func New(token string) *Server {
+	if token == "" {
+		token = DefaultToken
+	}
+	s := &Server{
+		token:   token,
+		members: make(map[string][]api.OrgMember),
+		pages:   make(map[string]*page),
+		files:   make(map[string]*file),
+	}
+	now := s.now()
+	s.orgs = append(s.orgs, api.Org{ExternalID: DefaultOrgID, Name: "Sandbox"})
+	s.members[DefaultOrgID] = []api.OrgMember{
+		{ExternalID: UserID, Email: UserEmail, Username: "dev", Role: "admin", Created: now},
+	}
+	s.projects = append(s.projects, &project{
+		id: DefaultProjectID, name: "Sandbox", orgID: DefaultOrgID, created: now, modified: now,
+	})
+	return s
+}
+
+// timeLayout is RFC 3339 with fixed-width milliseconds, so timestamps sort
+// as strings.
+const timeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// now returns the current time, strictly later than any time it returned
+// before, so pages updated in quick succession still sort by update.
+func (s *Server) now() string {
+	t := time.Now().UTC().Truncate(time.Millisecond)
+	if !t.After(s.clock) {
+		t = s.clock.Add(time.Millisecond)
+	}
+	s.clock = t
+	return t.Format(timeLayout)
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s_%d", prefix, s.nextID)
+}
+
+// AddProject creates a project in the seeded org.
+func (s *Server) AddProject(name string) api.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.createProject(DefaultOrgID, name, "")
+	return decode[api.Project](s.projectJSON(p, false))
+}
+
+// AddPage creates a txt page, as if through POST /pages/.
+func (s *Server) AddPage(projectID, title, content string) api.Page {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.createPage(projectID, title, map[string]any{"content": content, "filetype": "txt", "schema_version": 1})
+	return decode[api.Page](s.pageJSON(p, false))
+}
+
+// SetPageDetails merges fields into a page's details as another client
+// would, updating its modified time but recording no revision. A nil value
+// removes the field.
+func (s *Server) SetPageDetails(id string, fields map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.pages[id]
+	for k, v := range decode[map[string]any](fields) {
+		if v == nil {
+			delete(p.details, k)
+		} else {
+			p.details[k] = v
+		}
+	}
+	p.modified = s.now()
+}
+
+// SetPageRole sets the sandbox user's access to a page: api.RoleViewer,
+// RoleEditor, or RoleAdmin, the default.
+func (s *Server) SetPageRole(id, role string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pages[id].role = role
+}
+
+// Files returns a project's available files and their bytes, keyed by
+// filename.
+func (s *Server) Files(projectID string) map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := make(map[string][]byte)
+	for _, f := range s.files {
+		if f.projectID == projectID && f.Status == "available" {
+			files[f.Filename] = f.data
+		}
+	}
+	return files
+}
+
+// AccessCode returns a page's read-only access code, or "" if it has none.
+func (s *Server) AccessCode(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pages[id].accessCode
+}
+
+// Page returns a page with its content, or false if there is none with id.
+func (s *Server) Page(id string) (api.Page, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pages[id]
+	if !ok {
+		return api.Page{}, false
+	}
+	return decode[api.Page](s.pageJSON(p, false)), true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+	// Uploads and downloads are authorized by their URLs, not the token.
+	switch {
+	case r.Method == http.MethodPut && path == "internal/upload-local":
+		s.serveUpload(w, r)
+		return
+	case r.Method == http.MethodGet && len(parts) == 4 && parts[0] == "files":
+		s.serveDownload(w, parts[1:])
+		return
+	}
+
+	var serve func(http.ResponseWriter, *http.Request, []string)
+	switch parts[0] {
+	case "users":
+		serve = s.serveUsers
+	case "orgs":
+		serve = s.serveOrgs
+	case "projects":
+		serve = s.serveProjects
+	case "pages":
+		serve = s.servePages
+	case "files":
+		serve = s.serveFiles
+	default:
+		// As on the real server, a path no router has is not found, with
+		// or without a token.
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	serve(w, r, parts[1:])
+}
+
+func (s *Server) serveUsers(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case r.Method == http.MethodGet && route(parts, "me"):
+		writeJSON(w, http.StatusOK, api.User{ExternalID: UserID, Email: UserEmail})
+	case r.Method == http.MethodGet && route(parts, "me", "tokens"):
+		writeJSON(w, http.StatusOK, []api.AccessToken{
+			{ExternalID: "tok_sandbox", Label: "sandbox", IsDefault: true, IsActive: true, Created: s.clock.Format(timeLayout)},
+		})
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) serveOrgs(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 0:
+		orgs := []api.Org{}
+		for _, org := range s.orgs {
+			if s.isMember(org.ExternalID) {
+				orgs = append(orgs, org)
+			}
+		}
+		writeJSON(w, http.StatusOK, orgs)
+	case len(parts) >= 2 && parts[1] == "members":
+		members, ok := s.members[parts[0]]
+		if !ok || !s.isMember(parts[0]) {
+			writeError(w, http.StatusNotFound, "Organization not found")
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && len(parts) == 2:
+			writeJSON(w, http.StatusOK, members)
+		case r.Method == http.MethodDelete && len(parts) == 3:
+			s.removeMember(w, parts[0], parts[2])
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) isMember(orgID string) bool {
+	for _, m := range s.members[orgID] {
+		if m.ExternalID == UserID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) removeMember(w http.ResponseWriter, orgID, userID string) {
+	members := s.members[orgID]
+	admins := 0
+	for _, m := range members {
+		if m.Role == "admin" {
+			admins++
+		}
+	}
+	for i, m := range members {
+		if m.ExternalID != userID {
+			continue
+		}
+		if m.Role == "admin" && admins == 1 {
+			writeError(w, http.StatusBadRequest, "Cannot remove the only admin of an organization")
+			return
+		}
+		s.members[orgID] = append(members[:i:i], members[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeError(w, http.StatusNotFound, "Member not found")
+}
+
+func (s *Server) serveProjects(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			orgID := r.URL.Query().Get("org_id")
+			projects := []wireProject{}
+			for _, p := range s.projects {
+				if s.isMember(p.orgID) && (orgID == "" || p.orgID == orgID) {
+					projects = append(projects, s.projectJSON(p, false))
+				}
+			}
+			writeJSON(w, http.StatusOK, projects)
+		case http.MethodPost:
+			var req api.CreateProjectRequest
+			if !readJSON(w, r, &req) {
+				return
+			}
+			if req.Name == "" {
+				writeError(w, http.StatusBadRequest, "name is required")
+				return
+			}
+			if !s.isMember(req.OrgID) {
+				writeError(w, http.StatusNotFound, "Organization not found")
+				return
+			}
+			writeJSON(w, http.StatusCreated, s.projectJSON(s.createProject(req.OrgID, req.Name, req.Description), false))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	p := s.project(parts[0])
+	if p == nil {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		writeJSON(w, http.StatusOK, s.projectJSON(p, true))
+	case r.Method == http.MethodPost && route(parts[1:], "folders"):
+		var req api.CreateFolderRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		folder := api.Folder{ExternalID: s.newID("folder"), Name: req.Name}
+		if req.ParentID != nil {
+			folder.ParentID = *req.ParentID
+		}
+		p.folders = append(p.folders, folder)
+		writeJSON(w, http.StatusCreated, folder)
+	case r.Method == http.MethodPost && route(parts[1:], "folders", "move-pages"):
+		var req api.MovePagesRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		folderID := ""
+		if req.FolderID != nil {
+			folderID = *req.FolderID
+		}
+		for _, id := range req.PageIDs {
+			if pg, ok := s.pages[id]; ok && pg.projectID == p.id {
+				pg.folderID = folderID
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{})
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) project(id string) *project {
+	for _, p := range s.projects {
+		if p.id == id && s.isMember(p.orgID) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) createProject(orgID, name, description string) *project {
+	now := s.now()
+	p := &project{id: s.newID("proj"), name: name, description: description, orgID: orgID, created: now, modified: now}
+	s.projects = append(s.projects, p)
+	return p
+}
+
+// wireProject is a project as the API sends it.
+type wireProject struct {
+	api.Project
+	Pages []wirePage `json:"pages,omitempty"`
+}
+
+// projectJSON returns p as the API sends it, with its pages (newest first,
+// without content) and folders when full is set.
+func (s *Server) projectJSON(p *project, full bool) wireProject {
+	out := wireProject{Project: api.Project{
+		ExternalID:  p.id,
+		Name:        p.name,
+		Description: p.description,
+		Modified:    p.modified,
+		Created:     p.created,
+		Creator:     api.Creator{ExternalID: UserID, Email: UserEmail},
+	}}
+	for _, org := range s.orgs {
+		if org.ExternalID == p.orgID {
+			out.Org = org
+		}
+	}
+	if full {
+		out.Pages = []wirePage{}
+		for _, pg := range s.sortedPages() {
+			if pg.projectID == p.id {
+				out.Pages = append(out.Pages, s.pageJSON(pg, true))
+			}
+		}
+		out.Folders = append([]api.Folder{}, p.folders...)
+	}
+	return out
+}
+
+// route reports whether parts are exactly want.
+func route(parts []string, want ...string) bool {
+	if len(parts) != len(want) {
+		return false
+	}
+	for i := range want {
+		if parts[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// queryInt returns the integer query parameter name, or def when it is
+// missing or malformed.
+func queryInt(r *http.Request, name string, def int) int {
+	n, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || n < 0 {
+		return def
+	}
+	return n
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
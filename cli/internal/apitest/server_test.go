@@ -0,0 +1,168 @@
+package apitest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+func newTestClient(t *testing.T) (*Server, *api.Client) {
+	t.Helper()
+	s := New("tok")
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	return s, api.NewClient(server.URL, "tok")
+}
+
+func TestServerRejectsWrongToken(t *testing.T) {
+	server := httptest.NewServer(New("tok"))
+	defer server.Close()
+
+	_, err := api.NewClient(server.URL, "other").GetCurrentUser()
+	if err == nil || !strings.Contains(err.Error(), "authentication failed") {
+		t.Fatalf("GetCurrentUser with wrong token = %v, want authentication error", err)
+	}
+}
+
+func TestServerPageLifecycle(t *testing.T) {
+	s, client := newTestClient(t)
+
+	page, err := client.CreatePage(DefaultProjectID, "Deploy", "one\n", "txt")
+	if err != nil {
+		t.Fatalf("CreatePage: %v", err)
+	}
+	if page.ExternalID == "" || page.OrgID != DefaultOrgID || !page.CanEdit() {
+		t.Errorf("created page = %+v", page)
+	}
+
+	if _, err := client.UpdatePageContent(page.ExternalID, "two\n", "append"); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if _, err := client.UpdatePageContent(page.ExternalID, "zero\n", "prepend"); err != nil {
+		t.Fatalf("prepend: %v", err)
+	}
+	got, err := client.GetPage(page.ExternalID)
+	if err != nil {
+		t.Fatalf("GetPage: %v", err)
+	}
+	if got.Details.Content != "zero\none\ntwo\n" {
+		t.Errorf("content = %q", got.Details.Content)
+	}
+
+	meta, err := client.GetPageMetadata(page.ExternalID)
+	if err != nil {
+		t.Fatalf("GetPageMetadata: %v", err)
+	}
+	if meta.Details.Content != "" || meta.Details.ContentSize != int64(len("zero\none\ntwo\n")) {
+		t.Errorf("metadata details = %+v, want content_size only", meta.Details)
+	}
+
+	list, err := client.ListPageRevisions(page.ExternalID, 10, 0)
+	if err != nil {
+		t.Fatalf("ListPageRevisions: %v", err)
+	}
+	if list.Count != 3 || list.Items[0].Number != 3 || list.Items[0].LinesAdded != 1 {
+		t.Errorf("revisions = %+v, want 3, newest first with 1 line added", list)
+	}
+	first, err := client.GetRevision(page.ExternalID, list.Items[2].ExternalID)
+	if err != nil || first.Content != "one\n" {
+		t.Errorf("GetRevision(v1) = %+v, %v", first, err)
+	}
+
+	if err := client.DeletePage(page.ExternalID); err != nil {
+		t.Fatalf("DeletePage: %v", err)
+	}
+	if _, ok := s.Page(page.ExternalID); ok {
+		t.Error("page still exists after delete")
+	}
+}
+
+func TestServerSetPageDetails(t *testing.T) {
+	s, client := newTestClient(t)
+	page := s.AddPage(DefaultProjectID, "Notes", "body\n")
+
+	if _, err := client.SetPageDetails(page.ExternalID, map[string]any{"status": "done", "icon": "x"}); err != nil {
+		t.Fatalf("SetPageDetails: %v", err)
+	}
+	if _, err := client.SetPageDetails(page.ExternalID, map[string]any{"icon": nil}); err != nil {
+		t.Fatalf("SetPageDetails: %v", err)
+	}
+	got, _ := s.Page(page.ExternalID)
+	if got.Details.Status != "done" || got.Details.Icon != "" || got.Details.Content != "body\n" {
+		t.Errorf("details = %+v, want status kept, icon removed, content untouched", got.Details)
+	}
+}
+
+func TestServerListsNewestFirst(t *testing.T) {
+	s, client := newTestClient(t)
+	other := s.AddProject("Other")
+	a := s.AddPage(DefaultProjectID, "alpha", "")
+	b := s.AddPage(other.ExternalID, "beta", "")
+	if _, err := client.UpdatePageContent(a.ExternalID, "x", "append"); err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := client.ListPages("")
+	if err != nil {
+		t.Fatalf("ListPages: %v", err)
+	}
+	if len(pages) != 2 || pages[0].ExternalID != a.ExternalID || pages[1].ExternalID != b.ExternalID {
+		t.Errorf("ListPages = %+v, want alpha then beta", pages)
+	}
+
+	project, err := client.GetProject(other.ExternalID)
+	if err != nil || len(project.Pages) != 1 || project.Pages[0].ExternalID != b.ExternalID {
+		t.Errorf("GetProject = %+v, %v; want only beta", project, err)
+	}
+
+	found, err := client.SearchPages("ALP", "")
+	if err != nil || len(found) != 1 || found[0].ExternalID != a.ExternalID {
+		t.Errorf("SearchPages = %+v, %v; want alpha", found, err)
+	}
+}
+
+func TestServerRefusesToRemoveOnlyAdmin(t *testing.T) {
+	_, client := newTestClient(t)
+
+	err := client.RemoveOrgMember(DefaultOrgID, UserID)
+	if err == nil || !strings.Contains(err.Error(), "only admin") {
+		t.Fatalf("RemoveOrgMember = %v, want only-admin error", err)
+	}
+}
+
+func TestServerUnknownPage(t *testing.T) {
+	_, client := newTestClient(t)
+
+	_, err := client.GetPage("page_missing")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("GetPage(missing) = %v, want 404", err)
+	}
+}
+
+func TestServerOnlyServesAPIRoutes(t *testing.T) {
+	s := New("tok")
+	server := httptest.NewServer(s)
+	defer server.Close()
+	client := api.NewClient(server.URL, "tok")
+	page := s.AddPage(DefaultProjectID, "Log", "")
+
+	// The API has no telemetry or page event routes, so the CLI's
+	// fallbacks are what the fake exercises.
+	resp, err := http.Post(server.URL+"/cli/telemetry/", "application/json", strings.NewReader(`{"events":[]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("telemetry status = %d, want 404", resp.StatusCode)
+	}
+	err = client.WatchPageEvents(context.Background(), page.ExternalID, func(api.PageEvent) error { return nil })
+	if !errors.Is(err, api.ErrEventsUnsupported) {
+		t.Errorf("WatchPageEvents = %v, want ErrEventsUnsupported", err)
+	}
+}
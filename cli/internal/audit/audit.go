@@ -0,0 +1,116 @@
+// Package audit keeps a local, append-only log of the CLI commands that
+// changed something on the server, so what a token did can be answered
+// without server access.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const logFile = "audit.jsonl"
+
+// Entry is one command that made at least one mutating request.
+type Entry struct {
+	Time       time.Time         `json:"time"`
+	Command    string            `json:"command"`
+	Args       []string          `json:"args,omitempty"`
+	Flags      map[string]string `json:"flags,omitempty"`
+	Targets    []string          `json:"targets"`
+	Requests   int               `json:"requests"`
+	Failed     int               `json:"failed"`
+	BytesSent  int64             `json:"bytes_sent"`
+	Result     string            `json:"result"`
+	Error      string            `json:"error,omitempty"`
+	Token      string            `json:"token,omitempty"`
+	OnBehalfOf string            `json:"on_behalf_of,omitempty"`
+	APIURL     string            `json:"api_url"`
+}
+
+// Results recorded in Entry.Result.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+type Log struct {
+	path string
+}
+
+// Open returns an audit log stored in dir. The file is created on first
+// Record.
+func Open(dir string) *Log {
+	return &Log{path: filepath.Join(dir, logFile)}
+}
+
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends e. Entries are only ever appended; nothing in the CLI
+// rewrites or truncates the log.
+func (l *Log) Record(e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// Entries returns entries recorded at or after since and before until; a
+// zero until means no upper bound. Malformed lines are skipped so a
+// truncated write never makes the log unreadable.
+func (l *Log) Entries(since, until time.Time) ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if e.Time.Before(since) || (!until.IsZero() && !e.Time.Before(until)) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// TokenFingerprint identifies a token in the log without recording it: the
+// first 12 hex digits of its SHA-256. It is "" for no token.
+func TokenFingerprint(token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLogRecordAndEntries(t *testing.T) {
+	log := Open(t.TempDir())
+	now := time.Now().UTC()
+	for _, at := range []time.Time{now.Add(-72 * time.Hour), now.Add(-36 * time.Hour), now} {
+		if err := log.Record(Entry{Time: at, Command: "hyperclast page append", Result: ResultOK}); err != nil {
+			t.Fatalf("Record() returned error: %v", err)
+		}
+	}
+
+	got, err := log.Entries(now.Add(-48*time.Hour), now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Entries() returned error: %v", err)
+	}
+	if len(got) != 1 || !got[0].Time.Equal(now.Add(-36*time.Hour)) {
+		t.Errorf("Entries() = %v, want only the entry from 36h ago", got)
+	}
+
+	info, err := os.Stat(log.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("audit log permissions = %o, want 600", info.Mode().Perm())
+	}
+}
+
+func TestTokenFingerprint(t *testing.T) {
+	if TokenFingerprint("") != "" {
+		t.Error("fingerprint of no token should be empty")
+	}
+	a, b := TokenFingerprint("token-a"), TokenFingerprint("token-b")
+	if len(a) != 12 || a == b || a != TokenFingerprint("token-a") {
+		t.Errorf("fingerprints %q and %q should be stable, distinct, and 12 digits", a, b)
+	}
+}
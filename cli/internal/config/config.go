@@ -2,33 +2,110 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"math"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hyperclast/workspace/cli/internal/schedule"
 	"gopkg.in/yaml.v3"
 )
 
 type Defaults struct {
 	OrgID     string `yaml:"org_id,omitempty"`
 	ProjectID string `yaml:"project_id,omitempty"`
+	// Pages maps a project ID to its default (inbox) page, the target of
+	// 'hyperclast push'.
+	Pages map[string]string `yaml:"pages,omitempty"`
 }
 
 type Config struct {
-	APIURL   string   `yaml:"api_url"`
+	APIURL string `yaml:"api_url"`
+	// WebURL is the address of the web app, used for the links the CLI
+	// prints. It is derived from APIURL when unset.
+	WebURL   string   `yaml:"web_url,omitempty"`
 	Token    string   `yaml:"token,omitempty"`
+	Output   string   `yaml:"output,omitempty"`
+	Timeout  string   `yaml:"timeout,omitempty"`
+	Retries  int      `yaml:"retries,omitempty"`
+	Throttle string   `yaml:"throttle,omitempty"`
 	Defaults Defaults `yaml:"defaults,omitempty"`
 
+	// JournalMarker is the template of the marker line recorded by
+	// 'page append --journal'.
+	JournalMarker string `yaml:"journal_marker,omitempty"`
+
+	// PageTemplate pre-fills pages composed with 'page new' when there is
+	// no input to read.
+	PageTemplate string `yaml:"page_template,omitempty"`
+
+	// Routes decide where 'hyperclast capture' sends its input.
+	Routes []Route `yaml:"routes,omitempty"`
+
+	// QuietHours are daily windows, such as "09:00-17:30", during which
+	// background appends (mux, the capture package) are held back.
+	QuietHours string `yaml:"quiet_hours,omitempty"`
+
+	// UploadRate caps the average rate of background appends, such as
+	// "64KB/s".
+	UploadRate string `yaml:"upload_rate,omitempty"`
+
+	// ProtectedProjects are projects the CLI only writes to when named with
+	// --project, or after a confirmation.
+	ProtectedProjects []string `yaml:"protected_projects,omitempty"`
+
+	// Presets are named bundles of 'page new' settings, chosen with
+	// --preset.
+	Presets map[string]Preset `yaml:"presets,omitempty"`
+
+	// PinnedPages are the pages listed by 'page pinned', in the order they
+	// were pinned.
+	PinnedPages []string `yaml:"pinned_pages,omitempty"`
+
 	path string
 }
 
+// Preset is a named set of 'page new' flags. Flags given on the command
+// line take precedence; Labels are added to any given with --label.
+type Preset struct {
+	Project    string   `yaml:"project,omitempty"`
+	Title      string   `yaml:"title,omitempty"`
+	Filetype   string   `yaml:"filetype,omitempty"`
+	Meta       bool     `yaml:"meta,omitempty"`
+	Source     string   `yaml:"source,omitempty"`
+	Substitute bool     `yaml:"substitute,omitempty"`
+	Labels     []string `yaml:"labels,omitempty"`
+	Redact     bool     `yaml:"redact,omitempty"`
+}
+
+// Route sends captured content matching Match, a regular expression, to
+// Project with Labels. An empty Match matches everything.
+type Route struct {
+	Match    string   `yaml:"match,omitempty"`
+	Project  string   `yaml:"project"`
+	Title    string   `yaml:"title,omitempty"`
+	Filetype string   `yaml:"filetype,omitempty"`
+	Labels   []string `yaml:"labels,omitempty"`
+}
+
 const defaultAPIURL = "https://hyperclast.com/api"
 
+// DefaultPath returns the config file used when neither --config nor
+// $HYPERCLAST_CONFIG is given.
 func DefaultPath() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-	return filepath.Join(homeDir, ".config", "hyperclast", "config.yaml")
+	return ResolveDirs().ConfigPath()
+}
+
+// StateDir returns the directory for local CLI state such as the usage
+// ledger. It follows XDG_STATE_HOME, defaulting to ~/.local/state/hyperclast
+// (%LOCALAPPDATA%\hyperclast on Windows), or $HYPERCLAST_CONFIG_DIR/state.
+func StateDir() string {
+	return ResolveDirs().State
 }
 
 func Load(path string) (*Config, error) {
@@ -84,7 +161,21 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to serialize config: %w", err)
 	}
 
-	if err := os.WriteFile(c.path, data, 0600); err != nil {
+	// Write to a temp file and rename it into place, so a concurrent Load
+	// sees the old config or the new one, never a partial file.
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(c.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -111,6 +202,31 @@ func (c *Config) SetDefaultProject(projectID string) {
 	c.Defaults.ProjectID = projectID
 }
 
+// Preset returns the preset called name.
+func (c *Config) Preset(name string) (Preset, error) {
+	if preset, ok := c.Presets[name]; ok {
+		return preset, nil
+	}
+	if len(c.Presets) == 0 {
+		return Preset{}, fmt.Errorf("unknown preset %q: no presets are configured", name)
+	}
+	names := slices.Sorted(maps.Keys(c.Presets))
+	return Preset{}, fmt.Errorf("unknown preset %q (configured: %s)", name, strings.Join(names, ", "))
+}
+
+// SetDefaultPage records pageID as projectID's default page. An empty
+// pageID clears it.
+func (c *Config) SetDefaultPage(projectID, pageID string) {
+	if pageID == "" {
+		delete(c.Defaults.Pages, projectID)
+		return
+	}
+	if c.Defaults.Pages == nil {
+		c.Defaults.Pages = make(map[string]string)
+	}
+	c.Defaults.Pages[projectID] = pageID
+}
+
 func (c *Config) GetDefaultOrg() string {
 	return c.Defaults.OrgID
 }
@@ -119,6 +235,197 @@ func (c *Config) GetDefaultProject() string {
 	return c.Defaults.ProjectID
 }
 
+// IsProtectedProject reports whether projectID is listed in
+// protected_projects.
+func (c *Config) IsProtectedProject(projectID string) bool {
+	return projectID != "" && slices.Contains(c.ProtectedProjects, projectID)
+}
+
+// GetDefaultPage returns projectID's default page, or "" if none is set.
+func (c *Config) GetDefaultPage(projectID string) string {
+	return c.Defaults.Pages[projectID]
+}
+
+// PinPage adds pageID to the pinned pages. It reports false if the page was
+// already pinned.
+func (c *Config) PinPage(pageID string) bool {
+	if slices.Contains(c.PinnedPages, pageID) {
+		return false
+	}
+	c.PinnedPages = append(c.PinnedPages, pageID)
+	return true
+}
+
+// UnpinPage removes pageID from the pinned pages. It reports false if the
+// page was not pinned.
+func (c *Config) UnpinPage(pageID string) bool {
+	i := slices.Index(c.PinnedPages, pageID)
+	if i < 0 {
+		return false
+	}
+	c.PinnedPages = slices.Delete(c.PinnedPages, i, i+1)
+	return true
+}
+
+// UsesDefaultAPIURL reports whether the API URL is the hosted service rather
+// than a self-hosted instance.
+func (c *Config) UsesDefaultAPIURL() bool {
+	return strings.TrimSuffix(c.APIURL, "/") == defaultAPIURL
+}
+
+// GetWebURL returns the web app's address, without a trailing slash: the
+// configured web_url, or else the API URL without its /api or /api/v1 path
+// suffix. An API served from its own host, such as api.example.com, needs
+// web_url set.
+func (c *Config) GetWebURL() string {
+	if c.WebURL != "" {
+		return strings.TrimSuffix(c.WebURL, "/")
+	}
+	u, err := url.Parse(strings.TrimSpace(c.APIURL))
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(c.APIURL, "/")
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	if p, ok := strings.CutSuffix(path, "/api/v1"); ok {
+		path = p
+	} else {
+		path = strings.TrimSuffix(path, "/api")
+	}
+	web := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path}
+	return web.String()
+}
+
+// ValidateWebURL checks that s is an absolute http(s) URL, as web_url must
+// be.
+func ValidateWebURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid web URL %q (e.g. https://hyperclast.example.com)", s)
+	}
+	return nil
+}
+
+// GetOutput returns the configured default output format, or "" if unset.
+func (c *Config) GetOutput() string {
+	return c.Output
+}
+
+// GetTimeout returns the configured default request timeout, or 0 if unset.
+func (c *Config) GetTimeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q in config (e.g. 30s, 2m)", c.Timeout)
+	}
+	return d, nil
+}
+
+// GetRetries returns the configured default number of request retries.
+func (c *Config) GetRetries() int {
+	return c.Retries
+}
+
+// GetThrottle returns the configured request rate limit in requests per
+// second, or 0 if unset.
+func (c *Config) GetThrottle() (float64, error) {
+	if c.Throttle == "" {
+		return 0, nil
+	}
+	rps, err := ParseRate(c.Throttle)
+	if err != nil {
+		return 0, fmt.Errorf("invalid throttle %q in config (e.g. 5rps, 120/m)", c.Throttle)
+	}
+	return rps, nil
+}
+
+// GetQuietHours returns the configured quiet hours, or nil if unset.
+func (c *Config) GetQuietHours() ([]schedule.Window, error) {
+	windows, err := schedule.ParseWindows(c.QuietHours)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quiet_hours in config: %w", err)
+	}
+	return windows, nil
+}
+
+// GetUploadRate returns the configured cap on background appends in bytes
+// per second, or 0 if unset.
+func (c *Config) GetUploadRate() (int64, error) {
+	if c.UploadRate == "" {
+		return 0, nil
+	}
+	rate, err := ParseByteRate(c.UploadRate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid upload_rate %q in config (e.g. 64KB/s, 1MB/s)", c.UploadRate)
+	}
+	return rate, nil
+}
+
+// GetJournalMarker returns the configured journal marker template, or ""
+// to use the default.
+func (c *Config) GetJournalMarker() string {
+	return c.JournalMarker
+}
+
+// GetPageTemplate returns the configured template for composed pages, or
+// "" for none.
+func (c *Config) GetPageTemplate() string {
+	return c.PageTemplate
+}
+
+// ParseRate parses a request rate such as "5rps", "5/s", or "120/m" into
+// requests per second.
+func ParseRate(s string) (float64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	num, per := s, time.Second
+	switch {
+	case strings.HasSuffix(s, "rps"):
+		num = strings.TrimSuffix(s, "rps")
+	case strings.HasSuffix(s, "/s"):
+		num = strings.TrimSuffix(s, "/s")
+	case strings.HasSuffix(s, "/m"):
+		num, per = strings.TrimSuffix(s, "/m"), time.Minute
+	default:
+		return 0, fmt.Errorf("invalid rate %q (e.g. 5rps, 120/m)", s)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil || n <= 0 || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("invalid rate %q (e.g. 5rps, 120/m)", s)
+	}
+	return n / per.Seconds(), nil
+}
+
+// ParseByteSize parses a byte count with an optional binary unit suffix:
+// "512", "64KB", "1.5 MB".
+func ParseByteSize(s string) (int64, error) {
+	num := strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for i, suffix := range []string{"KB", "MB", "GB"} {
+		if n, ok := strings.CutSuffix(num, suffix); ok {
+			num, mult = n, int64(1)<<(10*(i+1))
+			break
+		}
+	}
+	num = strings.TrimSpace(strings.TrimSuffix(num, "B"))
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (examples: 4096, 64KB, 2MB)", s)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// ParseByteRate parses a transfer rate such as "64KB/s" or "1MB/s" into
+// bytes per second.
+func ParseByteRate(s string) (int64, error) {
+	size, ok := strings.CutSuffix(strings.TrimSpace(strings.ToLower(s)), "/s")
+	n, err := ParseByteSize(size)
+	if !ok || err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid rate %q (e.g. 64KB/s, 1MB/s)", s)
+	}
+	return n, nil
+}
+
 func (c *Config) Path() string {
 	return c.path
 }
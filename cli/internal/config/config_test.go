@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -133,3 +134,184 @@ func TestHyperclastTokenEnvVarEmptyDoesNotOverride(t *testing.T) {
 		t.Errorf("Token = %q, want %q (empty env var should not override)", cfg.Token, "file-token")
 	}
 }
+
+func TestLoadOutputFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("token: file-token\noutput: json\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.GetOutput() != "json" {
+		t.Errorf("GetOutput() = %q, want %q", cfg.GetOutput(), "json")
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"5rps", 5},
+		{"0.5rps", 0.5},
+		{"5/s", 5},
+		{" 120/m ", 2},
+		{"10RPS", 10},
+	}
+	for _, tt := range tests {
+		got, err := ParseRate(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRate(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "5", "fast", "0rps", "-1rps", "5/h"} {
+		if _, err := ParseRate(in); err == nil {
+			t.Errorf("ParseRate(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestGetThrottle(t *testing.T) {
+	cfg := &Config{Throttle: "5rps"}
+	if rps, err := cfg.GetThrottle(); err != nil || rps != 5 {
+		t.Errorf("GetThrottle() = %v, %v; want 5", rps, err)
+	}
+	if rps, err := (&Config{}).GetThrottle(); err != nil || rps != 0 {
+		t.Errorf("unset GetThrottle() = %v, %v; want 0", rps, err)
+	}
+	if _, err := (&Config{Throttle: "lots"}).GetThrottle(); err == nil {
+		t.Error("expected error for invalid throttle")
+	}
+}
+
+func TestConfigDirIsolatesEverything(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HYPERCLAST_CONFIG_DIR", root)
+	t.Setenv("HYPERCLAST_CONFIG", "")
+	t.Setenv("XDG_STATE_HOME", filepath.Join(t.TempDir(), "xdg"))
+
+	if got, want := DefaultPath(), filepath.Join(root, "config.yaml"); got != want {
+		t.Errorf("DefaultPath() = %q, want %q", got, want)
+	}
+	if got, want := StateDir(), filepath.Join(root, "state"); got != want {
+		t.Errorf("StateDir() = %q, want %q (XDG_STATE_HOME must not escape the root)", got, want)
+	}
+
+	f, err := ResolveDirs().CreateTemp("scratch-*")
+	if err != nil {
+		t.Fatalf("CreateTemp() returned error: %v", err)
+	}
+	_ = f.Close()
+	if filepath.Dir(f.Name()) != filepath.Join(root, "cache") {
+		t.Errorf("scratch file %q is outside %s/cache", f.Name(), root)
+	}
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	cfg.SetDefaultProject("proj_sandbox")
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "config.yaml")); err != nil {
+		t.Errorf("config not saved under root: %v", err)
+	}
+}
+
+func TestHyperclastConfigOverridesConfigDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+	if err := os.WriteFile(path, []byte("token: ci-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HYPERCLAST_CONFIG_DIR", t.TempDir())
+	t.Setenv("HYPERCLAST_CONFIG", path)
+	t.Setenv("HYPERCLAST_TOKEN", "")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.Token != "ci-token" {
+		t.Errorf("Token = %q, want %q", cfg.Token, "ci-token")
+	}
+}
+
+func TestSaveLeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := Load(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if err := cfg.Save(); err != nil {
+			t.Fatalf("Save() returned error: %v", err)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("config dir has %d entries, want only config.yaml", len(entries))
+	}
+}
+
+func TestGetUploadRateAndQuietHours(t *testing.T) {
+	cfg := &Config{UploadRate: "64KB/s", QuietHours: "09:00-17:30"}
+	if rate, err := cfg.GetUploadRate(); err != nil || rate != 64*1024 {
+		t.Errorf("GetUploadRate() = %v, %v; want 65536", rate, err)
+	}
+	if windows, err := cfg.GetQuietHours(); err != nil || len(windows) != 1 {
+		t.Errorf("GetQuietHours() = %v, %v; want one window", windows, err)
+	}
+	if rate, err := (&Config{}).GetUploadRate(); err != nil || rate != 0 {
+		t.Errorf("unset GetUploadRate() = %v, %v; want 0", rate, err)
+	}
+	for _, bad := range []string{"64KB", "fast/s", "0/s"} {
+		if _, err := (&Config{UploadRate: bad}).GetUploadRate(); err == nil {
+			t.Errorf("upload_rate %q accepted", bad)
+		}
+	}
+	if _, err := (&Config{QuietHours: "lunch"}).GetQuietHours(); err == nil {
+		t.Error("invalid quiet_hours accepted")
+	}
+}
+
+func TestIsProtectedProject(t *testing.T) {
+	cfg := &Config{ProtectedProjects: []string{"proj_prod_docs"}}
+	if !cfg.IsProtectedProject("proj_prod_docs") {
+		t.Error("proj_prod_docs should be protected")
+	}
+	if cfg.IsProtectedProject("proj_scratch") || cfg.IsProtectedProject("") {
+		t.Error("only listed projects should be protected")
+	}
+}
+
+func TestPinPage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.PinPage("page_a") || !cfg.PinPage("page_b") || cfg.PinPage("page_a") {
+		t.Fatal("PinPage should report only new pins")
+	}
+	if !cfg.UnpinPage("page_a") || cfg.UnpinPage("page_missing") {
+		t.Fatal("UnpinPage should report only pinned pages")
+	}
+	cfg.PinPage("page_c")
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(loaded.PinnedPages, ","); got != "page_b,page_c" {
+		t.Errorf("pinned pages after reload = %s, want page_b,page_c", got)
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Dirs locates the files the CLI keeps on this machine.
+type Dirs struct {
+	// Config holds config.yaml.
+	Config string
+	// State holds local state such as the usage ledger and TLS pins.
+	State string
+	// Cache holds scratch files such as buffered stdin. Empty means the
+	// system temp directory.
+	Cache string
+}
+
+// RootDirs puts everything under root: root/config.yaml, root/state, and
+// root/cache.
+func RootDirs(root string) Dirs {
+	return Dirs{
+		Config: root,
+		State:  filepath.Join(root, "state"),
+		Cache:  filepath.Join(root, "cache"),
+	}
+}
+
+// ResolveDirs returns the directories for this process. With
+// $HYPERCLAST_CONFIG_DIR set, they are all under it, so tests and sandboxed
+// scripts never touch the user's files. Otherwise they are the platform
+// defaults, with $XDG_STATE_HOME honored for state.
+//
+// Nothing is cached: each call reads the environment, so goroutines and
+// processes with different roots do not interfere.
+func ResolveDirs() Dirs {
+	if root := os.Getenv("HYPERCLAST_CONFIG_DIR"); root != "" {
+		return RootDirs(root)
+	}
+	dirs := Dirs{Config: configDir(), State: stateDir()}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		dirs.State = filepath.Join(dir, "hyperclast")
+	}
+	return dirs
+}
+
+// ConfigPath returns the config file in d, or "" if there is no config
+// directory.
+func (d Dirs) ConfigPath() string {
+	if d.Config == "" {
+		return ""
+	}
+	return filepath.Join(d.Config, "config.yaml")
+}
+
+// CreateTemp creates a scratch file in the cache directory, as os.CreateTemp
+// does.
+func (d Dirs) CreateTemp(pattern string) (*os.File, error) {
+	if d.Cache != "" {
+		if err := os.MkdirAll(d.Cache, 0700); err != nil {
+			return nil, err
+		}
+	}
+	return os.CreateTemp(d.Cache, pattern)
+}
+
+// MkdirTemp creates a scratch directory in the cache directory, as
+// os.MkdirTemp does.
+func (d Dirs) MkdirTemp(pattern string) (string, error) {
+	if d.Cache != "" {
+		if err := os.MkdirAll(d.Cache, 0700); err != nil {
+			return "", err
+		}
+	}
+	return os.MkdirTemp(d.Cache, pattern)
+}
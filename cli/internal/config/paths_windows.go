@@ -0,0 +1,33 @@
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// configDir returns %USERPROFILE%\.config\hyperclast. USERPROFILE is read
+// directly because shells like Git Bash set HOME to a different directory.
+func configDir() string {
+	home := os.Getenv("USERPROFILE")
+	if home == "" {
+		var err error
+		if home, err = os.UserHomeDir(); err != nil {
+			return ""
+		}
+	}
+	return filepath.Join(home, ".config", "hyperclast")
+}
+
+// stateDir returns %LOCALAPPDATA%\hyperclast, the per-machine location for
+// data that should not roam with the user profile.
+func stateDir() string {
+	if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+		return filepath.Join(dir, "hyperclast")
+	}
+	if dir := configDir(); dir != "" {
+		return filepath.Join(dir, "state")
+	}
+	return ""
+}
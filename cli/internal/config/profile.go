@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Export returns c as YAML, for sharing as a profile. With noSecrets the
+// token is left out, so the bundle can be handed to others.
+func (c *Config) Export(noSecrets bool) ([]byte, error) {
+	out := *c
+	if noSecrets {
+		out.Token = ""
+	}
+	data, err := yaml.Marshal(&out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+	return data, nil
+}
+
+// Import merges profile, a YAML bundle made by Export, into c. Settings the
+// profile sets replace c's, and maps such as defaults are merged key by key;
+// settings it leaves out are kept, so importing a profile exported with
+// noSecrets keeps c's token. It returns the dotted names of the settings
+// that changed, sorted.
+func (c *Config) Import(profile []byte) ([]string, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(profile))
+	dec.KnownFields(true)
+	var check Config
+	if err := dec.Decode(&check); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid profile: it is empty")
+		}
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+
+	var incoming map[string]any
+	if err := yaml.Unmarshal(profile, &incoming); err != nil {
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+	current := map[string]any{}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &current); err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+
+	var changed []string
+	mergeSettings(current, incoming, "", &changed)
+
+	if data, err = yaml.Marshal(current); err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+	merged := Config{path: c.path}
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return nil, fmt.Errorf("invalid profile: %w", err)
+	}
+	*c = merged
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// mergeSettings copies src into dst, descending into maps present in both,
+// and records the name of every value that changed.
+func mergeSettings(dst, src map[string]any, prefix string, changed *[]string) {
+	for key, value := range src {
+		name := prefix + key
+		sub, srcIsMap := value.(map[string]any)
+		existing, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeSettings(existing, sub, name+".", changed)
+			continue
+		}
+		if !reflect.DeepEqual(dst[key], value) {
+			dst[key] = value
+			*changed = append(*changed, name)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExport_NoSecrets(t *testing.T) {
+	c := &Config{APIURL: "https://hc.example.com/api", Token: "secret", Defaults: Defaults{ProjectID: "proj_a"}}
+
+	data, err := c.Export(true)
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	if strings.Contains(string(data), "secret") || !strings.Contains(string(data), "proj_a") {
+		t.Errorf("Export(true) = %q, want the defaults without the token", data)
+	}
+	if c.Token != "secret" {
+		t.Error("Export cleared the config's own token")
+	}
+}
+
+func TestImport_MergesAndKeepsToken(t *testing.T) {
+	c := &Config{
+		APIURL:   defaultAPIURL,
+		Token:    "mine",
+		Retries:  1,
+		Defaults: Defaults{OrgID: "org_mine", Pages: map[string]string{"proj_a": "page_a"}},
+		path:     "/tmp/config.yaml",
+	}
+	shared := &Config{
+		APIURL:   "https://hc.example.com/api",
+		Retries:  1,
+		Defaults: Defaults{ProjectID: "proj_team", Pages: map[string]string{"proj_team": "page_inbox"}},
+		Routes:   []Route{{Match: "^panic:", Project: "proj_team"}},
+	}
+	profile, err := shared.Export(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := c.Import(profile)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	want := []string{"api_url", "defaults.pages.proj_team", "defaults.project_id", "routes"}
+	if !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	if c.Token != "mine" || c.Defaults.OrgID != "org_mine" || c.Defaults.Pages["proj_a"] != "page_a" {
+		t.Errorf("Import dropped settings the profile leaves out: %+v", c)
+	}
+	if c.APIURL != shared.APIURL || c.Defaults.ProjectID != "proj_team" || len(c.Routes) != 1 {
+		t.Errorf("Import did not apply the profile: %+v", c)
+	}
+	if c.Path() != "/tmp/config.yaml" {
+		t.Errorf("Path() = %q, want it kept", c.Path())
+	}
+}
+
+func TestImport_RejectsInvalidProfile(t *testing.T) {
+	for _, profile := range []string{"", "api_ulr: https://typo.example.com\n", "retries: many\n"} {
+		c := &Config{APIURL: defaultAPIURL}
+		if _, err := c.Import([]byte(profile)); err == nil {
+			t.Errorf("Import(%q) succeeded, want an error", profile)
+		}
+		if c.APIURL != defaultAPIURL {
+			t.Errorf("Import(%q) changed the config despite failing", profile)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Package diff computes line-based differences between two texts.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Kind int
+
+const (
+	Equal Kind = iota
+	Insert
+	Delete
+)
+
+// Line is a single line of an edit script.
+type Line struct {
+	Kind Kind   `json:"-"`
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Hunk is a contiguous group of changes with surrounding context lines.
+// Start positions are 1-based, as in unified diff headers.
+type Hunk struct {
+	OldStart int    `json:"old_start"`
+	OldLines int    `json:"old_lines"`
+	NewStart int    `json:"new_start"`
+	NewLines int    `json:"new_lines"`
+	Lines    []Line `json:"lines"`
+}
+
+// maxLCSCells caps the size of the LCS table. Beyond this the changed middle
+// region is reported as a full replacement rather than a minimal diff.
+const maxLCSCells = 16 * 1024 * 1024
+
+var opNames = map[Kind]string{Equal: " ", Insert: "+", Delete: "-"}
+
+func newLine(kind Kind, text string) Line {
+	return Line{Kind: kind, Op: opNames[kind], Text: text}
+}
+
+// SplitLines splits text into lines without their trailing newline. A final
+// newline does not produce an empty trailing line.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.TrimSuffix(text, "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// Lines returns the edit script turning a into b.
+func Lines(a, b string) []Line {
+	return Compare(SplitLines(a), SplitLines(b))
+}
+
+// Compare returns the edit script turning a into b.
+func Compare(a, b []string) []Line {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]Line, 0, len(a)+len(b))
+	for _, text := range a[:prefix] {
+		result = append(result, newLine(Equal, text))
+	}
+	result = append(result, lcs(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		result = append(result, newLine(Equal, text))
+	}
+	return result
+}
+
+func lcs(a, b []string) []Line {
+	if len(a)*len(b) > maxLCSCells || len(a) == 0 || len(b) == 0 {
+		result := make([]Line, 0, len(a)+len(b))
+		for _, text := range a {
+			result = append(result, newLine(Delete, text))
+		}
+		for _, text := range b {
+			result = append(result, newLine(Insert, text))
+		}
+		return result
+	}
+
+	// table[i][j] is the LCS length of a[i:] and b[j:].
+	width := len(b) + 1
+	table := make([]int32, (len(a)+1)*width)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i*width+j] = table[(i+1)*width+j+1] + 1
+			} else if table[(i+1)*width+j] >= table[i*width+j+1] {
+				table[i*width+j] = table[(i+1)*width+j]
+			} else {
+				table[i*width+j] = table[i*width+j+1]
+			}
+		}
+	}
+
+	result := make([]Line, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, newLine(Equal, a[i]))
+			i++
+			j++
+		case table[(i+1)*width+j] >= table[i*width+j+1]:
+			result = append(result, newLine(Delete, a[i]))
+			i++
+		default:
+			result = append(result, newLine(Insert, b[j]))
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, newLine(Delete, a[i]))
+	}
+	for ; j < len(b); j++ {
+		result = append(result, newLine(Insert, b[j]))
+	}
+	return result
+}
+
+// Added returns only the inserted lines of an edit script.
+func Added(lines []Line) []string {
+	var added []string
+	for _, line := range lines {
+		if line.Kind == Insert {
+			added = append(added, line.Text)
+		}
+	}
+	return added
+}
+
+// Stat counts inserted and deleted lines.
+func Stat(lines []Line) (added, deleted int) {
+	for _, line := range lines {
+		switch line.Kind {
+		case Insert:
+			added++
+		case Delete:
+			deleted++
+		}
+	}
+	return added, deleted
+}
+
+// Hunks groups an edit script into hunks with the given number of context
+// lines around each change. Changes separated by at most 2*context equal
+// lines share a hunk.
+func Hunks(lines []Line, context int) []Hunk {
+	// oldPos[i]/newPos[i] are the 1-based line numbers at script index i.
+	oldPos := make([]int, len(lines)+1)
+	newPos := make([]int, len(lines)+1)
+	oldPos[0], newPos[0] = 1, 1
+	var changes []int
+	for i, line := range lines {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if line.Kind != Insert {
+			oldPos[i+1]++
+		}
+		if line.Kind != Delete {
+			newPos[i+1]++
+		}
+		if line.Kind != Equal {
+			changes = append(changes, i)
+		}
+	}
+
+	var hunks []Hunk
+	for g := 0; g < len(changes); {
+		first, last := changes[g], changes[g]
+		g++
+		for g < len(changes) && changes[g]-last-1 <= 2*context {
+			last = changes[g]
+			g++
+		}
+
+		start := max(first-context, 0)
+		end := min(last+context+1, len(lines))
+
+		h := Hunk{
+			OldStart: oldPos[start],
+			NewStart: newPos[start],
+			OldLines: oldPos[end] - oldPos[start],
+			NewLines: newPos[end] - newPos[start],
+			Lines:    append([]Line(nil), lines[start:end]...),
+		}
+		// Unified diff convention: an empty range starts at the line before.
+		if h.OldLines == 0 {
+			h.OldStart--
+		}
+		if h.NewLines == 0 {
+			h.NewStart--
+		}
+		hunks = append(hunks, h)
+	}
+
+	return hunks
+}
+
+// Unified renders hunks in unified diff format.
+func Unified(fromName, toName string, hunks []Hunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks {
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+		for _, line := range h.Lines {
+			b.WriteString(line.Op)
+			b.WriteString(line.Text)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
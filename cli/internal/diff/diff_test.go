@@ -0,0 +1,92 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitLines(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{"a\n", []string{"a"}},
+		{"a\nb\n", []string{"a", "b"}},
+		{"a\r\nb", []string{"a", "b"}},
+		{"a\n\nb", []string{"a", "", "b"}},
+	}
+	for _, tt := range tests {
+		got := SplitLines(tt.in)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+			t.Errorf("SplitLines(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLines_AddedAndStat(t *testing.T) {
+	script := Lines("a\nb\nc\n", "a\nx\nc\nd\n")
+	added := Added(script)
+	if strings.Join(added, ",") != "x,d" {
+		t.Errorf("Added() = %v, want [x d]", added)
+	}
+	ins, del := Stat(script)
+	if ins != 2 || del != 1 {
+		t.Errorf("Stat() = (%d, %d), want (2, 1)", ins, del)
+	}
+}
+
+func TestLines_Identical(t *testing.T) {
+	script := Lines("a\nb\n", "a\nb\n")
+	if ins, del := Stat(script); ins != 0 || del != 0 {
+		t.Errorf("Stat() = (%d, %d), want (0, 0)", ins, del)
+	}
+	if hunks := Hunks(script, 3); len(hunks) != 0 {
+		t.Errorf("expected no hunks, got %d", len(hunks))
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"
+
+	got := Unified("a", "b", Hunks(Lines(a, b), 1))
+	want := `--- a
++++ b
+@@ -2,3 +2,3 @@
+ 2
+-3
++three
+ 4
+@@ -10,1 +10,2 @@
+ 10
++11
+`
+	if got != want {
+		t.Errorf("Unified() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHunks_MergesNearbyChanges(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n"
+	b := "1\nX\n3\nY\n5\n"
+	hunks := Hunks(Lines(a, b), 1)
+	if len(hunks) != 1 {
+		t.Fatalf("expected 1 merged hunk, got %d", len(hunks))
+	}
+	if hunks[0].OldStart != 1 || hunks[0].OldLines != 5 || hunks[0].NewLines != 5 {
+		t.Errorf("hunk = %+v", hunks[0])
+	}
+}
+
+func TestHunks_InsertIntoEmpty(t *testing.T) {
+	hunks := Hunks(Lines("", "a\nb\n"), 3)
+	if len(hunks) != 1 {
+		t.Fatalf("expected 1 hunk, got %d", len(hunks))
+	}
+	h := hunks[0]
+	if h.OldStart != 0 || h.OldLines != 0 || h.NewStart != 1 || h.NewLines != 2 {
+		t.Errorf("hunk = %+v, want -0,0 +1,2", h)
+	}
+}
@@ -0,0 +1,439 @@
+// Package htmlmd converts HTML documents to Markdown, for pages created
+// from web pages. It handles the structure of articles and docs (headings,
+// paragraphs, lists, quotes, code, tables, links, and emphasis) and drops
+// scripts, styles, and anything else it cannot express.
+package htmlmd
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// skipped are elements whose content is never shown as text.
+var skipped = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true,
+}
+
+var (
+	attrRE     = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*(?:=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+	spaceRE    = regexp.MustCompile(`\s+`)
+	tagRE      = regexp.MustCompile(`<[^>]*>`)
+	languageRE = regexp.MustCompile(`class\s*=\s*["'][^"']*\blang(?:uage)?-([\w+-]+)`)
+	blanksRE   = regexp.MustCompile(`\n{3,}`)
+)
+
+// Convert returns the Markdown for an HTML document, and the text of its
+// <title>. Relative links and images are resolved against base, if set.
+func Convert(src string, base *url.URL) (title, markdown string) {
+	c := &converter{base: base, lineStart: true, blank: true}
+	c.run(src)
+	return c.title, c.markdown()
+}
+
+type list struct {
+	ordered bool
+	next    int
+}
+
+type converter struct {
+	base      *url.URL
+	out       strings.Builder
+	title     string
+	lineStart bool
+	// blank is set after a blank line, or before anything is written.
+	blank bool
+	// marker is set after a list item's marker, until its text.
+	marker bool
+
+	lists []list
+	quote int
+	// links holds the target of each open <a>, "" for one kept as text.
+	links []string
+
+	// cell collects the current table cell, and row the cells before it.
+	cell      *strings.Builder
+	row       []string
+	tableRows int
+}
+
+func (c *converter) run(src string) {
+	for i := 0; i < len(src); {
+		if src[i] != '<' {
+			end := strings.IndexByte(src[i:], '<')
+			if end < 0 {
+				end = len(src) - i
+			}
+			c.text(html.UnescapeString(src[i : i+end]))
+			i += end
+			continue
+		}
+
+		rest := src[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			i += skipPast(rest, "-->")
+			continue
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			i += skipPast(rest, ">")
+			continue
+		}
+
+		name, attrs, closing, n := parseTag(rest)
+		if n == 0 {
+			c.text("<")
+			i++
+			continue
+		}
+		i += n
+
+		switch {
+		case closing:
+			c.end(name)
+		case skipped[name]:
+			i += skipElement(src[i:], name)
+		case name == "title":
+			end := skipElement(src[i:], name)
+			if c.title == "" {
+				c.title = strings.TrimSpace(spaceRE.ReplaceAllString(html.UnescapeString(closeTagRE(name).ReplaceAllString(src[i:i+end], "")), " "))
+			}
+			i += end
+		case name == "pre":
+			end := skipElement(src[i:], name)
+			c.pre(src[i-n : i+end])
+			i += end
+		default:
+			c.start(name, attrs)
+		}
+	}
+}
+
+// skipPast returns the length of s up to and including the first end, or
+// all of s.
+func skipPast(s, end string) int {
+	if i := strings.Index(s, end); i >= 0 {
+		return i + len(end)
+	}
+	return len(s)
+}
+
+// closeTagRE matches the closing tag of name.
+func closeTagRE(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)</` + name + `\s*>`)
+}
+
+// skipElement returns the length of s up to and including the closing tag
+// of name, or all of s.
+func skipElement(s, name string) int {
+	if loc := closeTagRE(name).FindStringIndex(s); loc != nil {
+		return loc[1]
+	}
+	return len(s)
+}
+
+// parseTag parses the tag s starts with, returning its lowercased name,
+// attributes, whether it is a closing tag, and its length; 0 if s does not
+// start with a tag.
+func parseTag(s string) (name string, attrs map[string]string, closing bool, n int) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		closing = true
+		i++
+	}
+	start := i
+	for i < len(s) && (isLetter(s[i]) || (i > start && s[i] >= '0' && s[i] <= '9')) {
+		i++
+	}
+	if i == start {
+		return "", nil, false, 0
+	}
+	name = strings.ToLower(s[start:i])
+
+	var quote byte
+	end := -1
+	for j := i; j < len(s); j++ {
+		switch {
+		case quote != 0:
+			if s[j] == quote {
+				quote = 0
+			}
+		case s[j] == '"' || s[j] == '\'':
+			quote = s[j]
+		case s[j] == '>':
+			end = j
+		}
+		if end >= 0 {
+			break
+		}
+	}
+	if end < 0 {
+		return "", nil, false, 0
+	}
+
+	attrs = make(map[string]string)
+	for _, m := range attrRE.FindAllStringSubmatch(s[i:end], -1) {
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+	}
+	return name, attrs, closing, end + 1
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// write adds s to the current cell or line, starting a line with the
+// prefix of any open quotes.
+func (c *converter) write(s string) {
+	if c.cell != nil {
+		c.cell.WriteString(s)
+		return
+	}
+	if c.lineStart {
+		c.out.WriteString(strings.Repeat("> ", c.quote))
+		c.lineStart = false
+	}
+	c.out.WriteString(s)
+	c.blank, c.marker = false, false
+}
+
+func (c *converter) text(s string) {
+	s = spaceRE.ReplaceAllString(s, " ")
+	if c.cell != nil {
+		if c.cell.Len() == 0 {
+			s = strings.TrimLeft(s, " ")
+		}
+	} else if c.lineStart || c.marker || strings.HasSuffix(c.out.String(), " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	if s != "" {
+		c.write(s)
+	}
+}
+
+// newline ends the current line, if it has anything on it.
+func (c *converter) newline() {
+	if c.cell != nil {
+		c.cell.WriteString(" ")
+		return
+	}
+	if !c.lineStart {
+		c.out.WriteString("\n")
+		c.lineStart = true
+	}
+}
+
+// blankLine separates blocks. Inside a list, blocks stay on the item's
+// line so the list is not broken up.
+func (c *converter) blankLine() {
+	if len(c.lists) > 0 && c.cell == nil {
+		if !c.marker && !c.lineStart {
+			c.write(" ")
+		}
+		return
+	}
+	c.newline()
+	if c.cell != nil || c.blank {
+		return
+	}
+	c.out.WriteString(strings.TrimSpace(strings.Repeat("> ", c.quote)) + "\n")
+	c.blank = true
+}
+
+func (c *converter) start(name string, attrs map[string]string) {
+	switch name {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.blankLine()
+		c.write(strings.Repeat("#", int(name[1]-'0')) + " ")
+	case "p", "div", "section", "article", "header", "footer", "main", "figure", "dl", "dt", "dd":
+		c.blankLine()
+	case "br":
+		if c.cell != nil {
+			c.write(" ")
+			return
+		}
+		c.write("  ")
+		c.newline()
+	case "hr":
+		c.blankLine()
+		c.write("---")
+		c.blankLine()
+	case "ul", "ol":
+		if len(c.lists) == 0 {
+			c.blankLine()
+		}
+		l := list{ordered: name == "ol", next: 1}
+		if name == "ol" {
+			if n, err := strconv.Atoi(strings.TrimSpace(attrs["start"])); err == nil && n > 0 {
+				l.next = n
+			}
+		}
+		c.lists = append(c.lists, l)
+	case "li":
+		c.newline()
+		marker := "- "
+		if n := len(c.lists); n > 0 && c.lists[n-1].ordered {
+			marker = strconv.Itoa(c.lists[n-1].next) + ". "
+			c.lists[n-1].next++
+		}
+		c.write(strings.Repeat("  ", max(len(c.lists)-1, 0)) + marker)
+		c.marker = true
+	case "blockquote":
+		c.blankLine()
+		c.quote++
+	case "strong", "b":
+		c.write("**")
+	case "em", "i":
+		c.write("_")
+	case "code", "kbd", "samp", "tt":
+		c.write("`")
+	case "a":
+		href := c.resolve(attrs["href"])
+		if href != "" {
+			c.write("[")
+		}
+		c.links = append(c.links, href)
+	case "img":
+		if src := c.resolve(attrs["src"]); src != "" {
+			c.write("![" + attrs["alt"] + "](" + src + ")")
+		}
+	case "table":
+		c.blankLine()
+		c.tableRows = 0
+	case "tr":
+		c.row = nil
+	case "td", "th":
+		c.endCell()
+		c.cell = &strings.Builder{}
+	}
+}
+
+func (c *converter) end(name string) {
+	switch name {
+	case "h1", "h2", "h3", "h4", "h5", "h6", "p", "div", "section", "article", "header", "footer", "main", "figure", "dl":
+		c.blankLine()
+	case "dt", "dd":
+		c.newline()
+	case "ul", "ol":
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		if len(c.lists) == 0 {
+			c.blankLine()
+		}
+	case "blockquote":
+		if c.quote > 0 {
+			c.newline()
+			if c.blank {
+				// Drop the quote's trailing ">" line; the quote ends with a
+				// plain blank line instead.
+				s := strings.TrimSuffix(c.out.String(), strings.TrimSpace(strings.Repeat("> ", c.quote))+"\n")
+				c.out.Reset()
+				c.out.WriteString(s)
+				c.blank = false
+			}
+			c.quote--
+			c.blankLine()
+		}
+	case "strong", "b":
+		c.write("**")
+	case "em", "i":
+		c.write("_")
+	case "code", "kbd", "samp", "tt":
+		c.write("`")
+	case "a":
+		if n := len(c.links); n > 0 {
+			if href := c.links[n-1]; href != "" {
+				c.write("](" + href + ")")
+			}
+			c.links = c.links[:n-1]
+		}
+	case "td", "th":
+		c.endCell()
+	case "tr":
+		c.endCell()
+		if len(c.row) == 0 {
+			return
+		}
+		c.newline()
+		c.write("| " + strings.Join(c.row, " | ") + " |")
+		if c.tableRows == 0 {
+			c.newline()
+			c.write(strings.Repeat("| --- ", len(c.row)) + "|")
+		}
+		c.tableRows++
+		c.row = nil
+	case "table":
+		c.endCell()
+		c.blankLine()
+	}
+}
+
+// endCell adds the open table cell, if any, to the row.
+func (c *converter) endCell() {
+	if c.cell == nil {
+		return
+	}
+	cell := strings.TrimSpace(c.cell.String())
+	c.row = append(c.row, strings.ReplaceAll(cell, "|", `\|`))
+	c.cell = nil
+}
+
+// pre writes a <pre> element as a fenced code block, keeping its text as
+// is and taking the language from a language-* class.
+func (c *converter) pre(element string) {
+	lang := ""
+	if m := languageRE.FindStringSubmatch(element); m != nil {
+		lang = m[1]
+	}
+	_, inner, _ := strings.Cut(element, ">")
+	inner = closeTagRE("pre").ReplaceAllString(inner, "")
+	code := strings.Trim(html.UnescapeString(tagRE.ReplaceAllString(inner, "")), "\n")
+
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	c.blankLine()
+	c.write(fence + lang)
+	for _, line := range strings.Split(code, "\n") {
+		c.newline()
+		c.write(line)
+	}
+	c.newline()
+	c.write(fence)
+	c.blankLine()
+}
+
+// resolve makes ref absolute against the base URL. Fragment-only and
+// script links are dropped.
+func (c *converter) resolve(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(strings.ToLower(ref), "javascript:") {
+		return ""
+	}
+	if c.base == nil {
+		return ref
+	}
+	u, err := c.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+func (c *converter) markdown() string {
+	lines := strings.Split(c.out.String(), "\n")
+	for i, line := range lines {
+		if !strings.HasSuffix(line, "  ") {
+			lines[i] = strings.TrimRight(line, " ")
+		}
+	}
+	md := blanksRE.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	md = strings.Trim(md, "\n")
+	if md == "" {
+		return ""
+	}
+	return md + "\n"
+}
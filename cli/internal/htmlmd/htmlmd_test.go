@@ -0,0 +1,42 @@
+package htmlmd
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"heading and paragraph", "<h2>Deploy <em>v2</em></h2>\n<p>Run   it\n now.</p>", "## Deploy _v2_\n\nRun it now.\n"},
+		{"inline", "<p><strong>a</strong> <code>b &lt; c</code> <b>d</b></p>", "**a** `b < c` **d**\n"},
+		{"link", `<p>See <a href="/docs/run">the docs</a> or <a href="#top">top</a>.</p>`, "See [the docs](https://x.test/docs/run) or top.\n"},
+		{"image", `<img src="img/a.png" alt="graph">`, "![graph](https://x.test/guide/img/a.png)\n"},
+		{"unsafe link", `<a href="javascript:alert(1)">click</a>`, "click\n"},
+		{"lists", "<ul><li>a</li><li>b<ol start=\"3\"><li>c</li><li>d</li></ol></li></ul><p>after</p>", "- a\n- b\n  3. c\n  4. d\n\nafter\n"},
+		{"list item paragraphs", "<ul><li><p>a</p></li><li><p>b</p></li></ul>", "- a\n- b\n"},
+		{"quote", "<blockquote><p>one</p><p>two</p></blockquote><p>x</p>", "> one\n>\n> two\n\nx\n"},
+		{"pre", "<pre><code class=\"language-go\">if a &lt; b {\n\treturn\n}\n</code></pre>", "```go\nif a < b {\n\treturn\n}\n```\n"},
+		{"pre with fence", "<pre>```\nx\n```</pre>", "````\n```\nx\n```\n````\n"},
+		{"table", "<table><tr><th>host</th><th>cpu</th></tr><tr><td>web|1</td><td>12<br>%</td></tr></table>", "| host | cpu |\n| --- | --- |\n| web\\|1 | 12 % |\n"},
+		{"skipped", "<style>p{}</style><script>if (a < b) x()</script><p>kept<!-- gone --></p>", "kept\n"},
+		{"break and rule", "<p>a<br>b</p><hr><p>c</p>", "a  \nb\n\n---\n\nc\n"},
+		{"stray angle", "<p>a < b</p>", "a < b\n"},
+	}
+	base, _ := url.Parse("https://x.test/guide/index.html")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := Convert(tt.in, base); got != tt.want {
+				t.Errorf("Convert(%q) =\n%q\nwant\n%q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert_Title(t *testing.T) {
+	title, md := Convert("<!DOCTYPE html><html><head><title> Runbook &amp; FAQ </title></head><body><h1>Runbook</h1></body></html>", nil)
+	if title != "Runbook & FAQ" || md != "# Runbook\n" {
+		t.Errorf("Convert = %q, %q", title, md)
+	}
+}
@@ -0,0 +1,260 @@
+// Package index keeps a small inverted index of exported page content on
+// this machine so pages can be searched instantly and offline.
+package index
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	indexFile = "search-index.json"
+	version   = 1
+
+	// titleWeight counts a term in the title as this many occurrences in
+	// the body, so title matches rank first.
+	titleWeight = 3
+
+	maxSnippetRunes = 120
+)
+
+// Doc is one indexed page. Path is the absolute path of its content file.
+type Doc struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	ProjectID string `json:"project_id,omitempty"`
+	Updated   string `json:"updated,omitempty"`
+	Path      string `json:"path"`
+	SHA256    string `json:"sha256"`
+}
+
+type Index struct {
+	path     string
+	Version  int                       `json:"version"`
+	Docs     map[string]*Doc           `json:"docs"`
+	Postings map[string]map[string]int `json:"postings"`
+}
+
+// Open loads the index stored in dir, or returns an empty one if there is
+// none yet. An index written by another version is discarded and rebuilt.
+func Open(dir string) (*Index, error) {
+	ix := &Index{path: filepath.Join(dir, indexFile)}
+	data, err := os.ReadFile(ix.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, ix); err != nil {
+			return nil, fmt.Errorf("failed to parse search index %s: %w", ix.path, err)
+		}
+	}
+	if ix.Version != version {
+		ix.Version = version
+		ix.Docs = nil
+		ix.Postings = nil
+	}
+	if ix.Docs == nil {
+		ix.Docs = map[string]*Doc{}
+	}
+	if ix.Postings == nil {
+		ix.Postings = map[string]map[string]int{}
+	}
+	return ix, nil
+}
+
+func (ix *Index) Path() string {
+	return ix.path
+}
+
+// Exists reports whether the index has been saved before.
+func (ix *Index) Exists() bool {
+	_, err := os.Stat(ix.path)
+	return err == nil
+}
+
+func (ix *Index) Len() int {
+	return len(ix.Docs)
+}
+
+// Add indexes content for doc, replacing any earlier version of the page.
+func (ix *Index) Add(doc Doc, content string) {
+	ix.Remove(doc.ID)
+	counts := map[string]int{}
+	for _, t := range Tokenize(content) {
+		counts[t]++
+	}
+	for _, t := range Tokenize(doc.Title) {
+		counts[t] += titleWeight
+	}
+	for t, n := range counts {
+		if ix.Postings[t] == nil {
+			ix.Postings[t] = map[string]int{}
+		}
+		ix.Postings[t][doc.ID] = n
+	}
+	d := doc
+	ix.Docs[doc.ID] = &d
+}
+
+func (ix *Index) Remove(id string) {
+	if _, ok := ix.Docs[id]; !ok {
+		return
+	}
+	delete(ix.Docs, id)
+	for t, docs := range ix.Postings {
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(ix.Postings, t)
+		}
+	}
+}
+
+// Save writes the index atomically so an interrupted refresh never leaves
+// an unreadable file.
+func (ix *Index) Save() error {
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(ix.path), ".search-index-*")
+	if err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	return os.Rename(tmp.Name(), ix.path)
+}
+
+type Result struct {
+	Doc
+	Score   float64 `json:"score"`
+	Line    int     `json:"line,omitempty"`
+	Snippet string  `json:"snippet,omitempty"`
+}
+
+// Search returns pages containing every term in query, best first. The last
+// term also matches as a prefix, so results appear while a word is typed.
+func (ix *Index) Search(query string, limit int) []Result {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	scores := map[string]float64{}
+	for i, term := range terms {
+		matched := map[string]float64{}
+		for _, docs := range ix.postingsFor(term, i == len(terms)-1) {
+			idf := math.Log(1 + float64(len(ix.Docs))/float64(len(docs)))
+			for id, n := range docs {
+				matched[id] += (1 + math.Log(float64(n))) * idf
+			}
+		}
+		if i == 0 {
+			scores = matched
+			continue
+		}
+		for id := range scores {
+			if s, ok := matched[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, Result{Doc: *ix.Docs[id], Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Title < results[j].Title
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	for i := range results {
+		results[i].Line, results[i].Snippet = snippet(results[i].Path, terms)
+	}
+	return results
+}
+
+func (ix *Index) postingsFor(term string, prefix bool) map[string]map[string]int {
+	out := map[string]map[string]int{}
+	if docs, ok := ix.Postings[term]; ok {
+		out[term] = docs
+	}
+	if prefix {
+		for t, docs := range ix.Postings {
+			if strings.HasPrefix(t, term) {
+				out[t] = docs
+			}
+		}
+	}
+	return out
+}
+
+// snippet returns the first line of the file at path that mentions one of
+// terms. A missing file yields no snippet rather than an error, since the
+// export may have moved since it was indexed.
+func snippet(path string, terms []string) (int, string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, ""
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		lower := strings.ToLower(line)
+		for _, t := range terms {
+			if strings.Contains(lower, t) {
+				return n, truncate(strings.TrimSpace(line))
+			}
+		}
+	}
+	return 0, ""
+}
+
+func truncate(s string) string {
+	if utf8.RuneCountInString(s) <= maxSnippetRunes {
+		return s
+	}
+	return string([]rune(s)[:maxSnippetRunes-1]) + "…"
+}
+
+// Tokenize splits s into lowercase words of letters and digits. Single
+// characters are dropped; they match too much to be useful.
+func Tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := fields[:0]
+	for _, f := range fields {
+		if utf8.RuneCountInString(f) >= 2 {
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
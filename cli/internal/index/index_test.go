@@ -0,0 +1,77 @@
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Retry-after: 30s, a Déjà vu!")
+	want := []string{"retry", "after", "30s", "déjà", "vu"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize = %q, want %q", got, want)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.md")
+	_ = os.WriteFile(notes, []byte("# Notes\n\nThe deploy timed out after the retry.\n"), 0644)
+
+	ix, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ix.Add(Doc{ID: "page_notes", Title: "Notes", Path: notes, SHA256: "1"}, "# Notes\n\nThe deploy timed out after the retry.\n")
+	ix.Add(Doc{ID: "page_deploy", Title: "Deploy runbook", SHA256: "2"}, "Steps to deploy.")
+	ix.Add(Doc{ID: "page_other", Title: "Other", SHA256: "3"}, "nothing relevant")
+
+	results := ix.Search("deploy", 0)
+	if len(results) != 2 || results[0].ID != "page_deploy" {
+		t.Fatalf("results = %+v, want the title match ranked first", results)
+	}
+
+	results = ix.Search("deploy retr", 0)
+	if len(results) != 1 || results[0].ID != "page_notes" {
+		t.Fatalf("results = %+v, want only the page with every word, last as a prefix", results)
+	}
+	if results[0].Line != 3 || results[0].Snippet != "The deploy timed out after the retry." {
+		t.Errorf("snippet = %d: %q", results[0].Line, results[0].Snippet)
+	}
+
+	ix.Remove("page_deploy")
+	if results := ix.Search("runbook", 0); len(results) != 0 {
+		t.Errorf("removed page still found: %+v", results)
+	}
+	if _, ok := ix.Postings["runbook"]; ok {
+		t.Error("postings for a removed page were kept")
+	}
+}
+
+func TestSaveAndOpen(t *testing.T) {
+	dir := t.TempDir()
+	ix, _ := Open(dir)
+	if ix.Exists() {
+		t.Fatal("new index reported as existing")
+	}
+	ix.Add(Doc{ID: "page_a", Title: "Alpha"}, "offline search")
+	if err := ix.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	if !reopened.Exists() || reopened.Len() != 1 || len(reopened.Search("offline", 0)) != 1 {
+		t.Errorf("reopened index has %d docs", reopened.Len())
+	}
+
+	_ = os.WriteFile(reopened.Path(), []byte(`{"version": 99, "docs": {"x": {"id": "x"}}}`), 0600)
+	old, err := Open(dir)
+	if err != nil || old.Len() != 0 {
+		t.Errorf("index from another version kept %d docs (err %v), want it discarded", old.Len(), err)
+	}
+}
@@ -0,0 +1,82 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+type inner struct {
+	Name string `json:"name"`
+}
+
+type sample struct {
+	ID       string            `json:"id"`
+	Count    int               `json:"count,omitempty"`
+	Ratio    float64           `json:"ratio"`
+	OK       bool              `json:"ok"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Inner    *inner            `json:"inner,omitempty"`
+	Skipped  string            `json:"-"`
+	Untagged string
+	Extra    any `json:"extra"`
+	private  string
+}
+
+func TestFor(t *testing.T) {
+	s := For(sample{}, "sample")
+
+	if s.Schema != Draft || s.Title != "sample" || s.Type != "object" {
+		t.Fatalf("unexpected root: %+v", s)
+	}
+
+	wantTypes := map[string]string{
+		"id":       "string",
+		"count":    "integer",
+		"ratio":    "number",
+		"ok":       "boolean",
+		"tags":     "array",
+		"labels":   "object",
+		"inner":    "object",
+		"Untagged": "string",
+		"extra":    "",
+	}
+	if len(s.Properties) != len(wantTypes) {
+		t.Errorf("got %d properties, want %d", len(s.Properties), len(wantTypes))
+	}
+	for name, typ := range wantTypes {
+		p, ok := s.Properties[name]
+		if !ok {
+			t.Errorf("missing property %q", name)
+			continue
+		}
+		if p.Type != typ {
+			t.Errorf("%s type = %q, want %q", name, p.Type, typ)
+		}
+	}
+
+	if s.Properties["tags"].Items.Type != "string" {
+		t.Error("tags items should be strings")
+	}
+	if s.Properties["labels"].AdditionalProperties.Type != "string" {
+		t.Error("labels values should be strings")
+	}
+	if s.Properties["inner"].Properties["name"].Type != "string" {
+		t.Error("inner.name should be a string")
+	}
+
+	wantRequired := []string{"id", "ratio", "ok", "tags", "Untagged", "extra"}
+	if !reflect.DeepEqual(s.Required, wantRequired) {
+		t.Errorf("required = %v, want %v", s.Required, wantRequired)
+	}
+}
+
+func TestFor_Slice(t *testing.T) {
+	s := For([]inner{}, "list")
+	if s.Type != "array" || s.Items.Type != "object" {
+		t.Errorf("unexpected schema: %+v", s)
+	}
+	if s.Items.Schema != "" {
+		t.Error("$schema should only be set on the root")
+	}
+}
@@ -0,0 +1,336 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Markdown converts CommonMark-style markdown to an HTML fragment. It covers
+// what captured pages use: headings, paragraphs, fenced and indented code,
+// lists, block quotes, rules, pipe tables, and the common inline forms. Raw
+// HTML in the source is escaped, never passed through.
+func Markdown(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	renderBlocks(&b, lines)
+	return b.String()
+}
+
+var (
+	headingRe   = regexp.MustCompile(`^(#{1,6})(?:\s+(.*?))?\s*#*\s*$`)
+	ruleRe      = regexp.MustCompile(`^ {0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	fenceRe     = regexp.MustCompile("^ {0,3}(```+|~~~+)\\s*([^`\\s]*)")
+	listItemRe  = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])(\s+|$)`)
+	tableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	quoteMarkRe = regexp.MustCompile(`^ {0,3}> ?`)
+)
+
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case fenceRe.MatchString(line):
+			m := fenceRe.FindStringSubmatch(line)
+			fence := m[1]
+			var code []string
+			i++
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // closing fence
+			writeCode(b, code, m[2])
+
+		case strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t"):
+			var code []string
+			for i < len(lines) && (strings.HasPrefix(lines[i], "    ") || strings.HasPrefix(lines[i], "\t") || strings.TrimSpace(lines[i]) == "") {
+				code = append(code, strings.TrimPrefix(strings.TrimPrefix(lines[i], "\t"), "    "))
+				i++
+			}
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+			writeCode(b, code, "")
+
+		case headingRe.MatchString(trimmed):
+			m := headingRe.FindStringSubmatch(trimmed)
+			fmt.Fprintf(b, "<h%d>%s</h%d>\n", len(m[1]), inline(m[2]), len(m[1]))
+			i++
+
+		case ruleRe.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case quoteMarkRe.MatchString(line):
+			var quoted []string
+			for i < len(lines) && quoteMarkRe.MatchString(lines[i]) {
+				quoted = append(quoted, quoteMarkRe.ReplaceAllString(lines[i], ""))
+				i++
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+
+		case listItemRe.MatchString(line):
+			i = renderList(b, lines, i)
+
+		case strings.Contains(line, "|") && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"):
+			i = renderTable(b, lines, i)
+
+		default:
+			var para []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]) {
+				para = append(para, strings.TrimSpace(lines[i]))
+				i++
+			}
+			if len(para) == 0 {
+				// A line startsBlock rejects but no case above took.
+				para = append(para, trimmed)
+				i++
+			}
+			fmt.Fprintf(b, "<p>%s</p>\n", inline(strings.Join(para, "\n")))
+		}
+	}
+}
+
+// startsBlock reports whether line interrupts a paragraph.
+func startsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return fenceRe.MatchString(line) || headingRe.MatchString(trimmed) || ruleRe.MatchString(line) ||
+		quoteMarkRe.MatchString(line) || listItemRe.MatchString(line)
+}
+
+func writeCode(b *strings.Builder, code []string, lang string) {
+	if lang != "" {
+		fmt.Fprintf(b, "<pre><code class=\"language-%s\">", html.EscapeString(lang))
+	} else {
+		b.WriteString("<pre><code>")
+	}
+	for _, l := range code {
+		b.WriteString(html.EscapeString(l))
+		b.WriteByte('\n')
+	}
+	b.WriteString("</code></pre>\n")
+}
+
+// renderList writes the list starting at lines[i] and returns the index
+// after it. Lines indented under an item belong to it, so nested lists
+// render recursively.
+func renderList(b *strings.Builder, lines []string, i int) int {
+	first := listItemRe.FindStringSubmatch(lines[i])
+	ordered := first[2][0] >= '0' && first[2][0] <= '9'
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if n := strings.TrimRight(first[2], ".)"); n != "1" {
+			fmt.Fprintf(b, "<ol start=\"%s\">\n", strings.TrimLeft(n, "0"))
+		} else {
+			b.WriteString("<ol>\n")
+		}
+	} else {
+		b.WriteString("<ul>\n")
+	}
+
+	for i < len(lines) {
+		m := listItemRe.FindStringSubmatch(lines[i])
+		if m == nil || (m[2][0] >= '0' && m[2][0] <= '9') != ordered {
+			break
+		}
+		indent := len(m[0])
+		item := []string{lines[i][len(m[0]):]}
+		i++
+		for i < len(lines) {
+			l := lines[i]
+			if strings.TrimSpace(l) == "" {
+				// A blank line continues the item only if indented content follows.
+				if i+1 < len(lines) && leadingSpaces(lines[i+1]) >= indent {
+					item = append(item, "")
+					i++
+					continue
+				}
+				break
+			}
+			if leadingSpaces(l) >= min(indent, 4) && leadingSpaces(l) > 0 {
+				item = append(item, dedent(l, indent))
+				i++
+				continue
+			}
+			if listItemRe.MatchString(l) || startsBlock(l) {
+				break
+			}
+			item = append(item, l) // lazy continuation
+			i++
+		}
+
+		var inner strings.Builder
+		renderBlocks(&inner, item)
+		content := inner.String()
+		// Tight items are a single paragraph; drop its tags.
+		if strings.Count(content, "<p>") == 1 && strings.HasPrefix(content, "<p>") {
+			content = strings.Replace(strings.Replace(content, "<p>", "", 1), "</p>\n", "", 1)
+		}
+		fmt.Fprintf(b, "<li>%s</li>\n", strings.TrimSuffix(content, "\n"))
+
+		if i < len(lines) && strings.TrimSpace(lines[i]) == "" && i+1 < len(lines) && listItemRe.MatchString(lines[i+1]) {
+			i++
+		}
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+	return i
+}
+
+func leadingSpaces(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " "))
+}
+
+func dedent(s string, n int) string {
+	return s[min(n, leadingSpaces(s)):]
+}
+
+// renderTable writes the pipe table starting at lines[i] and returns the
+// index after it.
+func renderTable(b *strings.Builder, lines []string, i int) int {
+	header := splitRow(lines[i])
+	var aligns []string
+	for _, cell := range splitRow(lines[i+1]) {
+		switch left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":"); {
+		case left && right:
+			aligns = append(aligns, "center")
+		case right:
+			aligns = append(aligns, "right")
+		case left:
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+	cell := func(tag string, col int, text string) string {
+		if col < len(aligns) && aligns[col] != "" {
+			return fmt.Sprintf("<%s style=\"text-align: %s\">%s</%s>", tag, aligns[col], inline(text), tag)
+		}
+		return fmt.Sprintf("<%s>%s</%s>", tag, inline(text), tag)
+	}
+
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for col, text := range header {
+		b.WriteString(cell("th", col, text))
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	i += 2
+	for i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|") {
+		b.WriteString("<tr>")
+		row := splitRow(lines[i])
+		for col := range header {
+			text := ""
+			if col < len(row) {
+				text = row[col]
+			}
+			b.WriteString(cell("td", col, text))
+		}
+		b.WriteString("</tr>\n")
+		i++
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return i
+}
+
+// splitRow splits a table row on unescaped pipes.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cur strings.Builder
+	for j := 0; j < len(line); j++ {
+		switch {
+		case line[j] == '\\' && j+1 < len(line) && line[j+1] == '|':
+			cur.WriteByte('|')
+			j++
+		case line[j] == '|':
+			cells = append(cells, strings.TrimSpace(cur.String()))
+			cur.Reset()
+		default:
+			cur.WriteByte(line[j])
+		}
+	}
+	return append(cells, strings.TrimSpace(cur.String()))
+}
+
+var (
+	codeSpanRe = regexp.MustCompile("(`+)(.+?)(`+)")
+	imageRe    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+&quot;([^)]*)&quot;)?\)`)
+	linkRe     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&quot;([^)]*)&quot;)?\)`)
+	autolinkRe = regexp.MustCompile(`&lt;((?:https?|mailto):[^\s&]+)&gt;`)
+	strongRe   = regexp.MustCompile(`\*\*([^*\s](?:[^*]*[^*\s])?)\*\*|__([^_\s](?:[^_]*[^_\s])?)__`)
+	emRe       = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*|\b_([^_\s](?:[^_]*[^_\s])?)_\b`)
+	strikeRe   = regexp.MustCompile(`~~([^~\s](?:[^~]*[^~\s])?)~~`)
+)
+
+// inline renders the inline markup of one block's text. Code spans are cut
+// out first so nothing inside them is interpreted.
+func inline(text string) string {
+	var b strings.Builder
+	for {
+		loc := codeSpanRe.FindStringSubmatchIndex(text)
+		if loc == nil || text[loc[2]:loc[3]] != text[loc[6]:loc[7]] {
+			b.WriteString(inlineText(text))
+			return b.String()
+		}
+		b.WriteString(inlineText(text[:loc[0]]))
+		fmt.Fprintf(&b, "<code>%s</code>", html.EscapeString(strings.TrimSpace(text[loc[4]:loc[5]])))
+		text = text[loc[1]:]
+	}
+}
+
+func inlineText(text string) string {
+	s := html.EscapeString(text)
+	s = imageRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := imageRe.FindStringSubmatch(m)
+		if !safeURL(sub[2]) {
+			return sub[1]
+		}
+		return fmt.Sprintf(`<img src="%s" alt="%s"%s>`, sub[2], sub[1], titleAttr(sub[3]))
+	})
+	s = linkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := linkRe.FindStringSubmatch(m)
+		if !safeURL(sub[2]) {
+			return sub[1]
+		}
+		return fmt.Sprintf(`<a href="%s"%s>%s</a>`, sub[2], titleAttr(sub[3]), sub[1])
+	})
+	s = autolinkRe.ReplaceAllString(s, `<a href="$1">$1</a>`)
+	s = strongRe.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = emRe.ReplaceAllString(s, "<em>$1$2</em>")
+	s = strikeRe.ReplaceAllString(s, "<del>$1</del>")
+	return strings.ReplaceAll(s, "  \n", "<br>\n")
+}
+
+func titleAttr(title string) string {
+	if title == "" {
+		return ""
+	}
+	return fmt.Sprintf(` title="%s"`, title)
+}
+
+// safeURL rejects schemes such as javascript: that would run in the reader's
+// browser. The URL is already HTML-escaped.
+func safeURL(u string) bool {
+	scheme, _, found := strings.Cut(u, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return true // relative
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
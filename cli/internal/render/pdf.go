@@ -0,0 +1,185 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// PDF page layout, in points: A4 with a monospaced body.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 50
+	pdfFontSize   = 9
+	pdfLeading    = 11
+	pdfTitleSize  = 14
+	// pdfColumns is how many characters of the body font fit across the
+	// page; Courier's characters are 0.6em wide.
+	pdfColumns = (pdfPageWidth - 2*pdfMargin) * 10 / (pdfFontSize * 6)
+	pdfRows    = (pdfPageHeight - 2*pdfMargin - pdfLeading) / pdfLeading
+)
+
+// PDF renders a page as a PDF document of text, for when the server cannot
+// render one. Markdown pages are laid out as by Terminal, CSV pages become
+// an aligned table, and anything else is set as is, wrapped at the page
+// width. The document uses the standard Courier and Helvetica fonts, so
+// characters outside Latin-1, other than the bullets, dashes, and rules
+// Terminal lays text out with, are replaced with "?".
+func PDF(title, content, filetype string) []byte {
+	var text string
+	switch filetype {
+	case "md":
+		text = Terminal(content, pdfColumns, false)
+	case "csv":
+		if rows, ok := parseCSV(content); ok {
+			text = strings.Join(Table(rows, TableOptions{Width: pdfColumns}), "\n")
+		} else {
+			text = content
+		}
+	default:
+		text = content
+	}
+
+	lines := pdfLines(text)
+	// The title takes the first two rows of the first page.
+	var pages [][]string
+	for first, rows := true, pdfRows-2; first || len(lines) > 0; first, rows = false, pdfRows {
+		n := min(rows, len(lines))
+		pages = append(pages, lines[:n])
+		lines = lines[n:]
+	}
+
+	w := &pdfWriter{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1-5 are fixed; each page adds its page object and contents.
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	w.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	w.object(fmt.Sprintf("<< /Title (%s) /Producer (hyperclast) >>", pdfString(title)))
+
+	for i, page := range pages {
+		var s strings.Builder
+		y := pdfPageHeight - pdfMargin - pdfFontSize
+		if i == 0 {
+			fmt.Fprintf(&s, "BT /F2 %d Tf %d %d Td (%s) Tj ET\n", pdfTitleSize, pdfMargin, y, pdfString(title))
+			y -= 2 * pdfLeading
+		}
+		fmt.Fprintf(&s, "BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, y)
+		for _, line := range page {
+			fmt.Fprintf(&s, "(%s) Tj T*\n", pdfString(line))
+		}
+		s.WriteString("ET\n")
+		if len(pages) > 1 {
+			fmt.Fprintf(&s, "BT /F1 %d Tf %d %d Td (%d / %d) Tj ET\n", pdfFontSize, pdfPageWidth/2-15, pdfMargin/2, i+1, len(pages))
+		}
+
+		w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 7+2*i))
+		w.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", s.Len(), s.String()))
+	}
+	return w.finish()
+}
+
+// pdfLines splits text into lines that fit the page width, expanding tabs
+// and dropping other control characters.
+func pdfLines(text string) []string {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		var b strings.Builder
+		col := 0
+		for _, r := range line {
+			switch {
+			case r == '\t':
+				for n := 8 - col%8; n > 0; n-- {
+					b.WriteByte(' ')
+					col++
+				}
+			case r < ' ' || r == utf8.RuneError:
+				continue
+			default:
+				b.WriteRune(r)
+				col++
+			}
+		}
+		lines = append(lines, wrapColumns(b.String(), pdfColumns)...)
+	}
+	return lines
+}
+
+// wrapColumns cuts s into pieces of at most width characters.
+func wrapColumns(s string, width int) []string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return []string{s}
+	}
+	var pieces []string
+	for len(runes) > width {
+		pieces = append(pieces, string(runes[:width]))
+		runes = runes[width:]
+	}
+	return append(pieces, string(runes))
+}
+
+// winAnsi maps characters Terminal and Table lay text out with, outside
+// Latin-1, to their WinAnsiEncoding bytes or an ASCII stand-in.
+var winAnsi = map[rune]string{
+	'•': `\225`, '…': `\205`, '–': `\226`, '—': `\227`,
+	'‘': `\221`, '’': `\222`, '“': `\223`, '”': `\224`,
+	'─': "-", '│': "|", '☐': "[ ]", '☑': "[x]",
+}
+
+// pdfString escapes s for a PDF string literal in WinAnsiEncoding, which
+// matches Latin-1 for the characters kept.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if sub, ok := winAnsi[r]; ok {
+			b.WriteString(sub)
+			continue
+		}
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ':
+			continue
+		case r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// pdfWriter numbers objects from 1 in the order written and records their
+// offsets for the cross-reference table.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *pdfWriter) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+func (w *pdfWriter) finish() []byte {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, off := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xref)
+	return w.buf.Bytes()
+}
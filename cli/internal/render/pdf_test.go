@@ -0,0 +1,81 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// checkPDF verifies the cross-reference table points at each object.
+func checkPDF(t *testing.T, doc []byte) {
+	t.Helper()
+	if !bytes.HasPrefix(doc, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(doc, []byte("%%EOF\n")) {
+		t.Fatalf("not a PDF:\n%s", doc)
+	}
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(doc[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(doc[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, doc[off:off+10], want)
+		}
+	}
+}
+
+func TestPDF_Text(t *testing.T) {
+	doc := PDF("Deploy (prod)", "step 1\tok\nstep 2 \\ done\n", "log")
+	checkPDF(t, doc)
+	for _, want := range []string{
+		`/Title (Deploy \(prod\))`,
+		"(step 1  ok) Tj",
+		`(step 2 \\ done) Tj`,
+		"/Count 1",
+	} {
+		if !bytes.Contains(doc, []byte(want)) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+}
+
+func TestPDF_WrapsAndPaginates(t *testing.T) {
+	long := strings.Repeat("x", pdfColumns+5)
+	doc := PDF("Log", strings.Repeat("line\n", pdfRows*2)+long, "txt")
+	checkPDF(t, doc)
+	if !bytes.Contains(doc, []byte("/Count 3")) {
+		t.Error("want 3 pages")
+	}
+	if !bytes.Contains(doc, []byte("("+strings.Repeat("x", pdfColumns)+") Tj")) || !bytes.Contains(doc, []byte("(xxxxx) Tj")) {
+		t.Errorf("long line not wrapped at %d columns", pdfColumns)
+	}
+	if !bytes.Contains(doc, []byte("(3 / 3) Tj")) {
+		t.Error("missing page numbers")
+	}
+}
+
+func TestPDF_MarkdownAndCSV(t *testing.T) {
+	md := PDF("Notes", "# Plan\n\n- [ ] ship — soon\n- café\n- 日本", "md")
+	checkPDF(t, md)
+	for _, want := range []string{`\225 [ ] ship \227 soon) Tj`, `\225 caf\351) Tj`, `\225 ??) Tj`} {
+		if !bytes.Contains(md, []byte(want)) {
+			t.Errorf("markdown PDF missing %q", want)
+		}
+	}
+
+	csv := PDF("Hosts", "host,cpu\nweb1,12\n", "csv")
+	checkPDF(t, csv)
+	for _, want := range []string{"(host  cpu) Tj", "(----  ---) Tj", "(web1  12) Tj"} {
+		if !bytes.Contains(csv, []byte(want)) {
+			t.Errorf("CSV PDF missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package render turns page content into standalone HTML or PDF for sharing
+// outside Hyperclast, when the server cannot render it.
+package render
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// style keeps the document readable when opened from a file or attached to
+// an email, without loading anything external.
+const style = `body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif;line-height:1.5;max-width:860px;margin:2em auto;padding:0 1em;color:#1f2328}
+pre{background:#f6f8fa;padding:12px;overflow:auto;border-radius:6px}
+code{font-family:ui-monospace,SFMono-Regular,Menlo,Consolas,monospace;font-size:90%}
+table{border-collapse:collapse}th,td{border:1px solid #d0d7de;padding:4px 10px}
+blockquote{margin:0;padding:0 1em;color:#59636e;border-left:4px solid #d0d7de}
+img{max-width:100%}`
+
+// HTML renders a page as a complete HTML document. Markdown pages are
+// converted, CSV pages become a table, and anything else is preformatted
+// text.
+func HTML(title, content, filetype string) string {
+	var body string
+	switch filetype {
+	case "md":
+		body = Markdown(content)
+	case "csv":
+		body = csvTable(content)
+	default:
+		body = fmt.Sprintf("<pre>%s</pre>\n", html.EscapeString(content))
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+%s
+</style>
+</head>
+<body>
+%s</body>
+</html>
+`, html.EscapeString(title), style, body)
+}
+
+// csvTable renders CSV as a table, or as preformatted text if it does not
+// parse.
+func csvTable(content string) string {
+	records, ok := parseCSV(content)
+	if !ok {
+		return fmt.Sprintf("<pre>%s</pre>\n", html.EscapeString(content))
+	}
+
+	var b strings.Builder
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, h := range records[0] {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(h))
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range records[1:] {
+		b.WriteString("<tr>")
+		for _, v := range row {
+			fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(v))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String()
+}
+
+// parseCSV reads CSV, or TSV when the first line has more tabs than commas.
+// It reports false if the content does not parse or has no rows.
+func parseCSV(content string) ([][]string, bool) {
+	r := csv.NewReader(strings.NewReader(content))
+	r.FieldsPerRecord = -1
+	if first, _, _ := strings.Cut(content, "\n"); strings.Count(first, "\t") > strings.Count(first, ",") {
+		r.Comma = '\t'
+	}
+	records, err := r.ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, false
+	}
+	return records, true
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"heading", "## Deploy *v2*", "<h2>Deploy <em>v2</em></h2>\n"},
+		{"paragraph", "one **two**\nthree", "<p>one <strong>two</strong>\nthree</p>\n"},
+		{"code span", "run `a <b> *c*`", "<p>run <code>a &lt;b&gt; *c*</code></p>\n"},
+		{"link", "[docs](https://x.test/?a=1&b=2)", `<p><a href="https://x.test/?a=1&amp;b=2">docs</a></p>` + "\n"},
+		{"unsafe link", "[click](javascript:alert)", "<p>click</p>\n"},
+		{"raw html", "<script>x</script>", "<p>&lt;script&gt;x&lt;/script&gt;</p>\n"},
+		{"fence", "```go\nif a < b {}\n```", "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n"},
+		{"rule", "---", "<hr>\n"},
+		{"quote", "> a\n> b", "<blockquote>\n<p>a\nb</p>\n</blockquote>\n"},
+		{"list", "- a\n- b\n  - c", "<ul>\n<li>a</li>\n<li>b<ul>\n<li>c</li>\n</ul></li>\n</ul>\n"},
+		{"ordered", "3. a\n4. b", "<ol start=\"3\">\n<li>a</li>\n<li>b</li>\n</ol>\n"},
+		{"table", "| a | b |\n|---|--:|\n| 1 | 2 |", "<table>\n<thead>\n<tr><th>a</th><th style=\"text-align: right\">b</th></tr>\n</thead>\n<tbody>\n<tr><td>1</td><td style=\"text-align: right\">2</td></tr>\n</tbody>\n</table>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Markdown(tt.in); got != tt.want {
+				t.Errorf("Markdown(%q) =\n%s\nwant\n%s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	doc := HTML("Q1 <report>", "name,total\nacme,12\n", "csv")
+	for _, want := range []string{"<title>Q1 &lt;report&gt;</title>", "<th>name</th><th>total</th>", "<td>acme</td><td>12</td>"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document missing %q", want)
+		}
+	}
+
+	doc = HTML("build", "ok <done>\n", "log")
+	if !strings.Contains(doc, "<pre>ok &lt;done&gt;\n</pre>") {
+		t.Errorf("log not preformatted:\n%s", doc)
+	}
+}
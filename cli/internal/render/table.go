@@ -0,0 +1,124 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// minColumnWidth is as narrow as TableOptions.Width squeezes a column.
+const minColumnWidth = 4
+
+// TableOptions control how Table lays rows out.
+type TableOptions struct {
+	// Right aligns the columns marked true to the right.
+	Right []bool
+	// MaxCellWidth cuts longer cells short with "…"; 0 never does.
+	MaxCellWidth int
+	// Width, if set, narrows the widest columns until a row fits in it.
+	Width int
+	// Styled sets the header row in bold and the rule under it dim.
+	Styled bool
+}
+
+// Table aligns rows into columns two spaces apart, with a rule under the
+// first row, the header, and returns the lines. Short rows are padded with
+// empty cells.
+func Table(rows [][]string, opts TableOptions) []string {
+	if len(rows) == 0 {
+		return nil
+	}
+	t := &terminal{styled: opts.Styled}
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+
+	widths := make([]int, cols)
+	padded := make([][]string, len(rows))
+	for r, row := range rows {
+		padded[r] = make([]string, cols)
+		for c, cell := range row {
+			if opts.MaxCellWidth > 0 {
+				cell = truncate(cell, opts.MaxCellWidth)
+			}
+			padded[r][c] = cell
+			widths[c] = max(widths[c], visibleWidth(cell))
+		}
+	}
+	if opts.Width > 0 {
+		for total := sum(widths) + 2*(cols-1); total > opts.Width; total-- {
+			widest := 0
+			for c, w := range widths {
+				if w > widths[widest] {
+					widest = c
+				}
+			}
+			if widths[widest] <= minColumnWidth {
+				break
+			}
+			widths[widest]--
+		}
+	}
+
+	var out []string
+	for r, row := range padded {
+		cells := make([]string, cols)
+		for c, cell := range row {
+			cell = truncate(cell, widths[c])
+			gap := strings.Repeat(" ", widths[c]-visibleWidth(cell))
+			if c < len(opts.Right) && opts.Right[c] {
+				cells[c] = gap + cell
+			} else {
+				cells[c] = cell + gap
+			}
+			if r == 0 {
+				cells[c] = t.style(ansiBold, cells[c])
+			}
+		}
+		out = append(out, strings.TrimRight(strings.Join(cells, "  "), " "))
+		if r == 0 {
+			rule := make([]string, cols)
+			for c, w := range widths {
+				rule[c] = strings.Repeat("─", w)
+			}
+			out = append(out, t.style(ansiDim, strings.Join(rule, "  ")))
+		}
+	}
+	return out
+}
+
+// truncate cuts s to width columns, ending it with "…". Styles are dropped
+// from a cell that has to be cut.
+func truncate(s string, width int) string {
+	if visibleWidth(s) <= width {
+		return s
+	}
+	s = ansiRe.ReplaceAllString(s, "")
+	n := 0
+	for i := range s {
+		if n == width-1 {
+			return s[:i] + "…"
+		}
+		n++
+	}
+	return s
+}
+
+func sum(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	return total
+}
+
+var cellReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+
+// Cell flattens a value onto one line for a table cell.
+func Cell(s string) string {
+	s = cellReplacer.Replace(s)
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "�")
+	}
+	return s
+}
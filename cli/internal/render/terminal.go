@@ -0,0 +1,342 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI styles used by Terminal.
+const (
+	ansiReset     = "\033[0m"
+	ansiBold      = "\033[1m"
+	ansiDim       = "\033[2m"
+	ansiItalic    = "\033[3m"
+	ansiUnderline = "\033[4m"
+	ansiStrike    = "\033[9m"
+	ansiCyan      = "\033[36m"
+	ansiMagenta   = "\033[35m"
+)
+
+// minTerminalWidth keeps a narrow or unknown terminal from wrapping every
+// word onto its own line.
+const minTerminalWidth = 20
+
+// Terminal lays markdown out for reading in a terminal: paragraphs wrapped
+// to width, bulleted and numbered lists with hanging indents, quotes behind
+// a bar, code indented and never wrapped, and tables aligned. With styled,
+// headings, emphasis, code, and links are set off with ANSI styles;
+// otherwise the output is plain text and code spans keep their backticks.
+func Terminal(src string, width int, styled bool) string {
+	t := &terminal{styled: styled}
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	return strings.Join(t.blocks(lines, max(width, minTerminalWidth)), "\n") + "\n"
+}
+
+type terminal struct {
+	styled bool
+}
+
+func (t *terminal) style(code, s string) string {
+	if !t.styled || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// blocks lays out lines as blocks of at most width columns, separated by a
+// blank line, and returns the output lines.
+func (t *terminal) blocks(lines []string, width int) []string {
+	var out []string
+	add := func(block []string) {
+		if len(out) > 0 {
+			out = append(out, "")
+		}
+		out = append(out, block...)
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case fenceRe.MatchString(line):
+			fence := fenceRe.FindStringSubmatch(line)[1]
+			var code []string
+			i++
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // closing fence
+			add(t.code(code))
+
+		case strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t"):
+			var code []string
+			for i < len(lines) && (strings.HasPrefix(lines[i], "    ") || strings.HasPrefix(lines[i], "\t") || strings.TrimSpace(lines[i]) == "") {
+				code = append(code, strings.TrimPrefix(strings.TrimPrefix(lines[i], "\t"), "    "))
+				i++
+			}
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+			add(t.code(code))
+
+		case headingRe.MatchString(trimmed):
+			m := headingRe.FindStringSubmatch(trimmed)
+			color := ansiCyan
+			if len(m[1]) == 1 {
+				color = ansiMagenta
+			}
+			var heading []string
+			for _, l := range wrap(t.inline(m[2]), width-len(m[1])-1) {
+				heading = append(heading, t.style(ansiBold+color, m[1]+" "+l))
+			}
+			add(heading)
+			i++
+
+		case ruleRe.MatchString(line):
+			add([]string{t.style(ansiDim, strings.Repeat("─", width))})
+			i++
+
+		case quoteMarkRe.MatchString(line):
+			var quoted []string
+			for i < len(lines) && quoteMarkRe.MatchString(lines[i]) {
+				quoted = append(quoted, quoteMarkRe.ReplaceAllString(lines[i], ""))
+				i++
+			}
+			var block []string
+			for _, l := range t.blocks(quoted, width-2) {
+				block = append(block, strings.TrimRight(t.style(ansiDim, "│")+" "+l, " "))
+			}
+			add(block)
+
+		case listItemRe.MatchString(line):
+			var block []string
+			block, i = t.list(lines, i, width)
+			add(block)
+
+		case strings.Contains(line, "|") && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"):
+			var block []string
+			block, i = t.table(lines, i)
+			add(block)
+
+		default:
+			var para []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]) {
+				para = append(para, strings.TrimSpace(lines[i]))
+				i++
+			}
+			if len(para) == 0 {
+				para = append(para, trimmed)
+				i++
+			}
+			add(wrap(t.inline(strings.Join(para, " ")), width))
+		}
+	}
+	return out
+}
+
+func (t *terminal) code(code []string) []string {
+	block := make([]string, len(code))
+	for i, l := range code {
+		block[i] = "    " + l
+	}
+	return block
+}
+
+// list lays out the list starting at lines[i] and returns its lines and
+// the index after it. Items are grouped as in renderList; their content is
+// laid out recursively and indented under the marker.
+func (t *terminal) list(lines []string, i, width int) ([]string, int) {
+	first := listItemRe.FindStringSubmatch(lines[i])
+	ordered := first[2][0] >= '0' && first[2][0] <= '9'
+	n := 1
+	if ordered {
+		n, _ = strconv.Atoi(strings.TrimRight(first[2], ".)"))
+	}
+
+	var out []string
+	for i < len(lines) {
+		m := listItemRe.FindStringSubmatch(lines[i])
+		if m == nil || (m[2][0] >= '0' && m[2][0] <= '9') != ordered {
+			break
+		}
+		indent := len(m[0])
+		item := []string{lines[i][len(m[0]):]}
+		i++
+		for i < len(lines) {
+			l := lines[i]
+			if strings.TrimSpace(l) == "" {
+				if i+1 < len(lines) && leadingSpaces(lines[i+1]) >= indent {
+					item = append(item, "")
+					i++
+					continue
+				}
+				break
+			}
+			if leadingSpaces(l) >= min(indent, 4) && leadingSpaces(l) > 0 {
+				item = append(item, dedent(l, indent))
+				i++
+				continue
+			}
+			if listItemRe.MatchString(l) || startsBlock(l) {
+				break
+			}
+			item = append(item, l)
+			i++
+		}
+
+		marker := "•"
+		if ordered {
+			marker = fmt.Sprintf("%d.", n)
+			n++
+		}
+		if rest, ok := strings.CutPrefix(item[0], "[ ] "); ok {
+			marker, item[0] = marker+" ☐", rest
+		} else if rest, ok := strings.CutPrefix(strings.Replace(item[0], "[X] ", "[x] ", 1), "[x] "); ok {
+			marker, item[0] = marker+" ☑", rest
+		}
+		pad := utf8.RuneCountInString(marker) + 1
+		for j, l := range t.blocks(item, width-pad-2) {
+			// Tight items lay out without the blank lines between blocks.
+			if l == "" && !strings.Contains(strings.Join(item, "\n"), "\n\n") {
+				continue
+			}
+			if j == 0 {
+				out = append(out, "  "+marker+" "+l)
+			} else if l == "" {
+				out = append(out, "")
+			} else {
+				out = append(out, strings.Repeat(" ", pad+2)+l)
+			}
+		}
+
+		if i < len(lines) && strings.TrimSpace(lines[i]) == "" && i+1 < len(lines) && listItemRe.MatchString(lines[i+1]) {
+			i++
+		}
+	}
+	return out, i
+}
+
+// table aligns the pipe table starting at lines[i] and returns its lines
+// and the index after it. Cells are not wrapped; a table wider than the
+// terminal is left to the terminal.
+func (t *terminal) table(lines []string, i int) ([]string, int) {
+	rows := [][]string{splitRow(lines[i])}
+	cols := len(rows[0])
+	var right []bool
+	for _, cell := range splitRow(lines[i+1]) {
+		right = append(right, strings.HasSuffix(cell, ":") && !strings.HasPrefix(cell, ":"))
+	}
+	i += 2
+	for i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|") {
+		rows = append(rows, splitRow(lines[i]))
+		i++
+	}
+
+	for r, row := range rows {
+		row = row[:min(len(row), cols)]
+		for c := range row {
+			row[c] = t.inline(row[c])
+		}
+		rows[r] = row
+	}
+	return Table(rows, TableOptions{Right: right, Styled: t.styled}), i
+}
+
+var (
+	termImageRe    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^)]*")?\)`)
+	termLinkRe     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^)]*")?\)`)
+	termAutolinkRe = regexp.MustCompile(`<((?:https?|mailto):[^\s>]+)>`)
+)
+
+// inline renders the inline markup of one block's text, cutting out code
+// spans first like inline.
+func (t *terminal) inline(text string) string {
+	var b strings.Builder
+	for {
+		loc := codeSpanRe.FindStringSubmatchIndex(text)
+		if loc == nil || text[loc[2]:loc[3]] != text[loc[6]:loc[7]] {
+			b.WriteString(t.inlineText(text))
+			return b.String()
+		}
+		b.WriteString(t.inlineText(text[:loc[0]]))
+		code := strings.TrimSpace(text[loc[4]:loc[5]])
+		if t.styled {
+			b.WriteString(t.style(ansiCyan, code))
+		} else {
+			b.WriteString("`" + code + "`")
+		}
+		text = text[loc[1]:]
+	}
+}
+
+func (t *terminal) inlineText(s string) string {
+	link := func(text, url string) string {
+		if text == url || text == "" {
+			return t.style(ansiUnderline, url)
+		}
+		return text + " (" + t.style(ansiUnderline, url) + ")"
+	}
+	s = termImageRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := termImageRe.FindStringSubmatch(m)
+		return link("image: "+sub[1], sub[2])
+	})
+	s = termLinkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := termLinkRe.FindStringSubmatch(m)
+		return link(sub[1], sub[2])
+	})
+	s = termAutolinkRe.ReplaceAllStringFunc(s, func(m string) string {
+		url := termAutolinkRe.FindStringSubmatch(m)[1]
+		return link(url, url)
+	})
+	span := func(re *regexp.Regexp, code string) {
+		s = re.ReplaceAllStringFunc(s, func(m string) string {
+			sub := re.FindStringSubmatch(m)
+			return t.style(code, sub[1]+sub[len(sub)-1])
+		})
+	}
+	span(strongRe, ansiBold)
+	span(emRe, ansiItalic)
+	span(strikeRe, ansiStrike)
+	return s
+}
+
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// visibleWidth is the number of columns s takes, not counting ANSI styles.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiRe.ReplaceAllString(s, ""))
+}
+
+// wrap breaks s into lines of at most width columns at spaces. A word
+// longer than width gets a line of its own.
+func wrap(s string, width int) []string {
+	var lines []string
+	var cur strings.Builder
+	curWidth := 0
+	for _, word := range strings.Fields(s) {
+		w := visibleWidth(word)
+		if curWidth > 0 && curWidth+1+w > width {
+			lines = append(lines, cur.String())
+			cur.Reset()
+			curWidth = 0
+		}
+		if curWidth > 0 {
+			cur.WriteByte(' ')
+			curWidth++
+		}
+		cur.WriteString(word)
+		curWidth += w
+	}
+	if curWidth > 0 {
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
@@ -0,0 +1,55 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTerminal(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"heading", "## Deploy *v2*", "## Deploy v2\n"},
+		{"wrap", "one two three four five six seven eight nine ten eleven", "one two three four five six\nseven eight nine ten eleven\n"},
+		{"code span", "run `make *all*`", "run `make *all*`\n"},
+		{"link", "see [docs](https://x.test) or <https://y.test>", "see docs (https://x.test) or\nhttps://y.test\n"},
+		{"fence", "```\na  b\n```", "    a  b\n"},
+		{"quote", "> a\n> b", "│ a b\n"},
+		{"list", "- a\n- b\n  - c\n- [x] d", "  • a\n  • b\n      • c\n  • ☑ d\n"},
+		{"ordered", "3. a\n4. b", "  3. a\n  4. b\n"},
+		{"table", "| name | n |\n|---|--:|\n| acme | 12 |", "name   n\n────  ──\nacme  12\n"},
+		{"blocks", "# A\ntext\n\n---", "# A\n\ntext\n\n" + strings.Repeat("─", 30) + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Terminal(tt.in, 30, false); got != tt.want {
+				t.Errorf("Terminal(%q) =\n%s\nwant\n%s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminal_Styled(t *testing.T) {
+	got := Terminal("# Title\n\nsome **bold** and `code`", 80, true)
+	for _, want := range []string{ansiBold + ansiMagenta + "# Title" + ansiReset, ansiBold + "bold" + ansiReset, ansiCyan + "code" + ansiReset} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Terminal() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	rows := [][]string{{"name", "total"}, {"a very long customer name", "12"}, {"b"}}
+	got := strings.Join(Table(rows, TableOptions{Right: []bool{false, true}, MaxCellWidth: 10}), "\n")
+	want := "name        total\n──────────  ─────\na very lo…     12\nb"
+	if got != want {
+		t.Errorf("Table() =\n%s\nwant\n%s", got, want)
+	}
+
+	got = strings.Join(Table(rows, TableOptions{Width: 20}), "\n")
+	for _, line := range strings.Split(got, "\n") {
+		if visibleWidth(line) > 20 {
+			t.Errorf("line %q is wider than 20 columns", line)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package schedule decides when background uploads may go out: quiet hours
+// during which they are held back, and a byte budget that caps their
+// average rate.
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Window is a daily span of local time, as offsets from midnight. A window
+// whose End is before its Start runs past midnight.
+type Window struct {
+	Start, End time.Duration
+}
+
+// ParseWindows parses comma-separated "HH:MM-HH:MM" spans such as
+// "09:00-12:00, 22:30-07:00". An empty string means no windows.
+func ParseWindows(s string) ([]Window, error) {
+	var windows []Window
+	for _, spec := range strings.Split(s, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(spec, "-")
+		start, err1 := parseClock(from)
+		end, err2 := parseClock(to)
+		if !ok || err1 != nil || err2 != nil || start == end {
+			return nil, fmt.Errorf("invalid time window %q (e.g. 09:00-17:30, 22:00-07:00)", spec)
+		}
+		windows = append(windows, Window{Start: start, End: end})
+	}
+	return windows, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t's local time of day falls in w.
+func (w Window) Contains(t time.Time) bool {
+	h, m, s := t.Clock()
+	at := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	if w.Start < w.End {
+		return at >= w.Start && at < w.End
+	}
+	return at >= w.Start || at < w.End
+}
+
+func (w Window) String() string {
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return clock(w.Start) + "-" + clock(w.End)
+}
+
+// Quiet reports whether t falls in any of windows.
+func Quiet(windows []Window, t time.Time) bool {
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Budget caps the average rate of uploads in bytes per second. Unlike a
+// request limiter it never blocks: senders ask how much they may send now
+// and hold the rest for later. A nil Budget allows everything.
+type Budget struct {
+	rate   float64 // bytes per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewBudget allows bytesPerSecond on average, saving up at most window's
+// worth while idle. It returns nil if bytesPerSecond is not positive.
+func NewBudget(bytesPerSecond int64, window time.Duration) *Budget {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	rate := float64(bytesPerSecond)
+	burst := math.Max(rate, rate*window.Seconds())
+	return &Budget{rate: rate, burst: burst, tokens: burst}
+}
+
+// Available returns how many bytes may be sent at now. It is zero while an
+// earlier overdraft is being paid back.
+func (b *Budget) Available(now time.Time) int {
+	if b == nil {
+		return math.MaxInt
+	}
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	return int(math.Max(0, b.tokens))
+}
+
+// Spend records n bytes sent. Spending more than is available overdraws
+// the budget, so a line too long to fit can still go out once the budget
+// has any room, and the average rate holds.
+func (b *Budget) Spend(n int) {
+	if b != nil {
+		b.tokens -= float64(n)
+	}
+}
@@ -0,0 +1,60 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func at(clock string) time.Time {
+	t, _ := time.ParseInLocation("15:04", clock, time.Local)
+	return time.Date(2026, 10, 18, t.Hour(), t.Minute(), 0, 0, time.Local)
+}
+
+func TestParseWindows(t *testing.T) {
+	windows, err := ParseWindows("09:00-12:00, 22:30-07:00")
+	if err != nil || len(windows) != 2 || windows[1].String() != "22:30-07:00" {
+		t.Fatalf("ParseWindows() = %v, %v", windows, err)
+	}
+	for clock, want := range map[string]bool{
+		"08:59": false, "09:00": true, "11:59": true, "12:00": false,
+		"22:29": false, "23:00": true, "03:00": true, "07:00": false,
+	} {
+		if got := Quiet(windows, at(clock)); got != want {
+			t.Errorf("Quiet(%s) = %v, want %v", clock, got, want)
+		}
+	}
+
+	if windows, err := ParseWindows(""); err != nil || windows != nil {
+		t.Errorf("ParseWindows(\"\") = %v, %v; want none", windows, err)
+	}
+	for _, bad := range []string{"9-5", "09:00", "25:00-26:00", "10:00-10:00"} {
+		if _, err := ParseWindows(bad); err == nil {
+			t.Errorf("ParseWindows(%q) accepted", bad)
+		}
+	}
+}
+
+func TestBudget(t *testing.T) {
+	start := at("10:00")
+	b := NewBudget(100, 2*time.Second)
+
+	if got := b.Available(start); got != 200 {
+		t.Fatalf("Available() = %d, want a full 2s budget of 200", got)
+	}
+	b.Spend(250)
+	if got := b.Available(start.Add(time.Second / 4)); got != 0 {
+		t.Errorf("Available() = %d, want 0 while overdrawn", got)
+	}
+	if got := b.Available(start.Add(time.Second)); got != 50 {
+		t.Errorf("Available() = %d, want 50 after paying back the overdraft", got)
+	}
+	if got := b.Available(start.Add(time.Hour)); got != 200 {
+		t.Errorf("Available() = %d, want it capped at 200", got)
+	}
+
+	var unlimited *Budget = NewBudget(0, time.Second)
+	unlimited.Spend(1 << 30)
+	if unlimited != nil || unlimited.Available(start) <= 1<<30 {
+		t.Error("a zero rate should not limit")
+	}
+}
@@ -0,0 +1,168 @@
+// Package telemetry keeps the opt-in record of which CLI commands are run
+// and how long they take. Events wait in a local queue until a batch is
+// sent, so what would be sent can always be inspected first.
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	settingsFile = "telemetry.json"
+	queueFile    = "telemetry.jsonl"
+)
+
+// BatchSize is how many events are queued before they are sent.
+const BatchSize = 20
+
+// maxQueueSize bounds the queue when batches cannot be sent, such as to a
+// server without the telemetry endpoint. Events past it are dropped.
+const maxQueueSize = 64 * 1024
+
+// Event is one command run. Nothing else is recorded: no arguments, flag
+// values, page content, IDs, or errors.
+type Event struct {
+	Command    string `json:"command"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+type settings struct {
+	Enabled bool `json:"enabled"`
+	// Refused is the API URL of a server that answered that it has no
+	// telemetry endpoint, so batches are no longer sent to it.
+	Refused string `json:"refused,omitempty"`
+}
+
+type Store struct {
+	dir string
+}
+
+// Open returns the telemetry store in dir. Nothing is written until
+// telemetry is enabled.
+func Open(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) QueuePath() string {
+	return filepath.Join(s.dir, queueFile)
+}
+
+// Enabled reports whether the user opted in and has not set DO_NOT_TRACK
+// (https://consoledonottrack.com) since.
+func (s *Store) Enabled() bool {
+	if dnt := os.Getenv("DO_NOT_TRACK"); dnt != "" && dnt != "0" {
+		return false
+	}
+	return s.settings().Enabled
+}
+
+// SetEnabled records the user's choice, forgetting any server that refused
+// batches so that it is tried again. Disabling also discards any events
+// not yet sent.
+func (s *Store) SetEnabled(enabled bool) error {
+	if err := s.saveSettings(settings{Enabled: enabled}); err != nil {
+		return err
+	}
+	if !enabled {
+		return s.Clear()
+	}
+	return nil
+}
+
+// Refused reports whether the server at apiURL answered that it has no
+// telemetry endpoint, so batches should not be sent to it.
+func (s *Store) Refused(apiURL string) bool {
+	return apiURL != "" && s.settings().Refused == apiURL
+}
+
+// SetRefused records that the server at apiURL has no telemetry endpoint.
+// Events are still queued, up to the queue's limit, for 'telemetry show'.
+func (s *Store) SetRefused(apiURL string) error {
+	st := s.settings()
+	st.Refused = apiURL
+	return s.saveSettings(st)
+}
+
+func (s *Store) settings() settings {
+	var st settings
+	data, err := os.ReadFile(filepath.Join(s.dir, settingsFile))
+	if err == nil {
+		_ = json.Unmarshal(data, &st)
+	}
+	return st
+}
+
+func (s *Store) saveSettings(st settings) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, settingsFile), append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to save telemetry setting: %w", err)
+	}
+	return nil
+}
+
+// Record queues e if telemetry is enabled.
+func (s *Store) Record(e Event) error {
+	if !s.Enabled() {
+		return nil
+	}
+	if info, err := os.Stat(s.QueuePath()); err == nil && info.Size() >= maxQueueSize {
+		return nil
+	}
+	f, err := os.OpenFile(s.QueuePath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open telemetry queue: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// Pending returns the queued events, oldest first. Malformed lines are
+// skipped so a truncated write never makes the queue unreadable.
+func (s *Store) Pending() ([]Event, error) {
+	f, err := os.Open(s.QueuePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open telemetry queue: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Command == "" {
+			continue
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read telemetry queue: %w", err)
+	}
+	return events, nil
+}
+
+// Clear discards the queued events.
+func (s *Store) Clear() error {
+	if err := os.Remove(s.QueuePath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear telemetry queue: %w", err)
+	}
+	return nil
+}
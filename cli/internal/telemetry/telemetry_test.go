@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStore_OptIn(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	store := Open(t.TempDir())
+
+	if err := store.Record(Event{Command: "page new", DurationMS: 12}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(store.QueuePath()); !os.IsNotExist(err) {
+		t.Fatalf("queue written before opting in: %v", err)
+	}
+
+	if err := store.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []Event{{Command: "page new", DurationMS: 12}, {Command: "page list", DurationMS: 340}} {
+		if err := store.Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err := store.Pending()
+	if err != nil || len(events) != 2 || events[1] != (Event{Command: "page list", DurationMS: 340}) {
+		t.Fatalf("Pending() = %+v, %v", events, err)
+	}
+	if info, err := os.Stat(store.QueuePath()); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("queue permissions = %v, %v; want 600", info.Mode().Perm(), err)
+	}
+
+	if err := store.SetEnabled(false); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := store.Pending(); len(events) != 0 || store.Enabled() {
+		t.Errorf("after disabling: enabled %v, %d events queued", store.Enabled(), len(events))
+	}
+}
+
+func TestStore_DoNotTrack(t *testing.T) {
+	store := Open(t.TempDir())
+	if err := store.SetEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DO_NOT_TRACK", "1")
+	if store.Enabled() {
+		t.Error("Enabled() = true with DO_NOT_TRACK=1")
+	}
+}
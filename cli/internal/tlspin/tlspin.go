@@ -0,0 +1,139 @@
+// Package tlspin implements trust-on-first-use pinning of TLS certificates
+// for self-hosted API servers: the first certificate seen for a host is
+// recorded, and a different certificate later is treated as an attack.
+package tlspin
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const pinsFile = "tls_pins.json"
+
+// Host returns the host:port certificates are pinned under for an https
+// API URL, or "" when pinning does not apply.
+func Host(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return ""
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// Fingerprint returns the SHA-256 fingerprint of cert in the same
+// "SHA256:<base64>" form ssh uses for host keys.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// MismatchError reports that a host presented a certificate other than the
+// one pinned for it.
+type MismatchError struct {
+	Host   string
+	Pinned string
+	Got    string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("TLS certificate for %s has changed (pinned %s, got %s); "+
+		"this may be a man-in-the-middle attack. If the server's certificate was "+
+		"legitimately replaced, run 'hyperclast auth trust'", e.Host, e.Pinned, e.Got)
+}
+
+// Store holds pinned fingerprints keyed by host:port.
+type Store struct {
+	path string
+
+	mu   sync.Mutex
+	pins map[string]string
+}
+
+// Open loads the pins stored in dir. A missing file is an empty store.
+func Open(dir string) (*Store, error) {
+	s := &Store{path: filepath.Join(dir, pinsFile), pins: map[string]string{}}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read TLS pins: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.pins); err != nil {
+		return nil, fmt.Errorf("failed to parse TLS pins %s: %w", s.path, err)
+	}
+	return s, nil
+}
+
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Lookup returns the fingerprint pinned for host, or "".
+func (s *Store) Lookup(host string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pins[host]
+}
+
+// Pin records fingerprint for host, replacing any existing pin.
+func (s *Store) Pin(host, fingerprint string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pins[host] = fingerprint
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s.pins, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to save TLS pins: %w", err)
+	}
+	return nil
+}
+
+// Verifier returns a tls.Config.VerifyConnection function for host. It runs
+// after normal certificate verification. The first certificate seen is
+// pinned and reported to onPin; a different one fails the handshake with a
+// *MismatchError.
+func (s *Store) Verifier(host string, onPin func(host, fingerprint string)) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return fmt.Errorf("no TLS certificate presented by %s", host)
+		}
+		got := Fingerprint(cs.PeerCertificates[0])
+
+		switch pinned := s.Lookup(host); pinned {
+		case got:
+			return nil
+		case "":
+			if err := s.Pin(host, got); err != nil {
+				return err
+			}
+			if onPin != nil {
+				onPin(host, got)
+			}
+			return nil
+		default:
+			return &MismatchError{Host: host, Pinned: pinned, Got: got}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package tlspin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testCert(t *testing.T) *x509.Certificate {
+	t.Helper()
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	return server.Certificate()
+}
+
+func TestVerifier_TrustOnFirstUse(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cert := testCert(t)
+	var pinned string
+	verify := store.Verifier("example.com:443", func(host, fp string) { pinned = fp })
+
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if err := verify(state); err != nil {
+		t.Fatalf("first use should be trusted: %v", err)
+	}
+	if pinned != Fingerprint(cert) || !strings.HasPrefix(pinned, "SHA256:") {
+		t.Errorf("pinned = %q", pinned)
+	}
+
+	// The pin survives a reload and the same certificate keeps working.
+	reloaded, err := Open(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reloaded.Lookup("example.com:443") != pinned {
+		t.Error("pin was not persisted")
+	}
+	pinned = ""
+	if err := reloaded.Verifier("example.com:443", func(string, string) { pinned = "again" })(state); err != nil {
+		t.Errorf("same certificate should verify: %v", err)
+	}
+	if pinned != "" {
+		t.Error("onPin should only run on first use")
+	}
+}
+
+func TestVerifier_Mismatch(t *testing.T) {
+	store, _ := Open(t.TempDir())
+	_ = store.Pin("example.com:443", "SHA256:old")
+
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{testCert(t)}}
+	err := store.Verifier("example.com:443", nil)(state)
+
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("err = %v, want MismatchError", err)
+	}
+	if mismatch.Pinned != "SHA256:old" || !strings.Contains(err.Error(), "man-in-the-middle") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if store.Lookup("example.com:443") != "SHA256:old" {
+		t.Error("a mismatch must not replace the pin")
+	}
+}
+
+func TestOpen_Malformed(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := Open(dir)
+	_ = store.Pin("a:443", "x")
+	if err := os.WriteFile(store.Path(), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(dir); err == nil {
+		t.Error("expected error for malformed pins file")
+	}
+}
+
+func TestHost(t *testing.T) {
+	tests := []struct {
+		apiURL string
+		want   string
+	}{
+		{"https://notes.example.com/api", "notes.example.com:443"},
+		{"https://notes.example.com:8443/api", "notes.example.com:8443"},
+		{"http://localhost:9800/api", ""},
+		{"not a url", ""},
+	}
+	for _, tt := range tests {
+		if got := Host(tt.apiURL); got != tt.want {
+			t.Errorf("Host(%q) = %q, want %q", tt.apiURL, got, tt.want)
+		}
+	}
+}
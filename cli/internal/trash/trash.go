@@ -0,0 +1,149 @@
+// Package trash keeps local copies of the pages 'page trash' deletes, so
+// that a page deleted by mistake can be created again from its copy. The
+// server's delete is final as far as its API goes.
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+const binDir = "trash"
+
+// Retention is how long a copy is kept. Older copies are removed the next
+// time the trash is listed.
+const Retention = 30 * 24 * time.Hour
+
+// ErrNotTrashed is returned for a page with no copy in the trash.
+var ErrNotTrashed = errors.New("not in the trash")
+
+// Entry is a trashed page: the page as it was fetched before it was
+// deleted, with its details, and when it was trashed.
+type Entry struct {
+	Page      api.Page  `json:"page"`
+	TrashedAt time.Time `json:"trashed_at"`
+}
+
+type Bin struct {
+	dir string
+}
+
+// Open returns the trash kept in dir, a state directory. The trash
+// directory is created on first Put.
+func Open(dir string) *Bin {
+	if dir == "" {
+		return &Bin{}
+	}
+	return &Bin{dir: filepath.Join(dir, binDir)}
+}
+
+func (b *Bin) Dir() string {
+	return b.dir
+}
+
+// Put stores a copy of page, which must have been fetched with its
+// details, trashed at now.
+func (b *Bin) Put(page api.Page, now time.Time) (Entry, error) {
+	if b.dir == "" {
+		return Entry{}, fmt.Errorf("no state directory to keep the trash in")
+	}
+	if !validID(page.ExternalID) {
+		return Entry{}, fmt.Errorf("invalid page ID %q", page.ExternalID)
+	}
+	if err := os.MkdirAll(b.dir, 0700); err != nil {
+		return Entry{}, fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	e := Entry{Page: page, TrashedAt: now.UTC()}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, err
+	}
+	if err := os.WriteFile(b.path(page.ExternalID), data, 0600); err != nil {
+		return Entry{}, fmt.Errorf("failed to save page to the trash: %w", err)
+	}
+	return e, nil
+}
+
+// Get returns the copy of the page pageID, or ErrNotTrashed.
+func (b *Bin) Get(pageID string) (*Entry, error) {
+	if b.dir == "" || !validID(pageID) {
+		return nil, fmt.Errorf("page %s is %w", pageID, ErrNotTrashed)
+	}
+	data, err := os.ReadFile(b.path(pageID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("page %s is %w", pageID, ErrNotTrashed)
+		}
+		return nil, fmt.Errorf("failed to read the trash: %w", err)
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("failed to read the trash: page %s: %w", pageID, err)
+	}
+	return &e, nil
+}
+
+// List returns the trashed pages of projectID, or of every project when it
+// is empty, most recently trashed first. Copies past Retention are removed.
+func (b *Bin) List(projectID string, now time.Time) ([]Entry, error) {
+	if b.dir == "" {
+		return nil, nil
+	}
+	files, err := os.ReadDir(b.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the trash: %w", err)
+	}
+	var entries []Entry
+	for _, f := range files {
+		id, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok {
+			continue
+		}
+		e, err := b.Get(id)
+		if err != nil {
+			// A copy that cannot be read is left for the user to inspect.
+			continue
+		}
+		if now.Sub(e.TrashedAt) > Retention {
+			_ = b.Remove(id)
+			continue
+		}
+		if projectID == "" || e.Page.ProjectID == projectID {
+			entries = append(entries, *e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].TrashedAt.After(entries[j].TrashedAt) })
+	return entries, nil
+}
+
+// Remove deletes the copy of the page pageID, if there is one.
+func (b *Bin) Remove(pageID string) error {
+	if b.dir == "" || !validID(pageID) {
+		return nil
+	}
+	if err := os.Remove(b.path(pageID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove page %s from the trash: %w", pageID, err)
+	}
+	return nil
+}
+
+func (b *Bin) path(pageID string) string {
+	return filepath.Join(b.dir, pageID+".json")
+}
+
+// validID reports whether pageID can name a file in the trash, so that an
+// ID from the command line cannot reach outside it.
+func validID(pageID string) bool {
+	return pageID != "" && !strings.ContainsAny(pageID, `/\.`)
+}
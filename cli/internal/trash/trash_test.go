@@ -0,0 +1,92 @@
+package trash
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperclast/workspace/cli/internal/api"
+)
+
+func TestBin_PutGetRemove(t *testing.T) {
+	bin := Open(t.TempDir())
+	now := time.Now()
+	page := api.Page{ExternalID: "page_a", Title: "Build", ProjectID: "proj_a", Details: &api.PageDetails{Content: "log\n"}}
+
+	if _, err := bin.Put(page, now); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	got, err := bin.Get("page_a")
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	if got.Page.Title != "Build" || got.Page.Details.Content != "log\n" || !got.TrashedAt.Equal(now.UTC()) {
+		t.Errorf("Get() = %+v", got)
+	}
+	info, err := os.Stat(bin.path("page_a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("copy permissions = %o, want 600", info.Mode().Perm())
+	}
+
+	if err := bin.Remove("page_a"); err != nil {
+		t.Fatalf("Remove() returned error: %v", err)
+	}
+	if _, err := bin.Get("page_a"); !errors.Is(err, ErrNotTrashed) {
+		t.Errorf("Get() after Remove() = %v, want ErrNotTrashed", err)
+	}
+}
+
+func TestBin_List(t *testing.T) {
+	bin := Open(t.TempDir())
+	now := time.Now()
+	for _, e := range []struct {
+		id, project string
+		age         time.Duration
+	}{
+		{"page_old", "proj_a", time.Hour},
+		{"page_new", "proj_a", time.Minute},
+		{"page_other", "proj_b", time.Minute},
+		{"page_expired", "proj_a", Retention + time.Hour},
+	} {
+		if _, err := bin.Put(api.Page{ExternalID: e.id, ProjectID: e.project}, now.Add(-e.age)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := bin.List("proj_a", now)
+	if err != nil {
+		t.Fatalf("List() returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Page.ExternalID != "page_new" || entries[1].Page.ExternalID != "page_old" {
+		t.Errorf("List(proj_a) = %+v, want page_new then page_old", entries)
+	}
+	if _, err := bin.Get("page_expired"); !errors.Is(err, ErrNotTrashed) {
+		t.Errorf("copy past Retention was kept: %v", err)
+	}
+	if all, _ := bin.List("", now); len(all) != 3 {
+		t.Errorf("List(\"\") = %d entries, want 3", len(all))
+	}
+}
+
+func TestBin_RejectsPathIDs(t *testing.T) {
+	bin := Open(t.TempDir())
+	for _, id := range []string{"", "../config", "a/b", `a\b`} {
+		if _, err := bin.Get(id); !errors.Is(err, ErrNotTrashed) {
+			t.Errorf("Get(%q) = %v, want ErrNotTrashed", id, err)
+		}
+	}
+}
+
+func TestBin_NoDir(t *testing.T) {
+	bin := Open("")
+	if _, err := bin.Put(api.Page{ExternalID: "page_a"}, time.Now()); err == nil {
+		t.Error("Put() with no state directory succeeded")
+	}
+	if entries, err := bin.List("", time.Now()); err != nil || len(entries) != 0 {
+		t.Errorf("List() = %v, %v; want nothing", entries, err)
+	}
+}
@@ -0,0 +1,256 @@
+// Package usage keeps a local ledger of API requests made by the CLI so users
+// can see what is driving their quota. Entries are appended, and the oldest
+// are pruned once the ledger grows past maxLedgerBytes.
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const ledgerFile = "usage.jsonl"
+
+// Retention is how long entries are kept once the ledger is pruned, the
+// longest window 'stats usage' can report on.
+const Retention = 90 * 24 * time.Hour
+
+// maxLedgerBytes is the size past which Record prunes the ledger, to entries
+// within Retention and at most half this size.
+const maxLedgerBytes = 1 << 20
+
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	BytesSent int64     `json:"bytes_sent"`
+}
+
+type Ledger struct {
+	path string
+}
+
+// Open returns a ledger stored in dir. The file is created on first Record.
+// With no dir, as when no state directory can be found, nothing is recorded.
+func Open(dir string) *Ledger {
+	if dir == "" {
+		return &Ledger{}
+	}
+	return &Ledger{path: filepath.Join(dir, ledgerFile)}
+}
+
+func (l *Ledger) Path() string {
+	return l.path
+}
+
+func (l *Ledger) Record(e Entry) error {
+	if l.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if info, err := f.Stat(); err == nil && info.Size() > maxLedgerBytes {
+		return l.prune(e.Time.Add(-Retention))
+	}
+	return nil
+}
+
+// prune rewrites the ledger with the entries recorded at or after since,
+// dropping the oldest of those too until it is at most half of
+// maxLedgerBytes. The new file replaces the old in one rename.
+func (l *Ledger) prune(since time.Time) error {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+	var kept [][]byte
+	size := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var e Entry
+		if json.Unmarshal(line, &e) != nil || e.Time.Before(since) {
+			continue
+		}
+		kept = append(kept, line)
+		size += len(line) + 1
+	}
+	for len(kept) > 0 && size > maxLedgerBytes/2 {
+		size -= len(kept[0]) + 1
+		kept = kept[1:]
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), ledgerFile+".*")
+	if err != nil {
+		return fmt.Errorf("failed to prune usage ledger: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	w := bufio.NewWriter(tmp)
+	for _, line := range kept {
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to prune usage ledger: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to prune usage ledger: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), l.path); err != nil {
+		return fmt.Errorf("failed to prune usage ledger: %w", err)
+	}
+	return nil
+}
+
+// Entries returns entries recorded at or after since. Malformed lines are
+// skipped so a truncated write never makes the ledger unreadable.
+func (l *Ledger) Entries(since time.Time) ([]Entry, error) {
+	if l.path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if !e.Time.Before(since) {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+	return entries, nil
+}
+
+type EndpointUsage struct {
+	Endpoint      string `json:"endpoint"`
+	Calls         int    `json:"calls"`
+	BytesUploaded int64  `json:"bytes_uploaded"`
+}
+
+type Summary struct {
+	Since         time.Time       `json:"since"`
+	Calls         int             `json:"calls"`
+	Failed        int             `json:"failed"`
+	BytesUploaded int64           `json:"bytes_uploaded"`
+	PagesCreated  int             `json:"pages_created"`
+	Endpoints     []EndpointUsage `json:"endpoints"`
+}
+
+// Summarize aggregates entries, with endpoints sorted by call count.
+func Summarize(entries []Entry, since time.Time) Summary {
+	s := Summary{Since: since, Endpoints: []EndpointUsage{}}
+	byEndpoint := make(map[string]*EndpointUsage)
+
+	for _, e := range entries {
+		s.Calls++
+		s.BytesUploaded += e.BytesSent
+		ok := e.Status >= 200 && e.Status < 300
+		if !ok {
+			s.Failed++
+		}
+
+		endpoint := e.Method + " " + NormalizePath(e.Path)
+		if ok && endpoint == http.MethodPost+" /pages/" {
+			s.PagesCreated++
+		}
+
+		u, exists := byEndpoint[endpoint]
+		if !exists {
+			u = &EndpointUsage{Endpoint: endpoint}
+			byEndpoint[endpoint] = u
+		}
+		u.Calls++
+		u.BytesUploaded += e.BytesSent
+	}
+
+	for _, u := range byEndpoint {
+		s.Endpoints = append(s.Endpoints, *u)
+	}
+	sort.Slice(s.Endpoints, func(i, j int) bool {
+		if s.Endpoints[i].Calls != s.Endpoints[j].Calls {
+			return s.Endpoints[i].Calls > s.Endpoints[j].Calls
+		}
+		return s.Endpoints[i].Endpoint < s.Endpoints[j].Endpoint
+	})
+	return s
+}
+
+// staticSegments are path segments that appear where an ID would normally be,
+// or after one, as actions on a resource.
+var staticSegments = map[string]bool{
+	"me":           true,
+	"storage":      true,
+	"settings":     true,
+	"autocomplete": true,
+	"filetypes":    true,
+	"move-pages":   true,
+	"telemetry":    true,
+	"rewind":       true,
+	"restore":      true,
+	"finalize":     true,
+	"access-code":  true,
+	"download":     true,
+}
+
+// NormalizePath strips the query string and replaces resource IDs with {id}
+// so requests to different pages group under one endpoint. API paths
+// alternate collection and ID segments (/orgs/{id}/members/{id}/).
+func NormalizePath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i += 2 {
+		if !staticSegments[segments[i]] {
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/") + "/"
+}
+
+// PathIDs returns the resource IDs in path, the segments NormalizePath
+// replaces with {id}.
+func PathIDs(path string) []string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var ids []string
+	for i := 1; i < len(segments); i += 2 {
+		if !staticSegments[segments[i]] {
+			ids = append(ids, segments[i])
+		}
+	}
+	return ids
+}
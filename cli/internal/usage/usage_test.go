@@ -0,0 +1,153 @@
+package usage
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLedgerRecordAndEntries(t *testing.T) {
+	ledger := Open(t.TempDir())
+	now := time.Now().UTC()
+
+	entries := []Entry{
+		{Time: now.Add(-48 * time.Hour), Method: "GET", Path: "/pages/", Status: 200},
+		{Time: now.Add(-time.Hour), Method: "POST", Path: "/pages/", Status: 201, BytesSent: 100},
+		{Time: now, Method: "PUT", Path: "/pages/page_1/", Status: 500, BytesSent: 50},
+	}
+	for _, e := range entries {
+		if err := ledger.Record(e); err != nil {
+			t.Fatalf("Record() returned error: %v", err)
+		}
+	}
+
+	got, err := ledger.Entries(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Entries() returned error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries within window, got %d", len(got))
+	}
+
+	info, err := os.Stat(ledger.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("ledger permissions = %o, want 600", info.Mode().Perm())
+	}
+}
+
+func TestLedgerRecord_NoDir(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := Open("").Record(Entry{Time: time.Now(), Method: "GET", Path: "/pages/"}); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	if _, err := os.Stat(ledgerFile); !os.IsNotExist(err) {
+		t.Errorf("Record() with no dir wrote %s to the current directory", ledgerFile)
+	}
+}
+
+func TestLedgerRecord_Prunes(t *testing.T) {
+	ledger := Open(t.TempDir())
+	now := time.Now().UTC()
+
+	var old strings.Builder
+	line, _ := json.Marshal(Entry{Time: now.Add(-Retention - time.Hour), Method: "GET", Path: "/pages/"})
+	for old.Len() <= maxLedgerBytes/2 {
+		old.Write(append(line, '\n'))
+	}
+	var recent strings.Builder
+	line, _ = json.Marshal(Entry{Time: now.Add(-time.Hour), Method: "GET", Path: "/pages/page_1/"})
+	for recent.Len() <= maxLedgerBytes/2 {
+		recent.Write(append(line, '\n'))
+	}
+	if err := os.WriteFile(ledger.Path(), []byte(old.String()+recent.String()), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ledger.Record(Entry{Time: now, Method: "POST", Path: "/pages/", Status: 201}); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	info, err := os.Stat(ledger.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > maxLedgerBytes/2 {
+		t.Errorf("ledger is %d bytes after pruning, want at most %d", info.Size(), maxLedgerBytes/2)
+	}
+	got, err := ledger.Entries(time.Time{})
+	if err != nil {
+		t.Fatalf("Entries() returned error: %v", err)
+	}
+	if len(got) == 0 || got[0].Time.Before(now.Add(-Retention)) {
+		t.Errorf("entries older than Retention were kept")
+	}
+	if last := got[len(got)-1]; last.Method != "POST" {
+		t.Errorf("last entry = %+v, want the one just recorded", last)
+	}
+}
+
+func TestLedgerEntries_MissingFile(t *testing.T) {
+	got, err := Open(t.TempDir()).Entries(time.Time{})
+	if err != nil {
+		t.Fatalf("Entries() returned error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no entries, got %d", len(got))
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	entries := []Entry{
+		{Method: "POST", Path: "/pages/", Status: 201, BytesSent: 100},
+		{Method: "POST", Path: "/pages/", Status: 413, BytesSent: 999},
+		{Method: "PUT", Path: "/pages/page_a/", Status: 200, BytesSent: 10},
+		{Method: "PUT", Path: "/pages/page_b/", Status: 200, BytesSent: 20},
+		{Method: "PUT", Path: "/pages/page_c/", Status: 200, BytesSent: 30},
+	}
+
+	s := Summarize(entries, time.Time{})
+	if s.Calls != 5 || s.Failed != 1 || s.PagesCreated != 1 || s.BytesUploaded != 1159 {
+		t.Errorf("summary = %+v", s)
+	}
+	if len(s.Endpoints) != 2 || s.Endpoints[0].Endpoint != "PUT /pages/{id}/" || s.Endpoints[0].Calls != 3 {
+		t.Errorf("endpoints = %+v", s.Endpoints)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"/pages/":                                  "/pages/",
+		"/pages/page_1/":                           "/pages/{id}/",
+		"/projects/proj_1/?details=full":           "/projects/{id}/",
+		"/users/me/":                               "/users/me/",
+		"/orgs/org_1/members/usr_1/":               "/orgs/{id}/members/{id}/",
+		"/pages/page_1/rewind/rw_1/restore":        "/pages/{id}/rewind/{id}/restore/",
+		"/projects/proj_1/folders/move-pages/":     "/projects/{id}/folders/move-pages/",
+		"/cli/telemetry/":                          "/cli/telemetry/",
+		"/files/file_1/finalize/?mark_failed=true": "/files/{id}/finalize/",
+	}
+	for in, want := range tests {
+		if got := NormalizePath(in); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPathIDs(t *testing.T) {
+	for path, want := range map[string]string{
+		"/pages/":                              "",
+		"/pages/page_1/?omit=content":          "page_1",
+		"/orgs/org_1/members/user_2/":          "org_1,user_2",
+		"/pages/autocomplete/?q=deploy":        "",
+		"/projects/proj_1/folders/fold_2/":     "proj_1,fold_2",
+		"/projects/proj_1/folders/move-pages/": "proj_1",
+	} {
+		if got := strings.Join(PathIDs(path), ","); got != want {
+			t.Errorf("PathIDs(%q) = %q, want %q", path, got, want)
+		}
+	}
+}